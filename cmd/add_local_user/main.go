@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 
+	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	localidp_repository "authenticator-backend/infrastructure/localidp/repository"
+
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
 	"github.com/google/uuid"
@@ -24,7 +29,15 @@ type Operator struct {
 }
 
 func main() {
-	addLocal()
+	idp := flag.String("idp", config.IDPProviderFirebase, "identity provider to register the users (firebase|local)")
+	flag.Parse()
+
+	switch *idp {
+	case config.IDPProviderLocal:
+		addLocalIDP()
+	default:
+		addLocal()
+	}
 }
 
 func addLocal() {
@@ -43,6 +56,25 @@ func addLocal() {
 	addOperatorFromCSV(ctx, app, csvPath)
 }
 
+func addLocalIDP() {
+	ctx := context.Background()
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("error reading config: %v\n", err)
+	}
+	conn := config.NewDBConnection(cfg)
+	r := localidp_repository.NewLocalIDP(conn, cfg.LocalIDP.SigningKey, cfg.LocalIDP.Issuer, cfg.LocalIDP.IDTokenTTL, cfg.LocalIDP.RefreshTokenTTL)
+
+	for _, operator := range readOperators(seedPath) {
+		uid, err := r.CreateUser(ctx, operator.email, authentication.Password(operator.password), operator.operatorID, authentication.RoleAdmin)
+		if err != nil {
+			log.Fatalf("Error creating user for email %s: %v", operator.email, err)
+		}
+		fmt.Printf("Successfully created local user %s with email: %s. Password: %s\n", uid, operator.email, operator.password)
+	}
+}
+
 func addOperatorFromCSV(ctx context.Context, app *firebase.App, csvPath string) {
 	// make client
 	authClient, err := app.Auth(ctx)
@@ -50,6 +82,13 @@ func addOperatorFromCSV(ctx context.Context, app *firebase.App, csvPath string)
 		log.Fatalf("Error getting Auth client: %v", err)
 	}
 
+	// create user by each record
+	for _, operator := range readOperators(csvPath) {
+		addOperator(ctx, authClient, operator)
+	}
+}
+
+func readOperators(csvPath string) []Operator {
 	// read csv file
 	file, err := os.Open(csvPath)
 	if err != nil {
@@ -63,14 +102,14 @@ func addOperatorFromCSV(ctx context.Context, app *firebase.App, csvPath string)
 		log.Fatalf("Error reading CSV records: %v", err)
 	}
 
-	// create user by each record
+	operators := make([]Operator, 0, len(records))
 	for _, record := range records {
 		email := record[0]
 		password := record[1]
 		operatorID := record[2]
-		operator := Operator{operatorID, email, password}
-		addOperator(ctx, authClient, operator)
+		operators = append(operators, Operator{operatorID, email, password})
 	}
+	return operators
 }
 
 func addOperator(ctx context.Context, authClient *auth.Client, operator Operator) {
@@ -88,7 +127,8 @@ func addOperator(ctx context.Context, authClient *auth.Client, operator Operator
 
 	// set custom claims
 	customClaims := map[string]interface{}{
-		"operator_id": operator.operatorID,
+		"operator_id":            operator.operatorID,
+		authentication.RoleClaim: string(authentication.RoleAdmin),
 	}
 	err = authClient.SetCustomUserClaims(ctx, userRecord.UID, customClaims)
 	if err != nil {
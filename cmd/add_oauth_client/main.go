@@ -0,0 +1,67 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/persistence/datastore"
+
+	"github.com/google/uuid"
+)
+
+const secretBytes = 32
+
+func main() {
+	apiKey := flag.String("apiKey", "", "API key linked to the client")
+	scopes := flag.String("scopes", authentication.OAuthScopeSystemAuth, "space-delimited scopes allowed for the client")
+	flag.Parse()
+
+	if *apiKey == "" {
+		log.Fatalf("apiKey is required\n")
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("error reading config: %v\n", err)
+	}
+	conn := config.NewDBConnection(cfg)
+	r := datastore.NewAuthRepository(conn)
+
+	prefix := authentication.APIKeyPrefix(*apiKey)
+	apiKeys, err := r.ListAPIKeys(repository.APIKeysParam{KeyPrefix: &prefix})
+	if err != nil {
+		log.Fatalf("error looking up API key: %v\n", err)
+	}
+	linked, ok := apiKeys.FindAPIKey(*apiKey)
+	if !ok {
+		log.Fatalf("API key not found\n")
+	}
+
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("error generating client secret: %v\n", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	hash, err := authentication.HashOAuthClientSecret(secret)
+	if err != nil {
+		log.Fatalf("error hashing client secret: %v\n", err)
+	}
+
+	client := authentication.OAuthClient{
+		ClientID:         uuid.New().String(),
+		ClientSecretHash: hash,
+		APIKeyID:         linked.ID,
+		Scopes:           strings.Fields(*scopes),
+	}
+	if err := r.CreateOAuthClient(client); err != nil {
+		log.Fatalf("error creating OAuth client: %v\n", err)
+	}
+	fmt.Printf("Successfully created OAuth client for API key %s. Client ID: %s. Client secret: %s\n", linked.ID, client.ClientID, secret)
+}
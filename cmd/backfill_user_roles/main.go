@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"slices"
+
+	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	firebase_client "authenticator-backend/infrastructure/firebase"
+	"authenticator-backend/infrastructure/localidp/entity"
+
+	"google.golang.org/api/iterator"
+)
+
+// the users registered before the roles were introduced have no role and are treated as the viewer.
+// this command grants the given role to them once so that they keep the access they had.
+func main() {
+	role := flag.String("role", "", "role granted to the users without the role (admin|editor|viewer)")
+	flag.Parse()
+
+	if !slices.Contains(authentication.Roles, interface{}(authentication.Role(*role))) {
+		log.Fatalf("role must be one of admin, editor or viewer\n")
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("error reading config: %v\n", err)
+	}
+
+	switch cfg.IDPProvider {
+	case config.IDPProviderLocal:
+		backfillLocalIDP(cfg, authentication.Role(*role))
+	case config.IDPProviderFirebase:
+		backfillFirebase(cfg, authentication.Role(*role))
+	default:
+		log.Fatalf("the roles of the %s identity provider are managed by the provider\n", cfg.IDPProvider)
+	}
+}
+
+func backfillLocalIDP(cfg *config.Config, role authentication.Role) {
+	conn := config.NewDBConnection(cfg)
+
+	result := conn.Model(&entity.LocalUser{}).Where("role = ?", "").Update("role", string(role))
+	if result.Error != nil {
+		log.Fatalf("error updating local users: %v\n", result.Error)
+	}
+	fmt.Printf("Successfully granted role %s to %d local users\n", role, result.RowsAffected)
+}
+
+func backfillFirebase(cfg *config.Config, role authentication.Role) {
+	ctx := context.Background()
+
+	cli, err := firebase_client.NewClient(config.NewFirebaseConfig(cfg).ProjectID, cfg.FirebaseAuthEmulatorHost)
+	if err != nil {
+		log.Fatalf("error initializing firebase client: %v\n", err)
+	}
+
+	count := 0
+	iter := cli.Users(ctx, "")
+	for {
+		user, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			log.Fatalf("error listing users: %v\n", err)
+		}
+		if _, ok := user.CustomClaims[authentication.RoleClaim]; ok {
+			continue
+		}
+
+		customClaims := map[string]interface{}{}
+		for k, v := range user.CustomClaims {
+			customClaims[k] = v
+		}
+		customClaims[authentication.RoleClaim] = string(role)
+		if err := cli.SetCustomUserClaims(ctx, user.UID, customClaims); err != nil {
+			log.Fatalf("error setting custom claims for user %s: %v\n", user.UID, err)
+		}
+		count++
+	}
+	fmt.Printf("Successfully granted role %s to %d firebase users\n", role, count)
+}
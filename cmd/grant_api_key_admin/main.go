@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/persistence/datastore"
+)
+
+const grantUserID = "grant-api-key-admin"
+
+// no API key can manage the API keys until one of them is made the admin, so the first admin API key is granted with this command.
+func main() {
+	apiKey := flag.String("apiKey", "", "API key granted the management of the API keys")
+	admin := flag.Bool("admin", true, "false to withdraw the management of the API keys")
+	flag.Parse()
+
+	if *apiKey == "" {
+		log.Fatalf("apiKey is required\n")
+	}
+
+	cfg, err := config.NewConfig()
+	if err != nil {
+		log.Fatalf("error reading config: %v\n", err)
+	}
+	conn := config.NewDBConnection(cfg)
+	r := datastore.NewAuthRepository(conn)
+
+	prefix := authentication.APIKeyPrefix(*apiKey)
+	apiKeys, err := r.ListAPIKeys(repository.APIKeysParam{KeyPrefix: &prefix})
+	if err != nil {
+		log.Fatalf("error looking up API key: %v\n", err)
+	}
+	target, ok := apiKeys.FindAPIKey(*apiKey)
+	if !ok {
+		log.Fatalf("API key not found\n")
+	}
+
+	if err := r.UpdateAPIKey(repository.UpdateAPIKeyParam{
+		ID:      target.ID,
+		IsAdmin: admin,
+		UserID:  grantUserID,
+	}); err != nil {
+		log.Fatalf("error updating API key: %v\n", err)
+	}
+	fmt.Printf("Successfully set the admin of API key %s to %t\n", target.ID, *admin)
+}
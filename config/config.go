@@ -2,8 +2,28 @@ package config
 
 import (
 	"errors"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IDPProviderFirebase = "firebase"
+	IDPProviderLocal    = "local"
+	IDPProviderOIDC     = "oidc"
+
+	MailDriverSMTP  = "smtp"
+	MailDriverSpool = "spool"
+
+	IPRestrictionModeOff        = "off"
+	IPRestrictionModeReportOnly = "report-only"
+	IPRestrictionModeEnforce    = "enforce"
+
+	ClientIPSourceXForwardedFor = "x-forwarded-for"
+	ClientIPSourceForwarded     = "forwarded"
+	ClientIPSourceDirect        = "direct"
 )
 
 // Config
@@ -33,13 +53,116 @@ type Config struct {
 	SecureTokenAPI           string
 	FirebaseAuthEmulatorHost string
 
-	EnableIpRestriction bool
+	IDPProvider string
+	IDPClient   struct {
+		Timeout                    time.Duration
+		MaxRetries                 int
+		RetryBaseDelay             time.Duration
+		RetryMaxDelay              time.Duration
+		CircuitBreakerThreshold    int
+		CircuitBreakerOpenDuration time.Duration
+	}
+	LocalIDP struct {
+		SigningKey      string
+		Issuer          string
+		IDTokenTTL      time.Duration
+		RefreshTokenTTL time.Duration
+	}
+	OAuth struct {
+		Enabled        bool
+		SigningKey     string
+		Issuer         string
+		AccessTokenTTL time.Duration
+	}
+	OIDC struct {
+		IssuerURL       string
+		ClientID        string
+		ClientSecret    string
+		Scopes          []string
+		OperatorIDClaim string
+	}
+
+	Mail struct {
+		Driver   string
+		From     string
+		SpoolDir string
+		SMTP     struct {
+			Host     string
+			Port     string
+			Username string
+			Password string
+		}
+	}
+	PasswordReset struct {
+		URL            string
+		ThrottleLimit  int
+		ThrottleWindow time.Duration
+	}
+	PasswordPolicy struct {
+		MinLength               int
+		MaxLength               int
+		RequireUpperCase        bool
+		RequireLowerCase        bool
+		RequireDigit            bool
+		RequireSpecialCharacter bool
+		SpecialCharacters       string
+		DenyList                []string
+		DisallowEmailLocalPart  bool
+		HistoryCount            int
+	}
+	LoginThrottle struct {
+		FailureWindow      time.Duration
+		LockoutThreshold   int
+		LockoutDuration    time.Duration
+		DelayThreshold     int
+		BaseDelay          time.Duration
+		MaxDelay           time.Duration
+		IPFailureThreshold int
+		IPFailureWindow    time.Duration
+	}
+	MFA struct {
+		Enabled           bool
+		EncryptionKey     string
+		Issuer            string
+		ChallengeTTL      time.Duration
+		MaxAttempts       int
+		RecoveryCodeCount int
+	}
+	APIKey struct {
+		TTL                   time.Duration
+		RotationGracePeriod   time.Duration
+		ExpiryWarning         time.Duration
+		CacheRefreshInterval  time.Duration
+		RateLimitEnabled      bool
+		RateLimitByOperator   bool
+		IPRestrictionMode     string
+		SignatureClockSkew    time.Duration
+		RequestSigningEnabled bool
+		SigningEncryptionKey  string
+	}
+	ClientIP struct {
+		Source         string
+		TrustedProxies []*net.IPNet
+	}
+	TLS struct {
+		CertFile     string
+		KeyFile      string
+		ClientCAFile string
+	}
+	// RequireClientCertificate rejects the system API callers without the client certificate after the migration from the API key header
+	RequireClientCertificate bool
+
+	CheckRevokedTokens bool
+	// AuthEventBufferSize is the number of the auth events queued until they are recorded, and the events are dropped while the queue is full
+	AuthEventBufferSize int
 }
 
 var (
 	ErrEnvNotDefined    = errors.New("GO_ENV not defined")
 	ErrReadConfigFile   = errors.New("config file read error")
 	ErrConfigFileFormat = errors.New("config file formant error")
+	// ErrTrustedProxiesNotDefined is returned by the deployment which takes the client address from the forwarding header without TRUSTED_PROXIES
+	ErrTrustedProxiesNotDefined = errors.New("TRUSTED_PROXIES not defined: set it to the addresses of the proxies, or set CLIENT_IP_SOURCE to direct")
 )
 
 // NewConfig
@@ -76,9 +199,399 @@ func NewConfig() (*Config, error) {
 
 	cfg.GoogleProjectID = os.Getenv("GOOGLE_PROJECT_ID")
 
-	if current.EnableIpRestriction, err = strconv.ParseBool(os.Getenv("ENABLE_IP_RESTRICTION")); err != nil {
+	cfg.IDPProvider = getEnvDefault("IDP_PROVIDER", IDPProviderFirebase)
+	switch cfg.IDPProvider {
+	case IDPProviderFirebase, IDPProviderLocal, IDPProviderOIDC:
+	default:
+		return nil, ErrConfigFileFormat
+	}
+	if err := loadIDPClient(current); err != nil {
+		return nil, err
+	}
+	cfg.LocalIDP.SigningKey = os.Getenv("LOCAL_IDP_SIGNING_KEY")
+	cfg.LocalIDP.Issuer = getEnvDefault("LOCAL_IDP_ISSUER", "authenticator-backend")
+	if cfg.LocalIDP.IDTokenTTL, err = time.ParseDuration(getEnvDefault("LOCAL_IDP_ID_TOKEN_TTL", "1h")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	if cfg.LocalIDP.RefreshTokenTTL, err = time.ParseDuration(getEnvDefault("LOCAL_IDP_REFRESH_TOKEN_TTL", "720h")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	if cfg.IDPProvider == IDPProviderLocal && cfg.LocalIDP.SigningKey == "" {
+		return nil, ErrReadConfigFile
+	}
+
+	if cfg.OAuth.Enabled, err = strconv.ParseBool(getEnvDefault("OAUTH_ENABLED", "false")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	cfg.OAuth.SigningKey = os.Getenv("OAUTH_SIGNING_KEY")
+	cfg.OAuth.Issuer = getEnvDefault("OAUTH_ISSUER", "authenticator-backend")
+	if cfg.OAuth.AccessTokenTTL, err = time.ParseDuration(getEnvDefault("OAUTH_ACCESS_TOKEN_TTL", "15m")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	// the access tokens can not be issued nor verified without the signing key
+	if cfg.OAuth.Enabled && cfg.OAuth.SigningKey == "" {
+		return nil, ErrReadConfigFile
+	}
+
+	cfg.OIDC.IssuerURL = os.Getenv("OIDC_ISSUER_URL")
+	cfg.OIDC.ClientID = os.Getenv("OIDC_CLIENT_ID")
+	cfg.OIDC.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	cfg.OIDC.Scopes = strings.Fields(getEnvDefault("OIDC_SCOPES", "openid email"))
+	cfg.OIDC.OperatorIDClaim = getEnvDefault("OIDC_OPERATOR_ID_CLAIM", "operator_id")
+	if cfg.IDPProvider == IDPProviderOIDC && (cfg.OIDC.IssuerURL == "" || cfg.OIDC.ClientID == "") {
 		return nil, ErrReadConfigFile
 	}
 
+	cfg.Mail.Driver = getEnvDefault("MAIL_DRIVER", MailDriverSpool)
+	switch cfg.Mail.Driver {
+	case MailDriverSMTP, MailDriverSpool:
+	default:
+		return nil, ErrConfigFileFormat
+	}
+	cfg.Mail.From = getEnvDefault("MAIL_FROM", "no-reply@localhost")
+	cfg.Mail.SpoolDir = getEnvDefault("MAIL_SPOOL_DIR", "mail_spool")
+	cfg.Mail.SMTP.Host = os.Getenv("SMTP_HOST")
+	cfg.Mail.SMTP.Port = getEnvDefault("SMTP_PORT", "587")
+	cfg.Mail.SMTP.Username = os.Getenv("SMTP_USERNAME")
+	cfg.Mail.SMTP.Password = os.Getenv("SMTP_PASSWORD")
+	if cfg.Mail.Driver == MailDriverSMTP && cfg.Mail.SMTP.Host == "" {
+		return nil, ErrReadConfigFile
+	}
+
+	cfg.PasswordReset.URL = os.Getenv("PASSWORD_RESET_URL")
+	if cfg.PasswordReset.ThrottleLimit, err = strconv.Atoi(getEnvDefault("PASSWORD_RESET_THROTTLE_LIMIT", "3")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	if cfg.PasswordReset.ThrottleWindow, err = time.ParseDuration(getEnvDefault("PASSWORD_RESET_THROTTLE_WINDOW", "1h")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+
+	if err := loadPasswordPolicy(current); err != nil {
+		return nil, err
+	}
+	if err := loadLoginThrottle(current); err != nil {
+		return nil, err
+	}
+	if err := loadMFA(current); err != nil {
+		return nil, err
+	}
+	if err := loadAPIKey(current); err != nil {
+		return nil, err
+	}
+	if err := loadClientIP(current); err != nil {
+		return nil, err
+	}
+	if err := loadTLS(current); err != nil {
+		return nil, err
+	}
+
+	if current.CheckRevokedTokens, err = strconv.ParseBool(getEnvDefault("CHECK_REVOKED_TOKENS", "false")); err != nil {
+		return nil, ErrConfigFileFormat
+	}
+	if current.AuthEventBufferSize, err = strconv.Atoi(getEnvDefault("AUTH_EVENT_BUFFER_SIZE", "1024")); err != nil || current.AuthEventBufferSize <= 0 {
+		return nil, ErrConfigFileFormat
+	}
+
 	return current, nil
 }
+
+// loadIDPClient
+// Summary: This is function which loads the timeout, the retries and the circuit breaker of the calls to the identity provider from environment variables
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadIDPClient(cfg *Config) error {
+	var err error
+
+	if cfg.IDPClient.Timeout, err = time.ParseDuration(getEnvDefault("IDP_HTTP_TIMEOUT", "10s")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.IDPClient.MaxRetries, err = strconv.Atoi(getEnvDefault("IDP_HTTP_MAX_RETRIES", "2")); err != nil || cfg.IDPClient.MaxRetries < 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.IDPClient.RetryBaseDelay, err = time.ParseDuration(getEnvDefault("IDP_HTTP_RETRY_BASE_DELAY", "200ms")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.IDPClient.RetryMaxDelay, err = time.ParseDuration(getEnvDefault("IDP_HTTP_RETRY_MAX_DELAY", "2s")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.IDPClient.CircuitBreakerThreshold, err = strconv.Atoi(getEnvDefault("IDP_CIRCUIT_BREAKER_THRESHOLD", "5")); err != nil || cfg.IDPClient.CircuitBreakerThreshold < 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.IDPClient.CircuitBreakerOpenDuration, err = time.ParseDuration(getEnvDefault("IDP_CIRCUIT_BREAKER_OPEN_DURATION", "30s")); err != nil {
+		return ErrConfigFileFormat
+	}
+
+	return nil
+}
+
+// loadPasswordPolicy
+// Summary: This is function which loads the password policy from environment variables
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadPasswordPolicy(cfg *Config) error {
+	var err error
+
+	if cfg.PasswordPolicy.MinLength, err = strconv.Atoi(getEnvDefault("PASSWORD_MIN_LENGTH", "8")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.MaxLength, err = strconv.Atoi(getEnvDefault("PASSWORD_MAX_LENGTH", "20")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.MaxLength > 0 && cfg.PasswordPolicy.MaxLength < cfg.PasswordPolicy.MinLength {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.RequireUpperCase, err = strconv.ParseBool(getEnvDefault("PASSWORD_REQUIRE_UPPER_CASE", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.RequireLowerCase, err = strconv.ParseBool(getEnvDefault("PASSWORD_REQUIRE_LOWER_CASE", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.RequireDigit, err = strconv.ParseBool(getEnvDefault("PASSWORD_REQUIRE_DIGIT", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.RequireSpecialCharacter, err = strconv.ParseBool(getEnvDefault("PASSWORD_REQUIRE_SPECIAL_CHARACTER", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	cfg.PasswordPolicy.SpecialCharacters = getEnvDefault("PASSWORD_SPECIAL_CHARACTERS", "!@#$%^&*")
+	if cfg.PasswordPolicy.DisallowEmailLocalPart, err = strconv.ParseBool(getEnvDefault("PASSWORD_DISALLOW_EMAIL_LOCAL_PART", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.PasswordPolicy.HistoryCount, err = strconv.Atoi(getEnvDefault("PASSWORD_HISTORY_COUNT", "0")); err != nil || cfg.PasswordPolicy.HistoryCount < 0 {
+		return ErrConfigFileFormat
+	}
+
+	if path := os.Getenv("PASSWORD_DENY_LIST_FILE"); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return ErrReadConfigFile
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			cfg.PasswordPolicy.DenyList = append(cfg.PasswordPolicy.DenyList, line)
+		}
+	}
+
+	return nil
+}
+
+// loadLoginThrottle
+// Summary: This is function which loads the thresholds to throttle the failed login attempts from environment variables
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadLoginThrottle(cfg *Config) error {
+	var err error
+
+	if cfg.LoginThrottle.FailureWindow, err = time.ParseDuration(getEnvDefault("LOGIN_FAILURE_WINDOW", "15m")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.LockoutThreshold, err = strconv.Atoi(getEnvDefault("LOGIN_LOCKOUT_THRESHOLD", "5")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.LockoutDuration, err = time.ParseDuration(getEnvDefault("LOGIN_LOCKOUT_DURATION", "15m")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.DelayThreshold, err = strconv.Atoi(getEnvDefault("LOGIN_DELAY_THRESHOLD", "3")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.BaseDelay, err = time.ParseDuration(getEnvDefault("LOGIN_DELAY_BASE", "1s")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.MaxDelay, err = time.ParseDuration(getEnvDefault("LOGIN_DELAY_MAX", "30s")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.IPFailureThreshold, err = strconv.Atoi(getEnvDefault("LOGIN_IP_FAILURE_THRESHOLD", "20")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.LoginThrottle.IPFailureWindow, err = time.ParseDuration(getEnvDefault("LOGIN_IP_FAILURE_WINDOW", "15m")); err != nil {
+		return ErrConfigFileFormat
+	}
+
+	return nil
+}
+
+// loadMFA
+// Summary: This is function which loads the settings of the multi-factor authentication from environment variables
+// MFA_ENCRYPTION_KEY is required when MFA_ENABLED is true since the TOTP secrets are stored encrypted with it.
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadMFA(cfg *Config) error {
+	var err error
+
+	if cfg.MFA.Enabled, err = strconv.ParseBool(getEnvDefault("MFA_ENABLED", "false")); err != nil {
+		return ErrConfigFileFormat
+	}
+	cfg.MFA.EncryptionKey = os.Getenv("MFA_ENCRYPTION_KEY")
+	if cfg.MFA.Enabled && cfg.MFA.EncryptionKey == "" {
+		return ErrReadConfigFile
+	}
+	cfg.MFA.Issuer = getEnvDefault("MFA_ISSUER", "authenticator-backend")
+	if cfg.MFA.ChallengeTTL, err = time.ParseDuration(getEnvDefault("MFA_CHALLENGE_TTL", "5m")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.MFA.MaxAttempts, err = strconv.Atoi(getEnvDefault("MFA_MAX_ATTEMPTS", "5")); err != nil || cfg.MFA.MaxAttempts <= 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.MFA.RecoveryCodeCount, err = strconv.Atoi(getEnvDefault("MFA_RECOVERY_CODE_COUNT", "10")); err != nil || cfg.MFA.RecoveryCodeCount < 0 {
+		return ErrConfigFileFormat
+	}
+
+	return nil
+}
+
+// loadAPIKey
+// Summary: This is function which loads the expiry, the rotation, the rate limiting, the IP address restriction and the request signing of the API keys from environment variables
+// The limit per minute of the rate limiting applies to each instance, while the daily quota is counted in the database and shared by all the instances.
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadAPIKey(cfg *Config) error {
+	var err error
+
+	if cfg.APIKey.TTL, err = time.ParseDuration(getEnvDefault("API_KEY_TTL", "0s")); err != nil || cfg.APIKey.TTL < 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.RotationGracePeriod, err = time.ParseDuration(getEnvDefault("API_KEY_ROTATION_GRACE_PERIOD", "24h")); err != nil || cfg.APIKey.RotationGracePeriod < 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.ExpiryWarning, err = time.ParseDuration(getEnvDefault("API_KEY_EXPIRY_WARNING", "168h")); err != nil || cfg.APIKey.ExpiryWarning < 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.CacheRefreshInterval, err = time.ParseDuration(getEnvDefault("API_KEY_CACHE_REFRESH_INTERVAL", "1m")); err != nil || cfg.APIKey.CacheRefreshInterval <= 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.RateLimitEnabled, err = strconv.ParseBool(getEnvDefault("API_KEY_RATE_LIMIT_ENABLED", "true")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.RateLimitByOperator, err = strconv.ParseBool(getEnvDefault("API_KEY_RATE_LIMIT_BY_OPERATOR", "false")); err != nil {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.IPRestrictionMode, err = loadIPRestrictionMode(); err != nil {
+		return err
+	}
+	if cfg.APIKey.SignatureClockSkew, err = time.ParseDuration(getEnvDefault("API_KEY_SIGNATURE_CLOCK_SKEW", "5m")); err != nil || cfg.APIKey.SignatureClockSkew <= 0 {
+		return ErrConfigFileFormat
+	}
+	if cfg.APIKey.RequestSigningEnabled, err = strconv.ParseBool(getEnvDefault("API_KEY_REQUEST_SIGNING_ENABLED", "false")); err != nil {
+		return ErrConfigFileFormat
+	}
+	// the signing secrets are encrypted with their own key instead of MFA_ENCRYPTION_KEY.
+	// the secrets issued before the key was introduced can not be decrypted, so the request signing of those API keys has to be enabled again
+	cfg.APIKey.SigningEncryptionKey = os.Getenv("API_KEY_SIGNING_ENCRYPTION_KEY")
+	if cfg.APIKey.RequestSigningEnabled && cfg.APIKey.SigningEncryptionKey == "" {
+		return ErrReadConfigFile
+	}
+
+	return nil
+}
+
+// loadIPRestrictionMode
+// Summary: This is function which loads the default mode of the IP address restriction of the API keys.
+// ENABLE_IP_RESTRICTION which has been replaced by API_KEY_IP_RESTRICTION_MODE is still read when the mode is not set.
+// output: (string) mode of the IP address restriction
+// output: (error) error object
+func loadIPRestrictionMode() (string, error) {
+	mode, ok := os.LookupEnv("API_KEY_IP_RESTRICTION_MODE")
+	if !ok {
+		legacy, ok := os.LookupEnv("ENABLE_IP_RESTRICTION")
+		if !ok {
+			return IPRestrictionModeEnforce, nil
+		}
+		enabled, err := strconv.ParseBool(legacy)
+		if err != nil {
+			return "", ErrConfigFileFormat
+		}
+		if !enabled {
+			return IPRestrictionModeOff, nil
+		}
+		return IPRestrictionModeEnforce, nil
+	}
+
+	switch mode {
+	case IPRestrictionModeOff, IPRestrictionModeReportOnly, IPRestrictionModeEnforce:
+		return mode, nil
+	default:
+		return "", ErrConfigFileFormat
+	}
+}
+
+// loadClientIP
+// Summary: This is function which loads the resolution of the IP address of the client from environment variables
+// No proxy is trusted by default. TRUSTED_PROXIES must be set to the addresses of the load balancers when the address is taken
+// from the forwarding header, since the headers appended by the other hosts in the same private network must not be trusted.
+// The deployment which has taken the address from X-Forwarded-For without TRUSTED_PROXIES has to set it to the network of its load balancer,
+// or set CLIENT_IP_SOURCE to direct when the server is not behind any proxy.
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadClientIP(cfg *Config) error {
+	cfg.ClientIP.Source = getEnvDefault("CLIENT_IP_SOURCE", ClientIPSourceXForwardedFor)
+	switch cfg.ClientIP.Source {
+	case ClientIPSourceXForwardedFor, ClientIPSourceForwarded, ClientIPSourceDirect:
+	default:
+		return ErrConfigFileFormat
+	}
+
+	cfg.ClientIP.TrustedProxies = nil
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		// the address of a single proxy is trusted as the network of the address only
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				bits = 8 * net.IPv4len
+			}
+			proxy += "/" + strconv.Itoa(bits)
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return ErrConfigFileFormat
+		}
+		cfg.ClientIP.TrustedProxies = append(cfg.ClientIP.TrustedProxies, network)
+	}
+	if cfg.ClientIP.Source != ClientIPSourceDirect && len(cfg.ClientIP.TrustedProxies) == 0 {
+		return ErrTrustedProxiesNotDefined
+	}
+
+	return nil
+}
+
+// loadTLS
+// Summary: This is function which loads the TLS of the server from environment variables
+// The server is started without TLS when the certificate is not set, and the client certificate is verified when the CA bundle is set.
+// input: cfg(*Config) pointer of Config struct
+// output: (error) error object
+func loadTLS(cfg *Config) error {
+	var err error
+
+	cfg.TLS.CertFile = os.Getenv("TLS_CERT_FILE")
+	cfg.TLS.KeyFile = os.Getenv("TLS_KEY_FILE")
+	cfg.TLS.ClientCAFile = os.Getenv("TLS_CLIENT_CA_FILE")
+	if (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") || (cfg.TLS.ClientCAFile != "" && cfg.TLS.CertFile == "") {
+		return ErrReadConfigFile
+	}
+	if cfg.RequireClientCertificate, err = strconv.ParseBool(getEnvDefault("SYSTEM_AUTH_REQUIRE_CLIENT_CERTIFICATE", "false")); err != nil {
+		return ErrConfigFileFormat
+	}
+	// the client certificate can not be presented without the CA bundle
+	if cfg.RequireClientCertificate && cfg.TLS.ClientCAFile == "" {
+		return ErrReadConfigFile
+	}
+
+	return nil
+}
+
+// getEnvDefault
+// Summary: This is function which gets the environment variable or the default value when it is not set
+// input: key(string) environment variable name
+// input: defaultValue(string) value used when the environment variable is empty
+// output: (string) environment variable value
+func getEnvDefault(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
@@ -12,7 +12,12 @@ func NewDBConnection(cfg *Config) *gorm.DB {
 	return getPostgreSQLConn(cfg)
 }
 
-func getPostgreSQLConn(cfg *Config) *gorm.DB {
+// PostgreSQLDSN
+// Summary: This is function which builds the connection string of the database.
+// The connection string is shared by the connection pool and the listener of the notifications.
+// input: cfg(*Config) pointer of Config struct
+// output: (string) connection string
+func PostgreSQLDSN(cfg *Config) string {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s database=%s port=%s",
 		cfg.Database.Host,
@@ -38,7 +43,11 @@ func getPostgreSQLConn(cfg *Config) *gorm.DB {
 			dbRootCert, dbCert, dbKey)
 	}
 
-	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	return dsn
+}
+
+func getPostgreSQLConn(cfg *Config) *gorm.DB {
+	conn, err := gorm.Open(postgres.Open(PostgreSQLDSN(cfg)), &gorm.Config{})
 	if err != nil {
 		panic(err)
 	}
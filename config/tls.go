@@ -0,0 +1,47 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+)
+
+var ErrClientCAFile = errors.New("no certificate is found in the client CA file")
+
+// NewTLSConfig
+// Summary: This is function which creates the TLS configuration of the server.
+// The client certificate is verified with the CA bundle when it is presented, so that the clients without the certificate
+// can still call the APIs authenticated with the API key header. The system API validator decides whether it is required.
+// input: cfg(*Config) pointer of Config struct
+// output: (*tls.Config) TLS configuration, or nil when the server is started without TLS
+// output: (error) error object
+func NewTLSConfig(cfg *Config) (*tls.Config, error) {
+	if cfg.TLS.CertFile == "" {
+		return nil, nil
+	}
+	certificate, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.TLS.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	bundle, err := os.ReadFile(cfg.TLS.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(bundle) {
+		return nil, ErrClientCAFile
+	}
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+
+	return tlsConfig, nil
+}
@@ -9,6 +9,16 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// ClientIP
+// Summary: This is function which returns the IP address of the client
+// The address is resolved by the IP extractor of echo, which reads the forwarding headers only from the trusted proxies
+// so that it can not be spoofed by the client.
+// input: c(echo.Context): echo context
+// output: (string) IP address of the client
+func ClientIP(c echo.Context) string {
+	return c.RealIP()
+}
+
 // QueryParamPtr
 // Summary: This is function which returns the query parameter value as a pointer
 // input: c(echo.Context): echo context
@@ -10,28 +10,41 @@ import (
 )
 
 type HTTPError struct {
-	Code    string `json:"code"`
+	Code       string           `json:"code"`
+	Message    string           `json:"message"`
+	Detail     string           `json:"detail"`
+	Reason     string           `json:"reason,omitempty"`
+	Violations []ErrorViolation `json:"violations,omitempty"`
+}
+
+// ErrorViolation
+// Summary: This is structure which defines the rule which the request violates.
+type ErrorViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
-	Detail  string `json:"detail"`
 }
 
 // 下記はSwagger用のModel
 type HTTP400Error struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-	Detail  string `json:"detail"`
+	Code       string           `json:"code"`
+	Message    string           `json:"message"`
+	Detail     string           `json:"detail"`
+	Violations []ErrorViolation `json:"violations,omitempty"`
 }
 
 type HTTP401Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 type HTTP403Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 type HTTP404Error struct {
@@ -40,6 +53,25 @@ type HTTP404Error struct {
 	Detail  string `json:"detail"`
 }
 
+type HTTP409Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+}
+
+type HTTP423Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+}
+
+type HTTP429Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail"`
+	Reason  string `json:"reason,omitempty"`
+}
+
 type HTTP500Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -50,32 +82,76 @@ type HTTP503Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Detail  string `json:"detail"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 var (
 	// 400 Error Messages
-	Err400InvalidRequest  = "Invalid request parameters"
-	Err400InvalidJSON     = "Invalid JSON format"
-	Err400RequestTooLarge = "Request payload too large"
-	Err400Validation      = "Validation failed"
+	Err400InvalidRequest    = "Invalid request parameters"
+	Err400InvalidJSON       = "Invalid JSON format"
+	Err400RequestTooLarge   = "Request payload too large"
+	Err400Validation        = "Validation failed"
+	Err400InvalidResetCode  = "Invalid or expired password reset code"
+	Err400PasswordPolicy    = "Password does not satisfy the password policy"
+	Err400MFAAlreadyEnabled = "MFA is already enabled"
+	Err400MFANotEnrolled    = "MFA is not enrolled"
+	Err400OperatorNotFound  = "Operator does not exist"
 	// 401 Error Messages
-	Err401InvalidCredentials = "Invalid credentials"
-	Err401Authentication     = "Authentication required"
-	Err401InvalidToken       = "Invalid or expired token"
+	Err401InvalidCredentials  = "Invalid credentials"
+	Err401Authentication      = "Authentication required"
+	Err401InvalidToken        = "Invalid or expired token"
+	Err401InvalidMFACode      = "Invalid MFA code"
+	Err401InvalidMFAChallenge = "Invalid or expired MFA challenge"
 	// 403 Error Messages
-	Err403AccessDenied          = "You do not have the necessary privileges"
-	Err403InvalidKey            = "Invalid key"
-	Err403IPNotAuthorizedForKey = "IP address not authorized for this API key"
+	Err403AccessDenied              = "You do not have the necessary privileges"
+	Err403InvalidKey                = "Invalid key"
+	Err403IPNotAuthorizedForKey     = "IP address not authorized for this API key"
+	Err403RouteNotAuthorizedForKey  = "Endpoint not authorized for this API key"
+	Err403UserDisabled              = "User is disabled"
+	Err403ClientCertificateRequired = "Client certificate required"
+	Err403InvalidSignature          = "Invalid request signature"
+	Err403APIKeyNotAdmin            = "API key not authorized to manage API keys"
 	// 404 Error Messages
 	Err404ResourceNotFound = "Resource Not Found"
 	Err404ItemNotFound     = "Item or record Not Found"
 	Err404EndpointNotFound = "Endpoint Not Found"
+	Err404UserNotFound     = "User not found"
+	Err404APIKeyNotFound   = "API key not found"
+	// 409 Error Messages
+	Err409EmailAlreadyExists = "Email address is already registered"
+	// 423 Error Messages
+	Err423AccountLocked = "Account is temporarily locked"
+	// 429 Error Messages
+	Err429TooManyRequests      = "Too many requests"
+	Err429TooManyLoginAttempts = "Too many login attempts"
+	Err429RateLimitExceeded    = "Rate limit exceeded for this API key"
+	Err429QuotaExceeded        = "Daily quota exceeded for this API key"
 	// 500 Error Messages
 	Err500Unexpected = "Unexpected error occurred"
 	// 503 Error Messages
 	Err503OuterService = "Unexpected error occurred in outer service"
 )
 
+// Reason codes of the authentication errors which the clients can branch on.
+const (
+	ReasonInvalidCredentials  = "INVALID_CREDENTIALS"
+	ReasonInvalidRefreshToken = "INVALID_REFRESH_TOKEN"
+	ReasonUserDisabled        = "USER_DISABLED"
+	ReasonTooManyAttempts     = "TOO_MANY_ATTEMPTS"
+	ReasonTokenExpired        = "TOKEN_EXPIRED"
+	ReasonIdPQuotaExceeded    = "IDP_QUOTA_EXCEEDED"
+	ReasonIdPUnavailable      = "IDP_UNAVAILABLE"
+	ReasonAPIKeyExpired       = "API_KEY_EXPIRED"
+	ReasonAPIKeyNotYetValid   = "API_KEY_NOT_YET_VALID"
+	ReasonRateLimitExceeded   = "RATE_LIMIT_EXCEEDED"
+	ReasonQuotaExceeded       = "QUOTA_EXCEEDED"
+	ReasonIPNotAuthorized     = "IP_NOT_AUTHORIZED"
+	ReasonSignatureRequired   = "SIGNATURE_REQUIRED"
+	ReasonSignatureExpired    = "SIGNATURE_EXPIRED"
+	ReasonSignatureInvalid    = "SIGNATURE_INVALID"
+	ReasonNonceReused         = "NONCE_REUSED"
+)
+
 // HTTPErrorSource
 // Summary: This is enum which defines HTTPErrorSource.
 type HTTPErrorSource string
@@ -151,6 +227,27 @@ func HTTPErrorGenerate(
 			Detail:  detailMessage,
 		}
 		return 404, errorModel
+	case 409:
+		errorModel := HTTPError{
+			Code:    formatErrorCode("Conflict", source),
+			Message: errorMsg,
+			Detail:  detailMessage,
+		}
+		return 409, errorModel
+	case 423:
+		errorModel := HTTPError{
+			Code:    formatErrorCode("Locked", source),
+			Message: errorMsg,
+			Detail:  detailMessage,
+		}
+		return 423, errorModel
+	case 429:
+		errorModel := HTTPError{
+			Code:    formatErrorCode("TooManyRequests", source),
+			Message: errorMsg,
+			Detail:  detailMessage,
+		}
+		return 429, errorModel
 	case 500:
 		errorModel := HTTPError{
 			Code:    formatErrorCode("InternalServerError", source),
@@ -175,6 +272,58 @@ func HTTPErrorGenerate(
 	}
 }
 
+// HTTPErrorGenerateWithViolations
+// Summary: This is the function to generate HTTPError which reports the violated rules.
+// input: httpStatusCode(int) http status code
+// input: source(HTTPErrorSource) source of error
+// input: errorMsg(string) error message
+// input: operatorID(string) ID of the operator
+// input: dataTarget(string) target of the data
+// input: method(string) method of the request
+// input: violations([]ErrorViolation) violated rules
+// output: (int) http status code
+// output: (HTTPError) HTTPError object
+func HTTPErrorGenerateWithViolations(
+	httpStatusCode int,
+	source HTTPErrorSource,
+	errorMsg string,
+	operatorID string,
+	dataTarget string,
+	method string,
+	violations []ErrorViolation,
+) (int, HTTPError) {
+	code, errorModel := HTTPErrorGenerate(httpStatusCode, source, errorMsg, operatorID, dataTarget, method)
+	errorModel.Violations = violations
+
+	return code, errorModel
+}
+
+// HTTPErrorGenerateWithReason
+// Summary: This is the function to generate HTTPError which reports the reason code of the error.
+// input: httpStatusCode(int) http status code
+// input: source(HTTPErrorSource) source of error
+// input: errorMsg(string) error message
+// input: operatorID(string) ID of the operator
+// input: dataTarget(string) target of the data
+// input: method(string) method of the request
+// input: reason(string) reason code of the error
+// output: (int) http status code
+// output: (HTTPError) HTTPError object
+func HTTPErrorGenerateWithReason(
+	httpStatusCode int,
+	source HTTPErrorSource,
+	errorMsg string,
+	operatorID string,
+	dataTarget string,
+	method string,
+	reason string,
+) (int, HTTPError) {
+	code, errorModel := HTTPErrorGenerate(httpStatusCode, source, errorMsg, operatorID, dataTarget, method)
+	errorModel.Reason = reason
+
+	return code, errorModel
+}
+
 // formatErrorCode
 // Summary: This is the function to format error code.
 // input: code(string) error code
@@ -191,6 +340,9 @@ type CustomError struct {
 	Message       string
 	MessageDetail *string
 	Source        HTTPErrorSource
+	Violations    []ErrorViolation
+	RetryAfter    time.Duration
+	Reason        string
 }
 
 // NewCustomError
@@ -209,6 +361,54 @@ func NewCustomError(code CustomErrorCode, message string, messageDetail *string,
 	}
 }
 
+// NewCustomErrorWithViolations
+// Summary: This is the function to create new CustomError which reports the violated rules.
+// input: code(CustomErrorCode) error code
+// input: message(string) error message
+// input: violations([]ErrorViolation) violated rules
+// input: source(HTTPErrorSource) source of error
+// output: (*CustomError) CustomError object
+func NewCustomErrorWithViolations(code CustomErrorCode, message string, violations []ErrorViolation, source HTTPErrorSource) *CustomError {
+	return &CustomError{
+		Code:       code,
+		Message:    message,
+		Source:     source,
+		Violations: violations,
+	}
+}
+
+// NewCustomErrorWithRetryAfter
+// Summary: This is the function to create new CustomError which tells when the request can be retried.
+// input: code(CustomErrorCode) error code
+// input: message(string) error message
+// input: retryAfter(time.Duration) period to wait before retrying the request
+// input: source(HTTPErrorSource) source of error
+// output: (*CustomError) CustomError object
+func NewCustomErrorWithRetryAfter(code CustomErrorCode, message string, retryAfter time.Duration, source HTTPErrorSource) *CustomError {
+	return &CustomError{
+		Code:       code,
+		Message:    message,
+		Source:     source,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewCustomErrorWithReason
+// Summary: This is the function to create new CustomError which reports the reason code of the error.
+// input: code(CustomErrorCode) error code
+// input: message(string) error message
+// input: reason(string) reason code of the error
+// input: source(HTTPErrorSource) source of error
+// output: (*CustomError) CustomError object
+func NewCustomErrorWithReason(code CustomErrorCode, message string, reason string, source HTTPErrorSource) *CustomError {
+	return &CustomError{
+		Code:    code,
+		Message: message,
+		Source:  source,
+		Reason:  reason,
+	}
+}
+
 // Error
 // Summary: This is the function to get error message.
 // output: (string) error message
@@ -232,10 +432,51 @@ var (
 	CustomErrorCode401 CustomErrorCode = http.StatusUnauthorized
 	CustomErrorCode403 CustomErrorCode = http.StatusForbidden
 	CustomErrorCode404 CustomErrorCode = http.StatusNotFound
+	CustomErrorCode409 CustomErrorCode = http.StatusConflict
+	CustomErrorCode423 CustomErrorCode = http.StatusLocked
+	CustomErrorCode429 CustomErrorCode = http.StatusTooManyRequests
 	CustomErrorCode500 CustomErrorCode = http.StatusInternalServerError
 	CustomErrorCode503 CustomErrorCode = http.StatusServiceUnavailable
 )
 
+// OAuth 2.0 error codes (RFC 6749 section 5.2)
+const (
+	OAuthErrInvalidRequest       = "invalid_request"
+	OAuthErrInvalidClient        = "invalid_client"
+	OAuthErrUnsupportedGrantType = "unsupported_grant_type"
+	OAuthErrInvalidScope         = "invalid_scope"
+)
+
+// OAuthError
+// Summary: This is structure which defines the error of the OAuth 2.0 token endpoint.
+// It is reported in the format of RFC 6749 instead of the HTTPError so that the OAuth client libraries can handle it.
+type OAuthError struct {
+	Code        CustomErrorCode
+	ErrorCode   string
+	Description string
+}
+
+// NewOAuthError
+// Summary: This is the function to create new OAuthError.
+// input: code(CustomErrorCode) HTTP status code
+// input: errorCode(string) OAuth 2.0 error code
+// input: description(string) human-readable description
+// output: (*OAuthError) OAuthError object
+func NewOAuthError(code CustomErrorCode, errorCode string, description string) *OAuthError {
+	return &OAuthError{
+		Code:        code,
+		ErrorCode:   errorCode,
+		Description: description,
+	}
+}
+
+// Error
+// Summary: This is the function to get error message.
+// output: (string) error message
+func (e OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.ErrorCode, e.Description)
+}
+
 // FormatBindErrMsg
 // Summary: This is the function to format bind error message.
 // input: err(error) error object
@@ -1,18 +1,151 @@
 package authentication
 
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyBytes is the length of the random bytes of the generated API key
+	apiKeyBytes = 32
+	// APIKeyPrefixLength is the number of the leading characters of the API key stored in plaintext to look up the key
+	APIKeyPrefixLength = 8
+)
+
 // APIKey
 // Summary: This is structure which defines the APIKey model.
+// The API key itself is not stored. KeyPrefix is used to look up the key, and KeyDigest is the SHA-256 digest of the whole key.
+// The API key is valid from NotBefore until ExpiresAt, and never expires when ExpiresAt is nil.
+// RateLimit overrides the default limits of the application attribute, and IPRestrictionMode overrides the default mode of the policy.
+// The requests with the API key must be signed when SigningSecret is set, and SigningSecret is encrypted with SecretCipher.
+// Only the API key with IsAdmin can manage the API keys, and IsAdmin is granted in the database instead of the API.
+// DBName: api_keys
 type APIKey struct {
-	ID              string
-	APIKey          string
-	ApplicationName string
-	Attribute       ApplicationAttribute
+	ID                string
+	KeyPrefix         string
+	KeyDigest         string
+	ApplicationName   string
+	Attribute         ApplicationAttribute `gorm:"column:application_attribute"`
+	NotBefore         time.Time
+	ExpiresAt         *time.Time
+	RateLimit         APIKeyRateLimit `gorm:"embedded"`
+	IPRestrictionMode *IPRestrictionMode
+	SigningSecret     *string
+	IsAdmin           bool
+	CreatedAt         time.Time
+	CreatedUserID     string
+	UpdatedAt         time.Time
+	UpdatedUserID     string
 }
 
 // APIKeys
 // Summary: This is structure which defines the slice of APIKey.
 type APIKeys []APIKey
 
+// APIKeyPolicy
+// Summary: This is structure which defines the policy of the expiry and the rotation of the API keys.
+type APIKeyPolicy struct {
+	// TTL is the period the issued API key is valid, and the API key never expires when it is zero
+	TTL time.Duration
+	// RotationGracePeriod is the period both the rotated API key and its successor are valid
+	RotationGracePeriod time.Duration
+	// ExpiryWarning is the period before the expiry in which the API key is reported to expire soon
+	ExpiryWarning time.Duration
+	// IPRestrictionMode is the mode of the IP address restriction of the API keys which have no mode of their own
+	IPRestrictionMode IPRestrictionMode
+	// SignatureClockSkew is the maximum difference between the timestamp of the signed request and the current time
+	SignatureClockSkew time.Duration
+}
+
+// ExpiresAt
+// Summary: This is the function which returns the expiry of the API key issued with this policy.
+// input: notBefore(time.Time): time the API key becomes valid
+// output: (*time.Time) expiry of the API key. nil if the API key never expires
+func (p APIKeyPolicy) ExpiresAt(notBefore time.Time) *time.Time {
+	if p.TTL <= 0 {
+		return nil
+	}
+	expiresAt := notBefore.Add(p.TTL)
+	return &expiresAt
+}
+
+// RotatedExpiresAt
+// Summary: This is the function which returns the expiry of the rotated API key.
+// The rotated API key is valid until the end of the grace period, or until its own expiry if it comes earlier.
+// input: apiKey(APIKey): rotated API key
+// input: now(time.Time): time of the rotation
+// output: (time.Time) expiry of the rotated API key
+func (p APIKeyPolicy) RotatedExpiresAt(apiKey APIKey, now time.Time) time.Time {
+	expiresAt := now.Add(p.RotationGracePeriod)
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(expiresAt) {
+		return *apiKey.ExpiresAt
+	}
+	return expiresAt
+}
+
+// ExpiresSoon
+// Summary: This is the function which checks whether the API key expires within the warning period.
+// input: apiKey(APIKey): API key
+// input: now(time.Time): current time
+// output: (bool) true if the API key expires within the warning period, false otherwise
+func (p APIKeyPolicy) ExpiresSoon(apiKey APIKey, now time.Time) bool {
+	return apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Sub(now) <= p.ExpiryWarning
+}
+
+// IPRestrictionModeOf
+// Summary: This is the function which returns the mode of the IP address restriction of the API key.
+// input: apiKey(APIKey): API key
+// output: (IPRestrictionMode) mode of the IP address restriction
+func (p APIKeyPolicy) IPRestrictionModeOf(apiKey APIKey) IPRestrictionMode {
+	if apiKey.IPRestrictionMode != nil {
+		return *apiKey.IPRestrictionMode
+	}
+	return p.IPRestrictionMode
+}
+
+// AcceptsSignedAt
+// Summary: This is the function which checks whether the timestamp of the signed request is within the clock skew.
+// input: signedAt(time.Time): timestamp of the signed request
+// input: now(time.Time): current time
+// output: (bool) true if the timestamp is accepted, false otherwise
+func (p APIKeyPolicy) AcceptsSignedAt(signedAt time.Time, now time.Time) bool {
+	skew := now.Sub(signedAt)
+	return -p.SignatureClockSkew <= skew && skew <= p.SignatureClockSkew
+}
+
+// NonceExpiresAt
+// Summary: This is the function which returns the time until which the nonce of the signed request must be remembered.
+// The request replayed after that is rejected by its timestamp, so the nonce can be forgotten.
+// input: signedAt(time.Time): timestamp of the signed request
+// output: (time.Time) expiry of the nonce
+func (p APIKeyPolicy) NonceExpiresAt(signedAt time.Time) time.Time {
+	return signedAt.Add(p.SignatureClockSkew)
+}
+
+// IPRestrictionMode
+// Summary: This is the type which defines how the CIDR rules of the API key are applied to the requests.
+type IPRestrictionMode string
+
+const (
+	// IPRestrictionModeOff does not check the IP address
+	IPRestrictionModeOff IPRestrictionMode = "off"
+	// IPRestrictionModeReportOnly logs and audits the IP address which is not allowed, but accepts the request
+	IPRestrictionModeReportOnly IPRestrictionMode = "report-only"
+	// IPRestrictionModeEnforce rejects the request from the IP address which is not allowed
+	IPRestrictionModeEnforce IPRestrictionMode = "enforce"
+)
+
+// IPRestrictionModes
+// Summary: This is the list of the modes of the IP address restriction which can be assigned to the API key.
+var IPRestrictionModes = []interface{}{IPRestrictionModeOff, IPRestrictionModeReportOnly, IPRestrictionModeEnforce}
+
 // ApplicationAttribute
 // Summary: This is the type which defines the application attribute enum.
 type ApplicationAttribute string
@@ -23,15 +156,124 @@ const (
 	ApplicationAttributeTraceability ApplicationAttribute = "Traceability"
 )
 
-// ContainsAPIKey
-// Summary: This is the function which checks whether the API key exists in this struct slice.
-// input: apiKey(string): API key
+// ApplicationAttributes
+// Summary: This is the list of the application attributes which can be assigned to the API key.
+var ApplicationAttributes = []interface{}{ApplicationAttributeDataSpace, ApplicationAttributeApplication, ApplicationAttributeTraceability}
+
+// NewAPIKey
+// Summary: This is the function which creates the APIKey with the generated ID and key.
+// The generated key is returned only here because the APIKey holds its digest.
+// input: applicationName(string): application name
+// input: attribute(ApplicationAttribute): application attribute
+// input: userID(string): ID of the user who creates the API key
+// input: notBefore(time.Time): time the API key becomes valid
+// input: expiresAt(*time.Time): expiry of the API key. nil if the API key never expires
+// output: (APIKey) API key
+// output: (string) generated key
+// output: (error) error object
+func NewAPIKey(applicationName string, attribute ApplicationAttribute, userID string, notBefore time.Time, expiresAt *time.Time) (APIKey, string, error) {
+	key, err := GenerateAPIKey()
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	return APIKey{
+		ID:              uuid.New().String(),
+		KeyPrefix:       APIKeyPrefix(key),
+		KeyDigest:       DigestAPIKey(key),
+		ApplicationName: applicationName,
+		Attribute:       attribute,
+		NotBefore:       notBefore,
+		ExpiresAt:       expiresAt,
+		CreatedUserID:   userID,
+		UpdatedUserID:   userID,
+	}, key, nil
+}
+
+// GenerateAPIKey
+// Summary: This is the function which generates a new random API key.
+// output: (string) API key
+// output: (error) error object
+func GenerateAPIKey() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// APIKeyPrefix
+// Summary: This is the function which returns the prefix of the API key used to look up the key.
+// input: key(string): API key
+// output: (string) prefix of the API key
+func APIKeyPrefix(key string) string {
+	if len(key) <= APIKeyPrefixLength {
+		return key
+	}
+	return key[:APIKeyPrefixLength]
+}
+
+// DigestAPIKey
+// Summary: This is the function which returns the SHA-256 digest of the API key.
+// input: key(string): API key
+// output: (string) hex-encoded digest
+func DigestAPIKey(key string) string {
+	digest := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(digest[:])
+}
+
+// MaskAPIKey
+// Summary: This is the function which masks the API key except for the prefix so that it can be logged.
+// input: key(string): API key
+// output: (string) masked API key
+func MaskAPIKey(key string) string {
+	prefix := APIKeyPrefix(key)
+	return prefix + strings.Repeat("*", len(key)-len(prefix))
+}
+
+// Matches
+// Summary: This is the function which checks whether the API key matches the digest in constant time.
+// input: key(string): API key
+// output: (bool) true if the API key matches, false otherwise
+func (m APIKey) Matches(key string) bool {
+	return subtle.ConstantTimeCompare([]byte(DigestAPIKey(key)), []byte(m.KeyDigest)) == 1
+}
+
+// RequiresSignature
+// Summary: This is the function which checks whether the requests with the API key must be signed.
+// output: (bool) true if the signing secret is set, false otherwise
+func (m APIKey) RequiresSignature() bool {
+	return m.SigningSecret != nil
+}
+
+// IsActive
+// Summary: This is the function which checks whether the API key is valid at the time.
+// input: now(time.Time): current time
+// output: (bool) true if the API key is valid, false otherwise
+func (m APIKey) IsActive(now time.Time) bool {
+	return !now.Before(m.NotBefore) && !m.IsExpired(now)
+}
+
+// IsExpired
+// Summary: This is the function which checks whether the API key has expired at the time.
+// input: now(time.Time): current time
+// output: (bool) true if the API key has expired, false otherwise
+func (m APIKey) IsExpired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
+
+// FindAPIKey
+// Summary: This is the function which finds the API key in this struct slice.
+// All the API keys are compared so that the time does not depend on the position of the matched key.
+// input: key(string): API key
+// output: (APIKey) matched API key
 // output: (bool) true if the API key exists in this slice, false otherwise
-func (ms APIKeys) ContainsAPIKey(apiKey string) bool {
+func (ms APIKeys) FindAPIKey(key string) (APIKey, bool) {
+	var found APIKey
+	ok := false
 	for _, m := range ms {
-		if m.APIKey == apiKey {
-			return true
+		if m.Matches(key) {
+			found, ok = m, true
 		}
 	}
-	return false
+	return found, ok
 }
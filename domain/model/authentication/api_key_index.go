@@ -0,0 +1,120 @@
+package authentication
+
+import (
+	"crypto/x509"
+)
+
+// APIKeyIndex
+// Summary: This is structure which defines the in-memory index of the API keys, their CIDRs, their client certificates and their permissions.
+// The API keys are indexed by the digest and the ID, and the CIDR rules are parsed and sorted in advance.
+// The index is immutable so that it can be shared by the concurrent requests.
+type APIKeyIndex struct {
+	byDigest      map[string]APIKey
+	byID          map[string]APIKey
+	rules         map[string]cidrRules
+	byCertificate map[ClientCertificate]string
+	permissions   map[string]APIKeyPermissions
+}
+
+// NewAPIKeyIndex
+// Summary: This is the function which creates the index of the API keys, the CIDRs, the client certificates and the permissions.
+// The CIDR which cannot be parsed is not indexed, and it matches no IP address.
+// input: apiKeys(APIKeys): API keys which are not revoked
+// input: cidrs(Cidrs): CIDR rules of the API keys
+// input: certificates(ClientCertificates): client certificates mapped to the API keys
+// input: permissions(APIKeyPermissions): route permissions of the API keys
+// output: (APIKeyIndex) index of the API keys
+func NewAPIKeyIndex(apiKeys APIKeys, cidrs Cidrs, certificates ClientCertificates, permissions APIKeyPermissions) APIKeyIndex {
+	index := APIKeyIndex{
+		byDigest:      make(map[string]APIKey, len(apiKeys)),
+		byID:          make(map[string]APIKey, len(apiKeys)),
+		rules:         make(map[string]cidrRules),
+		byCertificate: make(map[ClientCertificate]string, len(certificates)),
+		permissions:   make(map[string]APIKeyPermissions),
+	}
+	for _, apiKey := range apiKeys {
+		index.byDigest[apiKey.KeyDigest] = apiKey
+		index.byID[apiKey.ID] = apiKey
+	}
+	cidrsByAPIKey := map[string]Cidrs{}
+	for _, cidr := range cidrs {
+		if cidr == nil {
+			continue
+		}
+		cidrsByAPIKey[cidr.APIKeyID] = append(cidrsByAPIKey[cidr.APIKeyID], cidr)
+	}
+	for apiKeyID, apiKeyCidrs := range cidrsByAPIKey {
+		index.rules[apiKeyID] = newCidrRules(apiKeyCidrs)
+	}
+	for _, certificate := range certificates {
+		if certificate == nil {
+			continue
+		}
+		index.byCertificate[ClientCertificate{Type: certificate.Type, Certificate: certificate.Certificate}] = certificate.APIKeyID
+	}
+	for _, permission := range permissions {
+		index.permissions[permission.APIKeyID] = append(index.permissions[permission.APIKeyID], permission)
+	}
+	return index
+}
+
+// FindAPIKey
+// Summary: This is the function which finds the API key by the digest of the key.
+// The lookup does not need the constant time comparison because it depends only on the digest of the given key.
+// input: key(string): API key
+// output: (APIKey) API key
+// output: (bool) true if the API key is found, false otherwise
+func (m APIKeyIndex) FindAPIKey(key string) (APIKey, bool) {
+	if key == "" {
+		return APIKey{}, false
+	}
+	apiKey, ok := m.byDigest[DigestAPIKey(key)]
+	return apiKey, ok
+}
+
+// GetAPIKey
+// Summary: This is the function which gets the API key by the ID.
+// input: id(string): ID of the API key
+// output: (APIKey) API key
+// output: (bool) true if the API key is found, false otherwise
+func (m APIKeyIndex) GetAPIKey(id string) (APIKey, bool) {
+	apiKey, ok := m.byID[id]
+	return apiKey, ok
+}
+
+// Allows
+// Summary: This is the function which checks whether the CIDR rules of the API key allow the IP address.
+// input: apiKeyID(string): ID of the API key
+// input: ip(string): IP address
+// output: (bool) true if the IP address is allowed, false otherwise
+func (m APIKeyIndex) Allows(apiKeyID string, ip string) bool {
+	return m.rules[apiKeyID].allows(ip)
+}
+
+// AllowsRoute
+// Summary: This is the function which checks whether the permissions of the API key allow the route.
+// input: apiKeyID(string): ID of the API key
+// input: method(string): HTTP method
+// input: path(string): route path
+// output: (bool) true if the route is allowed, false otherwise
+func (m APIKeyIndex) AllowsRoute(apiKeyID string, method string, path string) bool {
+	return m.permissions[apiKeyID].Allows(method, path)
+}
+
+// FindAPIKeyByCertificate
+// Summary: This is the function which finds the API key mapped to the client certificate.
+// The mapping by the fingerprint takes precedence over the mapping by the subject DN.
+// input: cert(*x509.Certificate): client certificate verified with the CA bundle
+// output: (APIKey) API key
+// output: (bool) true if the API key is found, false otherwise
+func (m APIKeyIndex) FindAPIKeyByCertificate(cert *x509.Certificate) (APIKey, bool) {
+	for _, certificate := range []ClientCertificate{
+		{Type: ClientCertificateTypeFingerprint, Certificate: CertificateFingerprint(cert)},
+		{Type: ClientCertificateTypeSubject, Certificate: CertificateSubject(cert)},
+	} {
+		if apiKeyID, ok := m.byCertificate[certificate]; ok {
+			return m.GetAPIKey(apiKeyID)
+		}
+	}
+	return APIKey{}, false
+}
@@ -0,0 +1,128 @@
+package authentication_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyIndex テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：APIキーで検索
+// [x] 1-2: 正常系：IDで検索
+// [x] 1-3: 正常系：APIキーのCIDRに含まれるIPアドレスの場合
+// [x] 1-4: 正常系：優先度の高い許可ルールが拒否ルールより先に評価される場合
+// [x] 1-5: 正常系：IPv6のCIDRに含まれるIPアドレスの場合
+// [x] 1-6: 正常系：フィンガープリントに対応付けられたクライアント証明書で検索
+// [x] 1-7: 正常系：サブジェクトDNに対応付けられたクライアント証明書で検索
+// [x] 1-8: 正常系：APIキーの権限で許可されたルートの場合
+// [x] 2-1: 異常系：登録されていないAPIキー・空のAPIキーの場合
+// [x] 2-2: 異常系：登録されていないIDの場合
+// [x] 2-3: 異常系：他のAPIキーのCIDRにのみ含まれるIPアドレスの場合
+// [x] 2-4: 異常系：IPアドレスの形式でない場合、解析できないCIDRの場合
+// [x] 2-5: 異常系：拒否ルールに含まれるIPアドレスの場合
+// [x] 2-6: 異常系：対応付けられていないクライアント証明書の場合
+// [x] 2-7: 異常系：他のAPIキーにのみ許可されたルートの場合、権限が登録されていないAPIキーの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyIndex(t *testing.T) {
+	fingerprintCert := &x509.Certificate{Raw: []byte("certificate-1"), Subject: pkix.Name{CommonName: "client-1"}}
+	subjectCert := &x509.Certificate{Raw: []byte("certificate-2"), Subject: pkix.Name{CommonName: "client-2", Organization: []string{"Example"}}}
+	unknownCert := &x509.Certificate{Raw: []byte("certificate-3"), Subject: pkix.Name{CommonName: "client-3"}}
+	index := authentication.NewAPIKeyIndex(
+		authentication.APIKeys{
+			{ID: "id-1", KeyPrefix: "Sample-A", KeyDigest: authentication.DigestAPIKey("Sample-APIKey1")},
+			{ID: "id-2", KeyPrefix: "Sample-A", KeyDigest: authentication.DigestAPIKey("Sample-APIKey2")},
+		},
+		authentication.Cidrs{
+			{APIKeyID: "id-1", Cidr: "10.0.0.0/8", Action: authentication.CidrActionAllow, Priority: 100},
+			{APIKeyID: "id-1", Cidr: "10.1.0.0/16", Action: authentication.CidrActionDeny, Priority: 100},
+			{APIKeyID: "id-1", Cidr: "10.1.1.0/24", Action: authentication.CidrActionAllow, Priority: 10},
+			{APIKeyID: "id-1", Cidr: "192.168.1.0/24", Action: authentication.CidrActionAllow, Priority: 100},
+			{APIKeyID: "id-1", Cidr: "2001:db8::/32", Action: authentication.CidrActionAllow, Priority: 100},
+			{APIKeyID: "id-2", Cidr: "172.16.0.0/12", Action: authentication.CidrActionAllow, Priority: 100},
+			{APIKeyID: "id-2", Cidr: "invalid", Action: authentication.CidrActionAllow, Priority: 100},
+			nil,
+		},
+		authentication.ClientCertificates{
+			{APIKeyID: "id-1", Type: authentication.ClientCertificateTypeFingerprint, Certificate: authentication.CertificateFingerprint(fingerprintCert)},
+			{APIKeyID: "id-2", Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client-1"},
+			{APIKeyID: "id-2", Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client-2,O=Example"},
+			nil,
+		},
+		authentication.APIKeyPermissions{
+			{APIKeyID: "id-1", Method: "GET", Path: "/api/v1/authInfo"},
+			{APIKeyID: "id-2", Method: "*", Path: "*"},
+		},
+	)
+
+	t.Run("1-1: 正常系：APIキーで検索", func(t *testing.T) {
+		actual, ok := index.FindAPIKey("Sample-APIKey2")
+		assert.True(t, ok)
+		assert.Equal(t, "id-2", actual.ID)
+	})
+	t.Run("1-2: 正常系：IDで検索", func(t *testing.T) {
+		actual, ok := index.GetAPIKey("id-1")
+		assert.True(t, ok)
+		assert.True(t, actual.Matches("Sample-APIKey1"))
+	})
+	t.Run("1-3: 正常系：APIキーのCIDRに含まれるIPアドレスの場合", func(t *testing.T) {
+		assert.True(t, index.Allows("id-1", "10.2.3.4"))
+		assert.True(t, index.Allows("id-1", "192.168.1.10"))
+		assert.True(t, index.Allows("id-2", "172.16.0.1"))
+	})
+	t.Run("1-4: 正常系：優先度の高い許可ルールが拒否ルールより先に評価される場合", func(t *testing.T) {
+		assert.True(t, index.Allows("id-1", "10.1.1.5"))
+	})
+	t.Run("1-5: 正常系：IPv6のCIDRに含まれるIPアドレスの場合", func(t *testing.T) {
+		assert.True(t, index.Allows("id-1", "2001:db8::1"))
+		assert.False(t, index.Allows("id-1", "2001:db9::1"))
+	})
+	t.Run("1-6: 正常系：フィンガープリントに対応付けられたクライアント証明書で検索", func(t *testing.T) {
+		actual, ok := index.FindAPIKeyByCertificate(fingerprintCert)
+		assert.True(t, ok)
+		assert.Equal(t, "id-1", actual.ID)
+	})
+	t.Run("1-7: 正常系：サブジェクトDNに対応付けられたクライアント証明書で検索", func(t *testing.T) {
+		actual, ok := index.FindAPIKeyByCertificate(subjectCert)
+		assert.True(t, ok)
+		assert.Equal(t, "id-2", actual.ID)
+	})
+	t.Run("1-8: 正常系：APIキーの権限で許可されたルートの場合", func(t *testing.T) {
+		assert.True(t, index.AllowsRoute("id-1", "GET", "/api/v1/authInfo"))
+		assert.True(t, index.AllowsRoute("id-2", "PUT", "/dataReset"))
+	})
+	t.Run("2-1: 異常系：登録されていないAPIキー・空のAPIキーの場合", func(t *testing.T) {
+		_, ok := index.FindAPIKey("Sample-APIKey3")
+		assert.False(t, ok)
+		_, ok = index.FindAPIKey("")
+		assert.False(t, ok)
+	})
+	t.Run("2-2: 異常系：登録されていないIDの場合", func(t *testing.T) {
+		_, ok := index.GetAPIKey("id-3")
+		assert.False(t, ok)
+	})
+	t.Run("2-3: 異常系：他のAPIキーのCIDRにのみ含まれるIPアドレスの場合", func(t *testing.T) {
+		assert.False(t, index.Allows("id-2", "10.2.3.4"))
+		assert.False(t, index.Allows("id-3", "10.2.3.4"))
+	})
+	t.Run("2-4: 異常系：IPアドレスの形式でない場合、解析できないCIDRの場合", func(t *testing.T) {
+		assert.False(t, index.Allows("id-1", "invalid"))
+		assert.False(t, index.Allows("id-2", "192.168.2.1"))
+	})
+	t.Run("2-5: 異常系：拒否ルールに含まれるIPアドレスの場合", func(t *testing.T) {
+		assert.False(t, index.Allows("id-1", "10.1.2.3"))
+	})
+	t.Run("2-6: 異常系：対応付けられていないクライアント証明書の場合", func(t *testing.T) {
+		_, ok := index.FindAPIKeyByCertificate(unknownCert)
+		assert.False(t, ok)
+	})
+	t.Run("2-7: 異常系：他のAPIキーにのみ許可されたルートの場合、権限が登録されていないAPIキーの場合", func(t *testing.T) {
+		assert.False(t, index.AllowsRoute("id-1", "PUT", "/dataReset"))
+		assert.False(t, index.AllowsRoute("id-3", "GET", "/api/v1/authInfo"))
+	})
+}
@@ -3,7 +3,7 @@ package authentication
 // APIKeyOperator
 // Summary: This is structure which defines the APIkeyOperator model.
 type APIKeyOperator struct {
-	APIKey     string `json:"api_key"`
+	APIKeyID   string `json:"api_key_id"`
 	OperatorID string `json:"operator_id"`
 }
 
@@ -0,0 +1,58 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+)
+
+// APIKeyPermissionWildcard
+// Summary: This is the value which matches any method, or any path when it is the suffix of the path pattern.
+const APIKeyPermissionWildcard = "*"
+
+// APIKeyPermissionMethods
+// Summary: This is the list of the methods which can be granted to the API keys.
+var APIKeyPermissionMethods = []interface{}{APIKeyPermissionWildcard, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// APIKeyPermission
+// Summary: This is structure which defines the APIKeyPermission model.
+// Method is the HTTP method or "*", and Path is the route path such as "/api/v1/authInfo".
+// The path ending with "*" matches all the routes which start with the preceding part.
+type APIKeyPermission struct {
+	APIKeyID string `json:"api_key_id"`
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+}
+
+// APIKeyPermissions
+// Summary: This is structure which defines the slice of APIKeyPermission.
+type APIKeyPermissions []APIKeyPermission
+
+// Matches
+// Summary: This is the function which checks whether the permission matches the route.
+// input: method(string): HTTP method
+// input: path(string): route path
+// output: (bool) true if the permission matches the route, false otherwise
+func (m APIKeyPermission) Matches(method string, path string) bool {
+	if m.Method != APIKeyPermissionWildcard && !strings.EqualFold(m.Method, method) {
+		return false
+	}
+	if strings.HasSuffix(m.Path, APIKeyPermissionWildcard) {
+		return strings.HasPrefix(path, strings.TrimSuffix(m.Path, APIKeyPermissionWildcard))
+	}
+	return m.Path == path
+}
+
+// Allows
+// Summary: This is the function which checks whether the route is allowed by this struct slice.
+// The API key without any permission is denied every route, and the permission of "*" for both the method and the path allows every route.
+// input: method(string): HTTP method
+// input: path(string): route path
+// output: (bool) true if the route is allowed, false otherwise
+func (ms APIKeyPermissions) Allows(method string, path string) bool {
+	for _, m := range ms {
+		if m.Matches(method, path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package authentication_test
+
+import (
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyPermissions Allows テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：メソッドとパスが一致する場合
+// [x] 1-2: 正常系：メソッドがワイルドカードの場合
+// [x] 1-3: 正常系：パスが前方一致のワイルドカードの場合
+// [x] 1-4: 正常系：メソッドとパスが共にワイルドカードの場合、全て許可
+// [x] 2-1: 異常系：メソッドが一致しない場合
+// [x] 2-2: 異常系：パスが一致しない場合
+// [x] 2-3: 異常系：権限が登録されていない場合、全て拒否
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyPermissions_Allows(tt *testing.T) {
+
+	readOnly := authentication.APIKeyPermissions{
+		{APIKeyID: "key", Method: "GET", Path: "/api/v1/authInfo"},
+		{APIKeyID: "key", Method: "*", Path: "/auth/login"},
+		{APIKeyID: "key", Method: "POST", Path: "/api/v1/systemAuth/users/*"},
+	}
+
+	tests := []struct {
+		name        string
+		permissions authentication.APIKeyPermissions
+		method      string
+		path        string
+		expect      bool
+	}{
+		{
+			name:        "1-1: 正常系：メソッドとパスが一致する場合",
+			permissions: readOnly,
+			method:      "GET",
+			path:        "/api/v1/authInfo",
+			expect:      true,
+		},
+		{
+			name:        "1-2: 正常系：メソッドがワイルドカードの場合",
+			permissions: readOnly,
+			method:      "POST",
+			path:        "/auth/login",
+			expect:      true,
+		},
+		{
+			name:        "1-3: 正常系：パスが前方一致のワイルドカードの場合",
+			permissions: readOnly,
+			method:      "POST",
+			path:        "/api/v1/systemAuth/users/:uid/disable",
+			expect:      true,
+		},
+		{
+			name:        "1-4: 正常系：メソッドとパスが共にワイルドカードの場合、全て許可",
+			permissions: authentication.APIKeyPermissions{{APIKeyID: "key", Method: "*", Path: "*"}},
+			method:      "PUT",
+			path:        "/dataReset",
+			expect:      true,
+		},
+		{
+			name:        "2-1: 異常系：メソッドが一致しない場合",
+			permissions: readOnly,
+			method:      "PUT",
+			path:        "/api/v1/authInfo",
+			expect:      false,
+		},
+		{
+			name:        "2-2: 異常系：パスが一致しない場合",
+			permissions: readOnly,
+			method:      "PUT",
+			path:        "/dataReset",
+			expect:      false,
+		},
+		{
+			name:        "2-3: 異常系：権限が登録されていない場合、全て拒否",
+			permissions: authentication.APIKeyPermissions{},
+			method:      "GET",
+			path:        "/api/v1/authInfo",
+			expect:      false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, test.permissions.Allows(test.method, test.path))
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package authentication_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NewAPIKey テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：生成したAPIキーのプレフィックスとダイジェストを保持し、APIキー自体は保持しない
+// /////////////////////////////////////////////////////////////////////////////////
+func TestNewAPIKey(t *testing.T) {
+	notBefore := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := notBefore.Add(24 * time.Hour)
+	apiKey, key, err := authentication.NewAPIKey("application", authentication.ApplicationAttributeDataSpace, "creator", notBefore, &expiresAt)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotEmpty(t, apiKey.ID)
+	assert.Equal(t, key[:authentication.APIKeyPrefixLength], apiKey.KeyPrefix)
+	assert.Equal(t, authentication.DigestAPIKey(key), apiKey.KeyDigest)
+	assert.NotContains(t, apiKey.KeyDigest, key)
+	assert.True(t, apiKey.Matches(key))
+	assert.Equal(t, "creator", apiKey.CreatedUserID)
+	assert.Equal(t, "creator", apiKey.UpdatedUserID)
+	assert.Equal(t, notBefore, apiKey.NotBefore)
+	assert.Equal(t, &expiresAt, apiKey.ExpiresAt)
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKey IsActive テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：有効期間内の場合
+// [x] 1-2: 正常系：有効期限が設定されていない場合
+// [x] 2-1: 異常系：有効期限を過ぎている場合
+// [x] 2-2: 異常系：有効期限と同時刻の場合
+// [x] 2-3: 異常系：有効開始日時より前の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKey_IsActive(tt *testing.T) {
+
+	notBefore := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := notBefore.Add(24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		expiresAt     *time.Time
+		now           time.Time
+		expectActive  bool
+		expectExpired bool
+	}{
+		{
+			name:         "1-1: 正常系：有効期間内の場合",
+			expiresAt:    &expiresAt,
+			now:          notBefore.Add(time.Hour),
+			expectActive: true,
+		},
+		{
+			name:         "1-2: 正常系：有効期限が設定されていない場合",
+			expiresAt:    nil,
+			now:          notBefore.Add(24 * 365 * time.Hour),
+			expectActive: true,
+		},
+		{
+			name:          "2-1: 異常系：有効期限を過ぎている場合",
+			expiresAt:     &expiresAt,
+			now:           expiresAt.Add(time.Second),
+			expectExpired: true,
+		},
+		{
+			name:          "2-2: 異常系：有効期限と同時刻の場合",
+			expiresAt:     &expiresAt,
+			now:           expiresAt,
+			expectExpired: true,
+		},
+		{
+			name:      "2-3: 異常系：有効開始日時より前の場合",
+			expiresAt: &expiresAt,
+			now:       notBefore.Add(-time.Second),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiKey := authentication.APIKey{NotBefore: notBefore, ExpiresAt: test.expiresAt}
+			assert.Equal(t, test.expectActive, apiKey.IsActive(test.now))
+			assert.Equal(t, test.expectExpired, apiKey.IsExpired(test.now))
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyPolicy テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：TTLから有効期限を算出
+// [x] 1-2: 正常系：TTLが0の場合は有効期限なし
+// [x] 1-3: 正常系：ローテーション後の有効期限は猶予期間の終了日時
+// [x] 1-4: 正常系：猶予期間より前に有効期限を迎える場合は有効期限を維持
+// [x] 1-5: 正常系：有効期限が警告期間内の場合
+// [x] 1-6: 正常系：有効期限が警告期間外、または設定されていない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyPolicy(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	policy := authentication.APIKeyPolicy{
+		TTL:                 30 * 24 * time.Hour,
+		RotationGracePeriod: 24 * time.Hour,
+		ExpiryWarning:       7 * 24 * time.Hour,
+	}
+
+	t.Run("1-1: 正常系：TTLから有効期限を算出", func(t *testing.T) {
+		expected := now.Add(30 * 24 * time.Hour)
+		assert.Equal(t, &expected, policy.ExpiresAt(now))
+	})
+	t.Run("1-2: 正常系：TTLが0の場合は有効期限なし", func(t *testing.T) {
+		assert.Nil(t, authentication.APIKeyPolicy{}.ExpiresAt(now))
+	})
+	t.Run("1-3: 正常系：ローテーション後の有効期限は猶予期間の終了日時", func(t *testing.T) {
+		assert.Equal(t, now.Add(24*time.Hour), policy.RotatedExpiresAt(authentication.APIKey{}, now))
+	})
+	t.Run("1-4: 正常系：猶予期間より前に有効期限を迎える場合は有効期限を維持", func(t *testing.T) {
+		expiresAt := now.Add(time.Hour)
+		assert.Equal(t, expiresAt, policy.RotatedExpiresAt(authentication.APIKey{ExpiresAt: &expiresAt}, now))
+	})
+	t.Run("1-5: 正常系：有効期限が警告期間内の場合", func(t *testing.T) {
+		expiresAt := now.Add(7 * 24 * time.Hour)
+		assert.True(t, policy.ExpiresSoon(authentication.APIKey{ExpiresAt: &expiresAt}, now))
+	})
+	t.Run("1-6: 正常系：有効期限が警告期間外、または設定されていない場合", func(t *testing.T) {
+		expiresAt := now.Add(8 * 24 * time.Hour)
+		assert.False(t, policy.ExpiresSoon(authentication.APIKey{ExpiresAt: &expiresAt}, now))
+		assert.False(t, policy.ExpiresSoon(authentication.APIKey{}, now))
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeys FindAPIKey テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：ダイジェストが一致するAPIキーを返却
+// [x] 2-1: 異常系：プレフィックスのみ一致する場合
+// [x] 2-2: 異常系：APIキーが空の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeys_FindAPIKey(tt *testing.T) {
+
+	apiKeys := authentication.APIKeys{
+		{ID: "id-1", KeyPrefix: "Sample-A", KeyDigest: authentication.DigestAPIKey("Sample-APIKey1")},
+		{ID: "id-2", KeyPrefix: "Sample-A", KeyDigest: authentication.DigestAPIKey("Sample-APIKey2")},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expectID string
+		expectOK bool
+	}{
+		{
+			name:     "1-1: 正常系：ダイジェストが一致するAPIキーを返却",
+			input:    "Sample-APIKey2",
+			expectID: "id-2",
+			expectOK: true,
+		},
+		{
+			name:     "2-1: 異常系：プレフィックスのみ一致する場合",
+			input:    "Sample-APIKey3",
+			expectOK: false,
+		},
+		{
+			name:     "2-2: 異常系：APIキーが空の場合",
+			input:    "",
+			expectOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, ok := apiKeys.FindAPIKey(test.input)
+			assert.Equal(t, test.expectOK, ok)
+			assert.Equal(t, test.expectID, actual.ID)
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// MaskAPIKey テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：プレフィックス以外をマスクする
+// [x] 1-2: 正常系：プレフィックスより短い場合はそのまま返却
+// /////////////////////////////////////////////////////////////////////////////////
+func TestMaskAPIKey(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:   "1-1: 正常系：プレフィックス以外をマスクする",
+			input:  "Sample-APIKey1",
+			expect: "Sample-A" + strings.Repeat("*", 6),
+		},
+		{
+			name:   "1-2: 正常系：プレフィックスより短い場合はそのまま返却",
+			input:  "short",
+			expect: "short",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expect, authentication.MaskAPIKey(test.input))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package authentication
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuthEvent
+// Summary: This is structure which defines the AuthEvent model.
+// It is recorded for every request dumped by the AuthDump middleware.
+// DBName: auth_events
+type AuthEvent struct {
+	ID                string
+	Event             string
+	Result            bool
+	ReasonCode        string
+	IPAddress         string
+	APIKeyID          *string
+	OperatorID        *string
+	OperatorAccountID *string
+	OccurredAt        time.Time
+	CreatedAt         time.Time
+	CreatedUserID     string
+	UpdatedAt         time.Time
+	UpdatedUserID     string
+}
+
+// AuthEvents
+// Summary: This is structure which defines the slice of AuthEvent.
+type AuthEvents []AuthEvent
+
+// AuthEventCursor
+// Summary: This is structure which defines the position of the auth event list.
+// The events are listed in descending order of the occurred time and the ID.
+type AuthEventCursor struct {
+	OccurredAt time.Time
+	ID         string
+}
+
+// NewAuthEventCursor
+// Summary: This is the function which creates the cursor pointing to the event.
+// input: event(AuthEvent): last event of the page
+// output: (AuthEventCursor) cursor
+func NewAuthEventCursor(event AuthEvent) AuthEventCursor {
+	return AuthEventCursor{OccurredAt: event.OccurredAt, ID: event.ID}
+}
+
+// Encode
+// Summary: This is the function which encodes the cursor into the opaque string.
+// output: (string) encoded cursor
+func (c AuthEventCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.OccurredAt.UTC().Format(time.RFC3339Nano) + " " + c.ID))
+}
+
+// ParseAuthEventCursor
+// Summary: This is the function which decodes the cursor encoded by Encode.
+// input: s(string): encoded cursor
+// output: (AuthEventCursor) cursor
+// output: (error) error object
+func ParseAuthEventCursor(s string) (AuthEventCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return AuthEventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	occurredAt, id, ok := strings.Cut(string(b), " ")
+	if !ok || id == "" {
+		return AuthEventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, occurredAt)
+	if err != nil {
+		return AuthEventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	return AuthEventCursor{OccurredAt: t, ID: id}, nil
+}
@@ -2,27 +2,113 @@ package authentication
 
 import (
 	"net"
+	"sort"
 )
 
+// CidrAction
+// Summary: This is enum which defines whether the CIDR rule allows or denies the IP addresses.
+type CidrAction string
+
+const (
+	CidrActionAllow CidrAction = "allow"
+	CidrActionDeny  CidrAction = "deny"
+)
+
+// CidrActions
+// Summary: This is the list of the actions which can be assigned to the CIDR rule.
+var CidrActions = []interface{}{CidrActionAllow, CidrActionDeny}
+
+// DefaultCidrPriority is the priority of the CIDR rule which is added without the priority
+const DefaultCidrPriority = 100
+
 // Cidr
 // Summary: This is structure which defines the CIDR model.
+// The CIDR of IPv4 or IPv6 allows or denies the IP addresses in it by Action.
+// The rules of the API key are evaluated in ascending order of Priority, and the first matching rule decides.
 type Cidr struct {
-	Cidr string `json:"cidr"`
+	Cidr     string     `json:"cidr"`
+	APIKeyID string     `json:"api_key_id"`
+	Action   CidrAction `json:"action"`
+	Priority int        `json:"priority"`
 }
 
 // Cidrs
 // Summary: This is structure which defines the slice of Cidr.
 type Cidrs []*Cidr
 
-// Contains
-// Summary: This is the function which checks whether the IP address exists in this struct slice.
+// NewCidr
+// Summary: This is the function which parses the CIDR and converts it to the network address.
+// input: value(string): CIDR such as "192.168.0.0/24" or "2001:db8::/32"
+// output: (Cidr) CIDR of the network address
+// output: (error) error object
+func NewCidr(value string) (Cidr, error) {
+	_, subnet, err := net.ParseCIDR(value)
+	if err != nil {
+		return Cidr{}, err
+	}
+	return Cidr{Cidr: subnet.String()}, nil
+}
+
+// Allows
+// Summary: This is the function which checks whether the CIDR rules allow the IP address.
 // input: ip(string): IP address
-// output: (bool) true if the IP address exists in this slice, false otherwise
-func (ms Cidrs) Contains(ip string) bool {
-	for _, cidr := range ms {
-		_, subnet, _ := net.ParseCIDR(cidr.Cidr)
-		if subnet.Contains(net.ParseIP(ip)) {
-			return true
+// output: (bool) true if the IP address is allowed, false otherwise
+func (ms Cidrs) Allows(ip string) bool {
+	return newCidrRules(ms).allows(ip)
+}
+
+// cidrRule
+// Summary: This is structure which defines the CIDR rule parsed in advance.
+type cidrRule struct {
+	network  *net.IPNet
+	action   CidrAction
+	priority int
+}
+
+// cidrRules
+// Summary: This is structure which defines the CIDR rules in the order of the evaluation.
+type cidrRules []cidrRule
+
+// newCidrRules
+// Summary: This is the function which parses the CIDR rules and sorts them in the order of the evaluation.
+// The CIDR which cannot be parsed is skipped, and it matches no IP address.
+// The deny rule is evaluated first among the rules of the same priority.
+// input: cidrs(Cidrs): CIDR rules
+// output: (cidrRules) CIDR rules in the order of the evaluation
+func newCidrRules(cidrs Cidrs) cidrRules {
+	rules := make(cidrRules, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		if cidr == nil {
+			continue
+		}
+		_, subnet, err := net.ParseCIDR(cidr.Cidr)
+		if err != nil {
+			continue
+		}
+		rules = append(rules, cidrRule{network: subnet, action: cidr.Action, priority: cidr.Priority})
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].priority != rules[j].priority {
+			return rules[i].priority < rules[j].priority
+		}
+		return rules[i].action == CidrActionDeny && rules[j].action != CidrActionDeny
+	})
+	return rules
+}
+
+// allows
+// Summary: This is the function which checks whether the first rule matching the IP address allows it.
+// The IP address which matches no rule is denied.
+// input: ip(string): IP address
+// output: (bool) true if the IP address is allowed, false otherwise
+func (rs cidrRules) allows(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, rule := range rs {
+		if rule.network.Contains(parsed) {
+			return rule.action == CidrActionAllow
 		}
 	}
 	return false
@@ -0,0 +1,78 @@
+package authentication_test
+
+import (
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NewCidr テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：IPv4のCIDRはネットワークアドレスに変換
+// [x] 1-2: 正常系：IPv6のCIDRはネットワークアドレスに変換
+// [x] 2-1: 異常系：CIDR形式でない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestNewCidr(t *testing.T) {
+	t.Run("1-1: 正常系：IPv4のCIDRはネットワークアドレスに変換", func(t *testing.T) {
+		actual, err := authentication.NewCidr("192.168.1.10/24")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "192.168.1.0/24", actual.Cidr)
+		}
+	})
+	t.Run("1-2: 正常系：IPv6のCIDRはネットワークアドレスに変換", func(t *testing.T) {
+		actual, err := authentication.NewCidr("2001:db8::1/32")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "2001:db8::/32", actual.Cidr)
+		}
+	})
+	t.Run("2-1: 異常系：CIDR形式でない場合", func(t *testing.T) {
+		_, err := authentication.NewCidr("192.168.1.10")
+		assert.Error(t, err)
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Cidrs Allows テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：優先度の順に最初に一致したルールで判定
+// [x] 1-2: 正常系：同じ優先度の場合は拒否ルールを優先
+// [x] 2-1: 異常系：一致するルールがない場合
+// [x] 2-2: 異常系：nilのルール、解析できないCIDRのルールは無視
+// /////////////////////////////////////////////////////////////////////////////////
+func TestCidrs_Allows(t *testing.T) {
+	t.Run("1-1: 正常系：優先度の順に最初に一致したルールで判定", func(t *testing.T) {
+		cidrs := authentication.Cidrs{
+			{Cidr: "10.0.0.0/8", Action: authentication.CidrActionDeny, Priority: 100},
+			{Cidr: "10.1.0.0/16", Action: authentication.CidrActionAllow, Priority: 10},
+		}
+		assert.True(t, cidrs.Allows("10.1.0.1"))
+		assert.False(t, cidrs.Allows("10.2.0.1"))
+	})
+	t.Run("1-2: 正常系：同じ優先度の場合は拒否ルールを優先", func(t *testing.T) {
+		cidrs := authentication.Cidrs{
+			{Cidr: "::/0", Action: authentication.CidrActionAllow, Priority: 100},
+			{Cidr: "2001:db8::/32", Action: authentication.CidrActionDeny, Priority: 100},
+		}
+		assert.False(t, cidrs.Allows("2001:db8::1"))
+		assert.True(t, cidrs.Allows("2001:db9::1"))
+	})
+	t.Run("2-1: 異常系：一致するルールがない場合", func(t *testing.T) {
+		cidrs := authentication.Cidrs{
+			{Cidr: "10.0.0.0/8", Action: authentication.CidrActionAllow, Priority: 100},
+		}
+		assert.False(t, cidrs.Allows("192.168.0.1"))
+		assert.False(t, authentication.Cidrs{}.Allows("192.168.0.1"))
+	})
+	t.Run("2-2: 異常系：nilのルール、解析できないCIDRのルールは無視", func(t *testing.T) {
+		cidrs := authentication.Cidrs{
+			nil,
+			{Cidr: "invalid", Action: authentication.CidrActionAllow, Priority: 100},
+			{Cidr: "10.0.0.0/8", Action: authentication.CidrActionAllow, Priority: 100},
+		}
+		assert.True(t, cidrs.Allows("10.0.0.1"))
+		assert.False(t, cidrs.Allows("192.168.0.1"))
+	})
+}
@@ -2,14 +2,45 @@ package authentication
 
 import (
 	"fmt"
+	"slices"
 
 	"firebase.google.com/go/v4/auth"
 )
 
+// OperatorIDClaim
+// Summary: This is the default name of the custom claim which holds the operator ID.
+const OperatorIDClaim = "operator_id"
+
+// EmailClaim
+// Summary: This is the name of the claim which holds the email of the user.
+const EmailClaim = "email"
+
+// RoleClaim
+// Summary: This is the name of the custom claim which holds the role of the user within the operator.
+const RoleClaim = "role"
+
+// Role
+// Summary: This is the type which defines the role of the user within the operator.
+type Role string
+
+const (
+	// RoleAdmin can update the operator and plant data and reset the data
+	RoleAdmin Role = "admin"
+	// RoleEditor can update the operator and plant data
+	RoleEditor Role = "editor"
+	// RoleViewer can only read the data
+	RoleViewer Role = "viewer"
+)
+
+// Roles
+// Summary: This is the list of the roles which can be assigned to the user.
+var Roles = []interface{}{RoleAdmin, RoleEditor, RoleViewer}
+
 // Claims
 // Summary: This is structure which defines the claims model.
 type Claims struct {
 	OperatorID string `json:"operator_id"`
+	Role       Role   `json:"role"`
 	auth.Token
 }
 
@@ -19,12 +50,51 @@ type Claims struct {
 // output: (Claims) Claims model
 // output: (error) error object
 func NewClaims(token *auth.Token) (Claims, error) {
-	operatorID, ok := token.Claims["operator_id"].(string)
+	return NewClaimsWithOperatorIDClaim(token, OperatorIDClaim)
+}
+
+// NewClaimsWithOperatorIDClaim
+// Summary: This is the function which creates the Claims model from the token whose operator ID is stored in the given claim.
+// input: token(*auth.Token): token
+// input: operatorIDClaim(string): name of the claim which holds the operator ID
+// output: (Claims) Claims model
+// output: (error) error object
+func NewClaimsWithOperatorIDClaim(token *auth.Token, operatorIDClaim string) (Claims, error) {
+	operatorID, ok := token.Claims[operatorIDClaim].(string)
 	if !ok {
-		return Claims{}, fmt.Errorf("token does not contain 'operator_id' in claims")
+		return Claims{}, fmt.Errorf("token does not contain '%s' in claims", operatorIDClaim)
+	}
+	// the token without the known role, such as the one of the users registered before the roles were introduced
+	// or the one issued by the OpenID Provider which does not emit the role, is given the least privilege
+	role := Role(fmt.Sprint(token.Claims[RoleClaim]))
+	if !slices.Contains(Roles, interface{}(role)) {
+		role = RoleViewer
 	}
 	return Claims{
 		OperatorID: operatorID,
+		Role:       role,
 		Token:      *token,
 	}, nil
 }
+
+// Email
+// Summary: This is the function which returns the email of the user in the token.
+// output: (string) email. empty when the token does not contain the email claim
+func (c Claims) Email() string {
+	email, _ := c.Claims[EmailClaim].(string)
+
+	return email
+}
+
+// HasRole
+// Summary: This is the function which checks whether the user has one of the given roles.
+// input: roles(...Role) allowed roles
+// output: (bool) true when the role of the user is included in the roles
+func (c Claims) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package authentication_test
+
+import (
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NewClaims テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：roleクレームを保持する場合
+// [x] 1-2: 正常系：roleクレームがない場合、viewerとして扱う
+// [x] 1-3: 正常系：未知のroleクレームの場合、viewerとして扱う
+// [x] 2-1: 異常系：operator_idクレームがない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestNewClaims(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		claims       map[string]interface{}
+		expectRole   authentication.Role
+		expectErrMsg string
+	}{
+		{
+			name: "1-1: 正常系：roleクレームを保持する場合",
+			claims: map[string]interface{}{
+				authentication.OperatorIDClaim: "b39e6248-c888-56ca-d9d0-89de1b1adc8e",
+				authentication.RoleClaim:       "viewer",
+			},
+			expectRole: authentication.RoleViewer,
+		},
+		{
+			name: "1-2: 正常系：roleクレームがない場合、viewerとして扱う",
+			claims: map[string]interface{}{
+				authentication.OperatorIDClaim: "b39e6248-c888-56ca-d9d0-89de1b1adc8e",
+			},
+			expectRole: authentication.RoleViewer,
+		},
+		{
+			name: "1-3: 正常系：未知のroleクレームの場合、viewerとして扱う",
+			claims: map[string]interface{}{
+				authentication.OperatorIDClaim: "b39e6248-c888-56ca-d9d0-89de1b1adc8e",
+				authentication.RoleClaim:       "owner",
+			},
+			expectRole: authentication.RoleViewer,
+		},
+		{
+			name: "2-1: 異常系：operator_idクレームがない場合",
+			claims: map[string]interface{}{
+				authentication.RoleClaim: "viewer",
+			},
+			expectErrMsg: "token does not contain 'operator_id' in claims",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := authentication.NewClaims(&auth.Token{Claims: test.claims})
+			if test.expectErrMsg != "" {
+				assert.EqualError(t, err, test.expectErrMsg)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, "b39e6248-c888-56ca-d9d0-89de1b1adc8e", actual.OperatorID)
+				assert.Equal(t, test.expectRole, actual.Role)
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// HasRole テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：許可されたロールの場合
+// [x] 2-1: 異常系：許可されていないロールの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestClaims_HasRole(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		role   authentication.Role
+		expect bool
+	}{
+		{
+			name:   "1-1: 正常系：許可されたロールの場合",
+			role:   authentication.RoleEditor,
+			expect: true,
+		},
+		{
+			name:   "2-1: 異常系：許可されていないロールの場合",
+			role:   authentication.RoleViewer,
+			expect: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims := authentication.Claims{Role: test.role}
+			assert.Equal(t, test.expect, claims.HasRole(authentication.RoleAdmin, authentication.RoleEditor))
+		})
+	}
+}
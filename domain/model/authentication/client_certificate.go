@@ -0,0 +1,85 @@
+package authentication
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ClientCertificateType
+// Summary: This is enum which defines how the client certificate is identified.
+type ClientCertificateType string
+
+const (
+	// ClientCertificateTypeFingerprint identifies the certificate by the SHA-256 fingerprint of the DER encoding
+	ClientCertificateTypeFingerprint ClientCertificateType = "fingerprint"
+	// ClientCertificateTypeSubject identifies the certificate by the subject DN in the format of RFC 2253, such as "CN=client,O=Example"
+	ClientCertificateTypeSubject ClientCertificateType = "subject"
+)
+
+// ClientCertificateTypes
+// Summary: This is the list of the types which identify the client certificate.
+var ClientCertificateTypes = []interface{}{ClientCertificateTypeFingerprint, ClientCertificateTypeSubject}
+
+var (
+	ErrInvalidCertificateFingerprint = errors.New("the fingerprint must be the SHA-256 digest in hex")
+	ErrInvalidCertificateSubject     = errors.New("the subject must not be empty")
+
+	fingerprintPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// ClientCertificate
+// Summary: This is structure which defines the client certificate mapped to the API key.
+// A certificate which is verified with the CA bundle authenticates the system API caller as the API key.
+type ClientCertificate struct {
+	Type        ClientCertificateType `json:"type" gorm:"column:certificate_type"`
+	Certificate string                `json:"certificate"`
+	APIKeyID    string                `json:"api_key_id"`
+}
+
+// ClientCertificates
+// Summary: This is structure which defines the slice of ClientCertificate.
+type ClientCertificates []*ClientCertificate
+
+// NewClientCertificate
+// Summary: This is the function which normalizes the fingerprint or the subject DN of the client certificate.
+// The fingerprint may be separated by colons, such as the output of openssl.
+// input: certificateType(ClientCertificateType): type of the identifier
+// input: value(string): fingerprint or subject DN
+// output: (ClientCertificate) client certificate
+// output: (error) error object
+func NewClientCertificate(certificateType ClientCertificateType, value string) (ClientCertificate, error) {
+	value = strings.TrimSpace(value)
+	switch certificateType {
+	case ClientCertificateTypeFingerprint:
+		value = strings.ToLower(strings.ReplaceAll(value, ":", ""))
+		if !fingerprintPattern.MatchString(value) {
+			return ClientCertificate{}, ErrInvalidCertificateFingerprint
+		}
+	default:
+		if value == "" {
+			return ClientCertificate{}, ErrInvalidCertificateSubject
+		}
+	}
+	return ClientCertificate{Type: certificateType, Certificate: value}, nil
+}
+
+// CertificateFingerprint
+// Summary: This is the function which returns the SHA-256 fingerprint of the certificate.
+// input: cert(*x509.Certificate): certificate
+// output: (string) fingerprint in lower case hex
+func CertificateFingerprint(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(digest[:])
+}
+
+// CertificateSubject
+// Summary: This is the function which returns the subject DN of the certificate in the format of RFC 2253.
+// input: cert(*x509.Certificate): certificate
+// output: (string) subject DN
+func CertificateSubject(cert *x509.Certificate) string {
+	return cert.Subject.String()
+}
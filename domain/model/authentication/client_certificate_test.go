@@ -0,0 +1,61 @@
+package authentication_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"strings"
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NewClientCertificate テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：コロン区切り、大文字のフィンガープリントは小文字の16進数に変換
+// [x] 1-2: 正常系：サブジェクトDNは前後の空白を除去
+// [x] 2-1: 異常系：SHA-256のフィンガープリントでない場合
+// [x] 2-2: 異常系：サブジェクトDNが空の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestNewClientCertificate(t *testing.T) {
+	fingerprint := strings.Repeat("ab", 32)
+
+	t.Run("1-1: 正常系：コロン区切り、大文字のフィンガープリントは小文字の16進数に変換", func(t *testing.T) {
+		actual, err := authentication.NewClientCertificate(authentication.ClientCertificateTypeFingerprint, strings.Repeat("AB:", 31)+"AB")
+		if assert.NoError(t, err) {
+			assert.Equal(t, authentication.ClientCertificate{Type: authentication.ClientCertificateTypeFingerprint, Certificate: fingerprint}, actual)
+		}
+	})
+	t.Run("1-2: 正常系：サブジェクトDNは前後の空白を除去", func(t *testing.T) {
+		actual, err := authentication.NewClientCertificate(authentication.ClientCertificateTypeSubject, " CN=client,O=Example ")
+		if assert.NoError(t, err) {
+			assert.Equal(t, authentication.ClientCertificate{Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client,O=Example"}, actual)
+		}
+	})
+	t.Run("2-1: 異常系：SHA-256のフィンガープリントでない場合", func(t *testing.T) {
+		_, err := authentication.NewClientCertificate(authentication.ClientCertificateTypeFingerprint, fingerprint[:40])
+		assert.ErrorIs(t, err, authentication.ErrInvalidCertificateFingerprint)
+	})
+	t.Run("2-2: 異常系：サブジェクトDNが空の場合", func(t *testing.T) {
+		_, err := authentication.NewClientCertificate(authentication.ClientCertificateTypeSubject, " ")
+		assert.ErrorIs(t, err, authentication.ErrInvalidCertificateSubject)
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// CertificateFingerprint / CertificateSubject テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：DERのSHA-256フィンガープリントとRFC 2253形式のサブジェクトDN
+// /////////////////////////////////////////////////////////////////////////////////
+func TestCertificateIdentifiers(t *testing.T) {
+	t.Run("1-1: 正常系：DERのSHA-256フィンガープリントとRFC 2253形式のサブジェクトDN", func(t *testing.T) {
+		cert := &x509.Certificate{
+			Raw:     []byte("certificate"),
+			Subject: pkix.Name{CommonName: "client", Organization: []string{"Example"}},
+		}
+		assert.Equal(t, "03d66dd08835c1ca3f128cceacd1f31ac94163096b20f445ae84285bc0832d72", authentication.CertificateFingerprint(cert))
+		assert.Equal(t, "CN=client,O=Example", authentication.CertificateSubject(cert))
+	})
+}
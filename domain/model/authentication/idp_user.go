@@ -0,0 +1,15 @@
+package authentication
+
+// IdPUser
+// Summary: This is structure which defines the user registered in the identity provider.
+type IdPUser struct {
+	UID        string
+	Email      string
+	OperatorID string
+	Role       Role
+	Disabled   bool
+}
+
+// IdPUsers
+// Summary: This is the type which defines the list of IdPUser.
+type IdPUsers []IdPUser
@@ -0,0 +1,187 @@
+package authentication
+
+import (
+	"time"
+)
+
+// LoginAttemptResult
+// Summary: This is enum which defines the result of the login attempt.
+type LoginAttemptResult string
+
+const (
+	// LoginAttemptResultPending is recorded before the credentials are verified and is counted as the failure until the result is recorded,
+	// so that the concurrent attempts can not exceed the thresholds
+	LoginAttemptResultPending LoginAttemptResult = "pending"
+	LoginAttemptResultFailure LoginAttemptResult = "failure"
+	LoginAttemptResultSuccess LoginAttemptResult = "success"
+	// LoginAttemptResultUnlock is recorded when the administrator unlocks the account
+	LoginAttemptResultUnlock LoginAttemptResult = "unlock"
+	// LoginAttemptResultCanceled is recorded when the attempt ends without the result of the credentials, such as when it is rejected
+	LoginAttemptResultCanceled LoginAttemptResult = "canceled"
+)
+
+// LoginAttempt
+// Summary: This is structure which defines the LoginAttempt model.
+// DBName: login_attempts
+type LoginAttempt struct {
+	ID            string
+	Email         string
+	IPAddress     string
+	Result        LoginAttemptResult
+	AttemptedAt   time.Time
+	CreatedAt     time.Time
+	CreatedUserID string
+	UpdatedAt     time.Time
+	UpdatedUserID string
+}
+
+// LoginAttempts
+// Summary: This is structure which defines the slice of LoginAttempt.
+type LoginAttempts []LoginAttempt
+
+// LoginThrottlePolicy
+// Summary: This is structure which defines the policy to throttle the failed login attempts.
+// Each rule is disabled when its threshold is 0.
+type LoginThrottlePolicy struct {
+	// FailureWindow is the period in which the consecutive failures of the account are counted
+	FailureWindow time.Duration
+	// LockoutThreshold is the number of the consecutive failures which locks the account
+	LockoutThreshold int
+	// LockoutDuration is the period the account is locked after the last failure
+	LockoutDuration time.Duration
+	// DelayThreshold is the number of the consecutive failures after which the progressive delay is applied
+	DelayThreshold int
+	// BaseDelay is the delay applied when the failures reach DelayThreshold. it is doubled for each further failure
+	BaseDelay time.Duration
+	// MaxDelay is the upper limit of the progressive delay
+	MaxDelay time.Duration
+	// IPFailureThreshold is the number of the failures from the client IP address which blocks the address
+	IPFailureThreshold int
+	// IPFailureWindow is the period in which the failures from the client IP address are counted
+	IPFailureWindow time.Duration
+}
+
+// LoginThrottleStatus
+// Summary: This is structure which defines whether the login attempt is accepted.
+type LoginThrottleStatus struct {
+	// Locked is true when the account is locked
+	Locked bool
+	// Throttled is true when the attempt is made too early
+	Throttled bool
+	// RetryAfter is the period to wait before the next attempt
+	RetryAfter time.Duration
+}
+
+// Rejected
+// Summary: This is the function which checks whether the login attempt is rejected.
+// output: (bool) true if the attempt is rejected, false otherwise
+func (s LoginThrottleStatus) Rejected() bool {
+	return s.Locked || s.Throttled
+}
+
+// Check
+// Summary: This is the function which checks whether the login attempt is accepted.
+// input: accountFailures(LoginAttempts): consecutive failures of the account in descending order of the attempt
+// input: ipFailures(LoginAttempts): failures from the client IP address in descending order of the attempt
+// input: now(time.Time): time of the attempt
+// output: (LoginThrottleStatus) status of the attempt
+func (p LoginThrottlePolicy) Check(accountFailures LoginAttempts, ipFailures LoginAttempts, now time.Time) LoginThrottleStatus {
+	if retryAfter := p.lockedFor(accountFailures, now); retryAfter > 0 {
+		return LoginThrottleStatus{Locked: true, RetryAfter: retryAfter}
+	}
+	if retryAfter := p.ipBlockedFor(ipFailures, now); retryAfter > 0 {
+		return LoginThrottleStatus{Throttled: true, RetryAfter: retryAfter}
+	}
+	if retryAfter := p.delayedFor(accountFailures, now); retryAfter > 0 {
+		return LoginThrottleStatus{Throttled: true, RetryAfter: retryAfter}
+	}
+	return LoginThrottleStatus{}
+}
+
+// AccountFailuresSince
+// Summary: This is the function which returns the oldest time of the account failures needed to check the attempt.
+// input: now(time.Time): time of the attempt
+// output: (time.Time) oldest time of the failures
+func (p LoginThrottlePolicy) AccountFailuresSince(now time.Time) time.Time {
+	return now.Add(-p.FailureWindow - p.LockoutDuration)
+}
+
+// IPFailuresSince
+// Summary: This is the function which returns the oldest time of the client IP address failures needed to check the attempt.
+// input: now(time.Time): time of the attempt
+// output: (time.Time) oldest time of the failures
+func (p LoginThrottlePolicy) IPFailuresSince(now time.Time) time.Time {
+	return now.Add(-p.IPFailureWindow)
+}
+
+// lockedFor
+// Summary: This is the function which calculates the remaining lockout period of the account.
+// The account is locked when the last LockoutThreshold failures are within FailureWindow.
+// input: failures(LoginAttempts): consecutive failures of the account in descending order of the attempt
+// input: now(time.Time): time of the attempt
+// output: (time.Duration) remaining lockout period. 0 when the account is not locked
+func (p LoginThrottlePolicy) lockedFor(failures LoginAttempts, now time.Time) time.Duration {
+	if p.LockoutThreshold <= 0 || len(failures) < p.LockoutThreshold {
+		return 0
+	}
+	last := failures[0].AttemptedAt
+	if last.Sub(failures[p.LockoutThreshold-1].AttemptedAt) > p.FailureWindow {
+		return 0
+	}
+	return remaining(last.Add(p.LockoutDuration), now)
+}
+
+// ipBlockedFor
+// Summary: This is the function which calculates the remaining period the client IP address is blocked.
+// input: failures(LoginAttempts): failures from the client IP address in descending order of the attempt
+// input: now(time.Time): time of the attempt
+// output: (time.Duration) remaining period. 0 when the address is not blocked
+func (p LoginThrottlePolicy) ipBlockedFor(failures LoginAttempts, now time.Time) time.Duration {
+	if p.IPFailureThreshold <= 0 || len(failures) < p.IPFailureThreshold {
+		return 0
+	}
+	// the address is unblocked when the oldest of the counted failures leaves the window
+	return remaining(failures[p.IPFailureThreshold-1].AttemptedAt.Add(p.IPFailureWindow), now)
+}
+
+// delayedFor
+// Summary: This is the function which calculates the remaining progressive delay of the account.
+// input: failures(LoginAttempts): consecutive failures of the account in descending order of the attempt
+// input: now(time.Time): time of the attempt
+// output: (time.Duration) remaining delay. 0 when the attempt can be made
+func (p LoginThrottlePolicy) delayedFor(failures LoginAttempts, now time.Time) time.Duration {
+	if p.DelayThreshold <= 0 {
+		return 0
+	}
+	count := 0
+	for _, failure := range failures {
+		if now.Sub(failure.AttemptedAt) > p.FailureWindow {
+			break
+		}
+		count++
+	}
+	if count < p.DelayThreshold {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.DelayThreshold; i < count && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return remaining(failures[0].AttemptedAt.Add(delay), now)
+}
+
+// remaining
+// Summary: This is the function which calculates the period until the time rounded up to seconds.
+// input: until(time.Time): time
+// input: now(time.Time): current time
+// output: (time.Duration) remaining period. 0 when the time has passed
+func remaining(until time.Time, now time.Time) time.Duration {
+	if !until.After(now) {
+		return 0
+	}
+	return (until.Sub(now) + time.Second - 1).Truncate(time.Second)
+}
@@ -0,0 +1,268 @@
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits     = 6
+	totpModulo     = 1000000
+	totpPeriod     = 30
+	totpSkew       = 1
+	totpSecretSize = 20
+
+	recoveryCodeLength   = 10
+	mfaChallengeTokenLen = 32
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAPolicy
+// Summary: This is structure which defines the policy of the multi-factor authentication.
+type MFAPolicy struct {
+	// Issuer is the name of the service shown in the authenticator application
+	Issuer string
+	// ChallengeTTL is the period the MFA challenge issued on login is valid
+	ChallengeTTL time.Duration
+	// MaxAttempts is the number of the wrong codes after which the MFA challenge is invalidated
+	MaxAttempts int
+	// RecoveryCodeCount is the number of the recovery codes issued on activation
+	RecoveryCodeCount int
+}
+
+// MFACredential
+// Summary: This is structure which defines the MFACredential model.
+// Secret is encrypted with SecretCipher and the recovery codes are stored as hashes.
+// DBName: mfa_credentials
+type MFACredential struct {
+	ID                 string
+	Email              string
+	Secret             string
+	Enabled            bool
+	LastUsedStep       int64
+	RecoveryCodeHashes []string `gorm:"serializer:json"`
+	CreatedAt          time.Time
+	CreatedUserID      string
+	UpdatedAt          time.Time
+	UpdatedUserID      string
+}
+
+// Verify
+// Summary: This is the function which verifies the TOTP code or the recovery code.
+// The used time step or recovery code is recorded in the credential so that it can not be used again.
+// input: secret(string): decrypted TOTP secret
+// input: code(string): TOTP code or recovery code
+// input: now(time.Time): time of the verification
+// output: (bool) true if the code is valid, false otherwise
+func (c *MFACredential) Verify(secret string, code string, now time.Time) bool {
+	if step, ok := VerifyTOTP(secret, code, c.LastUsedStep, now); ok {
+		c.LastUsedStep = step
+		return true
+	}
+	return c.useRecoveryCode(code)
+}
+
+// useRecoveryCode
+// Summary: This is the function which consumes the recovery code.
+// input: code(string): recovery code
+// output: (bool) true if the code is valid, false otherwise
+func (c *MFACredential) useRecoveryCode(code string) bool {
+	hash := HashRecoveryCode(code)
+	for i, h := range c.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			c.RecoveryCodeHashes = append(c.RecoveryCodeHashes[:i:i], c.RecoveryCodeHashes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// MFAChallenge
+// Summary: This is structure which defines the MFAChallenge model.
+// It keeps the encrypted tokens issued on login until the second factor is verified.
+// DBName: mfa_challenges
+type MFAChallenge struct {
+	ID            string
+	Email         string
+	TokenHash     string
+	AccessToken   string
+	RefreshToken  string
+	Attempts      int
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	CreatedUserID string
+	UpdatedAt     time.Time
+	UpdatedUserID string
+}
+
+// Expired
+// Summary: This is the function which checks whether the MFA challenge has expired.
+// input: now(time.Time): current time
+// output: (bool) true if expired, false otherwise
+func (c MFAChallenge) Expired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// NewTOTPSecret
+// Summary: This is the function which generates the random TOTP secret.
+// output: (string) base32 encoded secret
+// output: (error) error object
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPProvisioningURI
+// Summary: This is the function which builds the otpauth URI registered to the authenticator application with the QR code.
+// input: issuer(string): name of the service
+// input: email(string): email of the account
+// input: secret(string): base32 encoded secret
+// output: (string) otpauth URI
+func TOTPProvisioningURI(issuer string, email string, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + email,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// VerifyTOTP
+// Summary: This is the function which verifies the TOTP code of RFC 6238 allowing one time step of clock skew.
+// input: secret(string): base32 encoded secret
+// input: code(string): TOTP code
+// input: lastUsedStep(int64): time step of the code used last. the codes of the step or before are rejected
+// input: now(time.Time): time of the verification
+// output: (int64) time step of the code
+// output: (bool) true if the code is valid, false otherwise
+func VerifyTOTP(secret string, code string, lastUsedStep int64, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// TOTPCode
+// Summary: This is the function which generates the TOTP code at the time.
+// input: secret(string): base32 encoded secret
+// input: now(time.Time): time
+// output: (string) TOTP code
+// output: (error) error object
+func TOTPCode(secret string, now time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return totpCode(key, now.Unix()/totpPeriod), nil
+}
+
+// totpCode
+// Summary: This is the function which generates the HOTP code of the time step.
+// input: key([]byte): secret
+// input: step(int64): time step
+// output: (string) code
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo)
+}
+
+// NewRecoveryCodes
+// Summary: This is the function which generates the random recovery codes.
+// input: count(int): number of the codes
+// output: ([]string) recovery codes
+// output: ([]string) hashes of the recovery codes
+// output: (error) error object
+func NewRecoveryCodes(count int) ([]string, []string, error) {
+	codes := make([]string, count)
+	hashes := make([]string, count)
+	for i := range codes {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))[:recoveryCodeLength]
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode
+// Summary: This is the function which hashes the recovery code ignoring the case and the separators.
+// input: code(string): recovery code
+// output: (string) hex encoded hash
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// NewMFAChallengeToken
+// Summary: This is the function which generates the random MFA challenge token.
+// output: (string) token returned to the client
+// output: (string) hash of the token stored in the DB
+// output: (error) error object
+func NewMFAChallengeToken() (string, string, error) {
+	b := make([]byte, mfaChallengeTokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, HashMFAChallengeToken(token), nil
+}
+
+// HashMFAChallengeToken
+// Summary: This is the function which hashes the MFA challenge token.
+// input: token(string): MFA challenge token
+// output: (string) hex encoded hash
+func HashMFAChallengeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
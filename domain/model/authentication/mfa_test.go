@@ -0,0 +1,170 @@
+package authentication_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 Appendix B のSHA1用シークレット
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// /////////////////////////////////////////////////////////////////////////////////
+// VerifyTOTP テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：RFC 6238のテストベクタと一致する場合
+// [x] 1-2: 正常系：1ステップ前のコードの場合
+// [x] 2-1: 異常系：2ステップ前のコードの場合
+// [x] 2-2: 異常系：使用済みのステップのコードの場合
+// [x] 2-3: 異常系：桁数が異なる場合
+// [x] 2-4: 異常系：シークレットが不正な場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestVerifyTOTP(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		secret       string
+		code         string
+		lastUsedStep int64
+		now          time.Time
+		expectStep   int64
+		expectOK     bool
+	}{
+		{
+			name:       "1-1: 正常系：RFC 6238のテストベクタと一致する場合",
+			secret:     rfc6238Secret,
+			code:       "287082",
+			now:        time.Unix(59, 0),
+			expectStep: 1,
+			expectOK:   true,
+		},
+		{
+			name:       "1-2: 正常系：1ステップ前のコードの場合",
+			secret:     rfc6238Secret,
+			code:       "287082",
+			now:        time.Unix(89, 0),
+			expectStep: 1,
+			expectOK:   true,
+		},
+		{
+			name:     "2-1: 異常系：2ステップ前のコードの場合",
+			secret:   rfc6238Secret,
+			code:     "287082",
+			now:      time.Unix(119, 0),
+			expectOK: false,
+		},
+		{
+			name:         "2-2: 異常系：使用済みのステップのコードの場合",
+			secret:       rfc6238Secret,
+			code:         "287082",
+			lastUsedStep: 1,
+			now:          time.Unix(59, 0),
+			expectOK:     false,
+		},
+		{
+			name:     "2-3: 異常系：桁数が異なる場合",
+			secret:   rfc6238Secret,
+			code:     "94287082",
+			now:      time.Unix(59, 0),
+			expectOK: false,
+		},
+		{
+			name:     "2-4: 異常系：シークレットが不正な場合",
+			secret:   "!!!",
+			code:     "287082",
+			now:      time.Unix(59, 0),
+			expectOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			step, ok := authentication.VerifyTOTP(test.secret, test.code, test.lastUsedStep, test.now)
+			assert.Equal(t, test.expectOK, ok)
+			assert.Equal(t, test.expectStep, step)
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// MFACredential Verify テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：TOTPコードの場合は使用したステップを記録
+// [x] 1-2: 正常系：リカバリーコードの場合は使用したコードを削除(大文字・区切り文字を無視)
+// [x] 2-1: 異常系：使用済みのリカバリーコードの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestMFACredential_Verify(tt *testing.T) {
+	now := time.Now()
+	secret, err := authentication.NewTOTPSecret()
+	if err != nil {
+		assert.Fail(tt, err.Error())
+	}
+	code, err := authentication.TOTPCode(secret, now)
+	if err != nil {
+		assert.Fail(tt, err.Error())
+	}
+	recoveryCodes, hashes, err := authentication.NewRecoveryCodes(2)
+	if err != nil {
+		assert.Fail(tt, err.Error())
+	}
+
+	tt.Run("1-1: 正常系：TOTPコードの場合は使用したステップを記録", func(t *testing.T) {
+		credential := authentication.MFACredential{}
+		if assert.True(t, credential.Verify(secret, code, now)) {
+			assert.Equal(t, now.Unix()/30, credential.LastUsedStep)
+			assert.False(t, credential.Verify(secret, code, now))
+		}
+	})
+	tt.Run("1-2: 正常系：リカバリーコードの場合は使用したコードを削除(大文字・区切り文字を無視)", func(t *testing.T) {
+		credential := authentication.MFACredential{RecoveryCodeHashes: append([]string{}, hashes...)}
+		if assert.True(t, credential.Verify(secret, strings.ToUpper(strings.ReplaceAll(recoveryCodes[1], "-", "")), now)) {
+			assert.Equal(t, []string{hashes[0]}, credential.RecoveryCodeHashes)
+		}
+	})
+	tt.Run("2-1: 異常系：使用済みのリカバリーコードの場合", func(t *testing.T) {
+		credential := authentication.MFACredential{RecoveryCodeHashes: []string{hashes[0]}}
+		assert.False(t, credential.Verify(secret, recoveryCodes[1], now))
+		assert.Equal(t, []string{hashes[0]}, credential.RecoveryCodeHashes)
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// SecretCipher テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：暗号化した値を復号できる場合
+// [x] 2-1: 異常系：鍵が異なる場合
+// [x] 2-2: 異常系：鍵が未設定の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestSecretCipher(tt *testing.T) {
+
+	tt.Run("1-1: 正常系：暗号化した値を復号できる場合", func(t *testing.T) {
+		cipher := authentication.NewSecretCipher("key")
+		encrypted, err := cipher.Encrypt("secret")
+		if assert.NoError(t, err) {
+			assert.NotEqual(t, "secret", encrypted)
+			actual, err := cipher.Decrypt(encrypted)
+			if assert.NoError(t, err) {
+				assert.Equal(t, "secret", actual)
+			}
+		}
+	})
+	tt.Run("2-1: 異常系：鍵が異なる場合", func(t *testing.T) {
+		encrypted, err := authentication.NewSecretCipher("key").Encrypt("secret")
+		if assert.NoError(t, err) {
+			_, err := authentication.NewSecretCipher("other").Decrypt(encrypted)
+			assert.Error(t, err)
+		}
+	})
+	tt.Run("2-2: 異常系：鍵が未設定の場合", func(t *testing.T) {
+		_, err := authentication.NewSecretCipher("").Encrypt("secret")
+		assert.ErrorIs(t, err, authentication.ErrSecretCipherKeyNotConfigured)
+	})
+}
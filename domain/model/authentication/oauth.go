@@ -0,0 +1,199 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// OAuthGrantTypeClientCredentials is the grant type of the system-to-system calls
+	OAuthGrantTypeClientCredentials = "client_credentials"
+	// OAuthTokenTypeBearer is the type of the issued access token
+	OAuthTokenTypeBearer = "Bearer"
+	// OAuthScopeSystemAuth allows the access token to call /api/v1/systemAuth/*
+	OAuthScopeSystemAuth = "systemAuth"
+
+	oauthTokenUseAccess = "access"
+	oauthScopeClaim     = "scope"
+	oauthTokenUseClaim  = "token_use"
+)
+
+// ErrOAuthSigningKeyNotConfigured
+// Summary: This is the error returned when the signing key of the access token is not configured.
+var ErrOAuthSigningKeyNotConfigured = errors.New("signing key of the OAuth access token is not configured")
+
+// OAuthClient
+// Summary: This is structure which defines the OAuthClient model.
+// The client secret is stored as a bcrypt hash, and the client acts as the API key of APIKeyID.
+// DBName: oauth_clients
+type OAuthClient struct {
+	ClientID         string
+	ClientSecretHash string
+	APIKeyID         string
+	Scopes           []string `gorm:"serializer:json"`
+	CreatedAt        time.Time
+	CreatedUserID    string
+	UpdatedAt        time.Time
+	UpdatedUserID    string
+}
+
+// VerifySecret
+// Summary: This is the function which verifies the client secret.
+// input: secret(string): client secret
+// output: (bool) true if the secret matches the hash, false otherwise
+func (m OAuthClient) VerifySecret(secret string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(m.ClientSecretHash), []byte(secret)) == nil
+}
+
+// GrantScopes
+// Summary: This is the function which decides the scopes granted to the access token.
+// All the scopes of the client are granted when no scope is requested.
+// input: requested([]string): requested scopes
+// output: ([]string) granted scopes
+// output: (bool) false if any requested scope is not allowed for the client
+func (m OAuthClient) GrantScopes(requested []string) ([]string, bool) {
+	if len(requested) == 0 {
+		return m.Scopes, true
+	}
+	for _, scope := range requested {
+		if !containsScope(m.Scopes, scope) {
+			return nil, false
+		}
+	}
+	return requested, true
+}
+
+// HashOAuthClientSecret
+// Summary: This is the function which hashes the client secret to store.
+// input: secret(string): client secret
+// output: (string) bcrypt hash of the secret
+// output: (error) error object
+func HashOAuthClientSecret(secret string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// OAuthAccessToken
+// Summary: This is structure which defines the verified OAuth access token.
+type OAuthAccessToken struct {
+	ClientID  string
+	APIKeyID  string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// HasScope
+// Summary: This is the function which checks whether the scope is granted to the access token.
+// input: scope(string): scope
+// output: (bool) true if the scope is granted, false otherwise
+func (m OAuthAccessToken) HasScope(scope string) bool {
+	return containsScope(m.Scopes, scope)
+}
+
+// OAuthTokenSigner
+// Summary: This is structure which signs and verifies the OAuth access tokens.
+type OAuthTokenSigner struct {
+	signingKey     []byte
+	issuer         string
+	accessTokenTTL time.Duration
+}
+
+// NewOAuthTokenSigner
+// Summary: This is the function which creates the OAuthTokenSigner from the configuration.
+// input: signingKey(string): HMAC key used to sign the access tokens
+// input: issuer(string): value of the iss and aud claims
+// input: accessTokenTTL(time.Duration): lifetime of the access token
+// output: (OAuthTokenSigner) signer
+func NewOAuthTokenSigner(signingKey string, issuer string, accessTokenTTL time.Duration) OAuthTokenSigner {
+	return OAuthTokenSigner{[]byte(signingKey), issuer, accessTokenTTL}
+}
+
+// AccessTokenTTL
+// Summary: This is the function which returns the lifetime of the access token.
+// output: (time.Duration) lifetime of the access token
+func (s OAuthTokenSigner) AccessTokenTTL() time.Duration {
+	return s.accessTokenTTL
+}
+
+// Sign
+// Summary: This is the function which signs the access token of the client.
+// input: clientID(string): client ID
+// input: scopes([]string): granted scopes
+// input: now(time.Time): issued time
+// output: (string) signed access token
+// output: (error) error object
+func (s OAuthTokenSigner) Sign(clientID string, scopes []string, now time.Time) (string, error) {
+	if len(s.signingKey) == 0 {
+		return "", ErrOAuthSigningKeyNotConfigured
+	}
+	claims := jwt.MapClaims{
+		"iss":              s.issuer,
+		"aud":              s.issuer,
+		"sub":              clientID,
+		"jti":              uuid.New().String(),
+		"iat":              now.Unix(),
+		"exp":              now.Add(s.accessTokenTTL).Unix(),
+		oauthScopeClaim:    strings.Join(scopes, " "),
+		oauthTokenUseClaim: oauthTokenUseAccess,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+}
+
+// Verify
+// Summary: This is the function which verifies the signature and the claims of the access token.
+// input: token(string): signed access token
+// output: (OAuthAccessToken) verified access token. APIKeyID is not set
+// output: (error) error object
+func (s OAuthTokenSigner) Verify(token string) (OAuthAccessToken, error) {
+	if len(s.signingKey) == 0 {
+		return OAuthAccessToken{}, ErrOAuthSigningKeyNotConfigured
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return s.signingKey, nil
+	})
+	if err != nil {
+		return OAuthAccessToken{}, err
+	}
+	if !claims.VerifyIssuer(s.issuer, true) || !claims.VerifyAudience(s.issuer, true) {
+		return OAuthAccessToken{}, fmt.Errorf("access token is issued by another issuer")
+	}
+	if tokenUse, _ := claims[oauthTokenUseClaim].(string); tokenUse != oauthTokenUseAccess {
+		return OAuthAccessToken{}, fmt.Errorf("token is not an access token")
+	}
+
+	clientID, _ := claims["sub"].(string)
+	scope, _ := claims[oauthScopeClaim].(string)
+	exp, _ := claims["exp"].(float64)
+	return OAuthAccessToken{
+		ClientID:  clientID,
+		Scopes:    strings.Fields(scope),
+		ExpiresAt: time.Unix(int64(exp), 0),
+	}, nil
+}
+
+// containsScope
+// Summary: This is the function which checks whether the scope is included in the scopes.
+// input: scopes([]string): scopes
+// input: scope(string): scope
+// output: (bool) true if the scope is included, false otherwise
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
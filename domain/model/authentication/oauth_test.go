@@ -0,0 +1,137 @@
+package authentication_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// OAuthClient GrantScopes テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：スコープを要求しない場合、クライアントの全スコープを付与
+// [x] 1-2: 正常系：許可されたスコープを要求した場合
+// [x] 2-1: 異常系：許可されていないスコープを要求した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestOAuthClient_GrantScopes(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		requested    []string
+		expectScopes []string
+		expectOK     bool
+	}{
+		{
+			name:         "1-1: 正常系：スコープを要求しない場合、クライアントの全スコープを付与",
+			requested:    nil,
+			expectScopes: []string{"systemAuth", "users"},
+			expectOK:     true,
+		},
+		{
+			name:         "1-2: 正常系：許可されたスコープを要求した場合",
+			requested:    []string{"users"},
+			expectScopes: []string{"users"},
+			expectOK:     true,
+		},
+		{
+			name:      "2-1: 異常系：許可されていないスコープを要求した場合",
+			requested: []string{"systemAuth", "admin"},
+			expectOK:  false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := authentication.OAuthClient{Scopes: []string{"systemAuth", "users"}}
+			actual, ok := client.GrantScopes(test.requested)
+			assert.Equal(t, test.expectOK, ok)
+			assert.Equal(t, test.expectScopes, actual)
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// OAuthTokenSigner Sign / Verify テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：署名したアクセストークンを検証できる場合
+// [x] 2-1: 異常系：別の鍵で署名されている場合
+// [x] 2-2: 異常系：別の発行者が発行している場合
+// [x] 2-3: 異常系：有効期限切れの場合
+// [x] 2-4: 異常系：署名鍵が設定されていない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestOAuthTokenSigner_Verify(tt *testing.T) {
+
+	signer := authentication.NewOAuthTokenSigner("signing-key", "authenticator-backend", 15*time.Minute)
+
+	tests := []struct {
+		name         string
+		issuer       authentication.OAuthTokenSigner
+		verifier     authentication.OAuthTokenSigner
+		issuedAt     time.Time
+		expectErrMsg string
+	}{
+		{
+			name:     "1-1: 正常系：署名したアクセストークンを検証できる場合",
+			issuer:   signer,
+			verifier: signer,
+			issuedAt: time.Now(),
+		},
+		{
+			name:         "2-1: 異常系：別の鍵で署名されている場合",
+			issuer:       authentication.NewOAuthTokenSigner("another-key", "authenticator-backend", 15*time.Minute),
+			verifier:     signer,
+			issuedAt:     time.Now(),
+			expectErrMsg: "signature is invalid",
+		},
+		{
+			name:         "2-2: 異常系：別の発行者が発行している場合",
+			issuer:       authentication.NewOAuthTokenSigner("signing-key", "another-issuer", 15*time.Minute),
+			verifier:     signer,
+			issuedAt:     time.Now(),
+			expectErrMsg: "access token is issued by another issuer",
+		},
+		{
+			name:         "2-3: 異常系：有効期限切れの場合",
+			issuer:       signer,
+			verifier:     signer,
+			issuedAt:     time.Now().Add(-time.Hour),
+			expectErrMsg: "Token is expired",
+		},
+		{
+			name:         "2-4: 異常系：署名鍵が設定されていない場合",
+			issuer:       signer,
+			verifier:     authentication.NewOAuthTokenSigner("", "authenticator-backend", 15*time.Minute),
+			issuedAt:     time.Now(),
+			expectErrMsg: authentication.ErrOAuthSigningKeyNotConfigured.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := test.issuer.Sign("client-1", []string{"systemAuth"}, test.issuedAt)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			actual, err := test.verifier.Verify(token)
+			if test.expectErrMsg != "" {
+				assert.ErrorContains(t, err, test.expectErrMsg)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, "client-1", actual.ClientID)
+				assert.True(t, actual.HasScope(authentication.OAuthScopeSystemAuth))
+				assert.Equal(t, test.issuedAt.Add(15*time.Minute).Unix(), actual.ExpiresAt.Unix())
+			}
+		})
+	}
+}
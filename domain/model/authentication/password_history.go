@@ -0,0 +1,54 @@
+package authentication
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHistory
+// Summary: This is structure which defines the PasswordHistory model.
+// DBName: password_histories
+type PasswordHistory struct {
+	ID            string
+	Email         string
+	PasswordHash  string
+	CreatedAt     time.Time
+	CreatedUserID string
+	UpdatedAt     time.Time
+	UpdatedUserID string
+}
+
+// NewPasswordHistory
+// Summary: This is the function which creates the PasswordHistory with the hash of the password.
+// input: email(string): email of the user
+// input: password(Password): password
+// output: (PasswordHistory) PasswordHistory object
+// output: (error) error object
+func NewPasswordHistory(email string, password Password) (PasswordHistory, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password.ToString()), bcrypt.DefaultCost)
+	if err != nil {
+		return PasswordHistory{}, err
+	}
+	return PasswordHistory{
+		Email:        email,
+		PasswordHash: string(hash),
+	}, nil
+}
+
+// PasswordHistories
+// Summary: This is structure which defines the slice of PasswordHistory.
+type PasswordHistories []PasswordHistory
+
+// Contains
+// Summary: This is the function which checks whether the password has been used in this struct slice.
+// input: password(Password): password
+// output: (bool) true if the password has been used, false otherwise
+func (ms PasswordHistories) Contains(password Password) bool {
+	for _, m := range ms {
+		if bcrypt.CompareHashAndPassword([]byte(m.PasswordHash), []byte(password.ToString())) == nil {
+			return true
+		}
+	}
+	return false
+}
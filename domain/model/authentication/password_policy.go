@@ -0,0 +1,143 @@
+package authentication
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"authenticator-backend/domain/common"
+)
+
+const (
+	PasswordRuleMinLength        = "minLength"
+	PasswordRuleMaxLength        = "maxLength"
+	PasswordRuleUpperCase        = "upperCase"
+	PasswordRuleLowerCase        = "lowerCase"
+	PasswordRuleDigit            = "digit"
+	PasswordRuleSpecialCharacter = "specialCharacter"
+	PasswordRuleDenyList         = "denyList"
+	PasswordRuleEmailLocalPart   = "emailLocalPart"
+	PasswordRuleHistory          = "history"
+)
+
+// PasswordPolicy
+// Summary: This is structure which defines the password policy model.
+type PasswordPolicy struct {
+	MinLength               int
+	MaxLength               int
+	RequireUpperCase        bool
+	RequireLowerCase        bool
+	RequireDigit            bool
+	RequireSpecialCharacter bool
+	SpecialCharacters       string
+	DenyList                PasswordDenyList
+	DisallowEmailLocalPart  bool
+	HistoryCount            int
+}
+
+// PasswordPolicyParam
+// Summary: This is structure which defines the information of the user used to validate the password.
+type PasswordPolicyParam struct {
+	// Email is the email of the user. the email local part rule is skipped when empty
+	Email string
+	// CurrentPassword is the password in use. empty when it is unknown
+	CurrentPassword Password
+	// Histories are the passwords used in the past
+	Histories PasswordHistories
+}
+
+// Validate
+// Summary: This is the function which validates the password against all the rules of the policy.
+// input: password(Password): password to validate
+// input: param(PasswordPolicyParam): information of the user
+// output: (PasswordPolicyViolations) violated rules. empty when the password satisfies the policy
+func (p PasswordPolicy) Validate(password Password, param PasswordPolicyParam) PasswordPolicyViolations {
+	var violations PasswordPolicyViolations
+	value := password.ToString()
+
+	length := utf8.RuneCountInString(value)
+	if p.MinLength > 0 && length < p.MinLength {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleMinLength, fmt.Sprintf("must be at least %d characters", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleMaxLength, fmt.Sprintf("must be at most %d characters", p.MaxLength)})
+	}
+	if p.RequireUpperCase && !strings.ContainsFunc(value, unicode.IsUpper) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleUpperCase, "must include at least one upper case letter"})
+	}
+	if p.RequireLowerCase && !strings.ContainsFunc(value, unicode.IsLower) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleLowerCase, "must include at least one lower case letter"})
+	}
+	if p.RequireDigit && !strings.ContainsFunc(value, unicode.IsDigit) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleDigit, "must include at least one digit"})
+	}
+	if p.RequireSpecialCharacter && !strings.ContainsAny(value, p.SpecialCharacters) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleSpecialCharacter, fmt.Sprintf("must include at least one special character (%s)", p.SpecialCharacters)})
+	}
+	if p.DenyList.Contains(password) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleDenyList, "must not be a commonly used password"})
+	}
+	if p.DisallowEmailLocalPart {
+		if localPart, _, _ := strings.Cut(param.Email, "@"); localPart != "" && strings.Contains(strings.ToLower(value), strings.ToLower(localPart)) {
+			violations = append(violations, PasswordPolicyViolation{PasswordRuleEmailLocalPart, "must not contain the local part of the email address"})
+		}
+	}
+	if p.HistoryCount > 0 && ((param.CurrentPassword != "" && password == param.CurrentPassword) || param.Histories.Contains(password)) {
+		violations = append(violations, PasswordPolicyViolation{PasswordRuleHistory, fmt.Sprintf("must not be one of the last %d passwords", p.HistoryCount)})
+	}
+	return violations
+}
+
+// PasswordDenyList
+// Summary: This is the type which defines the set of the passwords which must not be used.
+type PasswordDenyList map[string]struct{}
+
+// NewPasswordDenyList
+// Summary: This is the function which creates the deny list from the passwords.
+// input: passwords([]string): passwords which must not be used. compared case-insensitively
+// output: (PasswordDenyList) deny list
+func NewPasswordDenyList(passwords []string) PasswordDenyList {
+	denyList := make(PasswordDenyList, len(passwords))
+	for _, password := range passwords {
+		denyList[strings.ToLower(password)] = struct{}{}
+	}
+	return denyList
+}
+
+// Contains
+// Summary: This is the function which checks whether the password is in the deny list.
+// input: password(Password): password
+// output: (bool) true if the password is in the deny list, false otherwise
+func (l PasswordDenyList) Contains(password Password) bool {
+	_, ok := l[strings.ToLower(password.ToString())]
+
+	return ok
+}
+
+// PasswordPolicyViolation
+// Summary: This is structure which defines the rule of the password policy which the password violates.
+type PasswordPolicyViolation struct {
+	Rule    string
+	Message string
+}
+
+// PasswordPolicyViolations
+// Summary: This is structure which defines the slice of PasswordPolicyViolation.
+type PasswordPolicyViolations []PasswordPolicyViolation
+
+// ToErrorViolations
+// Summary: This is the function which converts the violations to the violations of the error response.
+// input: field(string): name of the password field in the request
+// output: ([]common.ErrorViolation) violations of the error response
+func (ms PasswordPolicyViolations) ToErrorViolations(field string) []common.ErrorViolation {
+	violations := make([]common.ErrorViolation, len(ms))
+	for i, m := range ms {
+		violations[i] = common.ErrorViolation{
+			Field:   field,
+			Rule:    m.Rule,
+			Message: m.Message,
+		}
+	}
+	return violations
+}
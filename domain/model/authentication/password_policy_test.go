@@ -0,0 +1,127 @@
+package authentication_test
+
+import (
+	"testing"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// PasswordPolicy Validate テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：全てのルールを満たす場合
+// [x] 1-2: 正常系：無効化したルールは検証しない場合
+// [x] 2-1: 異常系：桁数が最小桁数未満の場合
+// [x] 2-2: 異常系：桁数が最大桁数を超える場合
+// [x] 2-3: 異常系：文字種を含まない場合
+// [x] 2-4: 異常系：禁止パスワードの場合(大文字小文字を区別しない)
+// [x] 2-5: 異常系：メールアドレスのローカル部を含む場合
+// [x] 2-6: 異常系：過去に使用したパスワードの場合
+// [x] 2-7: 異常系：現在のパスワードと同じ場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestPasswordPolicy_Validate(tt *testing.T) {
+
+	policy := authentication.PasswordPolicy{
+		MinLength:               8,
+		MaxLength:               20,
+		RequireUpperCase:        true,
+		RequireLowerCase:        true,
+		RequireDigit:            true,
+		RequireSpecialCharacter: true,
+		SpecialCharacters:       "!@#$%^&*",
+		DenyList:                authentication.NewPasswordDenyList([]string{"Password1!"}),
+		DisallowEmailLocalPart:  true,
+		HistoryCount:            3,
+	}
+	history, err := authentication.NewPasswordHistory("user@example.com", "Used1234!")
+	if err != nil {
+		assert.Fail(tt, err.Error())
+	}
+
+	tests := []struct {
+		name     string
+		policy   authentication.PasswordPolicy
+		password authentication.Password
+		param    authentication.PasswordPolicyParam
+		expect   []string
+	}{
+		{
+			name:     "1-1: 正常系：全てのルールを満たす場合",
+			policy:   policy,
+			password: "1Aa@1Aa@1Aa@",
+			param:    authentication.PasswordPolicyParam{Email: "user@example.com", CurrentPassword: "Current1!", Histories: authentication.PasswordHistories{history}},
+			expect:   []string{},
+		},
+		{
+			name:     "1-2: 正常系：無効化したルールは検証しない場合",
+			policy:   authentication.PasswordPolicy{MinLength: 4},
+			password: "user",
+			param:    authentication.PasswordPolicyParam{Email: "user@example.com", CurrentPassword: "user"},
+			expect:   []string{},
+		},
+		{
+			name:     "2-1: 異常系：桁数が最小桁数未満の場合",
+			policy:   policy,
+			password: "1Aa@1Aa",
+			expect:   []string{authentication.PasswordRuleMinLength},
+		},
+		{
+			name:     "2-2: 異常系：桁数が最大桁数を超える場合",
+			policy:   policy,
+			password: "1Aa@1Aa@1Aa@1Aa@1Aa@1",
+			expect:   []string{authentication.PasswordRuleMaxLength},
+		},
+		{
+			name:     "2-3: 異常系：文字種を含まない場合",
+			policy:   policy,
+			password: "abcdefgh",
+			expect:   []string{authentication.PasswordRuleUpperCase, authentication.PasswordRuleDigit, authentication.PasswordRuleSpecialCharacter},
+		},
+		{
+			name:     "2-4: 異常系：禁止パスワードの場合(大文字小文字を区別しない)",
+			policy:   policy,
+			password: "pASSWORD1!",
+			expect:   []string{authentication.PasswordRuleDenyList},
+		},
+		{
+			name:     "2-5: 異常系：メールアドレスのローカル部を含む場合",
+			policy:   policy,
+			password: "1Aa@USER1",
+			param:    authentication.PasswordPolicyParam{Email: "user@example.com"},
+			expect:   []string{authentication.PasswordRuleEmailLocalPart},
+		},
+		{
+			name:     "2-6: 異常系：過去に使用したパスワードの場合",
+			policy:   policy,
+			password: "Used1234!",
+			param:    authentication.PasswordPolicyParam{Histories: authentication.PasswordHistories{history}},
+			expect:   []string{authentication.PasswordRuleHistory},
+		},
+		{
+			name:     "2-7: 異常系：現在のパスワードと同じ場合",
+			policy:   policy,
+			password: "Current1!",
+			param:    authentication.PasswordPolicyParam{CurrentPassword: "Current1!"},
+			expect:   []string{authentication.PasswordRuleHistory},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				actual := test.policy.Validate(test.password, test.param)
+				rules := make([]string, len(actual))
+				for i, violation := range actual {
+					rules[i] = violation.Rule
+				}
+				assert.Equal(t, test.expect, rules)
+			},
+		)
+	}
+}
@@ -0,0 +1,16 @@
+package authentication
+
+import "time"
+
+// PasswordResetRequest
+// Summary: This is structure which defines the PasswordResetRequest model.
+// DBName: password_reset_requests
+type PasswordResetRequest struct {
+	ID            string
+	Email         string
+	RequestedAt   time.Time
+	CreatedAt     time.Time
+	CreatedUserID string
+	UpdatedAt     time.Time
+	UpdatedUserID string
+}
@@ -0,0 +1,207 @@
+package authentication
+
+import (
+	"time"
+)
+
+// RateLimit
+// Summary: This is structure which defines the limit of the requests with the API key.
+// The requests are limited by the token bucket which holds Burst tokens and is refilled at RequestsPerMinute,
+// and by DailyQuota requests per day in UTC. Each limit is disabled when it is 0.
+type RateLimit struct {
+	RequestsPerMinute int
+	// Burst is the number of the requests accepted at once. RequestsPerMinute is used when it is 0
+	Burst      int
+	DailyQuota int
+}
+
+// DefaultRateLimits
+// Summary: This is the limits of the API keys which have no limits of their own, by the application attribute.
+// The API keys of the data space and the traceability systems call the system APIs on behalf of many users,
+// so their limits are higher than those of the applications.
+var DefaultRateLimits = map[ApplicationAttribute]RateLimit{
+	ApplicationAttributeApplication:  {RequestsPerMinute: 600, Burst: 100},
+	ApplicationAttributeDataSpace:    {RequestsPerMinute: 1200, Burst: 200},
+	ApplicationAttributeTraceability: {RequestsPerMinute: 1200, Burst: 200},
+}
+
+// APIKeyRateLimit
+// Summary: This is structure which defines the limits configured for the API key.
+// The limit which is nil is taken from the default of the application attribute.
+type APIKeyRateLimit struct {
+	RequestsPerMinute *int `gorm:"column:rate_limit_per_minute"`
+	Burst             *int `gorm:"column:rate_limit_burst"`
+	DailyQuota        *int `gorm:"column:daily_quota"`
+}
+
+// RateLimitPolicy
+// Summary: This is structure which defines the policy to limit the requests with the API keys.
+type RateLimitPolicy struct {
+	// Defaults is the limits by the application attribute. The API key whose attribute is not in it is not limited
+	Defaults map[ApplicationAttribute]RateLimit
+	// ByOperator limits the requests of each operator separately when the operator of the request is known
+	ByOperator bool
+}
+
+// LimitOf
+// Summary: This is the function which returns the limit of the API key.
+// input: apiKey(APIKey): API key
+// output: (RateLimit) limit of the API key
+func (p RateLimitPolicy) LimitOf(apiKey APIKey) RateLimit {
+	limit := p.Defaults[apiKey.Attribute]
+	if apiKey.RateLimit.RequestsPerMinute != nil {
+		limit.RequestsPerMinute = *apiKey.RateLimit.RequestsPerMinute
+	}
+	if apiKey.RateLimit.Burst != nil {
+		limit.Burst = *apiKey.RateLimit.Burst
+	}
+	if apiKey.RateLimit.DailyQuota != nil {
+		limit.DailyQuota = *apiKey.RateLimit.DailyQuota
+	}
+	return limit
+}
+
+// BucketKey
+// Summary: This is the function which returns the key of the bucket the request is counted in.
+// input: apiKeyID(string): ID of the API key
+// input: operatorID(string): ID of the operator of the request, or empty when it is not known
+// output: (string) key of the bucket
+func (p RateLimitPolicy) BucketKey(apiKeyID string, operatorID string) string {
+	if !p.ByOperator || operatorID == "" {
+		return apiKeyID
+	}
+	return apiKeyID + "/" + operatorID
+}
+
+// Unlimited
+// Summary: This is the function which checks whether the requests are not limited at all.
+// output: (bool) true if the requests are not limited, false otherwise
+func (l RateLimit) Unlimited() bool {
+	return l.RequestsPerMinute <= 0 && l.DailyQuota <= 0
+}
+
+// capacity
+// Summary: This is the function which returns the number of the tokens the bucket holds at most.
+// output: (float64) capacity of the bucket
+func (l RateLimit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.RequestsPerMinute)
+}
+
+// RateLimitBucket
+// Summary: This is structure which defines the tokens counted for the API key.
+// The tokens are counted in each instance, while the daily usage is counted in the database and shared by all the instances.
+type RateLimitBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// RateLimitStatus
+// Summary: This is structure which defines whether the request is accepted and the rest of the limits.
+type RateLimitStatus struct {
+	Allowed bool
+	// QuotaExceeded is true when the request is rejected by the daily quota
+	QuotaExceeded  bool
+	Limit          int
+	Remaining      int
+	Reset          time.Duration
+	QuotaLimit     int
+	QuotaRemaining int
+	QuotaReset     time.Duration
+	// RetryAfter is the period to wait before the next request when the request is rejected
+	RetryAfter time.Duration
+}
+
+// Take
+// Summary: This is the function which takes a token from the bucket for the request.
+// Nothing is taken when the request is rejected.
+// input: limit(RateLimit): limit of the API key
+// input: now(time.Time): time of the request
+// output: (RateLimitBucket) bucket after the request
+// output: (RateLimitStatus) status of the request
+func (b RateLimitBucket) Take(limit RateLimit, now time.Time) (RateLimitBucket, RateLimitStatus) {
+	if limit.RequestsPerMinute <= 0 {
+		return b, RateLimitStatus{Allowed: true}
+	}
+
+	capacity := limit.capacity()
+	perSecond := float64(limit.RequestsPerMinute) / 60
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens += elapsed * perSecond
+	}
+	if b.Tokens > capacity {
+		b.Tokens = capacity
+	}
+	b.UpdatedAt = now
+
+	status := RateLimitStatus{Allowed: true}
+	if b.Tokens < 1 {
+		status = RateLimitStatus{RetryAfter: remaining(now.Add(secondsOf((1-b.Tokens)/perSecond)), now)}
+	} else {
+		b.Tokens--
+	}
+
+	status.Limit = int(capacity)
+	status.Remaining = int(b.Tokens)
+	status.Reset = remaining(now.Add(secondsOf((capacity-b.Tokens)/perSecond)), now)
+	return b, status
+}
+
+// Refund
+// Summary: This is the function which returns the token taken for the request rejected by the daily quota.
+// input: limit(RateLimit): limit of the API key
+// output: (RateLimitBucket) bucket after the refund
+func (b RateLimitBucket) Refund(limit RateLimit) RateLimitBucket {
+	if limit.RequestsPerMinute <= 0 {
+		return b
+	}
+	b.Tokens = min(b.Tokens+1, limit.capacity())
+	return b
+}
+
+// QuotaDayOf
+// Summary: This is the function which returns the day in UTC the request is counted in the daily quota.
+// input: now(time.Time): time of the request
+// output: (time.Time) beginning of the day in UTC
+func QuotaDayOf(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// WithQuota
+// Summary: This is the function which applies the daily quota to the status of the request accepted by the tokens.
+// input: limit(RateLimit): limit of the API key
+// input: used(int): number of the requests counted in the day including the request
+// input: exceeded(bool): true if the request is not counted because the daily quota has been used up
+// input: now(time.Time): time of the request
+// output: (RateLimitStatus) status of the request
+func (s RateLimitStatus) WithQuota(limit RateLimit, used int, exceeded bool, now time.Time) RateLimitStatus {
+	if limit.DailyQuota <= 0 {
+		return s
+	}
+	quotaReset := remaining(QuotaDayOf(now).Add(24*time.Hour), now)
+	if exceeded {
+		s.Allowed = false
+		s.QuotaExceeded = true
+		s.RetryAfter = quotaReset
+		// the token is refunded for the rejected request
+		if s.Limit > 0 {
+			s.Remaining = min(s.Remaining+1, s.Limit)
+		}
+	}
+	s.QuotaLimit = limit.DailyQuota
+	s.QuotaRemaining = max(limit.DailyQuota-used, 0)
+	s.QuotaReset = quotaReset
+	return s
+}
+
+// secondsOf
+// Summary: This is the function which converts the seconds to the duration.
+// input: seconds(float64): seconds
+// output: (time.Duration) duration
+func secondsOf(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,154 @@
+package authentication_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// RateLimitPolicy テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：APIキーに上限が設定されていない場合はアプリケーション属性の既定値
+// [x] 1-2: 正常系：APIキーに設定された上限で既定値を上書き
+// [x] 1-3: 正常系：オペレータ単位で制限する場合のバケットのキー
+// [x] 1-4: 正常系：オペレータ単位で制限しない、またはオペレータが不明な場合のバケットのキー
+// /////////////////////////////////////////////////////////////////////////////////
+func TestRateLimitPolicy(t *testing.T) {
+	policy := authentication.RateLimitPolicy{Defaults: authentication.DefaultRateLimits}
+	perMinute, quota := 10, 0
+
+	t.Run("1-1: 正常系：APIキーに上限が設定されていない場合はアプリケーション属性の既定値", func(t *testing.T) {
+		apiKey := authentication.APIKey{Attribute: authentication.ApplicationAttributeApplication}
+		assert.Equal(t, authentication.DefaultRateLimits[authentication.ApplicationAttributeApplication], policy.LimitOf(apiKey))
+	})
+	t.Run("1-2: 正常系：APIキーに設定された上限で既定値を上書き", func(t *testing.T) {
+		apiKey := authentication.APIKey{
+			Attribute: authentication.ApplicationAttributeDataSpace,
+			RateLimit: authentication.APIKeyRateLimit{RequestsPerMinute: &perMinute, DailyQuota: &quota},
+		}
+		expected := authentication.RateLimit{
+			RequestsPerMinute: 10,
+			Burst:             authentication.DefaultRateLimits[authentication.ApplicationAttributeDataSpace].Burst,
+		}
+		assert.Equal(t, expected, policy.LimitOf(apiKey))
+	})
+	t.Run("1-3: 正常系：オペレータ単位で制限する場合のバケットのキー", func(t *testing.T) {
+		byOperator := authentication.RateLimitPolicy{ByOperator: true}
+		assert.Equal(t, "apiKeyID/operatorID", byOperator.BucketKey("apiKeyID", "operatorID"))
+		assert.Equal(t, "apiKeyID", byOperator.BucketKey("apiKeyID", ""))
+	})
+	t.Run("1-4: 正常系：オペレータ単位で制限しない、またはオペレータが不明な場合のバケットのキー", func(t *testing.T) {
+		assert.Equal(t, "apiKeyID", policy.BucketKey("apiKeyID", "operatorID"))
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// RateLimitBucket Take テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：初回はバーストの上限まで受け付ける
+// [x] 1-2: 正常系：経過時間に応じてトークンを補充
+// [x] 1-3: 正常系：1分あたりの上限が0の場合はトークンを消費しない
+// [x] 1-4: 正常系：1日の上限で拒否された場合はトークンを返却
+// [x] 2-1: 異常系：トークンが不足している場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestRateLimitBucket_Take(tt *testing.T) {
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limit := authentication.RateLimit{RequestsPerMinute: 60, Burst: 2, DailyQuota: 3}
+
+	tt.Run("1-1: 正常系：初回はバーストの上限まで受け付ける", func(t *testing.T) {
+		t.Parallel()
+
+		bucket, status := authentication.RateLimitBucket{}.Take(limit, now)
+		expected := authentication.RateLimitStatus{
+			Allowed:   true,
+			Limit:     2,
+			Remaining: 1,
+			Reset:     time.Second,
+		}
+		assert.Equal(t, expected, status)
+		assert.InDelta(t, 1, bucket.Tokens, 0)
+	})
+	tt.Run("1-2: 正常系：経過時間に応じてトークンを補充", func(t *testing.T) {
+		t.Parallel()
+
+		bucket := authentication.RateLimitBucket{Tokens: 0, UpdatedAt: now}
+		_, status := bucket.Take(limit, now.Add(time.Second))
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 0, status.Remaining)
+	})
+	tt.Run("1-3: 正常系：1分あたりの上限が0の場合はトークンを消費しない", func(t *testing.T) {
+		t.Parallel()
+
+		bucket, status := authentication.RateLimitBucket{}.Take(authentication.RateLimit{DailyQuota: 3}, now)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 0, status.Limit)
+		assert.Equal(t, authentication.RateLimitBucket{}, bucket)
+	})
+	tt.Run("1-4: 正常系：1日の上限で拒否された場合はトークンを返却", func(t *testing.T) {
+		t.Parallel()
+
+		bucket, _ := authentication.RateLimitBucket{}.Take(limit, now)
+		assert.InDelta(t, 2, bucket.Refund(limit).Tokens, 0)
+		assert.InDelta(t, 2, authentication.RateLimitBucket{Tokens: 2, UpdatedAt: now}.Refund(limit).Tokens, 0)
+	})
+	tt.Run("2-1: 異常系：トークンが不足している場合", func(t *testing.T) {
+		t.Parallel()
+
+		bucket := authentication.RateLimitBucket{Tokens: 0.5, UpdatedAt: now}
+		actual, status := bucket.Take(limit, now)
+		assert.False(t, status.Allowed)
+		assert.False(t, status.QuotaExceeded)
+		assert.Equal(t, time.Second, status.RetryAfter)
+		assert.Equal(t, 0, status.Remaining)
+		assert.InDelta(t, 0.5, actual.Tokens, 0)
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// RateLimitStatus WithQuota テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：1日の上限の残りを設定
+// [x] 1-2: 正常系：1日の上限が0の場合は変更しない
+// [x] 2-1: 異常系：1日の上限に達している場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestRateLimitStatus_WithQuota(tt *testing.T) {
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limit := authentication.RateLimit{RequestsPerMinute: 60, Burst: 2, DailyQuota: 3}
+	accepted := authentication.RateLimitStatus{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}
+
+	tt.Run("1-1: 正常系：1日の上限の残りを設定", func(t *testing.T) {
+		t.Parallel()
+
+		expected := authentication.RateLimitStatus{
+			Allowed:        true,
+			Limit:          2,
+			Remaining:      1,
+			Reset:          time.Second,
+			QuotaLimit:     3,
+			QuotaRemaining: 2,
+			QuotaReset:     12 * time.Hour,
+		}
+		assert.Equal(t, expected, accepted.WithQuota(limit, 1, false, now))
+	})
+	tt.Run("1-2: 正常系：1日の上限が0の場合は変更しない", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, accepted, accepted.WithQuota(authentication.RateLimit{RequestsPerMinute: 60}, 1, false, now))
+	})
+	tt.Run("2-1: 異常系：1日の上限に達している場合", func(t *testing.T) {
+		t.Parallel()
+
+		status := accepted.WithQuota(limit, 3, true, now)
+		assert.False(t, status.Allowed)
+		assert.True(t, status.QuotaExceeded)
+		assert.Equal(t, 12*time.Hour, status.RetryAfter)
+		assert.Equal(t, 0, status.QuotaRemaining)
+		assert.Equal(t, 2, status.Remaining)
+	})
+}
@@ -0,0 +1,88 @@
+package authentication
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// signingSecretBytes is the length of the random bytes of the generated signing secret
+	signingSecretBytes = 32
+	// MaxSignatureNonceLength is the maximum length of the nonce of the signed request
+	MaxSignatureNonceLength = 128
+)
+
+// SignedRequest
+// Summary: This is structure which defines the parts of the request signed with the signing secret of the API key.
+// Path is the path with the query string so that the query parameters can not be changed.
+type SignedRequest struct {
+	Method    string
+	Path      string
+	Body      []byte
+	Timestamp int64
+	Nonce     string
+}
+
+// CanonicalString
+// Summary: This is the function which returns the string to be signed.
+// The method, the path, the SHA-256 digest of the body in hex, the timestamp in unix seconds and the nonce are joined with the line feeds.
+// output: (string) string to be signed
+func (r SignedRequest) CanonicalString() string {
+	digest := sha256.Sum256(r.Body)
+	return strings.Join([]string{
+		strings.ToUpper(r.Method),
+		r.Path,
+		hex.EncodeToString(digest[:]),
+		strconv.FormatInt(r.Timestamp, 10),
+		r.Nonce,
+	}, "\n")
+}
+
+// Sign
+// Summary: This is the function which signs the request with HMAC-SHA256.
+// input: secret(string): signing secret of the API key
+// output: (string) signature in lower case hex
+func (r SignedRequest) Sign(secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(r.CanonicalString()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify
+// Summary: This is the function which checks whether the signature of the request is valid in constant time.
+// input: secret(string): signing secret of the API key
+// input: signature(string): signature in hex
+// output: (bool) true if the signature is valid, false otherwise
+func (r SignedRequest) Verify(secret string, signature string) bool {
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(r.Sign(secret))
+	return hmac.Equal(expected, actual)
+}
+
+// SignedAt
+// Summary: This is the function which returns the time the request is signed.
+// output: (time.Time) time of the timestamp
+func (r SignedRequest) SignedAt() time.Time {
+	return time.Unix(r.Timestamp, 0)
+}
+
+// GenerateSigningSecret
+// Summary: This is the function which generates a new random signing secret of the API key.
+// output: (string) signing secret
+// output: (error) error object
+func GenerateSigningSecret() (string, error) {
+	b := make([]byte, signingSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
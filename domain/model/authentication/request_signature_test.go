@@ -0,0 +1,80 @@
+package authentication_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// SignedRequest Sign / Verify テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：メソッド、パス、ボディのダイジェスト、タイムスタンプ、ノンスを改行で連結して署名
+// [x] 1-2: 正常系：同じシークレットで署名した場合は検証に成功
+// [x] 2-1: 異常系：署名後にリクエストを改ざんした場合
+// [x] 2-2: 異常系：異なるシークレット、16進数でない署名の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestSignedRequest_Verify(t *testing.T) {
+	secret := "secret"
+	request := authentication.SignedRequest{
+		Method:    "post",
+		Path:      "/auth/login?lang=ja",
+		Body:      []byte(`{"operatorAccountId":"user@example.com"}`),
+		Timestamp: 1714564800,
+		Nonce:     "nonce-1",
+	}
+
+	t.Run("1-1: 正常系：メソッド、パス、ボディのダイジェスト、タイムスタンプ、ノンスを改行で連結して署名", func(t *testing.T) {
+		empty := authentication.SignedRequest{Method: "get", Path: "/", Timestamp: 1, Nonce: "n"}
+		assert.Equal(t, "GET\n/\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n1\nn", empty.CanonicalString())
+	})
+	t.Run("1-2: 正常系：同じシークレットで署名した場合は検証に成功", func(t *testing.T) {
+		assert.True(t, request.Verify(secret, request.Sign(secret)))
+	})
+	t.Run("2-1: 異常系：署名後にリクエストを改ざんした場合", func(t *testing.T) {
+		signature := request.Sign(secret)
+
+		tampered := request
+		tampered.Body = []byte(`{"operatorAccountId":"admin@example.com"}`)
+		assert.False(t, tampered.Verify(secret, signature))
+
+		tampered = request
+		tampered.Path = "/auth/login?lang=en"
+		assert.False(t, tampered.Verify(secret, signature))
+
+		tampered = request
+		tampered.Nonce = "nonce-2"
+		assert.False(t, tampered.Verify(secret, signature))
+	})
+	t.Run("2-2: 異常系：異なるシークレット、16進数でない署名の場合", func(t *testing.T) {
+		assert.False(t, request.Verify("another", request.Sign(secret)))
+		assert.False(t, request.Verify(secret, "not-hex"))
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyPolicy AcceptsSignedAt / NonceExpiresAt テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：許容する時刻のずれの範囲内の場合
+// [x] 1-2: 正常系：ノンスは署名時刻から許容する時刻のずれの間記録
+// [x] 2-1: 異常系：許容する時刻のずれを超えて過去、未来の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyPolicy_AcceptsSignedAt(t *testing.T) {
+	policy := authentication.APIKeyPolicy{SignatureClockSkew: 5 * time.Minute}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("1-1: 正常系：許容する時刻のずれの範囲内の場合", func(t *testing.T) {
+		assert.True(t, policy.AcceptsSignedAt(now.Add(-5*time.Minute), now))
+		assert.True(t, policy.AcceptsSignedAt(now.Add(5*time.Minute), now))
+	})
+	t.Run("1-2: 正常系：ノンスは署名時刻から許容する時刻のずれの間記録", func(t *testing.T) {
+		assert.Equal(t, now.Add(5*time.Minute), policy.NonceExpiresAt(now))
+	})
+	t.Run("2-1: 異常系：許容する時刻のずれを超えて過去、未来の場合", func(t *testing.T) {
+		assert.False(t, policy.AcceptsSignedAt(now.Add(-5*time.Minute-time.Second), now))
+		assert.False(t, policy.AcceptsSignedAt(now.Add(5*time.Minute+time.Second), now))
+	})
+}
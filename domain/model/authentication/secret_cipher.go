@@ -0,0 +1,94 @@
+package authentication
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSecretCipherKeyNotConfigured
+// Summary: This is the error returned when the secret is encrypted or decrypted without the key.
+var ErrSecretCipherKeyNotConfigured = errors.New("secret encryption key is not configured")
+
+// SecretCipher
+// Summary: This is structure which encrypts the secrets stored in the DB with AES-256-GCM.
+type SecretCipher struct {
+	key []byte
+}
+
+// NewSecretCipher
+// Summary: This is the function which creates the SecretCipher from the configured key.
+// The AES key is derived from the SHA-256 hash of the configured key.
+// input: key(string): configured key. the cipher can not be used when it is empty
+// output: (SecretCipher) SecretCipher
+func NewSecretCipher(key string) SecretCipher {
+	if key == "" {
+		return SecretCipher{}
+	}
+	sum := sha256.Sum256([]byte(key))
+
+	return SecretCipher{key: sum[:]}
+}
+
+// Encrypt
+// Summary: This is the function which encrypts the secret.
+// input: plaintext(string): secret
+// output: (string) base64 encoded nonce and ciphertext
+// output: (error) error object
+func (c SecretCipher) Encrypt(plaintext string) (string, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt
+// Summary: This is the function which decrypts the secret encrypted by Encrypt.
+// input: ciphertext(string): base64 encoded nonce and ciphertext
+// output: (string) secret
+// output: (error) error object
+func (c SecretCipher) Decrypt(ciphertext string) (string, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("encrypted secret is too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// aead
+// Summary: This is the function which creates the AES-GCM cipher.
+// output: (cipher.AEAD) AES-GCM cipher
+// output: (error) error object
+func (c SecretCipher) aead() (cipher.AEAD, error) {
+	if len(c.key) == 0 {
+		return nil, ErrSecretCipherKeyNotConfigured
+	}
+	block, err := aes.NewCipher(c.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
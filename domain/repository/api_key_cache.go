@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"authenticator-backend/domain/model/authentication"
+)
+
+// APIKeyCache
+// Summary: This is interface which defines the functions for the in-memory index of the API keys and the CIDRs.
+//
+//go:generate mockery --name APIKeyCache --output ../../test/mock --case underscore
+type APIKeyCache interface {
+	Index() (authentication.APIKeyIndex, error)
+	Invalidate()
+	Watch(ctx context.Context)
+}
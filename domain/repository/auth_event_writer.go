@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// AuthEventWriter
+// Summary: This is interface which defines the functions to record the auth events without blocking the requests.
+//
+//go:generate mockery --name AuthEventWriter --output ../../test/mock --case underscore
+type AuthEventWriter interface {
+	Write(param CreateAuthEventParam)
+	Run(ctx context.Context)
+}
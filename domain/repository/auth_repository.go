@@ -1,6 +1,10 @@
 package repository
 
-import "authenticator-backend/domain/model/authentication"
+import (
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+)
 
 // AuthRepository
 // Summary: This is interface which defines the functions for the authentication repository.
@@ -8,24 +12,223 @@ import "authenticator-backend/domain/model/authentication"
 //go:generate mockery --name AuthRepository --output ../../test/mock --case underscore
 type AuthRepository interface {
 	ListAPIKeys(param APIKeysParam) (authentication.APIKeys, error)
+	GetAPIKey(id string) (authentication.APIKey, error)
+	CreateAPIKey(apiKey authentication.APIKey) error
+	UpdateAPIKey(param UpdateAPIKeyParam) error
+	UpdateAPIKeySigningSecret(param UpdateAPIKeySigningSecretParam) error
+	DeleteAPIKey(param DeleteAPIKeyParam) error
+	RotateAPIKey(param RotateAPIKeyParam) error
 	ListAPIKeyOperators(param APIKeyOperatorsParam) (authentication.APIKeyOperators, error)
+	CreateAPIKeyOperator(param APIKeyOperatorParam) error
+	DeleteAPIKeyOperator(param APIKeyOperatorParam) error
 	ListCidrs(param APIKeyCidrsParam) (authentication.Cidrs, error)
+	CreateCidr(param APIKeyCidrParam) error
+	DeleteCidr(param APIKeyCidrParam) error
+	ListClientCertificates(param APIKeyClientCertificatesParam) (authentication.ClientCertificates, error)
+	CreateClientCertificate(param APIKeyClientCertificateParam) error
+	DeleteClientCertificate(param APIKeyClientCertificateParam) error
+	ListAPIKeyPermissions(param APIKeyPermissionsParam) (authentication.APIKeyPermissions, error)
+	CreateAPIKeyPermission(param APIKeyPermissionParam) error
+	DeleteAPIKeyPermission(param APIKeyPermissionParam) error
+	CountPasswordResetRequests(param PasswordResetRequestsParam) (int64, error)
+	CreatePasswordResetRequest(email string) error
+	ListPasswordHistories(param PasswordHistoriesParam) (authentication.PasswordHistories, error)
+	CreatePasswordHistory(param CreatePasswordHistoryParam) error
+	ListLoginFailures(param LoginFailuresParam) (authentication.LoginAttempts, error)
+	CreateLoginAttempt(param CreateLoginAttemptParam) (string, error)
+	UpdateLoginAttemptResult(param UpdateLoginAttemptResultParam) error
+	GetMFACredential(email string) (authentication.MFACredential, error)
+	SaveMFACredential(credential authentication.MFACredential) error
+	DeleteMFACredential(email string) error
+	CreateMFAChallenge(challenge authentication.MFAChallenge) error
+	GetMFAChallenge(tokenHash string) (authentication.MFAChallenge, error)
+	IncrementMFAChallengeAttempts(id string) error
+	DeleteMFAChallenge(id string) error
+	GetOAuthClient(clientID string) (authentication.OAuthClient, error)
+	CreateOAuthClient(client authentication.OAuthClient) error
+	CreateAuthEvent(param CreateAuthEventParam) error
+	ListAuthEvents(param AuthEventsParam) (authentication.AuthEvents, error)
 }
 
 // APIKeysParam
 // Summary: This is the structure which defines the parameters for the ListAPIKeys Method.
+// KeyPrefix narrows down the candidates of the API key, which are verified by APIKeys.FindAPIKey.
 type APIKeysParam struct {
 	Attributes []authentication.ApplicationAttribute
+	ID         *string
+	KeyPrefix  *string
+}
+
+// UpdateAPIKeyParam
+// Summary: This is the structure which defines the parameters for the UpdateAPIKey Method.
+// The fields which are nil are not changed.
+type UpdateAPIKeyParam struct {
+	ID                string
+	ApplicationName   *string
+	Attribute         *authentication.ApplicationAttribute
+	RateLimit         authentication.APIKeyRateLimit
+	IPRestrictionMode *authentication.IPRestrictionMode
+	IsAdmin           *bool
+	UserID            string
+}
+
+// UpdateAPIKeySigningSecretParam
+// Summary: This is the structure which defines the parameters for the UpdateAPIKeySigningSecret Method.
+// SigningSecret is the encrypted signing secret, and the request signing is disabled when it is nil.
+type UpdateAPIKeySigningSecretParam struct {
+	ID            string
+	SigningSecret *string
+	UserID        string
+}
+
+// DeleteAPIKeyParam
+// Summary: This is the structure which defines the parameters for the DeleteAPIKey Method.
+type DeleteAPIKeyParam struct {
+	ID     string
+	UserID string
+}
+
+// RotateAPIKeyParam
+// Summary: This is the structure which defines the parameters for the RotateAPIKey Method.
+// The API key of ID expires at ExpiresAt, and Successor takes over its operators, CIDRs, permissions, OAuth clients and client certificates.
+type RotateAPIKeyParam struct {
+	ID        string
+	ExpiresAt time.Time
+	Successor authentication.APIKey
+	UserID    string
 }
 
 // APIKeyOperatorsParam
 // Summary: This is the structure which defines the parameters for the ListAPIKeyOperators Method.
 type APIKeyOperatorsParam struct {
-	APIKey *string
+	APIKeyID *string
+}
+
+// APIKeyOperatorParam
+// Summary: This is the structure which defines the parameters for the CreateAPIKeyOperator and DeleteAPIKeyOperator Methods.
+type APIKeyOperatorParam struct {
+	APIKeyID   string
+	OperatorID string
+	UserID     string
 }
 
 // APIKeyCidrsParam
 // Summary: This is the structure which defines the parameters for the ListCidrs Method.
 type APIKeyCidrsParam struct {
-	APIKey *string
+	APIKeyID *string
+}
+
+// APIKeyCidrParam
+// Summary: This is the structure which defines the parameters for the CreateCidr and DeleteCidr Methods.
+// Action and Priority are used only by CreateCidr.
+type APIKeyCidrParam struct {
+	APIKeyID string
+	Cidr     string
+	Action   authentication.CidrAction
+	Priority int
+	UserID   string
+}
+
+// APIKeyClientCertificatesParam
+// Summary: This is the structure which defines the parameters for the ListClientCertificates Method.
+type APIKeyClientCertificatesParam struct {
+	APIKeyID *string
+}
+
+// APIKeyClientCertificateParam
+// Summary: This is the structure which defines the parameters for the CreateClientCertificate and DeleteClientCertificate Methods.
+type APIKeyClientCertificateParam struct {
+	APIKeyID    string
+	Type        authentication.ClientCertificateType
+	Certificate string
+	UserID      string
+}
+
+// APIKeyPermissionsParam
+// Summary: This is the structure which defines the parameters for the ListAPIKeyPermissions Method.
+type APIKeyPermissionsParam struct {
+	APIKeyID *string
+}
+
+// APIKeyPermissionParam
+// Summary: This is the structure which defines the parameters for the CreateAPIKeyPermission and DeleteAPIKeyPermission Methods.
+type APIKeyPermissionParam struct {
+	APIKeyID string
+	Method   string
+	Path     string
+	UserID   string
+}
+
+// PasswordResetRequestsParam
+// Summary: This is the structure which defines the parameters for the CountPasswordResetRequests Method.
+type PasswordResetRequestsParam struct {
+	Email string
+	Since time.Time
+}
+
+// PasswordHistoriesParam
+// Summary: This is the structure which defines the parameters for the ListPasswordHistories Method.
+type PasswordHistoriesParam struct {
+	Email string
+	Limit int
+}
+
+// CreatePasswordHistoryParam
+// Summary: This is the structure which defines the parameters for the CreatePasswordHistory Method.
+type CreatePasswordHistoryParam struct {
+	History authentication.PasswordHistory
+	Keep    int
+}
+
+// LoginFailuresParam
+// Summary: This is the structure which defines the parameters for the ListLoginFailures Method.
+// When Email is set, only the failures after the last successful login or unlock of the account are listed.
+// The pending attempts are listed as the failures, except for the attempt of ExcludeID.
+type LoginFailuresParam struct {
+	Email     string
+	IPAddress string
+	Since     time.Time
+	Limit     int
+	ExcludeID string
+}
+
+// CreateLoginAttemptParam
+// Summary: This is the structure which defines the parameters for the CreateLoginAttempt Method.
+type CreateLoginAttemptParam struct {
+	Email     string
+	IPAddress string
+	Result    authentication.LoginAttemptResult
+}
+
+// UpdateLoginAttemptResultParam
+// Summary: This is the structure which defines the parameters for the UpdateLoginAttemptResult Method.
+type UpdateLoginAttemptResultParam struct {
+	ID     string
+	Result authentication.LoginAttemptResult
+}
+
+// CreateAuthEventParam
+// Summary: This is the structure which defines the parameters for the CreateAuthEvent Method.
+type CreateAuthEventParam struct {
+	Event             string
+	Result            bool
+	ReasonCode        string
+	IPAddress         string
+	APIKeyID          *string
+	OperatorID        *string
+	OperatorAccountID *string
+	// OccurredAt is the time of the request, and the time of the record is used when it is zero
+	OccurredAt time.Time
+}
+
+// AuthEventsParam
+// Summary: This is the structure which defines the parameters for the ListAuthEvents Method.
+// The events are listed in descending order of the occurred time, starting after the cursor.
+type AuthEventsParam struct {
+	From       *time.Time
+	To         *time.Time
+	OperatorID *string
+	Events     []string
+	After      *authentication.AuthEventCursor
+	Limit      int
 }
@@ -1,14 +1,74 @@
 package repository
 
-import "authenticator-backend/domain/model/authentication"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"authenticator-backend/domain/model/authentication"
+)
+
+// ErrIdPOperationNotSupported
+// Summary: This is the error returned when the identity provider does not support the operation.
+var ErrIdPOperationNotSupported = errors.New("operation is not supported by the identity provider")
+
+// ErrPasswordResetCodeInvalid
+// Summary: This is the error returned when the password reset code is invalid, expired or already used.
+var ErrPasswordResetCodeInvalid = errors.New("password reset code is invalid or expired")
+
+// ErrIdPUserNotFound
+// Summary: This is the error returned when the user does not exist in the identity provider.
+var ErrIdPUserNotFound = errors.New("user is not found in the identity provider")
+
+// ErrIdPEmailAlreadyExists
+// Summary: This is the error returned when the email is already registered to another user of the identity provider.
+var ErrIdPEmailAlreadyExists = errors.New("email is already registered in the identity provider")
+
+// IdPErrorKind
+// Summary: This is enum which defines the kind of the error returned by the identity provider.
+type IdPErrorKind string
+
+const (
+	IdPErrorUserDisabled    IdPErrorKind = "USER_DISABLED"
+	IdPErrorTooManyAttempts IdPErrorKind = "TOO_MANY_ATTEMPTS"
+	IdPErrorTokenExpired    IdPErrorKind = "TOKEN_EXPIRED"
+	IdPErrorQuotaExceeded   IdPErrorKind = "IDP_QUOTA_EXCEEDED"
+	IdPErrorUnavailable     IdPErrorKind = "IDP_UNAVAILABLE"
+)
+
+// IdPError
+// Summary: This is the error returned when the identity provider rejects the sign-in or the token refresh
+// for a reason other than the invalid credentials.
+type IdPError struct {
+	Kind IdPErrorKind
+	// Message is the raw error message returned by the identity provider
+	Message string
+}
+
+// Error
+// Summary: This is the function to get error message.
+// output: (string) error message
+func (e IdPError) Error() string {
+	return fmt.Sprintf("identity provider error %s: %s", e.Kind, e.Message)
+}
 
 // FirebaseRepository
 // Summary: This is interface which defines FirebaseRepository　functions.
 //
 //go:generate mockery --name FirebaseRepository --output ../../test/mock --case underscore
 type FirebaseRepository interface {
-	SignInWithPassword(email string, password string) (authentication.LoginResult, error)
-	VerifyIDToken(idToken string) (authentication.Claims, error)
-	RefreshToken(refreshToken string) (string, error)
-	ChangePassword(uid string, newPassword authentication.Password) error
+	SignInWithPassword(ctx context.Context, email string, password string) (authentication.LoginResult, error)
+	VerifyIDToken(ctx context.Context, idToken string) (authentication.Claims, error)
+	VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (authentication.Claims, error)
+	RefreshToken(ctx context.Context, refreshToken string) (string, error)
+	ChangePassword(ctx context.Context, uid string, newPassword authentication.Password) error
+	RevokeRefreshTokens(ctx context.Context, uid string) error
+	GeneratePasswordResetCode(ctx context.Context, email string) (string, error)
+	VerifyPasswordResetCode(ctx context.Context, code string) (string, error)
+	ConfirmPasswordReset(ctx context.Context, code string, newPassword authentication.Password) error
+	CreateUser(ctx context.Context, email string, password authentication.Password, operatorID string, role authentication.Role) (string, error)
+	ListUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error)
+	SetUserDisabled(ctx context.Context, uid string, disabled bool) error
+	SetUserRole(ctx context.Context, uid string, role authentication.Role) error
+	DeleteUser(ctx context.Context, uid string) error
 }
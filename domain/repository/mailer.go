@@ -0,0 +1,17 @@
+package repository
+
+// Mailer
+// Summary: This is interface which defines the functions for the mail delivery.
+//
+//go:generate mockery --name Mailer --output ../../test/mock --case underscore
+type Mailer interface {
+	Send(param MailParam) error
+}
+
+// MailParam
+// Summary: This is the structure which defines the parameters for the Send Method.
+type MailParam struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+)
+
+// NonceStore
+// Summary: This is interface which defines the functions to remember the nonces of the signed requests.
+// The nonces must be shared by all the instances so that the request can not be replayed to another instance.
+//
+//go:generate mockery --name NonceStore --output ../../test/mock --case underscore
+type NonceStore interface {
+	Use(key string, expiresAt time.Time, now time.Time) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+)
+
+// RateLimiter
+// Summary: This is interface which defines the functions to count the requests with the API keys.
+// The tokens may be counted in each instance, but the daily quota must be shared by all the instances.
+//
+//go:generate mockery --name RateLimiter --output ../../test/mock --case underscore
+type RateLimiter interface {
+	Take(key string, limit authentication.RateLimit, now time.Time) (authentication.RateLimitStatus, error)
+}
@@ -16,6 +16,7 @@ require (
 	github.com/stretchr/testify v1.8.1
 	github.com/swaggo/echo-swagger v1.3.5
 	go.uber.org/zap v1.24.0
+	golang.org/x/crypto v0.20.0
 	google.golang.org/api v0.114.0
 	gorm.io/driver/postgres v1.4.5
 	gorm.io/driver/sqlite v1.5.5
@@ -71,7 +72,6 @@ require (
 	go.uber.org/atomic v1.10.0 // indirect
 	go.uber.org/goleak v1.1.12 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
-	golang.org/x/crypto v0.20.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/oauth2 v0.7.0 // indirect
 	golang.org/x/sync v0.1.0 // indirect
@@ -0,0 +1,23 @@
+package entity
+
+// ResetPasswordResponse
+// Summary: This is the struct which defines the ResetPasswordResponse entity.
+type ResetPasswordResponse struct {
+	Email       string             `json:"email"`
+	RequestType string             `json:"requestType"`
+	Error       *IdentityToolError `json:"error"`
+}
+
+// IdentityToolError
+// Summary: This is the struct which defines the error entity returned by the Identity Toolkit API.
+type IdentityToolError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// IdentityToolErrorResponse
+// Summary: This is the struct which defines the error envelope returned by the Identity Toolkit and the Secure Token API.
+type IdentityToolErrorResponse struct {
+	Error *IdentityToolError `json:"error"`
+}
@@ -3,27 +3,53 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
 
-	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 	"authenticator-backend/infrastructure/firebase/entity"
+	"authenticator-backend/infrastructure/idpclient"
 
 	"firebase.google.com/go/v4/auth"
+	"firebase.google.com/go/v4/errorutils"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/api/iterator"
 )
 
-const errorCodeUnavailable = "UNAVAILABLE"
+const (
+	signInWithPasswordResource = "accounts:signInWithPassword"
+	resetPasswordResource      = "accounts:resetPassword"
+	oobCodeParam               = "oobCode"
+)
+
+// invalidCredentialMessages
+// Summary: This is the list of the Identity Toolkit and Secure Token API error messages which mean the credentials are invalid.
+var invalidCredentialMessages = []string{"EMAIL_NOT_FOUND", "INVALID_PASSWORD", "INVALID_LOGIN_CREDENTIALS", "INVALID_EMAIL", "USER_NOT_FOUND", "INVALID_REFRESH_TOKEN"}
+
+// identityToolErrorKinds
+// Summary: This is the map from the Identity Toolkit and Secure Token API error messages to the kinds of the error.
+var identityToolErrorKinds = map[string]repository.IdPErrorKind{
+	"USER_DISABLED":               repository.IdPErrorUserDisabled,
+	"TOO_MANY_ATTEMPTS_TRY_LATER": repository.IdPErrorTooManyAttempts,
+	"TOKEN_EXPIRED":               repository.IdPErrorTokenExpired,
+	"QUOTA_EXCEEDED":              repository.IdPErrorQuotaExceeded,
+}
+
+// invalidOobCodeMessages
+// Summary: This is the list of the Identity Toolkit error messages which mean the password reset code can not be used.
+var invalidOobCodeMessages = []string{"INVALID_OOB_CODE", "EXPIRED_OOB_CODE"}
 
 // firebaseRepository
 // Summary: This struct is the repository for the firebase.
 type firebaseRepository struct {
 	cli                   *auth.Client
+	client                *idpclient.Client
 	signInWithPasswordURL string
 	idpApikey             string
 	secureTokenApiKey     string
@@ -33,6 +59,7 @@ type firebaseRepository struct {
 // NewFirebase
 // Summary: This is the function which creates the firebase repository.
 // input: cli(*auth.Client) auth client
+// input: client(*idpclient.Client) client which calls the identity provider with the timeout, the retries and the circuit breaker
 // input: signInWithPasswordURL(string) sign in with password URL
 // input: idpApikey(string) idp api key
 // input: secureTokenApiKey(string) secure token api key
@@ -40,6 +67,7 @@ type firebaseRepository struct {
 // output: (firebaseRepository) firebase repository
 func NewFirebase(
 	cli *auth.Client,
+	client *idpclient.Client,
 	signInWithPasswordURL string,
 	idpApikey string,
 	secureTokenApiKey string,
@@ -47,6 +75,7 @@ func NewFirebase(
 ) firebaseRepository {
 	return firebaseRepository{
 		cli,
+		client,
 		signInWithPasswordURL,
 		idpApikey,
 		secureTokenApiKey,
@@ -56,11 +85,13 @@ func NewFirebase(
 
 // SignInWithPassword
 // Summary: This is the function which signs in with email and password.
+// The sign-in is not retried because the failed attempts are counted by the identity provider.
+// input: ctx(context.Context) context
 // input: email(string) email
 // input: password(string) password
-// output: (authentication.LoginResult) login result
-// output: (error) error object
-func (r firebaseRepository) SignInWithPassword(email string, password string) (authentication.LoginResult, error) {
+// output: (authentication.LoginResult) login result. the tokens are empty when the credentials are invalid
+// output: (error) error object. repository.IdPError when the identity provider rejects the sign-in for another reason
+func (r firebaseRepository) SignInWithPassword(ctx context.Context, email string, password string) (authentication.LoginResult, error) {
 	reqBody := map[string]interface{}{
 		"email":             email,
 		"password":          password,
@@ -73,7 +104,7 @@ func (r firebaseRepository) SignInWithPassword(email string, password string) (a
 		return authentication.LoginResult{}, err
 	}
 
-	request, err := http.NewRequest(http.MethodPost, r.signInWithPasswordURL, strings.NewReader(string(reqBodyJson)))
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.signInWithPasswordURL, strings.NewReader(string(reqBodyJson)))
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
@@ -84,13 +115,11 @@ func (r firebaseRepository) SignInWithPassword(email string, password string) (a
 	values.Add("key", r.idpApikey)
 	request.URL.RawQuery = values.Encode()
 
-	client := new(http.Client)
-
-	response, err := client.Do(request)
+	response, err := r.client.HTTPClient().Do(request)
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
-		return authentication.LoginResult{}, err
+		return authentication.LoginResult{}, idpclient.ConvertUnavailable(err)
 	}
 	defer response.Body.Close()
 
@@ -100,7 +129,10 @@ func (r firebaseRepository) SignInWithPassword(email string, password string) (a
 
 		return authentication.LoginResult{}, err
 	}
-	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		// the empty result is returned when id/pass is invalid
+		return authentication.LoginResult{}, identityToolError(response.StatusCode, body)
+	}
 
 	var loginResponse entity.LoginResponse
 	err = json.Unmarshal(body, &loginResponse)
@@ -120,10 +152,12 @@ func (r firebaseRepository) SignInWithPassword(email string, password string) (a
 
 // RefreshToken
 // Summary: This is the function which refreshes the token.
+// The refresh is retried on the transient failures because it does not change the state of the user.
+// input: ctx(context.Context) context
 // input: refreshToken(string) refresh token
-// output: (string) access token
-// output: (error) error object
-func (r firebaseRepository) RefreshToken(refreshToken string) (string, error) {
+// output: (string) access token. empty when the refresh token is invalid
+// output: (error) error object. repository.IdPError when the identity provider rejects the refresh for another reason
+func (r firebaseRepository) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
 	param := url.Values{}
 	param.Add("key", r.idpApikey)
 	requestURL, err := url.Parse(r.secureTokenApi)
@@ -139,7 +173,7 @@ func (r firebaseRepository) RefreshToken(refreshToken string) (string, error) {
 	formData.Add("grant_type", "refresh_token")
 	formData.Add("refresh_token", refreshToken)
 
-	request, err := http.NewRequest(http.MethodPost, requestURL.String(), strings.NewReader(formData.Encode()))
+	request, err := http.NewRequestWithContext(idpclient.WithIdempotent(ctx), http.MethodPost, requestURL.String(), strings.NewReader(formData.Encode()))
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
@@ -147,13 +181,11 @@ func (r firebaseRepository) RefreshToken(refreshToken string) (string, error) {
 	}
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	client := new(http.Client)
-
-	response, err := client.Do(request)
+	response, err := r.client.HTTPClient().Do(request)
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
-		return "", err
+		return "", idpclient.ConvertUnavailable(err)
 	}
 
 	body, err := io.ReadAll(response.Body)
@@ -163,6 +195,10 @@ func (r firebaseRepository) RefreshToken(refreshToken string) (string, error) {
 		return "", err
 	}
 	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		// the empty token is returned when the refresh token is invalid
+		return "", identityToolError(response.StatusCode, body)
+	}
 
 	var refreshResponse entity.RefreshResponse
 	err = json.Unmarshal(body, &refreshResponse)
@@ -177,20 +213,44 @@ func (r firebaseRepository) RefreshToken(refreshToken string) (string, error) {
 
 // VerifyIDToken
 // Summary: This is the function which verifies the ID token.
+// input: ctx(context.Context) context
 // input: idToken(string) id token
 // output: (authentication.Claims) claims
 // output: (error) error object
-func (r firebaseRepository) VerifyIDToken(idToken string) (authentication.Claims, error) {
-	ctx := context.Background()
-	token, err := r.cli.VerifyIDToken(ctx, idToken)
-	if err != nil {
-		if errorCode, ok := extractErrorCode(err); ok {
-			if errorCode == errorCodeUnavailable {
-				logger.Set(nil).Errorf(err.Error())
+func (r firebaseRepository) VerifyIDToken(ctx context.Context, idToken string) (authentication.Claims, error) {
+	var token *auth.Token
+	err := r.call(ctx, true, func(ctx context.Context) (err error) {
+		token, err = r.cli.VerifyIDToken(ctx, idToken)
+		return err
+	})
 
-				return authentication.Claims{}, common.NewCustomError(common.CustomErrorCode503, common.Err503OuterService, nil, common.HTTPErrorSourceAuth)
-			}
-		}
+	return r.newClaims(token, err)
+}
+
+// VerifyIDTokenAndCheckRevoked
+// Summary: This is the function which verifies the ID token and checks that the refresh tokens of the user have not been revoked.
+// input: ctx(context.Context) context
+// input: idToken(string) id token
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r firebaseRepository) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (authentication.Claims, error) {
+	var token *auth.Token
+	err := r.call(ctx, true, func(ctx context.Context) (err error) {
+		token, err = r.cli.VerifyIDTokenAndCheckRevoked(ctx, idToken)
+		return err
+	})
+
+	return r.newClaims(token, err)
+}
+
+// newClaims
+// Summary: This is the function which converts the result of the token verification to the claims.
+// input: token(*auth.Token) verified token
+// input: err(error) error of the verification
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r firebaseRepository) newClaims(token *auth.Token, err error) (authentication.Claims, error) {
+	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
 		return authentication.Claims{}, err
@@ -206,14 +266,220 @@ func (r firebaseRepository) VerifyIDToken(idToken string) (authentication.Claims
 
 // ChangePassword
 // Summary: This is the function which changes the password.
+// input: ctx(context.Context) context
+// input: uid(string) firebase UID
+// input: newPassword(authentication.Password) new password
+// output: (error) error object
+func (r firebaseRepository) ChangePassword(ctx context.Context, uid string, newPassword authentication.Password) error {
+	err := r.call(ctx, true, func(ctx context.Context) error {
+		_, err := r.cli.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Password(newPassword.ToString()))
+		return err
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// RevokeRefreshTokens
+// Summary: This is the function which revokes all the refresh tokens of the user.
+// input: ctx(context.Context) context
 // input: uid(string) firebase UID
+// output: (error) error object
+func (r firebaseRepository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	err := r.call(ctx, true, func(ctx context.Context) error {
+		return r.cli.RevokeRefreshTokens(ctx, uid)
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// GeneratePasswordResetCode
+// Summary: This is the function which generates the one-time code to reset the password.
+// input: ctx(context.Context) context
+// input: email(string) email
+// output: (string) password reset code. empty when the user does not exist
+// output: (error) error object
+func (r firebaseRepository) GeneratePasswordResetCode(ctx context.Context, email string) (string, error) {
+	var link string
+	err := r.call(ctx, true, func(ctx context.Context) (err error) {
+		link, err = r.cli.PasswordResetLink(ctx, email)
+		return err
+	})
+	if err != nil {
+		if auth.IsEmailNotFound(err) || auth.IsUserNotFound(err) {
+			return "", nil
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return u.Query().Get(oobCodeParam), nil
+}
+
+// VerifyPasswordResetCode
+// Summary: This is the function which verifies the one-time code without resetting the password.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// output: (string) email of the account the code was issued for
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r firebaseRepository) VerifyPasswordResetCode(ctx context.Context, code string) (string, error) {
+	resetResponse, err := r.resetPassword(idpclient.WithIdempotent(ctx), map[string]interface{}{
+		"oobCode": code,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resetResponse.Email, nil
+}
+
+// ConfirmPasswordReset
+// Summary: This is the function which resets the password with the one-time code.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
 // input: newPassword(authentication.Password) new password
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r firebaseRepository) ConfirmPasswordReset(ctx context.Context, code string, newPassword authentication.Password) error {
+	_, err := r.resetPassword(ctx, map[string]interface{}{
+		"oobCode":     code,
+		"newPassword": newPassword.ToString(),
+	})
+	return err
+}
+
+// CreateUser
+// Summary: This is the function which creates the user and sets the operator ID and the role to the custom claims.
+// input: ctx(context.Context) context
+// input: email(string) email
+// input: password(authentication.Password) password
+// input: operatorID(string) operator ID set to the operator_id claim
+// input: role(authentication.Role) role set to the role claim
+// output: (string) created firebase UID
+// output: (error) error object. repository.ErrIdPEmailAlreadyExists when the email is already registered
+func (r firebaseRepository) CreateUser(ctx context.Context, email string, password authentication.Password, operatorID string, role authentication.Role) (string, error) {
+	params := (&auth.UserToCreate{}).
+		UID(uuid.New().String()).
+		Email(email).
+		Password(password.ToString())
+	var user *auth.UserRecord
+	err := r.call(ctx, false, func(ctx context.Context) (err error) {
+		user, err = r.cli.CreateUser(ctx, params)
+		return err
+	})
+	if err != nil {
+		if auth.IsEmailAlreadyExists(err) {
+			logger.Set(nil).Warnf(err.Error())
+
+			return "", repository.ErrIdPEmailAlreadyExists
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	customClaims := map[string]interface{}{
+		authentication.OperatorIDClaim: operatorID,
+		authentication.RoleClaim:       string(role),
+	}
+	err = r.call(ctx, true, func(ctx context.Context) error {
+		return r.cli.SetCustomUserClaims(ctx, user.UID, customClaims)
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		// the user without the operator ID can not use the API, so it is not left
+		if err := r.call(ctx, true, func(ctx context.Context) error { return r.cli.DeleteUser(ctx, user.UID) }); err != nil {
+			logger.Set(nil).Errorf(err.Error())
+		}
+		return "", err
+	}
+	return user.UID, nil
+}
+
+// ListUsers
+// Summary: This is the function which lists the users.
+// input: ctx(context.Context) context
+// input: operatorID(string) operator ID of the users. all the users are listed when it is empty
+// output: (authentication.IdPUsers) users
+// output: (error) error object
+func (r firebaseRepository) ListUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error) {
+	var users authentication.IdPUsers
+	err := r.call(ctx, true, func(ctx context.Context) (err error) {
+		users, err = r.listUsers(ctx, operatorID)
+		return err
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return users, nil
+}
+
+// listUsers
+// Summary: This is the function which lists the users by iterating all the pages.
+// input: ctx(context.Context) context
+// input: operatorID(string) operator ID of the users. all the users are listed when it is empty
+// output: (authentication.IdPUsers) users
 // output: (error) error object
-func (r firebaseRepository) ChangePassword(uid string, newPassword authentication.Password) error {
-	ctx := context.Background()
+func (r firebaseRepository) listUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error) {
+	users := authentication.IdPUsers{}
+	iter := r.cli.Users(ctx, "")
+	for {
+		user, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	_, err := r.cli.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Password(newPassword.ToString()))
+		userOperatorID, _ := user.CustomClaims[authentication.OperatorIDClaim].(string)
+		if operatorID != "" && userOperatorID != operatorID {
+			continue
+		}
+		role, _ := user.CustomClaims[authentication.RoleClaim].(string)
+		users = append(users, authentication.IdPUser{
+			UID:        user.UID,
+			Email:      user.Email,
+			OperatorID: userOperatorID,
+			Role:       authentication.Role(role),
+			Disabled:   user.Disabled,
+		})
+	}
+	return users, nil
+}
+
+// SetUserDisabled
+// Summary: This is the function which disables or enables the user.
+// input: ctx(context.Context) context
+// input: uid(string) firebase UID
+// input: disabled(bool) true to disable the user, false to enable
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r firebaseRepository) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	err := r.call(ctx, true, func(ctx context.Context) error {
+		_, err := r.cli.UpdateUser(ctx, uid, (&auth.UserToUpdate{}).Disabled(disabled))
+		return err
+	})
 	if err != nil {
+		if auth.IsUserNotFound(err) {
+			logger.Set(nil).Warnf(err.Error())
+
+			return repository.ErrIdPUserNotFound
+		}
 		logger.Set(nil).Errorf(err.Error())
 
 		return err
@@ -221,22 +487,196 @@ func (r firebaseRepository) ChangePassword(uid string, newPassword authenticatio
 	return nil
 }
 
-// extractErrorCode
-// Summary: This is the function which extracts the error code.
-// input: err(error) error object
-// output: (string) error code
-// output: (bool) true if the error code is extracted, false otherwise
-func extractErrorCode(err error) (string, bool) {
-	val := reflect.ValueOf(err)
-	if val.Kind() == reflect.Ptr && !val.IsNil() {
-		val = val.Elem()
+// SetUserRole
+// Summary: This is the function which changes the role claim of the user. The other custom claims are kept.
+// input: ctx(context.Context) context
+// input: uid(string) firebase UID
+// input: role(authentication.Role) role set to the role claim
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r firebaseRepository) SetUserRole(ctx context.Context, uid string, role authentication.Role) error {
+	var user *auth.UserRecord
+	err := r.call(ctx, true, func(ctx context.Context) (err error) {
+		user, err = r.cli.GetUser(ctx, uid)
+		return err
+	})
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			logger.Set(nil).Warnf(err.Error())
+
+			return repository.ErrIdPUserNotFound
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+
+	customClaims := map[string]interface{}{}
+	for k, v := range user.CustomClaims {
+		customClaims[k] = v
+	}
+	customClaims[authentication.RoleClaim] = string(role)
+	err = r.call(ctx, true, func(ctx context.Context) error {
+		return r.cli.SetCustomUserClaims(ctx, uid, customClaims)
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// DeleteUser
+// Summary: This is the function which deletes the user.
+// input: ctx(context.Context) context
+// input: uid(string) firebase UID
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r firebaseRepository) DeleteUser(ctx context.Context, uid string) error {
+	err := r.call(ctx, true, func(ctx context.Context) error {
+		return r.cli.DeleteUser(ctx, uid)
+	})
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			logger.Set(nil).Warnf(err.Error())
+
+			return repository.ErrIdPUserNotFound
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// resetPassword
+// Summary: This is the function which calls the resetPassword API of the Identity Toolkit.
+// input: ctx(context.Context) context
+// input: reqBody(map[string]interface{}) request body
+// output: (entity.ResetPasswordResponse) response of the API
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r firebaseRepository) resetPassword(ctx context.Context, reqBody map[string]interface{}) (entity.ResetPasswordResponse, error) {
+	reqBodyJson, err := json.Marshal(reqBody)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, err
+	}
+
+	resetPasswordURL := strings.Replace(r.signInWithPasswordURL, signInWithPasswordResource, resetPasswordResource, 1)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, resetPasswordURL, strings.NewReader(string(reqBodyJson)))
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, err
+	}
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	values := url.Values{}
+	values.Add("key", r.idpApikey)
+	request.URL.RawQuery = values.Encode()
+
+	response, err := r.client.HTTPClient().Do(request)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, idpclient.ConvertUnavailable(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, err
+	}
+
+	var resetResponse entity.ResetPasswordResponse
+	if err := json.Unmarshal(body, &resetResponse); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, err
+	}
+	if resetResponse.Error != nil {
+		for _, message := range invalidOobCodeMessages {
+			if strings.HasPrefix(resetResponse.Error.Message, message) {
+				logger.Set(nil).Warnf(resetResponse.Error.Message)
+
+				return entity.ResetPasswordResponse{}, repository.ErrPasswordResetCodeInvalid
+			}
+		}
+		err := fmt.Errorf("failed to reset password: %s", resetResponse.Error.Message)
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.ResetPasswordResponse{}, err
+	}
+	return resetResponse, nil
+}
+
+// call
+// Summary: This is the function which calls the Admin SDK through the client of the identity provider.
+// input: ctx(context.Context) context
+// input: idempotent(bool) true if the call is safe to retry
+// input: fn(func(ctx context.Context) error) call of the Admin SDK
+// output: (error) error object. repository.IdPError when the identity provider is unavailable
+func (r firebaseRepository) call(ctx context.Context, idempotent bool, fn func(ctx context.Context) error) error {
+	err := r.client.Do(ctx, idempotent, func(ctx context.Context) error {
+		err := fn(ctx)
+		if errorutils.IsUnavailable(err) || errorutils.IsInternal(err) || errorutils.IsDeadlineExceeded(err) {
+			return idpclient.Transient(err)
+		}
+		return err
+	})
+	return idpclient.ConvertUnavailable(err)
+}
+
+// identityToolError
+// Summary: This is the function which converts the error response of the Identity Toolkit and the Secure Token API.
+// input: statusCode(int) HTTP status code
+// input: body([]byte) response body
+// output: (error) repository.IdPError for the known errors. nil when the error means the credentials are invalid
+func identityToolError(statusCode int, body []byte) error {
+	var res entity.IdentityToolErrorResponse
+	if err := json.Unmarshal(body, &res); err != nil || res.Error == nil {
+		if statusCode >= http.StatusInternalServerError {
+			err := repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: http.StatusText(statusCode)}
+			logger.Set(nil).Errorf(err.Error())
+
+			return err
+		}
+		err := fmt.Errorf("unexpected response from the identity provider: %d", statusCode)
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
 	}
 
-	if val.Kind() == reflect.Struct {
-		errorCodeField := val.FieldByName("ErrorCode")
-		if errorCodeField.IsValid() && errorCodeField.Kind() == reflect.String {
-			return errorCodeField.String(), true
+	// the message may be followed by the description, e.g. "TOO_MANY_ATTEMPTS_TRY_LATER : Access to this account has been temporarily disabled"
+	message, _, _ := strings.Cut(res.Error.Message, " ")
+	for _, m := range invalidCredentialMessages {
+		if message == m {
+			logger.Set(nil).Warnf(res.Error.Message)
+
+			return nil
 		}
 	}
-	return "", false
+
+	kind, ok := identityToolErrorKinds[message]
+	switch {
+	case ok:
+	case statusCode == http.StatusTooManyRequests:
+		kind = repository.IdPErrorQuotaExceeded
+	case statusCode >= http.StatusInternalServerError:
+		kind = repository.IdPErrorUnavailable
+	default:
+		err := fmt.Errorf("identity provider error: %s", res.Error.Message)
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	err := repository.IdPError{Kind: kind, Message: res.Error.Message}
+	if kind == repository.IdPErrorQuotaExceeded || kind == repository.IdPErrorUnavailable {
+		logger.Set(nil).Errorf(err.Error())
+	} else {
+		logger.Set(nil).Warnf(err.Error())
+	}
+
+	return err
 }
@@ -2,10 +2,14 @@ package repository_test
 
 import (
 	"authenticator-backend/domain/model/authentication"
+	domain_repository "authenticator-backend/domain/repository"
 	"authenticator-backend/infrastructure/firebase/repository"
+	"authenticator-backend/infrastructure/idpclient"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -24,15 +28,18 @@ import (
 // Firebase SignInWithPassword テストケース
 // /////////////////////////////////////////////////////////////////////////////////
 // [x] 1-1: 正常系：正常返却の場合
+// [x] 1-2: 正常系：パスワードが誤っている場合、空のトークンを返却
+// [x] 1-3: 正常系：メールアドレスが存在しない場合、空のトークンを返却
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectRepository_Firebase_SignInWithPassword(tt *testing.T) {
 
 	tests := []struct {
-		name         string
-		inputIdPPath string
-		inputSecPath string
-		receiveBody  string
-		expect       authentication.LoginResult
+		name          string
+		inputIdPPath  string
+		inputSecPath  string
+		receiveStatus int
+		receiveBody   string
+		expect        authentication.LoginResult
 	}{
 		{
 			name:         "1-1: 正常系",
@@ -53,6 +60,22 @@ func TestProjectRepository_Firebase_SignInWithPassword(tt *testing.T) {
 				RefreshToken: "refreshToken",
 			},
 		},
+		{
+			name:          "1-2: 正常系：パスワードが誤っている場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "INVALID_PASSWORD", "status": "INVALID_ARGUMENT"}}`,
+			expect:        authentication.LoginResult{},
+		},
+		{
+			name:          "1-3: 正常系：メールアドレスが存在しない場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "EMAIL_NOT_FOUND", "status": "INVALID_ARGUMENT"}}`,
+			expect:        authentication.LoginResult{},
+		},
 	}
 
 	for _, test := range tests {
@@ -62,6 +85,9 @@ func TestProjectRepository_Firebase_SignInWithPassword(tt *testing.T) {
 			func(t *testing.T) {
 				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					if strings.HasSuffix(r.URL.Path, test.inputIdPPath) {
+						if test.receiveStatus != 0 {
+							w.WriteHeader(test.receiveStatus)
+						}
 						code, err := w.Write([]byte(test.receiveBody))
 						if err != nil {
 							w.WriteHeader(code)
@@ -77,8 +103,8 @@ func TestProjectRepository_Firebase_SignInWithPassword(tt *testing.T) {
 				ts := httptest.NewServer(handler)
 				defer ts.Close()
 
-				r := repository.NewFirebase(nil, fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
-				actual, err := r.SignInWithPassword("aaa@aaa.com", "password")
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
+				actual, err := r.SignInWithPassword(context.Background(), "aaa@aaa.com", "password")
 				if assert.NoError(t, err) {
 					assert.Equal(t, test.expect.AccessToken, actual.AccessToken)
 					assert.Equal(t, test.expect.RefreshToken, actual.RefreshToken)
@@ -92,18 +118,77 @@ func TestProjectRepository_Firebase_SignInWithPassword(tt *testing.T) {
 // Firebase SignInWithPassword テストケース
 // /////////////////////////////////////////////////////////////////////////////////
 // [x] 2-1: 異常系：400の場合
+// [x] 2-2: 異常系：ユーザが無効化されている場合
+// [x] 2-3: 異常系：試行回数が多すぎる場合
+// [x] 2-4: 異常系：クォータを超過した場合
+// [x] 2-5: 異常系：429の場合
+// [x] 2-6: 異常系：503の場合
+// [x] 2-7: 異常系：500でJSON以外が返却された場合
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectRepository_Firebase_SignInWithPassword_Abnormal(tt *testing.T) {
 
 	tests := []struct {
-		name         string
-		inputIdPPath string
-		inputSecPath string
+		name          string
+		inputIdPPath  string
+		inputSecPath  string
+		receiveStatus int
+		receiveBody   string
+		expectKind    domain_repository.IdPErrorKind
 	}{
 		{
-			name:         "2-1: 異常系：503の場合",
-			inputIdPPath: "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
-			inputSecPath: "securetoken.googleapis.com/v1/token",
+			name:          "2-1: 異常系：400の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   "Bad Request",
+		},
+		{
+			name:          "2-2: 異常系：ユーザが無効化されている場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "USER_DISABLED", "status": "INVALID_ARGUMENT"}}`,
+			expectKind:    domain_repository.IdPErrorUserDisabled,
+		},
+		{
+			name:          "2-3: 異常系：試行回数が多すぎる場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "TOO_MANY_ATTEMPTS_TRY_LATER : Access to this account has been temporarily disabled due to many failed login attempts.", "status": "INVALID_ARGUMENT"}}`,
+			expectKind:    domain_repository.IdPErrorTooManyAttempts,
+		},
+		{
+			name:          "2-4: 異常系：クォータを超過した場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "QUOTA_EXCEEDED : Exceeded quota for verifying passwords.", "status": "INVALID_ARGUMENT"}}`,
+			expectKind:    domain_repository.IdPErrorQuotaExceeded,
+		},
+		{
+			name:          "2-5: 異常系：429の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusTooManyRequests,
+			receiveBody:   `{"error": {"code": 429, "message": "RESOURCE_EXHAUSTED", "status": "RESOURCE_EXHAUSTED"}}`,
+			expectKind:    domain_repository.IdPErrorQuotaExceeded,
+		},
+		{
+			name:          "2-6: 異常系：503の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusServiceUnavailable,
+			receiveBody:   `{"error": {"code": 503, "message": "The service is currently unavailable.", "status": "UNAVAILABLE"}}`,
+			expectKind:    domain_repository.IdPErrorUnavailable,
+		},
+		{
+			name:          "2-7: 異常系：500でJSON以外が返却された場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusInternalServerError,
+			receiveBody:   "Internal Server Error",
+			expectKind:    domain_repository.IdPErrorUnavailable,
 		},
 	}
 
@@ -113,8 +198,8 @@ func TestProjectRepository_Firebase_SignInWithPassword_Abnormal(tt *testing.T) {
 			test.name,
 			func(t *testing.T) {
 				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					w.WriteHeader(http.StatusBadRequest)
-					code, err := w.Write([]byte("Bad Request"))
+					w.WriteHeader(test.receiveStatus)
+					code, err := w.Write([]byte(test.receiveBody))
 					if err != nil {
 						w.WriteHeader(code)
 					}
@@ -122,9 +207,16 @@ func TestProjectRepository_Firebase_SignInWithPassword_Abnormal(tt *testing.T) {
 				ts := httptest.NewServer(handler)
 				defer ts.Close()
 
-				r := repository.NewFirebase(nil, fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
-				_, err := r.SignInWithPassword("aaa@aaa.com", "password")
-				assert.Error(t, err)
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
+				_, err := r.SignInWithPassword(context.Background(), "aaa@aaa.com", "password")
+				if assert.Error(t, err) {
+					var idpErr domain_repository.IdPError
+					if test.expectKind == "" {
+						assert.False(t, errors.As(err, &idpErr))
+					} else if assert.ErrorAs(t, err, &idpErr) {
+						assert.Equal(t, test.expectKind, idpErr.Kind)
+					}
+				}
 			},
 		)
 	}
@@ -134,15 +226,17 @@ func TestProjectRepository_Firebase_SignInWithPassword_Abnormal(tt *testing.T) {
 // Firebase RefreshToken テストケース
 // /////////////////////////////////////////////////////////////////////////////////
 // [x] 1-1: 正常系：正常返却の場合
+// [x] 1-2: 正常系：リフレッシュトークンが無効な場合、空のトークンを返却
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectRepository_Firebase_RefreshToken(tt *testing.T) {
 
 	tests := []struct {
-		name         string
-		inputIdPPath string
-		inputSecPath string
-		receiveBody  string
-		expect       string
+		name          string
+		inputIdPPath  string
+		inputSecPath  string
+		receiveStatus int
+		receiveBody   string
+		expect        string
 	}{
 		{
 			name:         "1-1: 正常系",
@@ -159,6 +253,14 @@ func TestProjectRepository_Firebase_RefreshToken(tt *testing.T) {
 			}`,
 			expect: "accessToken",
 		},
+		{
+			name:          "1-2: 正常系：リフレッシュトークンが無効な場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "INVALID_REFRESH_TOKEN", "status": "INVALID_ARGUMENT"}}`,
+			expect:        "",
+		},
 	}
 
 	for _, test := range tests {
@@ -168,6 +270,9 @@ func TestProjectRepository_Firebase_RefreshToken(tt *testing.T) {
 			func(t *testing.T) {
 				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					if strings.HasSuffix(r.URL.Path, test.inputSecPath) {
+						if test.receiveStatus != 0 {
+							w.WriteHeader(test.receiveStatus)
+						}
 						code, err := w.Write([]byte(test.receiveBody))
 						if err != nil {
 							w.WriteHeader(code)
@@ -182,8 +287,8 @@ func TestProjectRepository_Firebase_RefreshToken(tt *testing.T) {
 				})
 				ts := httptest.NewServer(handler)
 				defer ts.Close()
-				r := repository.NewFirebase(nil, fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
-				actual, err := r.RefreshToken("token")
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
+				actual, err := r.RefreshToken(context.Background(), "token")
 				if assert.NoError(t, err) {
 					assert.Equal(t, test.expect, actual)
 				}
@@ -196,18 +301,50 @@ func TestProjectRepository_Firebase_RefreshToken(tt *testing.T) {
 // Firebase RefreshToken テストケース
 // /////////////////////////////////////////////////////////////////////////////////
 // [x] 2-1: 異常系：400の場合
+// [x] 2-2: 異常系：トークンの有効期限が切れている場合
+// [x] 2-3: 異常系：ユーザが無効化されている場合
+// [x] 2-4: 異常系：503の場合
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectRepository_Firebase_RefreshToken_Abnormal(tt *testing.T) {
 
 	tests := []struct {
-		name         string
-		inputIdPPath string
-		inputSecPath string
+		name          string
+		inputIdPPath  string
+		inputSecPath  string
+		receiveStatus int
+		receiveBody   string
+		expectKind    domain_repository.IdPErrorKind
 	}{
 		{
-			name:         "2-1: 異常系：400の場合",
-			inputIdPPath: "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
-			inputSecPath: "securetoken.googleapis.com/v1/token",
+			name:          "2-1: 異常系：400の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   "Bad Request",
+		},
+		{
+			name:          "2-2: 異常系：トークンの有効期限が切れている場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "TOKEN_EXPIRED", "status": "INVALID_ARGUMENT"}}`,
+			expectKind:    domain_repository.IdPErrorTokenExpired,
+		},
+		{
+			name:          "2-3: 異常系：ユーザが無効化されている場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody:   `{"error": {"code": 400, "message": "USER_DISABLED", "status": "INVALID_ARGUMENT"}}`,
+			expectKind:    domain_repository.IdPErrorUserDisabled,
+		},
+		{
+			name:          "2-4: 異常系：503の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			inputSecPath:  "securetoken.googleapis.com/v1/token",
+			receiveStatus: http.StatusServiceUnavailable,
+			receiveBody:   "Service Unavailable",
+			expectKind:    domain_repository.IdPErrorUnavailable,
 		},
 	}
 
@@ -217,8 +354,8 @@ func TestProjectRepository_Firebase_RefreshToken_Abnormal(tt *testing.T) {
 			test.name,
 			func(t *testing.T) {
 				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					w.WriteHeader(http.StatusBadRequest)
-					code, err := w.Write([]byte("Bad Request"))
+					w.WriteHeader(test.receiveStatus)
+					code, err := w.Write([]byte(test.receiveBody))
 					if err != nil {
 						w.WriteHeader(code)
 					}
@@ -226,9 +363,16 @@ func TestProjectRepository_Firebase_RefreshToken_Abnormal(tt *testing.T) {
 				ts := httptest.NewServer(handler)
 				defer ts.Close()
 
-				r := repository.NewFirebase(nil, fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
-				_, err := r.RefreshToken("token")
-				assert.Error(t, err)
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", fmt.Sprintf("%s/%s", ts.URL, test.inputSecPath))
+				_, err := r.RefreshToken(context.Background(), "token")
+				if assert.Error(t, err) {
+					var idpErr domain_repository.IdPError
+					if test.expectKind == "" {
+						assert.False(t, errors.As(err, &idpErr))
+					} else if assert.ErrorAs(t, err, &idpErr) {
+						assert.Equal(t, test.expectKind, idpErr.Kind)
+					}
+				}
 			},
 		)
 	}
@@ -300,8 +444,8 @@ func TestProjectRepository_Firebase_VerifyIDToken(tt *testing.T) {
 				ctx := context.Background()
 				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
 				authCli, _ := app.Auth(ctx)
-				r := repository.NewFirebase(authCli, ts.URL, "aaa", "apikey", ts.URL)
-				actual, err := r.VerifyIDToken(test.inputClaim(test.inputProjectID, test.expect))
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				actual, err := r.VerifyIDToken(context.Background(), test.inputClaim(test.inputProjectID, test.expect))
 				if assert.NoError(t, err) {
 					assert.Equal(t, test.expect, actual.OperatorID)
 				}
@@ -398,8 +542,8 @@ func TestProjectRepository_Firebase_VerifyIDToken_Abnormal(tt *testing.T) {
 				ctx := context.Background()
 				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
 				authCli, _ := app.Auth(ctx)
-				r := repository.NewFirebase(authCli, ts.URL, "aaa", "apikey", ts.URL)
-				_, err := r.VerifyIDToken(test.inputClaim(test.inputProjectID, ""))
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				_, err := r.VerifyIDToken(context.Background(), test.inputClaim(test.inputProjectID, ""))
 				if assert.Error(t, err) {
 					assert.Equal(t, test.expect.Error(), err.Error())
 				}
@@ -464,8 +608,8 @@ func TestProjectRepository_Firebase_ChangePassword(tt *testing.T) {
 				ctx := context.Background()
 				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
 				authCli, _ := app.Auth(ctx)
-				r := repository.NewFirebase(authCli, ts.URL, "aaa", "apikey", ts.URL)
-				err := r.ChangePassword("test", "newpass")
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				err := r.ChangePassword(context.Background(), "test", "newpass")
 				assert.NoError(t, err)
 			},
 		)
@@ -508,10 +652,540 @@ func TestProjectRepository_Firebase_ChangePassword_Abnormal(tt *testing.T) {
 				ctx := context.Background()
 				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
 				authCli, _ := app.Auth(ctx)
-				r := repository.NewFirebase(authCli, ts.URL, "aaa", "apikey", ts.URL)
-				err := r.ChangePassword("test", "newpass")
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				err := r.ChangePassword(context.Background(), "test", "newpass")
 				assert.Error(t, err)
 			},
 		)
 	}
 }
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase GeneratePasswordResetCode テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：リンクからoobCodeを返却する場合
+// [x] 1-2: 正常系：対象ユーザーなしの場合、空のコードを返却
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_GeneratePasswordResetCode(tt *testing.T) {
+
+	tests := []struct {
+		name           string
+		inputProjectID string
+		receiveStatus  int
+		receiveBody    string
+		expect         string
+	}{
+		{
+			name:           "1-1: 正常系：リンクからoobCodeを返却する場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusOK,
+			receiveBody: `{
+				"email": "aaa@aaa.com",
+				"oobLink": "https://local.firebaseapp.com/__/auth/action?mode=resetPassword&oobCode=code123&apiKey=aaa"
+			}`,
+			expect: "code123",
+		},
+		{
+			name:           "1-2: 正常系：対象ユーザーなしの場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusBadRequest,
+			receiveBody: `{
+				"error": {"code": 400, "message": "EMAIL_NOT_FOUND"}
+			}`,
+			expect: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:sendOobCode", test.inputProjectID)) {
+						w.WriteHeader(test.receiveStatus)
+						code, err := w.Write([]byte(test.receiveBody))
+						if err != nil {
+							w.WriteHeader(code)
+						}
+					} else {
+						w.WriteHeader(http.StatusBadRequest)
+						code, err := w.Write([]byte("Bad Request"))
+						if err != nil {
+							w.WriteHeader(code)
+						}
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+				conf := &firebase.Config{ProjectID: test.inputProjectID}
+				os.Setenv("FIREBASE_AUTH_EMULATOR_HOST", strings.Replace(ts.URL, "http://", "", 1))
+				ctx := context.Background()
+				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+				authCli, _ := app.Auth(ctx)
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				actual, err := r.GeneratePasswordResetCode(context.Background(), "aaa@aaa.com")
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase ConfirmPasswordReset テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：正常返却の場合
+// [x] 2-1: 異常系：コードが無効の場合
+// [x] 2-2: 異常系：コードが期限切れの場合
+// [x] 2-3: 異常系：その他のエラーの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_ConfirmPasswordReset(tt *testing.T) {
+
+	tests := []struct {
+		name          string
+		inputIdPPath  string
+		receiveStatus int
+		receiveBody   string
+		expect        error
+	}{
+		{
+			name:          "1-1: 正常系",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			receiveStatus: http.StatusOK,
+			receiveBody: `{
+				"email": "aaa@aaa.com",
+				"requestType": "PASSWORD_RESET"
+			}`,
+			expect: nil,
+		},
+		{
+			name:          "2-1: 異常系：コードが無効の場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody: `{
+				"error": {"code": 400, "message": "INVALID_OOB_CODE"}
+			}`,
+			expect: domain_repository.ErrPasswordResetCodeInvalid,
+		},
+		{
+			name:          "2-2: 異常系：コードが期限切れの場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody: `{
+				"error": {"code": 400, "message": "EXPIRED_OOB_CODE"}
+			}`,
+			expect: domain_repository.ErrPasswordResetCodeInvalid,
+		},
+		{
+			name:          "2-3: 異常系：その他のエラーの場合",
+			inputIdPPath:  "identitytoolkit.googleapis.com/v1/accounts:signInWithPassword",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody: `{
+				"error": {"code": 400, "message": "WEAK_PASSWORD : Password should be at least 6 characters"}
+			}`,
+			expect: fmt.Errorf("failed to reset password: WEAK_PASSWORD : Password should be at least 6 characters"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, "identitytoolkit.googleapis.com/v1/accounts:resetPassword") {
+						w.WriteHeader(test.receiveStatus)
+						code, err := w.Write([]byte(test.receiveBody))
+						if err != nil {
+							w.WriteHeader(code)
+						}
+					} else {
+						w.WriteHeader(http.StatusBadRequest)
+						code, err := w.Write([]byte("Bad Request"))
+						if err != nil {
+							w.WriteHeader(code)
+						}
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/%s", ts.URL, test.inputIdPPath), "aaa", "apikey", ts.URL)
+				err := r.ConfirmPasswordReset(context.Background(), "code123", authentication.Password("1Aa@1Aa@1Aa@"))
+				if test.expect == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, test.expect.Error())
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase VerifyPasswordResetCode テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：正常返却の場合
+// [x] 2-1: 異常系：コードが無効の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_VerifyPasswordResetCode(tt *testing.T) {
+
+	tests := []struct {
+		name          string
+		receiveStatus int
+		receiveBody   string
+		expect        string
+		expectError   error
+	}{
+		{
+			name:          "1-1: 正常系",
+			receiveStatus: http.StatusOK,
+			receiveBody: `{
+				"email": "aaa@aaa.com",
+				"requestType": "PASSWORD_RESET"
+			}`,
+			expect: "aaa@aaa.com",
+		},
+		{
+			name:          "2-1: 異常系：コードが無効の場合",
+			receiveStatus: http.StatusBadRequest,
+			receiveBody: `{
+				"error": {"code": 400, "message": "INVALID_OOB_CODE"}
+			}`,
+			expectError: domain_repository.ErrPasswordResetCodeInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var reqBody map[string]interface{}
+					if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil || reqBody["newPassword"] != nil {
+						w.WriteHeader(http.StatusBadRequest)
+
+						return
+					}
+					w.WriteHeader(test.receiveStatus)
+					code, err := w.Write([]byte(test.receiveBody))
+					if err != nil {
+						w.WriteHeader(code)
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+
+				r := repository.NewFirebase(nil, idpclient.New(idpclient.Policy{}), fmt.Sprintf("%s/identitytoolkit.googleapis.com/v1/accounts:signInWithPassword", ts.URL), "aaa", "apikey", ts.URL)
+				actual, err := r.VerifyPasswordResetCode(context.Background(), "code123")
+				if test.expectError == nil {
+					if assert.NoError(t, err) {
+						assert.Equal(t, test.expect, actual)
+					}
+				} else {
+					assert.EqualError(t, err, test.expectError.Error())
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase CreateUser テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：ユーザを作成してoperator_id、roleを設定する場合
+// [x] 2-1: 異常系：メールアドレスが登録済みの場合
+// [x] 2-2: 異常系：カスタムクレームの設定に失敗した場合、作成したユーザを削除
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_CreateUser(tt *testing.T) {
+
+	tests := []struct {
+		name              string
+		inputProjectID    string
+		receiveStatus     int
+		receiveBody       string
+		receiveClaimsFail bool
+		expectErr         error
+		expectDelete      bool
+	}{
+		{
+			name:           "1-1: 正常系：ユーザを作成してoperator_id、roleを設定する場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusOK,
+			receiveBody:    `{"localId": "test"}`,
+		},
+		{
+			name:           "2-1: 異常系：メールアドレスが登録済みの場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusBadRequest,
+			receiveBody:    `{"error": {"code": 400, "message": "EMAIL_EXISTS"}}`,
+			expectErr:      domain_repository.ErrIdPEmailAlreadyExists,
+		},
+		{
+			name:              "2-2: 異常系：カスタムクレームの設定に失敗した場合、作成したユーザを削除",
+			inputProjectID:    "local",
+			receiveStatus:     http.StatusOK,
+			receiveBody:       `{"localId": "test"}`,
+			receiveClaimsFail: true,
+			expectDelete:      true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				var customAttributes string
+				var deleted bool
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts", test.inputProjectID)):
+						w.WriteHeader(test.receiveStatus)
+						_, _ = w.Write([]byte(test.receiveBody))
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:lookup", test.inputProjectID)):
+						_, _ = w.Write([]byte(`{"users": [{"localId": "test", "email": "aaa@aaa.com"}]}`))
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:update", test.inputProjectID)):
+						if test.receiveClaimsFail {
+							w.WriteHeader(http.StatusInternalServerError)
+							_, _ = w.Write([]byte(`{"error": {"code": 500, "message": "INTERNAL_ERROR"}}`))
+							return
+						}
+						var body map[string]interface{}
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						customAttributes, _ = body["customAttributes"].(string)
+						_, _ = w.Write([]byte(`{"localId": "test"}`))
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:delete", test.inputProjectID)):
+						deleted = true
+						_, _ = w.Write([]byte(`{}`))
+					default:
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte("Bad Request"))
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+				conf := &firebase.Config{ProjectID: test.inputProjectID}
+				os.Setenv("FIREBASE_AUTH_EMULATOR_HOST", strings.Replace(ts.URL, "http://", "", 1))
+				ctx := context.Background()
+				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+				authCli, _ := app.Auth(ctx)
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				actual, err := r.CreateUser(context.Background(), "aaa@aaa.com", "newpass", "b39e6248-c888-56ca-d9d0-89de1b1adc8e", authentication.RoleEditor)
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+				} else if test.receiveClaimsFail {
+					assert.Error(t, err)
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, "test", actual)
+					assert.JSONEq(t, `{"operator_id": "b39e6248-c888-56ca-d9d0-89de1b1adc8e", "role": "editor"}`, customAttributes)
+				}
+				assert.Equal(t, test.expectDelete, deleted)
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase ListUsers テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：operator_idが一致するユーザのみ返却する場合
+// [x] 1-2: 正常系：operator_id未指定の場合、全てのユーザを返却
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_ListUsers(tt *testing.T) {
+
+	tests := []struct {
+		name            string
+		inputProjectID  string
+		inputOperatorID string
+		expect          authentication.IdPUsers
+	}{
+		{
+			name:            "1-1: 正常系：operator_idが一致するユーザのみ返却する場合",
+			inputProjectID:  "local",
+			inputOperatorID: "b39e6248-c888-56ca-d9d0-89de1b1adc8e",
+			expect: authentication.IdPUsers{
+				{UID: "user1", Email: "aaa@aaa.com", OperatorID: "b39e6248-c888-56ca-d9d0-89de1b1adc8e", Role: authentication.RoleEditor},
+			},
+		},
+		{
+			name:           "1-2: 正常系：operator_id未指定の場合、全てのユーザを返却",
+			inputProjectID: "local",
+			expect: authentication.IdPUsers{
+				{UID: "user1", Email: "aaa@aaa.com", OperatorID: "b39e6248-c888-56ca-d9d0-89de1b1adc8e", Role: authentication.RoleEditor},
+				{UID: "user2", Email: "bbb@bbb.com", OperatorID: "15572d1c-ec13-0d78-7f92-dd4278871373", Disabled: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:batchGet", test.inputProjectID)) {
+						_, _ = w.Write([]byte(`{
+							"users": [
+								{"localId": "user1", "email": "aaa@aaa.com", "createdAt": "1700000000000", "customAttributes": "{\"operator_id\":\"b39e6248-c888-56ca-d9d0-89de1b1adc8e\",\"role\":\"editor\"}"},
+								{"localId": "user2", "email": "bbb@bbb.com", "disabled": true, "createdAt": "1700000001000", "customAttributes": "{\"operator_id\":\"15572d1c-ec13-0d78-7f92-dd4278871373\"}"}
+							]
+						}`))
+					} else {
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte("Bad Request"))
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+				conf := &firebase.Config{ProjectID: test.inputProjectID}
+				os.Setenv("FIREBASE_AUTH_EMULATOR_HOST", strings.Replace(ts.URL, "http://", "", 1))
+				ctx := context.Background()
+				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+				authCli, _ := app.Auth(ctx)
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				actual, err := r.ListUsers(context.Background(), test.inputOperatorID)
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase SetUserDisabled / DeleteUser テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：ユーザを無効化、削除する場合
+// [x] 2-1: 異常系：ユーザが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_SetUserDisabledAndDeleteUser(tt *testing.T) {
+
+	tests := []struct {
+		name           string
+		inputProjectID string
+		receiveStatus  int
+		receiveBody    string
+		expectErr      error
+	}{
+		{
+			name:           "1-1: 正常系：ユーザを無効化、削除する場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusOK,
+			receiveBody:    `{"localId": "test"}`,
+		},
+		{
+			name:           "2-1: 異常系：ユーザが存在しない場合",
+			inputProjectID: "local",
+			receiveStatus:  http.StatusBadRequest,
+			receiveBody:    `{"error": {"code": 400, "message": "USER_NOT_FOUND"}}`,
+			expectErr:      domain_repository.ErrIdPUserNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:update", test.inputProjectID)),
+						strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:delete", test.inputProjectID)):
+						w.WriteHeader(test.receiveStatus)
+						_, _ = w.Write([]byte(test.receiveBody))
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:lookup", test.inputProjectID)):
+						_, _ = w.Write([]byte(`{"users": [{"localId": "test", "disabled": true}]}`))
+					default:
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte("Bad Request"))
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+				conf := &firebase.Config{ProjectID: test.inputProjectID}
+				os.Setenv("FIREBASE_AUTH_EMULATOR_HOST", strings.Replace(ts.URL, "http://", "", 1))
+				ctx := context.Background()
+				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+				authCli, _ := app.Auth(ctx)
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				if test.expectErr != nil {
+					assert.ErrorIs(t, r.SetUserDisabled(context.Background(), "test", true), test.expectErr)
+					assert.ErrorIs(t, r.DeleteUser(context.Background(), "test"), test.expectErr)
+				} else {
+					assert.NoError(t, r.SetUserDisabled(context.Background(), "test", true))
+					assert.NoError(t, r.DeleteUser(context.Background(), "test"))
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Firebase SetUserRole テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：operator_idを維持してroleを変更する場合
+// [x] 2-1: 異常系：ユーザが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Firebase_SetUserRole(tt *testing.T) {
+
+	tests := []struct {
+		name           string
+		inputProjectID string
+		receiveBody    string
+		expectErr      error
+	}{
+		{
+			name:           "1-1: 正常系：operator_idを維持してroleを変更する場合",
+			inputProjectID: "local",
+			receiveBody:    `{"users": [{"localId": "test", "customAttributes": "{\"operator_id\":\"b39e6248-c888-56ca-d9d0-89de1b1adc8e\",\"role\":\"editor\"}"}]}`,
+		},
+		{
+			name:           "2-1: 異常系：ユーザが存在しない場合",
+			inputProjectID: "local",
+			receiveBody:    `{"users": []}`,
+			expectErr:      domain_repository.ErrIdPUserNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				var customAttributes string
+				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch {
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:lookup", test.inputProjectID)):
+						_, _ = w.Write([]byte(test.receiveBody))
+					case strings.HasSuffix(r.URL.Path, fmt.Sprintf("%s/accounts:update", test.inputProjectID)):
+						var body map[string]interface{}
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						customAttributes, _ = body["customAttributes"].(string)
+						_, _ = w.Write([]byte(`{"localId": "test"}`))
+					default:
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = w.Write([]byte("Bad Request"))
+					}
+				})
+				ts := httptest.NewServer(handler)
+				defer ts.Close()
+				conf := &firebase.Config{ProjectID: test.inputProjectID}
+				os.Setenv("FIREBASE_AUTH_EMULATOR_HOST", strings.Replace(ts.URL, "http://", "", 1))
+				ctx := context.Background()
+				app, _ := firebase.NewApp(ctx, conf, option.WithoutAuthentication())
+				authCli, _ := app.Auth(ctx)
+				r := repository.NewFirebase(authCli, idpclient.New(idpclient.Policy{}), ts.URL, "aaa", "apikey", ts.URL)
+				err := r.SetUserRole(context.Background(), "test", authentication.RoleViewer)
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+				} else if assert.NoError(t, err) {
+					assert.JSONEq(t, `{"operator_id": "b39e6248-c888-56ca-d9d0-89de1b1adc8e", "role": "viewer"}`, customAttributes)
+				}
+			},
+		)
+	}
+}
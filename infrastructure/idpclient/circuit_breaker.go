@@ -0,0 +1,98 @@
+package idpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen
+// Summary: This is the error returned without calling the identity provider while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open: identity provider is unavailable")
+
+// circuitBreaker
+// Summary: This is the structure which stops calling the identity provider after the consecutive failures.
+// After the open duration, one trial call is allowed and the breaker is closed when it succeeds.
+type circuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+	now          func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// newCircuitBreaker
+// Summary: This is the function which creates the circuit breaker.
+// input: threshold(int) consecutive failures to open the breaker. 0 disables the breaker
+// input: openDuration(time.Duration) period to fail fast after the breaker is opened
+// output: (*circuitBreaker) circuit breaker
+func newCircuitBreaker(threshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, openDuration: openDuration, now: time.Now}
+}
+
+// allow
+// Summary: This is the function which checks whether the call is allowed.
+// output: (error) ErrCircuitOpen when the breaker is open
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.now().Before(b.openUntil) || b.probing {
+		return ErrCircuitOpen
+	}
+	// half-open: only one trial call is allowed until its result is recorded
+	b.probing = true
+
+	return nil
+}
+
+// success
+// Summary: This is the function which records the successful call and closes the breaker.
+func (b *circuitBreaker) success() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+}
+
+// release
+// Summary: This is the function which releases the trial call without recording the result.
+// It is used when the call is abandoned by the caller before the identity provider responds.
+func (b *circuitBreaker) release() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// failure
+// Summary: This is the function which records the failed call and opens the breaker when the failures reach the threshold.
+func (b *circuitBreaker) failure() {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.openDuration)
+	}
+}
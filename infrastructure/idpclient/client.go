@@ -0,0 +1,313 @@
+package idpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"authenticator-backend/domain/repository"
+)
+
+// Policy
+// Summary: This is structure which defines how the identity provider is called.
+type Policy struct {
+	// Timeout bounds each attempt. 0 means no timeout other than the one of the caller's context
+	Timeout time.Duration
+	// MaxRetries is the number of the retries of the idempotent calls after a transient failure
+	MaxRetries int
+	// RetryBaseDelay and RetryMaxDelay bound the exponential backoff. The actual delay is jittered
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// BreakerThreshold is the number of the consecutive transient failures to open the circuit breaker. 0 disables the breaker
+	BreakerThreshold int
+	// BreakerOpenDuration is the period to fail fast after the circuit breaker is opened
+	BreakerOpenDuration time.Duration
+}
+
+// Client
+// Summary: This is structure which calls the identity provider with the timeout, the retries and the circuit breaker.
+// It is shared by all the calls to the identity provider so that the breaker sees every failure.
+type Client struct {
+	policy  Policy
+	base    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+// New
+// Summary: This is the function which creates the Client.
+// input: policy(Policy) policy of the calls
+// output: (*Client) client
+func New(policy Policy) *Client {
+	return &Client{
+		policy:  policy,
+		base:    http.DefaultTransport,
+		breaker: newCircuitBreaker(policy.BreakerThreshold, policy.BreakerOpenDuration),
+	}
+}
+
+// HTTPClient
+// Summary: This is the function which returns the HTTP client whose requests are sent through the Client.
+// output: (*http.Client) HTTP client
+func (c *Client) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// idempotentContextKey
+// Summary: This is the key of the context which marks the request as idempotent.
+type idempotentContextKey struct{}
+
+// WithIdempotent
+// Summary: This is the function which marks the request as safe to retry even when the method is not idempotent.
+// input: ctx(context.Context) context of the request
+// output: (context.Context) context marked as idempotent
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentContextKey{}, true)
+}
+
+// transientError
+// Summary: This is the error which means the identity provider could not serve the call.
+type transientError struct {
+	err error
+}
+
+// Error
+// Summary: This is the function to get error message.
+// output: (string) error message
+func (e transientError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap
+// Summary: This is the function to get the wrapped error.
+// output: (error) wrapped error
+func (e transientError) Unwrap() error {
+	return e.err
+}
+
+// Transient
+// Summary: This is the function which marks the error as transient so that Do retries the call.
+// input: err(error) error object
+// output: (error) transient error. nil when err is nil
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return transientError{err}
+}
+
+// IsUnavailable
+// Summary: This is the function which checks whether the error means the identity provider is unavailable.
+// input: err(error) error returned through the Client
+// output: (bool) true when the call failed transiently or the circuit breaker is open
+func IsUnavailable(err error) bool {
+	var t transientError
+	return errors.Is(err, ErrCircuitOpen) || errors.As(err, &t)
+}
+
+// ConvertUnavailable
+// Summary: This is the function which converts the error of the unavailable identity provider to repository.IdPError.
+// input: err(error) error returned through the Client
+// output: (error) repository.IdPError when the identity provider is unavailable, the error itself otherwise
+func ConvertUnavailable(err error) error {
+	if !IsUnavailable(err) {
+		return err
+	}
+	return repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: err.Error()}
+}
+
+// Do
+// Summary: This is the function which calls the identity provider through the Client.
+// The transient failures are retried only when the call is idempotent.
+// input: ctx(context.Context) context of the request
+// input: idempotent(bool) true if the call is safe to retry
+// input: fn(func(ctx context.Context) error) call to the identity provider. it returns Transient(err) for the transient failures
+// output: (error) error object. ErrCircuitOpen when the circuit breaker is open
+func (c *Client) Do(ctx context.Context, idempotent bool, fn func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.allow(); err != nil {
+			return err
+		}
+
+		attemptCtx, cancel := c.attemptContext(ctx)
+		err := fn(attemptCtx)
+		cancel()
+		if ctx.Err() != nil {
+			c.breaker.release()
+
+			return err
+		}
+		if err != nil && isTransient(err) {
+			err = Transient(err)
+			c.breaker.failure()
+		} else {
+			c.breaker.success()
+
+			return err
+		}
+
+		if !idempotent || attempt >= c.policy.MaxRetries {
+			return err
+		}
+		if waitErr := c.wait(ctx, attempt); waitErr != nil {
+			return err
+		}
+	}
+}
+
+// RoundTrip
+// Summary: This is the function which sends the HTTP request through the Client.
+// The requests are retried on the network errors and the 5xx responses when the method is idempotent
+// or the context is marked by WithIdempotent.
+// input: req(*http.Request) HTTP request
+// output: (*http.Response) HTTP response
+// output: (error) error object
+func (c *Client) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := isIdempotent(req) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		res, cancel, err := c.roundTrip(req, attempt)
+		if req.Context().Err() != nil {
+			c.breaker.release()
+			if res != nil {
+				res.Body.Close()
+			}
+			cancel()
+
+			return nil, req.Context().Err()
+		}
+		transient := err != nil || res.StatusCode >= http.StatusInternalServerError
+		if transient {
+			c.breaker.failure()
+		} else {
+			c.breaker.success()
+		}
+
+		if !transient || !idempotent || attempt >= c.policy.MaxRetries {
+			if err != nil {
+				cancel()
+
+				return nil, Transient(err)
+			}
+			res.Body = cancelOnClose{res.Body, cancel}
+
+			return res, nil
+		}
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		cancel()
+		if waitErr := c.wait(req.Context(), attempt); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+}
+
+// roundTrip
+// Summary: This is the function which sends one attempt of the HTTP request.
+// input: req(*http.Request) HTTP request
+// input: attempt(int) number of the attempt starting from 0
+// output: (*http.Response) HTTP response
+// output: (context.CancelFunc) function to release the context of the attempt
+// output: (error) error object
+func (c *Client) roundTrip(req *http.Request, attempt int) (*http.Response, context.CancelFunc, error) {
+	ctx, cancel := c.attemptContext(req.Context())
+	r := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, cancel, err
+		}
+		r.Body = body
+	}
+
+	res, err := c.base.RoundTrip(r)
+	return res, cancel, err
+}
+
+// attemptContext
+// Summary: This is the function which creates the context bounded by the timeout of the attempt.
+// input: ctx(context.Context) context of the request
+// output: (context.Context) context of the attempt
+// output: (context.CancelFunc) function to release the context
+func (c *Client) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.policy.Timeout > 0 {
+		return context.WithTimeout(ctx, c.policy.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// wait
+// Summary: This is the function which waits for the backoff before the retry.
+// The delay is chosen at random up to the exponential backoff (full jitter) so that the retries are spread out.
+// input: ctx(context.Context) context of the request
+// input: attempt(int) number of the failed attempt starting from 0
+// output: (error) error of the context when it is done while waiting
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	backoff := c.policy.RetryBaseDelay << attempt
+	if backoff <= 0 || (c.policy.RetryMaxDelay > 0 && backoff > c.policy.RetryMaxDelay) {
+		backoff = c.policy.RetryMaxDelay
+	}
+	if backoff <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff))) + 1)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isIdempotent
+// Summary: This is the function which checks whether the request is safe to retry.
+// input: req(*http.Request) HTTP request
+// output: (bool) true if the request is safe to retry
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentContextKey{}).(bool)
+	return idempotent
+}
+
+// isTransient
+// Summary: This is the function which checks whether the error returned by the call is transient.
+// input: err(error) error object
+// output: (bool) true when the error is marked by Transient, a network error or the timeout of the attempt
+func isTransient(err error) bool {
+	var t transientError
+	if errors.As(err, &t) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// cancelOnClose
+// Summary: This is structure which releases the context of the attempt when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close
+// Summary: This is the function which closes the response body and releases the context.
+// output: (error) error object
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,262 @@
+package idpclient_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/idpclient"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// RoundTrip テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：GETが5xxの場合、リトライして成功する
+// [x] 1-2: 正常系：WithIdempotentを指定したPOSTが5xxの場合、ボディを再送してリトライする
+// [x] 2-1: 異常系：POSTが5xxの場合、リトライしない
+// [x] 2-2: 異常系：GETが5xxのまま最大リトライ回数を超えた場合
+// [x] 2-3: 異常系：タイムアウトの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestClient_RoundTrip(tt *testing.T) {
+
+	tests := []struct {
+		name          string
+		method        string
+		idempotent    bool
+		failures      int32
+		sleep         time.Duration
+		expectStatus  int
+		expectCalls   int32
+		expectTimeout bool
+	}{
+		{
+			name:         "1-1: 正常系：GETが5xxの場合、リトライして成功する",
+			method:       http.MethodGet,
+			failures:     1,
+			expectStatus: http.StatusOK,
+			expectCalls:  2,
+		},
+		{
+			name:         "1-2: 正常系：WithIdempotentを指定したPOSTが5xxの場合、ボディを再送してリトライする",
+			method:       http.MethodPost,
+			idempotent:   true,
+			failures:     2,
+			expectStatus: http.StatusOK,
+			expectCalls:  3,
+		},
+		{
+			name:         "2-1: 異常系：POSTが5xxの場合、リトライしない",
+			method:       http.MethodPost,
+			failures:     1,
+			expectStatus: http.StatusServiceUnavailable,
+			expectCalls:  1,
+		},
+		{
+			name:         "2-2: 異常系：GETが5xxのまま最大リトライ回数を超えた場合",
+			method:       http.MethodGet,
+			failures:     10,
+			expectStatus: http.StatusServiceUnavailable,
+			expectCalls:  3,
+		},
+		{
+			name:          "2-3: 異常系：タイムアウトの場合",
+			method:        http.MethodPost,
+			sleep:         200 * time.Millisecond,
+			expectCalls:   1,
+			expectTimeout: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost && string(body) != "payload" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if test.sleep > 0 {
+					time.Sleep(test.sleep)
+				}
+				if n <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer ts.Close()
+
+			client := idpclient.New(idpclient.Policy{
+				Timeout:        50 * time.Millisecond,
+				MaxRetries:     2,
+				RetryBaseDelay: time.Millisecond,
+				RetryMaxDelay:  5 * time.Millisecond,
+			})
+			ctx := context.Background()
+			if test.idempotent {
+				ctx = idpclient.WithIdempotent(ctx)
+			}
+			var body io.Reader
+			if test.method == http.MethodPost {
+				body = strings.NewReader("payload")
+			}
+			req, err := http.NewRequestWithContext(ctx, test.method, ts.URL, body)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			res, err := client.HTTPClient().Do(req)
+			if test.expectTimeout {
+				assert.Error(t, err)
+				assert.True(t, idpclient.IsUnavailable(err))
+			} else if assert.NoError(t, err) {
+				res.Body.Close()
+				assert.Equal(t, test.expectStatus, res.StatusCode)
+			}
+			assert.Equal(t, test.expectCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// CircuitBreaker テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：連続失敗で遮断し、遮断期間の経過後に復旧する
+// /////////////////////////////////////////////////////////////////////////////////
+func TestClient_CircuitBreaker(t *testing.T) {
+	var calls int32
+	var healthy atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := idpclient.New(idpclient.Policy{
+		BreakerThreshold:    2,
+		BreakerOpenDuration: 50 * time.Millisecond,
+	}).HTTPClient()
+	get := func() (*http.Response, error) {
+		res, err := client.Get(ts.URL)
+		if err == nil {
+			res.Body.Close()
+		}
+		return res, err
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := get()
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		}
+	}
+
+	// the identity provider is not called while the breaker is open
+	_, err := get()
+	assert.ErrorIs(t, err, idpclient.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	var idpErr repository.IdPError
+	if assert.ErrorAs(t, idpclient.ConvertUnavailable(err), &idpErr) {
+		assert.Equal(t, repository.IdPErrorUnavailable, idpErr.Kind)
+	}
+
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+
+	res, err := get()
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+	res, err = get()
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Do テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：冪等な呼び出しが一時的なエラーの場合、リトライして成功する
+// [x] 2-1: 異常系：冪等でない呼び出しの場合、リトライしない
+// [x] 2-2: 異常系：一時的でないエラーの場合、リトライしない
+// /////////////////////////////////////////////////////////////////////////////////
+func TestClient_Do(tt *testing.T) {
+
+	errIdP := errors.New("idp error")
+
+	tests := []struct {
+		name        string
+		idempotent  bool
+		receive     error
+		expectErr   bool
+		expectCalls int
+	}{
+		{
+			name:        "1-1: 正常系：冪等な呼び出しが一時的なエラーの場合、リトライして成功する",
+			idempotent:  true,
+			receive:     idpclient.Transient(errIdP),
+			expectCalls: 2,
+		},
+		{
+			name:        "2-1: 異常系：冪等でない呼び出しの場合、リトライしない",
+			idempotent:  false,
+			receive:     idpclient.Transient(errIdP),
+			expectErr:   true,
+			expectCalls: 1,
+		},
+		{
+			name:        "2-2: 異常系：一時的でないエラーの場合、リトライしない",
+			idempotent:  true,
+			receive:     errIdP,
+			expectErr:   true,
+			expectCalls: 1,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := idpclient.New(idpclient.Policy{
+				MaxRetries:     2,
+				RetryBaseDelay: time.Millisecond,
+			})
+
+			calls := 0
+			err := client.Do(context.Background(), test.idempotent, func(ctx context.Context) error {
+				calls++
+				if calls == 1 {
+					return test.receive
+				}
+				return nil
+			})
+			if test.expectErr {
+				assert.ErrorIs(t, err, errIdP)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LocalUser
+// Summary: This is the struct which defines the local identity provider user entity.
+// DBName: local_users
+type LocalUser struct {
+	UID              string `gorm:"type:varchar(256);primaryKey"`
+	Email            string `gorm:"type:varchar(256);not null"`
+	PasswordHash     string `gorm:"type:text;not null"`
+	OperatorID       string `gorm:"type:varchar(256);not null"`
+	Role             string `gorm:"type:varchar(32);not null;default:''"`
+	Disabled         bool   `gorm:"not null"`
+	TokensValidAfter *time.Time
+	TokenRevision    int            `gorm:"not null;default:0"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	CreatedAt        time.Time      `gorm:"<-:create"`
+	CreatedUserID    string         `gorm:"type:text;not null;<-:create"`
+	UpdatedAt        time.Time
+	UpdatedUserID    string `gorm:"type:text;not null"`
+}
+
+// TableName
+// Summary: This is the function which returns the table name of the entity.
+// output: (string) table name
+func (LocalUser) TableName() string {
+	return "local_users"
+}
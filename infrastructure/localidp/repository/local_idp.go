@@ -0,0 +1,683 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/infrastructure/localidp/entity"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	tokenUseID             = "id"
+	tokenUseRefresh        = "refresh"
+	tokenUsePasswordReset  = "password_reset"
+	passwordHashClaim      = "pwh"
+	tokenRevisionClaim     = "rev"
+	passwordResetTTL       = time.Hour
+	signInProviderPassword = "password"
+	localIDPUserID         = "local-idp"
+)
+
+// localIDPRepository
+// Summary: This struct is the repository for the built-in local identity provider.
+type localIDPRepository struct {
+	db              *gorm.DB
+	signingKey      []byte
+	issuer          string
+	idTokenTTL      time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewLocalIDP
+// Summary: This is the function which creates the local identity provider repository.
+// input: db(*gorm.DB) database
+// input: signingKey(string) HMAC key used to sign the ID and refresh tokens
+// input: issuer(string) value of the iss and aud claims
+// input: idTokenTTL(time.Duration) lifetime of the ID token
+// input: refreshTokenTTL(time.Duration) lifetime of the refresh token
+// output: (localIDPRepository) local identity provider repository
+func NewLocalIDP(
+	db *gorm.DB,
+	signingKey string,
+	issuer string,
+	idTokenTTL time.Duration,
+	refreshTokenTTL time.Duration,
+) localIDPRepository {
+	return localIDPRepository{
+		db,
+		[]byte(signingKey),
+		issuer,
+		idTokenTTL,
+		refreshTokenTTL,
+	}
+}
+
+// SignInWithPassword
+// Summary: This is the function which signs in with email and password.
+// input: ctx(context.Context) context
+// input: email(string) email
+// input: password(string) password
+// output: (authentication.LoginResult) login result. the tokens are empty when the credentials are invalid
+// output: (error) error object
+func (r localIDPRepository) SignInWithPassword(ctx context.Context, email string, password string) (authentication.LoginResult, error) {
+	user, err := r.getUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return authentication.LoginResult{}, nil
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.LoginResult{}, err
+	}
+	if user.Disabled {
+		return authentication.LoginResult{}, nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return authentication.LoginResult{}, nil
+	}
+
+	now := time.Now()
+	idToken, err := r.signIDToken(user, now, now.Unix())
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.LoginResult{}, err
+	}
+	refreshToken, err := r.signRefreshToken(user, now)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.LoginResult{}, err
+	}
+
+	return authentication.LoginResult{
+		AccessToken:  idToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// RefreshToken
+// Summary: This is the function which refreshes the token.
+// input: ctx(context.Context) context
+// input: refreshToken(string) refresh token
+// output: (string) ID token. empty when the refresh token is invalid
+// output: (error) error object
+func (r localIDPRepository) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+	claims, err := r.parseToken(refreshToken, tokenUseRefresh)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return "", nil
+	}
+
+	uid, _ := claims["sub"].(string)
+	user, err := r.getUserByUID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	if user.Disabled || isRevoked(user, claims) {
+		return "", nil
+	}
+
+	authTime, _ := claims["auth_time"].(float64)
+	idToken, err := r.signIDToken(user, time.Now(), int64(authTime))
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return idToken, nil
+}
+
+// VerifyIDToken
+// Summary: This is the function which verifies the ID token.
+// input: ctx(context.Context) context
+// input: idToken(string) id token
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r localIDPRepository) VerifyIDToken(ctx context.Context, idToken string) (authentication.Claims, error) {
+	claims, err := r.parseToken(idToken, tokenUseID)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+
+	token := newAuthToken(claims)
+	result, err := authentication.NewClaims(&token)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	return result, nil
+}
+
+// VerifyIDTokenAndCheckRevoked
+// Summary: This is the function which verifies the ID token and checks that the user is enabled and the tokens of the user have not been revoked.
+// input: ctx(context.Context) context
+// input: idToken(string) id token
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r localIDPRepository) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (authentication.Claims, error) {
+	claims, err := r.parseToken(idToken, tokenUseID)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+
+	uid, _ := claims["sub"].(string)
+	user, err := r.getUserByUID(ctx, uid)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	if user.Disabled {
+		err := fmt.Errorf("user has been disabled")
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	if isRevoked(user, claims) {
+		err := fmt.Errorf("ID token has been revoked")
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+
+	token := newAuthToken(claims)
+	result, err := authentication.NewClaims(&token)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	return result, nil
+}
+
+// ChangePassword
+// Summary: This is the function which changes the password.
+// input: ctx(context.Context) context
+// input: uid(string) local user ID
+// input: newPassword(authentication.Password) new password
+// output: (error) error object
+func (r localIDPRepository) ChangePassword(ctx context.Context, uid string, newPassword authentication.Password) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword.ToString()), bcrypt.DefaultCost)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+
+	result := r.db.WithContext(ctx).Model(&entity.LocalUser{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"password_hash":   string(hash),
+		"updated_user_id": uid,
+	})
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Errorf(gorm.ErrRecordNotFound.Error())
+
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RevokeRefreshTokens
+// Summary: This is the function which revokes all the tokens of the user issued before now.
+// The revision of the tokens is incremented, so the tokens issued in the same second as the revocation are also revoked.
+// input: ctx(context.Context) context
+// input: uid(string) local user ID
+// output: (error) error object
+func (r localIDPRepository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	result := r.db.WithContext(ctx).Model(&entity.LocalUser{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"tokens_valid_after": time.Now().Truncate(time.Second),
+		"token_revision":     gorm.Expr("token_revision + 1"),
+		"updated_user_id":    uid,
+	})
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Errorf(gorm.ErrRecordNotFound.Error())
+
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GeneratePasswordResetCode
+// Summary: This is the function which generates the signed one-time code to reset the password.
+// The code is bound to the current password hash, so it can not be used once the password has been changed.
+// input: ctx(context.Context) context
+// input: email(string) email
+// output: (string) password reset code. empty when the user does not exist or is disabled
+// output: (error) error object
+func (r localIDPRepository) GeneratePasswordResetCode(ctx context.Context, email string) (string, error) {
+	user, err := r.getUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	if user.Disabled {
+		return "", nil
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":             r.issuer,
+		"aud":             r.issuer,
+		"sub":             user.UID,
+		"jti":             uuid.New().String(),
+		"iat":             now.Unix(),
+		"exp":             now.Add(passwordResetTTL).Unix(),
+		"token_use":       tokenUsePasswordReset,
+		passwordHashClaim: passwordHashFingerprint(user.PasswordHash),
+	}
+	code, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(r.signingKey)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return code, nil
+}
+
+// VerifyPasswordResetCode
+// Summary: This is the function which verifies the one-time code without resetting the password.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// output: (string) email of the user the code was issued for
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r localIDPRepository) VerifyPasswordResetCode(ctx context.Context, code string) (string, error) {
+	user, err := r.getUserByPasswordResetCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	return user.Email, nil
+}
+
+// ConfirmPasswordReset
+// Summary: This is the function which resets the password with the one-time code and revokes the tokens of the user.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// input: newPassword(authentication.Password) new password
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r localIDPRepository) ConfirmPasswordReset(ctx context.Context, code string, newPassword authentication.Password) error {
+	user, err := r.getUserByPasswordResetCode(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := r.ChangePassword(ctx, user.UID, newPassword); err != nil {
+		return err
+	}
+	return r.RevokeRefreshTokens(ctx, user.UID)
+}
+
+// getUserByPasswordResetCode
+// Summary: This is the function which gets the user the password reset code was issued for.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// output: (entity.LocalUser) user
+// output: (error) error object. repository.ErrPasswordResetCodeInvalid when the code can not be used
+func (r localIDPRepository) getUserByPasswordResetCode(ctx context.Context, code string) (entity.LocalUser, error) {
+	claims, err := r.parseToken(code, tokenUsePasswordReset)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return entity.LocalUser{}, repository.ErrPasswordResetCodeInvalid
+	}
+
+	uid, _ := claims["sub"].(string)
+	user, err := r.getUserByUID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.LocalUser{}, repository.ErrPasswordResetCodeInvalid
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return entity.LocalUser{}, err
+	}
+	if fingerprint, _ := claims[passwordHashClaim].(string); user.Disabled || fingerprint != passwordHashFingerprint(user.PasswordHash) {
+		return entity.LocalUser{}, repository.ErrPasswordResetCodeInvalid
+	}
+	return user, nil
+}
+
+// CreateUser
+// Summary: This is the function which registers a user to the local identity provider.
+// input: ctx(context.Context) context
+// input: email(string) email
+// input: password(authentication.Password) password
+// input: operatorID(string) operator ID set to the operator_id claim
+// input: role(authentication.Role) role set to the role claim
+// output: (string) created user ID
+// output: (error) error object. repository.ErrIdPEmailAlreadyExists when the email is already registered
+func (r localIDPRepository) CreateUser(ctx context.Context, email string, password authentication.Password, operatorID string, role authentication.Role) (string, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&entity.LocalUser{}).Where("email = ?", strings.ToLower(email)).Count(&count).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	if count > 0 {
+		logger.Set(nil).Warnf(repository.ErrIdPEmailAlreadyExists.Error())
+
+		return "", repository.ErrIdPEmailAlreadyExists
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password.ToString()), bcrypt.DefaultCost)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	user := entity.LocalUser{
+		UID:           uuid.New().String(),
+		Email:         strings.ToLower(email),
+		PasswordHash:  string(hash),
+		OperatorID:    operatorID,
+		Role:          string(role),
+		CreatedUserID: localIDPUserID,
+		UpdatedUserID: localIDPUserID,
+	}
+	if err := r.db.WithContext(ctx).Create(&user).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return user.UID, nil
+}
+
+// ListUsers
+// Summary: This is the function which lists the users of the local identity provider.
+// input: ctx(context.Context) context
+// input: operatorID(string) operator ID of the users. all the users are listed when it is empty
+// output: (authentication.IdPUsers) users ordered by the created time
+// output: (error) error object
+func (r localIDPRepository) ListUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error) {
+	query := r.db.WithContext(ctx).Order("created_at").Order("uid")
+	if operatorID != "" {
+		query = query.Where("operator_id = ?", operatorID)
+	}
+
+	var users []entity.LocalUser
+	if err := query.Find(&users).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+
+	result := make(authentication.IdPUsers, len(users))
+	for i, user := range users {
+		result[i] = authentication.IdPUser{
+			UID:        user.UID,
+			Email:      user.Email,
+			OperatorID: user.OperatorID,
+			Role:       authentication.Role(user.Role),
+			Disabled:   user.Disabled,
+		}
+	}
+	return result, nil
+}
+
+// SetUserDisabled
+// Summary: This is the function which disables or enables the user.
+// The disabled user can not sign in, and the tokens already issued are rejected when the revocation is checked.
+// input: ctx(context.Context) context
+// input: uid(string) local user ID
+// input: disabled(bool) true to disable the user, false to enable
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r localIDPRepository) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	result := r.db.WithContext(ctx).Model(&entity.LocalUser{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"disabled":        disabled,
+		"updated_user_id": localIDPUserID,
+	})
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Warnf(repository.ErrIdPUserNotFound.Error())
+
+		return repository.ErrIdPUserNotFound
+	}
+	return nil
+}
+
+// SetUserRole
+// Summary: This is the function which changes the role of the user.
+// The new role is set to the ID tokens issued after the change.
+// input: ctx(context.Context) context
+// input: uid(string) local user ID
+// input: role(authentication.Role) role set to the role claim
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r localIDPRepository) SetUserRole(ctx context.Context, uid string, role authentication.Role) error {
+	result := r.db.WithContext(ctx).Model(&entity.LocalUser{}).Where("uid = ?", uid).Updates(map[string]interface{}{
+		"role":            string(role),
+		"updated_user_id": localIDPUserID,
+	})
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Warnf(repository.ErrIdPUserNotFound.Error())
+
+		return repository.ErrIdPUserNotFound
+	}
+	return nil
+}
+
+// DeleteUser
+// Summary: This is the function which deletes the user.
+// The user is deleted physically so that the email can be registered again.
+// input: ctx(context.Context) context
+// input: uid(string) local user ID
+// output: (error) error object. repository.ErrIdPUserNotFound when the user does not exist
+func (r localIDPRepository) DeleteUser(ctx context.Context, uid string) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("uid = ?", uid).Delete(&entity.LocalUser{})
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Warnf(repository.ErrIdPUserNotFound.Error())
+
+		return repository.ErrIdPUserNotFound
+	}
+	return nil
+}
+
+// getUserByEmail
+// Summary: This is the function which gets the user by email.
+// input: ctx(context.Context) context
+// input: email(string) email
+// output: (entity.LocalUser) user
+// output: (error) error object
+func (r localIDPRepository) getUserByEmail(ctx context.Context, email string) (entity.LocalUser, error) {
+	var user entity.LocalUser
+	if err := r.db.WithContext(ctx).Where("email = ?", strings.ToLower(email)).First(&user).Error; err != nil {
+		return entity.LocalUser{}, err
+	}
+	return user, nil
+}
+
+// getUserByUID
+// Summary: This is the function which gets the user by user ID.
+// input: ctx(context.Context) context
+// input: uid(string) user ID
+// output: (entity.LocalUser) user
+// output: (error) error object
+func (r localIDPRepository) getUserByUID(ctx context.Context, uid string) (entity.LocalUser, error) {
+	var user entity.LocalUser
+	if err := r.db.WithContext(ctx).Where("uid = ?", uid).First(&user).Error; err != nil {
+		return entity.LocalUser{}, err
+	}
+	return user, nil
+}
+
+// signIDToken
+// Summary: This is the function which signs the ID token of the user.
+// input: user(entity.LocalUser) user
+// input: now(time.Time) issued time
+// input: authTime(int64) time when the user signed in with the password
+// output: (string) signed ID token
+// output: (error) error object
+func (r localIDPRepository) signIDToken(user entity.LocalUser, now time.Time, authTime int64) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":                          r.issuer,
+		"aud":                          r.issuer,
+		"sub":                          user.UID,
+		"iat":                          now.Unix(),
+		"exp":                          now.Add(r.idTokenTTL).Unix(),
+		"auth_time":                    authTime,
+		"email":                        user.Email,
+		authentication.OperatorIDClaim: user.OperatorID,
+		"token_use":                    tokenUseID,
+		tokenRevisionClaim:             user.TokenRevision,
+		"firebase": map[string]interface{}{
+			"sign_in_provider": signInProviderPassword,
+		},
+	}
+	if user.Role != "" {
+		claims[authentication.RoleClaim] = user.Role
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(r.signingKey)
+}
+
+// signRefreshToken
+// Summary: This is the function which signs the refresh token of the user.
+// input: user(entity.LocalUser) user
+// input: now(time.Time) issued time
+// output: (string) signed refresh token
+// output: (error) error object
+func (r localIDPRepository) signRefreshToken(user entity.LocalUser, now time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":              r.issuer,
+		"aud":              r.issuer,
+		"sub":              user.UID,
+		"jti":              uuid.New().String(),
+		"iat":              now.Unix(),
+		"exp":              now.Add(r.refreshTokenTTL).Unix(),
+		"auth_time":        now.Unix(),
+		"token_use":        tokenUseRefresh,
+		tokenRevisionClaim: user.TokenRevision,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(r.signingKey)
+}
+
+// parseToken
+// Summary: This is the function which verifies the signature and the registered claims of the token.
+// input: tokenString(string) signed token
+// input: tokenUse(string) expected value of the token_use claim
+// output: (jwt.MapClaims) claims of the token
+// output: (error) error object
+func (r localIDPRepository) parseToken(tokenString string, tokenUse string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return r.signingKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claims.VerifyIssuer(r.issuer, true) || !claims.VerifyAudience(r.issuer, true) {
+		return nil, fmt.Errorf("token has invalid issuer or audience")
+	}
+	if use, _ := claims["token_use"].(string); use != tokenUse {
+		return nil, fmt.Errorf("token is not %s token", tokenUse)
+	}
+	return claims, nil
+}
+
+// isRevoked
+// Summary: This is the function which checks whether the token was issued before the tokens of the user were revoked.
+// The token without the revision claim is issued before the revision was introduced and is checked only with its issued time.
+// input: user(entity.LocalUser) user
+// input: claims(jwt.MapClaims) verified claims
+// output: (bool) true if the token has been revoked, false otherwise
+func isRevoked(user entity.LocalUser, claims jwt.MapClaims) bool {
+	revision, _ := claims[tokenRevisionClaim].(float64)
+	if int(revision) != user.TokenRevision {
+		return true
+	}
+	if user.TokensValidAfter == nil {
+		return false
+	}
+	iat, _ := claims["iat"].(float64)
+
+	return int64(iat) < user.TokensValidAfter.Unix()
+}
+
+// passwordHashFingerprint
+// Summary: This is the function which derives the value of the password hash claim from the stored password hash.
+// input: passwordHash(string) bcrypt password hash
+// output: (string) fingerprint of the password hash
+func passwordHashFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// newAuthToken
+// Summary: This is the function which converts the verified claims to the Firebase token model.
+// input: claims(jwt.MapClaims) verified claims
+// output: (auth.Token) token
+func newAuthToken(claims jwt.MapClaims) auth.Token {
+	token := auth.Token{
+		Claims: map[string]interface{}(claims),
+	}
+	token.Issuer, _ = claims["iss"].(string)
+	token.Audience, _ = claims["aud"].(string)
+	token.Subject, _ = claims["sub"].(string)
+	token.UID = token.Subject
+	if v, ok := claims["auth_time"].(float64); ok {
+		token.AuthTime = int64(v)
+	}
+	if v, ok := claims["exp"].(float64); ok {
+		token.Expires = int64(v)
+	}
+	if v, ok := claims["iat"].(float64); ok {
+		token.IssuedAt = int64(v)
+	}
+	token.Firebase.SignInProvider = signInProviderPassword
+	return token
+}
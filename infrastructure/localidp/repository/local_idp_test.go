@@ -0,0 +1,350 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	domain_repository "authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/localidp/repository"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testSigningKey = "local-idp-signing-key"
+	testIssuer     = "authenticator-backend"
+	testOperatorID = "b39e6248-c888-56ca-d9d0-89de1b1adc8e"
+	testEmail      = "oem_a@example.com"
+	testPassword   = "oemA&user_01"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// LocalIDP SignInWithPassword / RefreshToken / VerifyIDToken / ChangePassword テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：ログイン、リフレッシュ、パスワード変更の一連の流れ
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_LocalIDP_Flow(t *testing.T) {
+	db, err := testhelper.NewMockDB()
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+	r := repository.NewLocalIDP(db, testSigningKey, testIssuer, time.Hour, 24*time.Hour)
+
+	uid, err := r.CreateUser(context.Background(), testEmail, authentication.Password(testPassword), testOperatorID, authentication.RoleAdmin)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	loginResult, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, loginResult.AccessToken)
+		assert.NotEmpty(t, loginResult.RefreshToken)
+	}
+
+	claims, err := r.VerifyIDToken(context.Background(), loginResult.AccessToken)
+	if assert.NoError(t, err) {
+		assert.Equal(t, testOperatorID, claims.OperatorID)
+		assert.Equal(t, authentication.RoleAdmin, claims.Role)
+		assert.Equal(t, uid, claims.UID)
+	}
+
+	idToken, err := r.RefreshToken(context.Background(), loginResult.RefreshToken)
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, idToken)
+	}
+
+	err = r.ChangePassword(context.Background(), uid, authentication.Password("1Aa@1Aa@1Aa@"))
+	assert.NoError(t, err)
+
+	loginResult, err = r.SignInWithPassword(context.Background(), testEmail, "1Aa@1Aa@1Aa@")
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, loginResult.AccessToken)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// LocalIDP 異常系テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 2-1: 異常系：パスワード不一致の場合、空のトークンを返却
+// [x] 2-2: 異常系：存在しないユーザの場合、空のトークンを返却
+// [x] 2-3: 異常系：リフレッシュトークンをIDトークンとして検証した場合
+// [x] 2-4: 異常系：署名鍵が異なる場合
+// [x] 2-5: 異常系：IDトークンをリフレッシュトークンとして利用した場合
+// [x] 2-6: 異常系：トークン失効後に失効前のトークンを利用した場合
+// [x] 2-7: 異常系：同一秒内に発行したトークンを失効した場合、失効後に発行したトークンのみ利用可能
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_LocalIDP_Abnormal(t *testing.T) {
+	db, err := testhelper.NewMockDB()
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+	r := repository.NewLocalIDP(db, testSigningKey, testIssuer, time.Hour, 24*time.Hour)
+	if _, err := r.CreateUser(context.Background(), testEmail, authentication.Password(testPassword), testOperatorID, authentication.RoleAdmin); !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("2-1: 異常系：パスワード不一致の場合", func(t *testing.T) {
+		actual, err := r.SignInWithPassword(context.Background(), testEmail, "wrong-password")
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual.AccessToken)
+			assert.Empty(t, actual.RefreshToken)
+		}
+	})
+
+	t.Run("2-2: 異常系：存在しないユーザの場合", func(t *testing.T) {
+		actual, err := r.SignInWithPassword(context.Background(), "unknown@example.com", testPassword)
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual.AccessToken)
+		}
+	})
+
+	loginResult, _ := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+
+	t.Run("2-3: 異常系：リフレッシュトークンをIDトークンとして検証した場合", func(t *testing.T) {
+		_, err := r.VerifyIDToken(context.Background(), loginResult.RefreshToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("2-4: 異常系：署名鍵が異なる場合", func(t *testing.T) {
+		other := repository.NewLocalIDP(db, "other-signing-key", testIssuer, time.Hour, 24*time.Hour)
+		_, err := other.VerifyIDToken(context.Background(), loginResult.AccessToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("2-5: 異常系：IDトークンをリフレッシュトークンとして利用した場合", func(t *testing.T) {
+		actual, err := r.RefreshToken(context.Background(), loginResult.AccessToken)
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual)
+		}
+	})
+
+	t.Run("2-6: 異常系：トークン失効後に失効前のトークンを利用した場合", func(t *testing.T) {
+		claims, err := r.VerifyIDToken(context.Background(), loginResult.AccessToken)
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.NoError(t, r.RevokeRefreshTokens(context.Background(), claims.UID)) {
+			return
+		}
+
+		_, err = r.VerifyIDTokenAndCheckRevoked(context.Background(), loginResult.AccessToken)
+		assert.Error(t, err)
+
+		actual, err := r.RefreshToken(context.Background(), loginResult.RefreshToken)
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual)
+		}
+
+		_, err = r.VerifyIDToken(context.Background(), loginResult.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("2-7: 異常系：同一秒内に発行したトークンを失効した場合", func(t *testing.T) {
+		issued, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+		if !assert.NoError(t, err) {
+			return
+		}
+		claims, err := r.VerifyIDTokenAndCheckRevoked(context.Background(), issued.AccessToken)
+		if !assert.NoError(t, err) {
+			return
+		}
+		if !assert.NoError(t, r.RevokeRefreshTokens(context.Background(), claims.UID)) {
+			return
+		}
+		reissued, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		_, err = r.VerifyIDTokenAndCheckRevoked(context.Background(), issued.AccessToken)
+		assert.Error(t, err)
+
+		actual, err := r.RefreshToken(context.Background(), issued.RefreshToken)
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual)
+		}
+
+		_, err = r.VerifyIDTokenAndCheckRevoked(context.Background(), reissued.AccessToken)
+		assert.NoError(t, err)
+
+		actual, err = r.RefreshToken(context.Background(), reissued.RefreshToken)
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, actual)
+		}
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// LocalIDP GeneratePasswordResetCode / VerifyPasswordResetCode / ConfirmPasswordReset テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：コードでパスワードを再設定できる場合
+// [x] 1-2: 正常系：存在しないユーザの場合、空のコードを返却
+// [x] 2-1: 異常系：使用済みのコードの場合
+// [x] 2-2: 異常系：IDトークンをコードとして利用した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_LocalIDP_PasswordReset(t *testing.T) {
+	db, err := testhelper.NewMockDB()
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+	r := repository.NewLocalIDP(db, testSigningKey, testIssuer, time.Hour, 24*time.Hour)
+	if _, err := r.CreateUser(context.Background(), testEmail, authentication.Password(testPassword), testOperatorID, authentication.RoleAdmin); !assert.NoError(t, err) {
+		return
+	}
+
+	code, err := r.GeneratePasswordResetCode(context.Background(), testEmail)
+	if !assert.NoError(t, err) || !assert.NotEmpty(t, code) {
+		return
+	}
+
+	t.Run("1-1: 正常系：コードでパスワードを再設定できる場合", func(t *testing.T) {
+		email, err := r.VerifyPasswordResetCode(context.Background(), code)
+		if !assert.NoError(t, err) || !assert.Equal(t, testEmail, email) {
+			return
+		}
+
+		err = r.ConfirmPasswordReset(context.Background(), code, authentication.Password("1Aa@1Aa@1Aa@"))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		loginResult, err := r.SignInWithPassword(context.Background(), testEmail, "1Aa@1Aa@1Aa@")
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, loginResult.AccessToken)
+		}
+	})
+
+	t.Run("1-2: 正常系：存在しないユーザの場合", func(t *testing.T) {
+		actual, err := r.GeneratePasswordResetCode(context.Background(), "unknown@example.com")
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual)
+		}
+	})
+
+	t.Run("2-1: 異常系：使用済みのコードの場合", func(t *testing.T) {
+		_, err := r.VerifyPasswordResetCode(context.Background(), code)
+		assert.ErrorIs(t, err, domain_repository.ErrPasswordResetCodeInvalid)
+
+		err = r.ConfirmPasswordReset(context.Background(), code, authentication.Password("2Bb@2Bb@2Bb@"))
+		assert.ErrorIs(t, err, domain_repository.ErrPasswordResetCodeInvalid)
+	})
+
+	t.Run("2-2: 異常系：IDトークンをコードとして利用した場合", func(t *testing.T) {
+		loginResult, _ := r.SignInWithPassword(context.Background(), testEmail, "1Aa@1Aa@1Aa@")
+
+		err := r.ConfirmPasswordReset(context.Background(), loginResult.AccessToken, authentication.Password("2Bb@2Bb@2Bb@"))
+		assert.ErrorIs(t, err, domain_repository.ErrPasswordResetCodeInvalid)
+	})
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// LocalIDP CreateUser / ListUsers / SetUserDisabled / SetUserRole / DeleteUser テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：事業者ごとにユーザを一覧取得できる場合
+// [x] 1-2: 正常系：無効化したユーザはログインできず、有効化すると再度ログインできる場合
+// [x] 1-3: 正常系：削除したユーザのメールアドレスを再登録できる場合
+// [x] 1-4: 正常系：ロールを変更すると以降に発行したIDトークンに反映される場合
+// [x] 2-1: 異常系：メールアドレスが登録済みの場合(大文字小文字を区別しない)
+// [x] 2-2: 異常系：存在しないユーザの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_LocalIDP_UserAdministration(t *testing.T) {
+	const otherOperatorID = "15572d1c-ec13-0d78-7f92-dd4278871373"
+
+	db, err := testhelper.NewMockDB()
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+	r := repository.NewLocalIDP(db, testSigningKey, testIssuer, time.Hour, 24*time.Hour)
+	uid, err := r.CreateUser(context.Background(), testEmail, authentication.Password(testPassword), testOperatorID, authentication.RoleEditor)
+	if !assert.NoError(t, err) {
+		return
+	}
+	otherUID, err := r.CreateUser(context.Background(), "oem_b@example.com", authentication.Password(testPassword), otherOperatorID, authentication.RoleViewer)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("1-1: 正常系：事業者ごとにユーザを一覧取得できる場合", func(t *testing.T) {
+		users, err := r.ListUsers(context.Background(), testOperatorID)
+		if assert.NoError(t, err) && assert.Len(t, users, 1) {
+			assert.Equal(t, uid, users[0].UID)
+			assert.Equal(t, testEmail, users[0].Email)
+			assert.Equal(t, testOperatorID, users[0].OperatorID)
+			assert.Equal(t, authentication.RoleEditor, users[0].Role)
+			assert.False(t, users[0].Disabled)
+		}
+
+		users, err = r.ListUsers(context.Background(), "")
+		if assert.NoError(t, err) {
+			assert.Len(t, users, 2)
+		}
+	})
+
+	t.Run("1-2: 正常系：無効化したユーザはログインできず、有効化すると再度ログインできる場合", func(t *testing.T) {
+		if !assert.NoError(t, r.SetUserDisabled(context.Background(), uid, true)) {
+			return
+		}
+		loginResult, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+		if assert.NoError(t, err) {
+			assert.Empty(t, loginResult.AccessToken)
+		}
+		users, err := r.ListUsers(context.Background(), testOperatorID)
+		if assert.NoError(t, err) && assert.Len(t, users, 1) {
+			assert.True(t, users[0].Disabled)
+		}
+
+		if !assert.NoError(t, r.SetUserDisabled(context.Background(), uid, false)) {
+			return
+		}
+		loginResult, err = r.SignInWithPassword(context.Background(), testEmail, testPassword)
+		if assert.NoError(t, err) {
+			assert.NotEmpty(t, loginResult.AccessToken)
+		}
+	})
+
+	t.Run("1-3: 正常系：削除したユーザのメールアドレスを再登録できる場合", func(t *testing.T) {
+		if !assert.NoError(t, r.DeleteUser(context.Background(), otherUID)) {
+			return
+		}
+		users, err := r.ListUsers(context.Background(), otherOperatorID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, users)
+		}
+
+		_, err = r.CreateUser(context.Background(), "oem_b@example.com", authentication.Password(testPassword), otherOperatorID, authentication.RoleViewer)
+		assert.NoError(t, err)
+	})
+
+	t.Run("1-4: 正常系：ロールを変更すると以降に発行したIDトークンに反映される場合", func(t *testing.T) {
+		if !assert.NoError(t, r.SetUserRole(context.Background(), uid, authentication.RoleViewer)) {
+			return
+		}
+		users, err := r.ListUsers(context.Background(), testOperatorID)
+		if assert.NoError(t, err) && assert.Len(t, users, 1) {
+			assert.Equal(t, authentication.RoleViewer, users[0].Role)
+		}
+
+		loginResult, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+		if !assert.NoError(t, err) {
+			return
+		}
+		claims, err := r.VerifyIDToken(context.Background(), loginResult.AccessToken)
+		if assert.NoError(t, err) {
+			assert.Equal(t, authentication.RoleViewer, claims.Role)
+		}
+	})
+
+	t.Run("2-1: 異常系：メールアドレスが登録済みの場合(大文字小文字を区別しない)", func(t *testing.T) {
+		_, err := r.CreateUser(context.Background(), "OEM_A@example.com", authentication.Password(testPassword), testOperatorID, authentication.RoleViewer)
+		assert.ErrorIs(t, err, domain_repository.ErrIdPEmailAlreadyExists)
+	})
+
+	t.Run("2-2: 異常系：存在しないユーザの場合", func(t *testing.T) {
+		assert.ErrorIs(t, r.SetUserDisabled(context.Background(), "unknown", true), domain_repository.ErrIdPUserNotFound)
+		assert.ErrorIs(t, r.SetUserRole(context.Background(), "unknown", authentication.RoleViewer), domain_repository.ErrIdPUserNotFound)
+		assert.ErrorIs(t, r.DeleteUser(context.Background(), "unknown"), domain_repository.ErrIdPUserNotFound)
+	})
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"time"
+
+	"authenticator-backend/domain/repository"
+)
+
+// buildMessage
+// Summary: This is the function which builds the RFC 5322 message of the mail.
+// input: from(string) sender address
+// input: param(repository.MailParam) mail to send
+// input: now(time.Time) time set to the Date header
+// output: ([]byte) message
+func buildMessage(from string, param repository.MailParam, now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", param.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", param.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(param.Body)
+
+	return buf.Bytes()
+}
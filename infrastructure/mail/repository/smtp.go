@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"net"
+	"net/smtp"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+)
+
+// smtpMailer
+// Summary: This struct is the mailer which delivers the mail through the SMTP server.
+type smtpMailer struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer
+// Summary: This is the function which creates the SMTP mailer.
+// input: host(string) SMTP server host
+// input: port(string) SMTP server port
+// input: username(string) user name for the PLAIN authentication. the authentication is skipped when empty
+// input: password(string) password for the PLAIN authentication
+// input: from(string) sender address
+// output: (smtpMailer) SMTP mailer
+func NewSMTPMailer(
+	host string,
+	port string,
+	username string,
+	password string,
+	from string,
+) smtpMailer {
+	return smtpMailer{
+		host,
+		port,
+		username,
+		password,
+		from,
+	}
+}
+
+// Send
+// Summary: This is the function which sends the mail through the SMTP server.
+// input: param(repository.MailParam) mail to send
+// output: (error) error object
+func (m smtpMailer) Send(param repository.MailParam) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	msg := buildMessage(m.from, param, time.Now())
+	if err := smtp.SendMail(net.JoinHostPort(m.host, m.port), auth, m.from, []string{param.To}, msg); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
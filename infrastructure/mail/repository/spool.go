@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"github.com/google/uuid"
+)
+
+// spoolMailer
+// Summary: This struct is the mailer which writes the mail to the local spool directory instead of delivering it.
+type spoolMailer struct {
+	dir  string
+	from string
+}
+
+// NewSpoolMailer
+// Summary: This is the function which creates the spool mailer.
+// input: dir(string) spool directory. created when it does not exist
+// input: from(string) sender address
+// output: (spoolMailer) spool mailer
+func NewSpoolMailer(dir string, from string) spoolMailer {
+	return spoolMailer{
+		dir,
+		from,
+	}
+}
+
+// Send
+// Summary: This is the function which writes the mail to the spool directory as an .eml file.
+// input: param(repository.MailParam) mail to send
+// output: (error) error object
+func (m spoolMailer) Send(param repository.MailParam) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s_%s.eml", now.UTC().Format("20060102T150405Z"), uuid.New().String())
+	if err := os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, param, now), 0o600); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"authenticator-backend/domain/repository"
+	mail_repository "authenticator-backend/infrastructure/mail/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// SpoolMailer Send テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：スプールディレクトリにメールを書き出す場合
+// [x] 1-2: 正常系：スプールディレクトリが存在しない場合、作成して書き出す
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_SpoolMailer_Send(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		subDir string
+	}{
+		{
+			name:   "1-1: 正常系：スプールディレクトリにメールを書き出す場合",
+			subDir: "",
+		},
+		{
+			name:   "1-2: 正常系：スプールディレクトリが存在しない場合",
+			subDir: "spool/mail",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				dir := filepath.Join(t.TempDir(), test.subDir)
+				m := mail_repository.NewSpoolMailer(dir, "no-reply@example.com")
+
+				err := m.Send(repository.MailParam{
+					To:      "oem_a@example.com",
+					Subject: "パスワード再設定",
+					Body:    "code: 123456",
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				files, err := os.ReadDir(dir)
+				if assert.NoError(t, err) && assert.Len(t, files, 1) {
+					assert.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+
+					b, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+					if assert.NoError(t, err) {
+						actual := string(b)
+						assert.Contains(t, actual, "From: no-reply@example.com\r\n")
+						assert.Contains(t, actual, "To: oem_a@example.com\r\n")
+						assert.Contains(t, actual, "Subject: =?UTF-8?q?")
+						assert.True(t, strings.HasSuffix(actual, "\r\n\r\ncode: 123456"))
+					}
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,14 @@
+package entity
+
+// DiscoveryMetadata
+// Summary: This is the struct which defines the OpenID Provider metadata entity (/.well-known/openid-configuration).
+type DiscoveryMetadata struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksURI               string   `json:"jwks_uri"`
+	RevocationEndpoint    string   `json:"revocation_endpoint"`
+	EndSessionEndpoint    string   `json:"end_session_endpoint"`
+	GrantTypesSupported   []string `json:"grant_types_supported"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+// JSONWebKeySet
+// Summary: This is the struct which defines the JWK Set entity returned by the jwks_uri.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey
+// Summary: This is the struct which defines the JSON Web Key entity.
+type JSONWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+// TokenResponse
+// Summary: This is the struct which defines the token endpoint response entity.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope"`
+}
+
+// ErrorResponse
+// Summary: This is the struct which defines the token endpoint error response entity (RFC 6749 5.2).
+type ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
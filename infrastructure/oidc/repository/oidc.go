@@ -0,0 +1,622 @@
+package repository
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/infrastructure/idpclient"
+	"authenticator-backend/infrastructure/oidc/entity"
+
+	"firebase.google.com/go/v4/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	discoveryPath          = "/.well-known/openid-configuration"
+	grantTypePassword      = "password"
+	grantTypeRefreshToken  = "refresh_token"
+	errorCodeInvalidGrant  = "invalid_grant"
+	errorCodeInvalidClient = "invalid_client"
+
+	// keyRefetchInterval is the minimum interval of fetching the JWK Set for the unknown kid
+	keyRefetchInterval = 30 * time.Second
+)
+
+// oidcProvider
+// Summary: This struct holds the discovery metadata and the signing keys fetched from the OpenID Provider.
+// The key set is fetched by one caller at a time, and fetchedAt limits how often the unknown kid makes the key set fetched.
+type oidcProvider struct {
+	mu        sync.Mutex
+	metadata  *entity.DiscoveryMetadata
+	keys      map[string]interface{}
+	fetchMu   sync.Mutex
+	fetchedAt time.Time
+}
+
+// oidcRepository
+// Summary: This struct is the repository for a standards-compliant OpenID Connect provider.
+type oidcRepository struct {
+	httpClient      *http.Client
+	issuerURL       string
+	clientID        string
+	clientSecret    string
+	scopes          []string
+	operatorIDClaim string
+	provider        *oidcProvider
+}
+
+// NewOIDC
+// Summary: This is the function which creates the OpenID Connect repository.
+// input: httpClient(*http.Client) http client used for the calls to the provider
+// input: issuerURL(string) issuer URL of the provider. the discovery document is fetched from <issuerURL>/.well-known/openid-configuration
+// input: clientID(string) client ID registered in the provider
+// input: clientSecret(string) client secret registered in the provider
+// input: scopes([]string) scopes requested on sign in
+// input: operatorIDClaim(string) name of the claim which holds the operator ID
+// output: (oidcRepository) OpenID Connect repository
+func NewOIDC(
+	httpClient *http.Client,
+	issuerURL string,
+	clientID string,
+	clientSecret string,
+	scopes []string,
+	operatorIDClaim string,
+) oidcRepository {
+	return oidcRepository{
+		httpClient,
+		strings.TrimSuffix(issuerURL, "/"),
+		clientID,
+		clientSecret,
+		scopes,
+		operatorIDClaim,
+		&oidcProvider{},
+	}
+}
+
+// SignInWithPassword
+// Summary: This is the function which signs in with email and password by the resource owner password credentials grant.
+// input: ctx(context.Context) context
+// input: email(string) email
+// input: password(string) password
+// output: (authentication.LoginResult) login result. the tokens are empty when the credentials are invalid
+// output: (error) error object
+func (r oidcRepository) SignInWithPassword(ctx context.Context, email string, password string) (authentication.LoginResult, error) {
+	form := url.Values{}
+	form.Add("grant_type", grantTypePassword)
+	form.Add("username", email)
+	form.Add("password", password)
+	form.Add("scope", strings.Join(r.scopes, " "))
+
+	tokenResponse, err := r.requestToken(ctx, form)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.LoginResult{}, err
+	}
+
+	return authentication.LoginResult{
+		AccessToken:  tokenResponse.IDToken,
+		RefreshToken: tokenResponse.RefreshToken,
+	}, nil
+}
+
+// RefreshToken
+// Summary: This is the function which refreshes the token by the refresh_token grant.
+// input: ctx(context.Context) context
+// input: refreshToken(string) refresh token
+// output: (string) ID token. empty when the refresh token is invalid
+// output: (error) error object
+func (r oidcRepository) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+	form := url.Values{}
+	form.Add("grant_type", grantTypeRefreshToken)
+	form.Add("refresh_token", refreshToken)
+
+	tokenResponse, err := r.requestToken(idpclient.WithIdempotent(ctx), form)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	return tokenResponse.IDToken, nil
+}
+
+// VerifyIDToken
+// Summary: This is the function which verifies the ID token with the keys published on the jwks_uri.
+// input: ctx(context.Context) context
+// input: idToken(string) id token
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r oidcRepository) VerifyIDToken(ctx context.Context, idToken string) (authentication.Claims, error) {
+	metadata, err := r.discover(ctx)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, r.keyFunc(ctx))
+	if err != nil {
+		// the failure to fetch the key set is reported as the error of the provider
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Inner != nil {
+			logger.Set(nil).Errorf(err.Error())
+
+			return authentication.Claims{}, idpclient.ConvertUnavailable(validationErr.Inner)
+		}
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	if !claims.VerifyIssuer(metadata.Issuer, true) {
+		err := fmt.Errorf("ID token has invalid issuer")
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	if !claims.VerifyAudience(r.clientID, true) {
+		err := fmt.Errorf("ID token has invalid audience")
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	// the exp claim is optional for the parser, but the ID token without the expiry must not be accepted
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		err := fmt.Errorf("ID token has no or expired exp")
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+
+	token := newAuthToken(claims)
+	result, err := authentication.NewClaimsWithOperatorIDClaim(&token, r.operatorIDClaim)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.Claims{}, err
+	}
+	return result, nil
+}
+
+// VerifyIDTokenAndCheckRevoked
+// Summary: This is the function which verifies the ID token. OpenID Connect does not define a revocation check of the ID token, so the check relies on the short lifetime of the ID token issued by the provider.
+// input: ctx(context.Context) context
+// input: idToken(string) id token
+// output: (authentication.Claims) claims
+// output: (error) error object
+func (r oidcRepository) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (authentication.Claims, error) {
+	return r.VerifyIDToken(ctx, idToken)
+}
+
+// ChangePassword
+// Summary: This is the function which changes the password. OpenID Connect does not define the password change, so this is not supported.
+// input: ctx(context.Context) context
+// input: uid(string) subject of the user
+// input: newPassword(authentication.Password) new password
+// output: (error) error object
+func (r oidcRepository) ChangePassword(ctx context.Context, uid string, newPassword authentication.Password) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// RevokeRefreshTokens
+// Summary: This is the function which revokes the refresh tokens of the user. OpenID Connect does not define a revocation by the subject, so this is not supported.
+// input: ctx(context.Context) context
+// input: uid(string) subject of the user
+// output: (error) error object
+func (r oidcRepository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// GeneratePasswordResetCode
+// Summary: This is the function which generates the password reset code. The password reset is handled by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: email(string) email
+// output: (string) password reset code
+// output: (error) error object
+func (r oidcRepository) GeneratePasswordResetCode(ctx context.Context, email string) (string, error) {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return "", repository.ErrIdPOperationNotSupported
+}
+
+// VerifyPasswordResetCode
+// Summary: This is the function which verifies the password reset code. The password reset is handled by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// output: (string) email
+// output: (error) error object
+func (r oidcRepository) VerifyPasswordResetCode(ctx context.Context, code string) (string, error) {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return "", repository.ErrIdPOperationNotSupported
+}
+
+// ConfirmPasswordReset
+// Summary: This is the function which resets the password with the code. The password reset is handled by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: code(string) password reset code
+// input: newPassword(authentication.Password) new password
+// output: (error) error object
+func (r oidcRepository) ConfirmPasswordReset(ctx context.Context, code string, newPassword authentication.Password) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// CreateUser
+// Summary: This is the function which registers a user. The users are managed by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: email(string) email
+// input: password(authentication.Password) password
+// input: operatorID(string) operator ID
+// input: role(authentication.Role) role
+// output: (string) created user ID
+// output: (error) error object
+func (r oidcRepository) CreateUser(ctx context.Context, email string, password authentication.Password, operatorID string, role authentication.Role) (string, error) {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return "", repository.ErrIdPOperationNotSupported
+}
+
+// ListUsers
+// Summary: This is the function which lists the users. The users are managed by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: operatorID(string) operator ID
+// output: (authentication.IdPUsers) users
+// output: (error) error object
+func (r oidcRepository) ListUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error) {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return nil, repository.ErrIdPOperationNotSupported
+}
+
+// SetUserDisabled
+// Summary: This is the function which disables or enables the user. The users are managed by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: uid(string) subject of the user
+// input: disabled(bool) true to disable the user, false to enable
+// output: (error) error object
+func (r oidcRepository) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// SetUserRole
+// Summary: This is the function which changes the role of the user. The users are managed by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: uid(string) subject of the user
+// input: role(authentication.Role) role
+// output: (error) error object
+func (r oidcRepository) SetUserRole(ctx context.Context, uid string, role authentication.Role) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// DeleteUser
+// Summary: This is the function which deletes the user. The users are managed by the OpenID Provider, so this is not supported.
+// input: ctx(context.Context) context
+// input: uid(string) subject of the user
+// output: (error) error object
+func (r oidcRepository) DeleteUser(ctx context.Context, uid string) error {
+	logger.Set(nil).Warnf(repository.ErrIdPOperationNotSupported.Error())
+
+	return repository.ErrIdPOperationNotSupported
+}
+
+// requestToken
+// Summary: This is the function which calls the token endpoint with the client credentials.
+// input: ctx(context.Context) context
+// input: form(url.Values) grant parameters
+// output: (entity.TokenResponse) token response. empty when the grant is rejected
+// output: (error) error object
+func (r oidcRepository) requestToken(ctx context.Context, form url.Values) (entity.TokenResponse, error) {
+	metadata, err := r.discover(ctx)
+	if err != nil {
+		return entity.TokenResponse{}, err
+	}
+
+	form.Add("client_id", r.clientID)
+	if r.clientSecret != "" {
+		form.Add("client_secret", r.clientSecret)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return entity.TokenResponse{}, err
+	}
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	request.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return entity.TokenResponse{}, idpclient.ConvertUnavailable(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return entity.TokenResponse{}, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		var errorResponse entity.ErrorResponse
+		if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Error == errorCodeInvalidGrant {
+			// when the credentials, the code or the refresh token is invalid
+			return entity.TokenResponse{}, nil
+		}
+		if errorResponse.Error == errorCodeInvalidClient {
+			return entity.TokenResponse{}, fmt.Errorf("token endpoint rejected the client: %s", errorResponse.ErrorDescription)
+		}
+		if response.StatusCode >= http.StatusInternalServerError {
+			return entity.TokenResponse{}, repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: fmt.Sprintf("token endpoint returned status %d", response.StatusCode)}
+		}
+		return entity.TokenResponse{}, fmt.Errorf("token endpoint returned status %d", response.StatusCode)
+	}
+
+	var tokenResponse entity.TokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return entity.TokenResponse{}, err
+	}
+	return tokenResponse, nil
+}
+
+// discover
+// Summary: This is the function which fetches the discovery metadata once and caches it.
+// input: ctx(context.Context) context
+// output: (*entity.DiscoveryMetadata) discovery metadata
+// output: (error) error object
+func (r oidcRepository) discover(ctx context.Context) (*entity.DiscoveryMetadata, error) {
+	r.provider.mu.Lock()
+	defer r.provider.mu.Unlock()
+
+	if r.provider.metadata != nil {
+		return r.provider.metadata, nil
+	}
+
+	var metadata entity.DiscoveryMetadata
+	if err := r.getJSON(ctx, r.issuerURL+discoveryPath, &metadata); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(metadata.Issuer, "/") != r.issuerURL {
+		return nil, fmt.Errorf("issuer in discovery metadata does not match: %s", metadata.Issuer)
+	}
+	if metadata.TokenEndpoint == "" || metadata.JwksURI == "" {
+		return nil, fmt.Errorf("discovery metadata does not contain token_endpoint or jwks_uri")
+	}
+	r.provider.metadata = &metadata
+
+	return r.provider.metadata, nil
+}
+
+// keyFunc
+// Summary: This is the function which returns the function to look up the verification key of the token by its kid. the key set is fetched again when the kid is unknown, so that the key rotation of the provider is followed.
+// The key set is not fetched again within keyRefetchInterval, so the tokens with the unknown kid sent by the unauthenticated callers are rejected without calling the provider.
+// input: ctx(context.Context) context
+// output: (jwt.Keyfunc) function which returns the verification key of the token
+func (r oidcRepository) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+
+		if key, ok := r.lookupKey(kid); ok {
+			return key, nil
+		}
+		if err := r.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+		if key, ok := r.lookupKey(kid); ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("signing key not found: kid=%s", kid)
+	}
+}
+
+// lookupKey
+// Summary: This is the function which looks up the verification key in the fetched key set.
+// input: kid(string) key ID
+// output: (interface{}) verification key
+// output: (bool) true when the key is found
+func (r oidcRepository) lookupKey(kid string) (interface{}, bool) {
+	r.provider.mu.Lock()
+	defer r.provider.mu.Unlock()
+
+	key, ok := r.provider.keys[kid]
+	return key, ok
+}
+
+// refreshKeys
+// Summary: This is the function which fetches the key set unless it has been fetched within keyRefetchInterval.
+// The concurrent callers wait for the fetch in progress and use its result instead of fetching the key set again.
+// input: ctx(context.Context) context
+// output: (error) error object
+func (r oidcRepository) refreshKeys(ctx context.Context) error {
+	r.provider.fetchMu.Lock()
+	defer r.provider.fetchMu.Unlock()
+
+	r.provider.mu.Lock()
+	fetchedAt := r.provider.fetchedAt
+	r.provider.mu.Unlock()
+	if time.Since(fetchedAt) < keyRefetchInterval {
+		return nil
+	}
+	return r.fetchKeys(ctx)
+}
+
+// fetchKeys
+// Summary: This is the function which fetches the JWK Set from the jwks_uri.
+// input: ctx(context.Context) context
+// output: (error) error object
+func (r oidcRepository) fetchKeys(ctx context.Context) error {
+	// the failed fetch also counts, so that the provider in trouble is not called on every request
+	r.provider.mu.Lock()
+	r.provider.fetchedAt = time.Now()
+	r.provider.mu.Unlock()
+
+	metadata, err := r.discover(ctx)
+	if err != nil {
+		return err
+	}
+
+	var keySet entity.JSONWebKeySet
+	if err := r.getJSON(ctx, metadata.JwksURI, &keySet); err != nil {
+		return err
+	}
+
+	keys := make(map[string]interface{}, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			logger.Set(nil).Warnf(err.Error())
+
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	r.provider.mu.Lock()
+	r.provider.keys = keys
+	r.provider.mu.Unlock()
+
+	return nil
+}
+
+// getJSON
+// Summary: This is the function which gets the JSON document from the provider.
+// input: ctx(context.Context) context
+// input: endpoint(string) URL of the document
+// input: v(interface{}) destination of the decoded document
+// output: (error) error object
+func (r oidcRepository) getJSON(ctx context.Context, endpoint string, v interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return idpclient.ConvertUnavailable(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: fmt.Sprintf("%s returned status %d", endpoint, response.StatusCode)}
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", endpoint, response.StatusCode)
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+// parseJSONWebKey
+// Summary: This is the function which converts the JSON Web Key to the public key.
+// input: jwk(entity.JSONWebKey) JSON Web Key
+// output: (interface{}) *rsa.PublicKey or *ecdsa.PublicKey
+// output: (error) error object
+func parseJSONWebKey(jwk entity.JSONWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.Kty)
+	}
+}
+
+// newAuthToken
+// Summary: This is the function which converts the verified claims to the Firebase token model.
+// input: claims(jwt.MapClaims) verified claims
+// output: (auth.Token) token
+func newAuthToken(claims jwt.MapClaims) auth.Token {
+	token := auth.Token{
+		Claims: map[string]interface{}(claims),
+	}
+	token.Issuer, _ = claims["iss"].(string)
+	token.Subject, _ = claims["sub"].(string)
+	token.UID = token.Subject
+	switch aud := claims["aud"].(type) {
+	case string:
+		token.Audience = aud
+	case []interface{}:
+		if len(aud) > 0 {
+			token.Audience, _ = aud[0].(string)
+		}
+	}
+	if v, ok := claims["auth_time"].(float64); ok {
+		token.AuthTime = int64(v)
+	}
+	if v, ok := claims["exp"].(float64); ok {
+		token.Expires = int64(v)
+	}
+	if v, ok := claims["iat"].(float64); ok {
+		token.IssuedAt = int64(v)
+	}
+	return token
+}
@@ -0,0 +1,266 @@
+package repository_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"authenticator-backend/infrastructure/oidc/repository"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientID     = "authenticator-backend"
+	testClientSecret = "secret"
+	testKid          = "test-key"
+	testEmail        = "oem_a@example.com"
+	testPassword     = "oemA&user_01"
+	testRefreshToken = "refresh-token"
+	testOperatorID   = "b39e6248-c888-56ca-d9d0-89de1b1adc8e"
+)
+
+// stubIdP
+// Summary: This is the httptest stand-in of an OpenID Provider.
+type stubIdP struct {
+	server          *httptest.Server
+	key             *rsa.PrivateKey
+	operatorIDClaim string
+	audience        string
+	kid             string
+	withoutExp      bool
+	certsRequests   int32
+}
+
+// newStubIdP
+// Summary: This is the function which starts the stand-in OpenID Provider.
+func newStubIdP(t *testing.T, operatorIDClaim string) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{key: key, operatorIDClaim: operatorIDClaim, audience: testClientID, kid: testKid}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":         idp.server.URL,
+			"token_endpoint": idp.server.URL + "/token",
+			"jwks_uri":       idp.server.URL + "/certs",
+		})
+	})
+	mux.HandleFunc("/certs", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&idp.certsRequests, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": testKid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("client_id") != testClientID || r.PostForm.Get("client_secret") != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		valid := false
+		switch r.PostForm.Get("grant_type") {
+		case "password":
+			valid = r.PostForm.Get("username") == testEmail && r.PostForm.Get("password") == testPassword
+		case "refresh_token":
+			valid = r.PostForm.Get("refresh_token") == testRefreshToken
+		}
+		if !valid {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-token",
+			"token_type":    "Bearer",
+			"expires_in":    300,
+			"refresh_token": testRefreshToken,
+			"id_token":      idp.signIDToken(t),
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+
+	return idp
+}
+
+// signIDToken
+// Summary: This is the function which issues the ID token of the test user.
+func (idp *stubIdP) signIDToken(t *testing.T) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":               idp.server.URL,
+		"aud":               []string{idp.audience},
+		"sub":               "subject",
+		"iat":               now.Unix(),
+		"exp":               now.Add(time.Hour).Unix(),
+		"auth_time":         now.Unix(),
+		idp.operatorIDClaim: testOperatorID,
+	})
+	if idp.withoutExp {
+		delete(token.Claims.(jwt.MapClaims), "exp")
+	}
+	token.Header["kid"] = idp.kid
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// OIDC SignInWithPassword / RefreshToken / VerifyIDToken テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：operator_idクレームの場合
+// [x] 1-2: 正常系：operator_idのクレーム名を変更した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_OIDC(tt *testing.T) {
+
+	tests := []struct {
+		name            string
+		operatorIDClaim string
+	}{
+		{
+			name:            "1-1: 正常系：operator_idクレームの場合",
+			operatorIDClaim: "operator_id",
+		},
+		{
+			name:            "1-2: 正常系：operator_idのクレーム名を変更した場合",
+			operatorIDClaim: "https://ouranos.example.com/operator",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				idp := newStubIdP(t, test.operatorIDClaim)
+				defer idp.server.Close()
+
+				r := repository.NewOIDC(idp.server.Client(), idp.server.URL, testClientID, testClientSecret, []string{"openid"}, test.operatorIDClaim)
+
+				loginResult, err := r.SignInWithPassword(context.Background(), testEmail, testPassword)
+				if assert.NoError(t, err) {
+					assert.NotEmpty(t, loginResult.AccessToken)
+					assert.Equal(t, testRefreshToken, loginResult.RefreshToken)
+				}
+
+				claims, err := r.VerifyIDToken(context.Background(), loginResult.AccessToken)
+				if assert.NoError(t, err) {
+					assert.Equal(t, testOperatorID, claims.OperatorID)
+					assert.Equal(t, "subject", claims.UID)
+				}
+
+				idToken, err := r.RefreshToken(context.Background(), loginResult.RefreshToken)
+				if assert.NoError(t, err) {
+					assert.NotEmpty(t, idToken)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// OIDC 異常系テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 2-1: 異常系：パスワード不一致の場合、空のトークンを返却
+// [x] 2-2: 異常系：リフレッシュトークンが無効の場合、空のトークンを返却
+// [x] 2-3: 異常系：audienceが異なる場合
+// [x] 2-4: 異常系：operator_idクレームが存在しない場合
+// [x] 2-5: 異常系：クライアントシークレットが誤っている場合
+// [x] 2-6: 異常系：未知のkidの場合、鍵セットの再取得は一定間隔内に1回のみ
+// [x] 2-7: 異常系：expクレームが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_OIDC_Abnormal(t *testing.T) {
+	idp := newStubIdP(t, "operator_id")
+	defer idp.server.Close()
+
+	r := repository.NewOIDC(idp.server.Client(), idp.server.URL, testClientID, testClientSecret, []string{"openid"}, "operator_id")
+
+	t.Run("2-1: 異常系：パスワード不一致の場合", func(t *testing.T) {
+		actual, err := r.SignInWithPassword(context.Background(), testEmail, "wrong-password")
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual.AccessToken)
+			assert.Empty(t, actual.RefreshToken)
+		}
+	})
+
+	t.Run("2-2: 異常系：リフレッシュトークンが無効の場合", func(t *testing.T) {
+		actual, err := r.RefreshToken(context.Background(), "invalid")
+		if assert.NoError(t, err) {
+			assert.Empty(t, actual)
+		}
+	})
+
+	t.Run("2-3: 異常系：audienceが異なる場合", func(t *testing.T) {
+		idp.audience = "other-client"
+		defer func() { idp.audience = testClientID }()
+
+		_, err := r.VerifyIDToken(context.Background(), idp.signIDToken(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("2-4: 異常系：operator_idクレームが存在しない場合", func(t *testing.T) {
+		other := repository.NewOIDC(idp.server.Client(), idp.server.URL, testClientID, testClientSecret, []string{"openid"}, "tenant_operator")
+
+		_, err := other.VerifyIDToken(context.Background(), idp.signIDToken(t))
+		assert.Error(t, err)
+	})
+
+	t.Run("2-5: 異常系：クライアントシークレットが誤っている場合", func(t *testing.T) {
+		other := repository.NewOIDC(idp.server.Client(), idp.server.URL, testClientID, "wrong", []string{"openid"}, "operator_id")
+
+		_, err := other.SignInWithPassword(context.Background(), testEmail, testPassword)
+		assert.Error(t, err)
+	})
+
+	t.Run("2-6: 異常系：未知のkidの場合、鍵セットの再取得は一定間隔内に1回のみ", func(t *testing.T) {
+		other := repository.NewOIDC(idp.server.Client(), idp.server.URL, testClientID, testClientSecret, []string{"openid"}, "operator_id")
+		idp.kid = "unknown-key"
+		defer func() { idp.kid = testKid }()
+		atomic.StoreInt32(&idp.certsRequests, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := other.VerifyIDToken(context.Background(), idp.signIDToken(t))
+				assert.Error(t, err)
+			}()
+		}
+		wg.Wait()
+		_, err := other.VerifyIDToken(context.Background(), idp.signIDToken(t))
+		assert.Error(t, err)
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&idp.certsRequests))
+	})
+
+	t.Run("2-7: 異常系：expクレームが存在しない場合", func(t *testing.T) {
+		idp.withoutExp = true
+		defer func() { idp.withoutExp = false }()
+
+		_, err := r.VerifyIDToken(context.Background(), idp.signIDToken(t))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+package datastore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+)
+
+// apiKeysChangedChannel is the channel notified by the triggers on api_keys and the tables bound to them
+const apiKeysChangedChannel = "api_keys_changed"
+
+// apiKeyCache
+// Summary: This is structure which defines the in-memory index of the api keys, the cidrs, the client certificates and the permissions.
+// The index is loaded on the first use, reloaded periodically and dropped as soon as it is invalidated.
+type apiKeyCache struct {
+	db              *gorm.DB
+	refreshInterval time.Duration
+	// listenDSN is the connection string to listen for the notifications, and the notifications are not used when it is empty
+	listenDSN string
+
+	// loadMu serializes the loads so that the concurrent requests do not query the database at once
+	loadMu     sync.Mutex
+	mu         sync.RWMutex
+	index      *authentication.APIKeyIndex
+	loadedAt   time.Time
+	generation uint64
+}
+
+// NewAPIKeyCache
+// Summary: This is the function which creates the in-memory index of the api keys and the cidrs.
+// input: db(*gorm.DB): gorm db
+// input: refreshInterval(time.Duration): interval the index is reloaded
+// input: listenDSN(string): connection string to listen for the notifications on PostgreSQL, or empty
+// output: (repository.APIKeyCache) api key cache
+func NewAPIKeyCache(db *gorm.DB, refreshInterval time.Duration, listenDSN string) repository.APIKeyCache {
+	return &apiKeyCache{db: db, refreshInterval: refreshInterval, listenDSN: listenDSN}
+}
+
+// Index
+// Summary: This is the function which returns the index of the api keys and the cidrs.
+// The database is queried only when the index is not loaded, invalidated or left stale by the stopped refresh.
+// output: (authentication.APIKeyIndex) index of the api keys
+// output: (error) error object
+func (r *apiKeyCache) Index() (authentication.APIKeyIndex, error) {
+	if index, ok := r.current(); ok {
+		return index, nil
+	}
+
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+	if index, ok := r.current(); ok {
+		return index, nil
+	}
+	return r.load()
+}
+
+// Invalidate
+// Summary: This is the function which drops the index so that the next request reloads it.
+func (r *apiKeyCache) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.index = nil
+	r.generation++
+}
+
+// Watch
+// Summary: This is the function which reloads the index periodically until the context is done.
+// On PostgreSQL, the index is also invalidated by the notifications of the changes from any instance.
+// input: ctx(context.Context): context to stop watching
+func (r *apiKeyCache) Watch(ctx context.Context) {
+	if r.listenDSN != "" {
+		go r.listenLoop(ctx)
+	}
+
+	ticker := time.NewTicker(r.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.loadMu.Lock()
+			_, err := r.load()
+			r.loadMu.Unlock()
+			if err != nil {
+				logger.Set(nil).Errorf(err.Error())
+			}
+		}
+	}
+}
+
+// current
+// Summary: This is the function which returns the loaded index unless it is invalidated or stale.
+// The index is stale after twice the refresh interval, which happens only when the periodic refresh has stopped.
+// output: (authentication.APIKeyIndex) index of the api keys
+// output: (bool) true if the index can be used, false otherwise
+func (r *apiKeyCache) current() (authentication.APIKeyIndex, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.index == nil || time.Since(r.loadedAt) >= 2*r.refreshInterval {
+		return authentication.APIKeyIndex{}, false
+	}
+	return *r.index, true
+}
+
+// load
+// Summary: This is the function which loads the index from the database. The caller must hold loadMu.
+// The loaded index is not kept when it is invalidated during the load, because it may miss the change.
+// output: (authentication.APIKeyIndex) index of the api keys
+// output: (error) error object
+func (r *apiKeyCache) load() (authentication.APIKeyIndex, error) {
+	r.mu.RLock()
+	generation := r.generation
+	r.mu.RUnlock()
+
+	authRepository := NewAuthRepository(r.db)
+	apiKeys, err := authRepository.ListAPIKeys(repository.APIKeysParam{})
+	if err != nil {
+		return authentication.APIKeyIndex{}, err
+	}
+	cidrs, err := authRepository.ListCidrs(repository.APIKeyCidrsParam{})
+	if err != nil {
+		return authentication.APIKeyIndex{}, err
+	}
+	certificates, err := authRepository.ListClientCertificates(repository.APIKeyClientCertificatesParam{})
+	if err != nil {
+		return authentication.APIKeyIndex{}, err
+	}
+	permissions, err := authRepository.ListAPIKeyPermissions(repository.APIKeyPermissionsParam{})
+	if err != nil {
+		return authentication.APIKeyIndex{}, err
+	}
+	index := authentication.NewAPIKeyIndex(apiKeys, cidrs, certificates, permissions)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == generation {
+		r.index = &index
+		r.loadedAt = time.Now()
+	}
+	return index, nil
+}
+
+// listenLoop
+// Summary: This is the function which keeps listening for the notifications and reconnects after the refresh interval on the failure.
+// input: ctx(context.Context): context to stop listening
+func (r *apiKeyCache) listenLoop(ctx context.Context) {
+	for {
+		err := r.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Set(nil).Warnf(err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.refreshInterval):
+		}
+	}
+}
+
+// listen
+// Summary: This is the function which invalidates the index whenever the change of the api keys or the cidrs is notified.
+// input: ctx(context.Context): context to stop listening
+// output: (error) error object
+func (r *apiKeyCache) listen(ctx context.Context) error {
+	conn, err := pgconn.Connect(ctx, r.listenDSN)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+apiKeysChangedChannel).ReadAll(); err != nil {
+		return err
+	}
+	// the changes notified while the listener was disconnected are lost
+	r.Invalidate()
+
+	for {
+		if err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		r.Invalidate()
+	}
+}
@@ -0,0 +1,118 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/infrastructure/persistence/datastore"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyCache テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：初回はAPIキーとCIDRを読み込む
+// [x] 1-2: 正常系：読み込み済みのインデックスを返却し、無効化後は再読み込みする
+// [x] 1-3: 正常系：Watch中は定期的に再読み込みする
+// [x] 2-1: 異常系：読み込みに失敗した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_APIKeyCache(tt *testing.T) {
+
+	newKey := authentication.APIKey{
+		ID:              "00000000-0000-0000-0000-000000000010",
+		KeyPrefix:       authentication.APIKeyPrefix("New-APIKey"),
+		KeyDigest:       authentication.DigestAPIKey("New-APIKey"),
+		ApplicationName: "New-Application",
+		Attribute:       authentication.ApplicationAttributeDataSpace,
+		CreatedUserID:   "creator",
+		UpdatedUserID:   "creator",
+	}
+
+	tt.Run("1-1: 正常系：初回はAPIキーとCIDRを読み込む", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		cache := datastore.NewAPIKeyCache(db, time.Minute, "")
+
+		index, err := cache.Index()
+		if assert.NoError(t, err) {
+			actual, ok := index.FindAPIKey("Sample-APIKey1")
+			if assert.True(t, ok) {
+				assert.Equal(t, "00000000-0000-0000-0000-000000000001", actual.ID)
+				assert.True(t, index.Allows(actual.ID, "10.0.0.1"))
+			}
+		}
+	})
+
+	tt.Run("1-2: 正常系：読み込み済みのインデックスを返却し、無効化後は再読み込みする", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		cache := datastore.NewAPIKeyCache(db, time.Minute, "")
+		if _, err := cache.Index(); !assert.NoError(t, err) {
+			return
+		}
+		if err := datastore.NewAuthRepository(db).CreateAPIKey(newKey); !assert.NoError(t, err) {
+			return
+		}
+
+		index, err := cache.Index()
+		if assert.NoError(t, err) {
+			_, ok := index.FindAPIKey("New-APIKey")
+			assert.False(t, ok)
+		}
+
+		cache.Invalidate()
+		index, err = cache.Index()
+		if assert.NoError(t, err) {
+			_, ok := index.FindAPIKey("New-APIKey")
+			assert.True(t, ok)
+		}
+	})
+
+	tt.Run("1-3: 正常系：Watch中は定期的に再読み込みする", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		cache := datastore.NewAPIKeyCache(db, 10*time.Millisecond, "")
+		if _, err := cache.Index(); !assert.NoError(t, err) {
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go cache.Watch(ctx)
+
+		if err := datastore.NewAuthRepository(db).CreateAPIKey(newKey); !assert.NoError(t, err) {
+			return
+		}
+		assert.Eventually(t, func() bool {
+			index, err := cache.Index()
+			if err != nil {
+				return false
+			}
+			_, ok := index.GetAPIKey(newKey.ID)
+			return ok
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	tt.Run("2-1: 異常系：読み込みに失敗した場合", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		if err := db.Exec("DROP TABLE cidrs").Error; !assert.NoError(t, err) {
+			return
+		}
+		cache := datastore.NewAPIKeyCache(db, time.Minute, "")
+
+		_, err = cache.Index()
+		assert.Error(t, err)
+	})
+}
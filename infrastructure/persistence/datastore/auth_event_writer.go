@@ -0,0 +1,68 @@
+package datastore
+
+import (
+	"context"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"gorm.io/gorm"
+)
+
+// authEventWriter
+// Summary: This is structure which defines the writer which queues the auth events and records them in the background.
+// The events are dropped while the queue is full so that the requests are never blocked by the database.
+type authEventWriter struct {
+	authRepository repository.AuthRepository
+	events         chan repository.CreateAuthEventParam
+}
+
+// NewAuthEventWriter
+// Summary: This is the function which creates the writer of the auth events.
+// input: db(*gorm.DB): gorm db
+// input: bufferSize(int): number of the events queued until they are recorded
+// output: (repository.AuthEventWriter) auth event writer
+func NewAuthEventWriter(db *gorm.DB, bufferSize int) repository.AuthEventWriter {
+	return &authEventWriter{authRepository: NewAuthRepository(db), events: make(chan repository.CreateAuthEventParam, bufferSize)}
+}
+
+// Write
+// Summary: This is the function which queues the auth event to be recorded.
+// input: param(repository.CreateAuthEventParam): auth event param
+func (w *authEventWriter) Write(param repository.CreateAuthEventParam) {
+	select {
+	case w.events <- param:
+	default:
+		logger.Set(nil).Warnf("auth event dropped: %s", param.Event)
+	}
+}
+
+// Run
+// Summary: This is the function which records the queued auth events until the context is done.
+// The events queued when the context is done are recorded before returning.
+// input: ctx(context.Context): context to stop recording
+func (w *authEventWriter) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			w.flush()
+			return
+		case param := <-w.events:
+			// the error is logged by the repository
+			_ = w.authRepository.CreateAuthEvent(param)
+		}
+	}
+}
+
+// flush
+// Summary: This is the function which records the auth events left in the queue.
+func (w *authEventWriter) flush() {
+	for {
+		select {
+		case param := <-w.events:
+			_ = w.authRepository.CreateAuthEvent(param)
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package datastore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/persistence/datastore"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// AuthEventWriter テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：Run中に書き込んだイベントをリクエストの時刻で記録する
+// [x] 1-2: 正常系：停止時にキューに残ったイベントを記録する
+// [x] 2-1: 異常系：キューが一杯の場合はイベントを破棄する
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_AuthEventWriter(tt *testing.T) {
+
+	occurredAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt.Run("1-1: 正常系：Run中に書き込んだイベントをリクエストの時刻で記録する", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		writer := datastore.NewAuthEventWriter(db, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go writer.Run(ctx)
+
+		writer.Write(repository.CreateAuthEventParam{Event: "operatorLogin", Result: true, IPAddress: "127.0.0.1", OccurredAt: occurredAt})
+
+		r := datastore.NewAuthRepository(db)
+		assert.Eventually(t, func() bool {
+			events, err := r.ListAuthEvents(repository.AuthEventsParam{})
+			return err == nil && len(events) == 1
+		}, time.Second, 10*time.Millisecond)
+		events, err := r.ListAuthEvents(repository.AuthEventsParam{})
+		if assert.NoError(t, err) && assert.Len(t, events, 1) {
+			assert.Equal(t, "operatorLogin", events[0].Event)
+			assert.True(t, occurredAt.Equal(events[0].OccurredAt))
+		}
+	})
+
+	tt.Run("1-2: 正常系：停止時にキューに残ったイベントを記録する", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		writer := datastore.NewAuthEventWriter(db, 10)
+		writer.Write(repository.CreateAuthEventParam{Event: "operatorLogin", Result: true, IPAddress: "127.0.0.1"})
+		writer.Write(repository.CreateAuthEventParam{Event: "operatorLogout", Result: true, IPAddress: "127.0.0.1"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		writer.Run(ctx)
+
+		events, err := datastore.NewAuthRepository(db).ListAuthEvents(repository.AuthEventsParam{})
+		if assert.NoError(t, err) {
+			assert.Len(t, events, 2)
+		}
+	})
+
+	tt.Run("2-1: 異常系：キューが一杯の場合はイベントを破棄する", func(t *testing.T) {
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		writer := datastore.NewAuthEventWriter(db, 1)
+		writer.Write(repository.CreateAuthEventParam{Event: "operatorLogin", Result: true, IPAddress: "127.0.0.1"})
+		writer.Write(repository.CreateAuthEventParam{Event: "operatorLogout", Result: true, IPAddress: "127.0.0.1"})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		writer.Run(ctx)
+
+		events, err := datastore.NewAuthRepository(db).ListAuthEvents(repository.AuthEventsParam{})
+		if assert.NoError(t, err) && assert.Len(t, events, 1) {
+			assert.Equal(t, "operatorLogin", events[0].Event)
+		}
+	})
+}
@@ -1,11 +1,27 @@
 package datastore
 
 import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
 	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	passwordResetUserID   = "password-reset"
+	passwordHistoryUserID = "password-history"
+	loginAttemptUserID    = "login-attempt"
+	mfaUserID             = "mfa"
+	oauthClientUserID     = "oauth-client"
+	authEventUserID       = "auth-event"
 )
 
 // authRepository
@@ -32,10 +48,16 @@ func NewAuthRepository(
 func (r *authRepository) ListAPIKeys(param repository.APIKeysParam) (authentication.APIKeys, error) {
 	var apikeys []authentication.APIKey
 
-	query := r.db.Table("api_keys")
+	query := r.db.Table("api_keys").Where("deleted_at IS NULL")
 	if param.Attributes != nil {
 		query = query.Where("application_attribute in (?)", param.Attributes)
 	}
+	if param.ID != nil {
+		query = query.Where("id = ?", *param.ID)
+	}
+	if param.KeyPrefix != nil {
+		query = query.Where("key_prefix = ?", *param.KeyPrefix)
+	}
 	if err := query.Find(&apikeys).Error; err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
@@ -44,6 +66,170 @@ func (r *authRepository) ListAPIKeys(param repository.APIKeysParam) (authenticat
 	return apikeys, nil
 }
 
+// GetAPIKey
+// Summary: This is the function which gets the api key.
+// input: id(string): ID of the api key
+// output: (authentication.APIKey) api key
+// output: (error) error object. gorm.ErrRecordNotFound when the api key does not exist or is revoked
+func (r *authRepository) GetAPIKey(id string) (authentication.APIKey, error) {
+	var apikey authentication.APIKey
+
+	if err := r.db.Table("api_keys").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&apikey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(err.Error())
+		} else {
+			logger.Set(nil).Errorf(err.Error())
+		}
+
+		return authentication.APIKey{}, err
+	}
+	return apikey, nil
+}
+
+// CreateAPIKey
+// Summary: This is the function which creates the api key.
+// input: apiKey(authentication.APIKey): api key
+// output: (error) error object
+func (r *authRepository) CreateAPIKey(apiKey authentication.APIKey) error {
+	now := time.Now().UTC()
+	apiKey.CreatedAt = now
+	apiKey.UpdatedAt = now
+
+	if err := r.db.Table("api_keys").Create(&apiKey).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// UpdateAPIKey
+// Summary: This is the function which changes the application name, the application attribute, the limits of the requests and the mode of the ip address restriction of the api key.
+// input: param(repository.UpdateAPIKeyParam): update api key param
+// output: (error) error object. gorm.ErrRecordNotFound when the api key does not exist or is revoked
+func (r *authRepository) UpdateAPIKey(param repository.UpdateAPIKeyParam) error {
+	values := map[string]interface{}{
+		"updated_at":      time.Now().UTC(),
+		"updated_user_id": param.UserID,
+	}
+	if param.ApplicationName != nil {
+		values["application_name"] = *param.ApplicationName
+	}
+	if param.Attribute != nil {
+		values["application_attribute"] = *param.Attribute
+	}
+	if param.RateLimit.RequestsPerMinute != nil {
+		values["rate_limit_per_minute"] = *param.RateLimit.RequestsPerMinute
+	}
+	if param.RateLimit.Burst != nil {
+		values["rate_limit_burst"] = *param.RateLimit.Burst
+	}
+	if param.RateLimit.DailyQuota != nil {
+		values["daily_quota"] = *param.RateLimit.DailyQuota
+	}
+	if param.IPRestrictionMode != nil {
+		values["ip_restriction_mode"] = *param.IPRestrictionMode
+	}
+	if param.IsAdmin != nil {
+		values["is_admin"] = *param.IsAdmin
+	}
+
+	return r.updateRows(r.db.Table("api_keys").Where("id = ? AND deleted_at IS NULL", param.ID), values)
+}
+
+// UpdateAPIKeySigningSecret
+// Summary: This is the function which sets or clears the encrypted signing secret of the api key.
+// input: param(repository.UpdateAPIKeySigningSecretParam): update api key signing secret param
+// output: (error) error object. gorm.ErrRecordNotFound when the api key does not exist or is revoked
+func (r *authRepository) UpdateAPIKeySigningSecret(param repository.UpdateAPIKeySigningSecretParam) error {
+	return r.updateRows(r.db.Table("api_keys").Where("id = ? AND deleted_at IS NULL", param.ID), map[string]interface{}{
+		"signing_secret":  param.SigningSecret,
+		"updated_at":      time.Now().UTC(),
+		"updated_user_id": param.UserID,
+	})
+}
+
+// DeleteAPIKey
+// Summary: This is the function which revokes the api key by the logical deletion.
+// input: param(repository.DeleteAPIKeyParam): delete api key param
+// output: (error) error object. gorm.ErrRecordNotFound when the api key does not exist or is already revoked
+func (r *authRepository) DeleteAPIKey(param repository.DeleteAPIKeyParam) error {
+	now := time.Now().UTC()
+
+	return r.updateRows(r.db.Table("api_keys").Where("id = ? AND deleted_at IS NULL", param.ID), map[string]interface{}{
+		"deleted_at":      now,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	})
+}
+
+// RotateAPIKey
+// Summary: This is the function which rotates the api key to the successor.
+// The api key expires at the end of the grace period, and the successor takes over its operators, cidrs, permissions, oauth clients and client certificates.
+// input: param(repository.RotateAPIKeyParam): rotate api key param
+// output: (error) error object. gorm.ErrRecordNotFound when the api key does not exist or is revoked
+func (r *authRepository) RotateAPIKey(param repository.RotateAPIKeyParam) error {
+	now := time.Now().UTC()
+	successor := param.Successor
+	successor.CreatedAt = now
+	successor.UpdatedAt = now
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Table("api_keys").Where("id = ? AND deleted_at IS NULL", param.ID).Updates(map[string]interface{}{
+			"expires_at":      param.ExpiresAt,
+			"updated_at":      now,
+			"updated_user_id": param.UserID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Table("api_keys").Create(&successor).Error; err != nil {
+			return err
+		}
+
+		// the bindings are copied so that both the api key and the successor work during the grace period
+		for table, columns := range map[string]string{
+			"apikey_operators":    "operator_id",
+			"cidrs":               "cidr, action, priority",
+			"api_key_permissions": "method, path",
+		} {
+			if err := tx.Exec(
+				"INSERT INTO "+table+" (api_key_id, "+columns+", deleted_at, created_at, created_user_id, updated_at, updated_user_id) "+
+					"SELECT ?, "+columns+", NULL, ?, ?, ?, ? FROM "+table+" WHERE api_key_id = ? AND deleted_at IS NULL",
+				successor.ID, now, param.UserID, now, param.UserID, param.ID,
+			).Error; err != nil {
+				return err
+			}
+		}
+
+		// the client and the certificate identify only one api key, so they are moved to the successor
+		for _, table := range []string{"oauth_clients", "api_key_certificates"} {
+			if err := tx.Table(table).Where("api_key_id = ? AND deleted_at IS NULL", param.ID).Updates(map[string]interface{}{
+				"api_key_id":      successor.ID,
+				"updated_at":      now,
+				"updated_user_id": param.UserID,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(err.Error())
+		} else {
+			logger.Set(nil).Errorf(err.Error())
+		}
+
+		return err
+	}
+	return nil
+}
+
 // ListAPIKeyOperators
 // Summary: This is the function which lists the api key operators.
 // input: param(APIKeyOperatorsParam): apikey operators param
@@ -52,9 +238,9 @@ func (r *authRepository) ListAPIKeys(param repository.APIKeysParam) (authenticat
 func (r *authRepository) ListAPIKeyOperators(param repository.APIKeyOperatorsParam) (authentication.APIKeyOperators, error) {
 	var apikeyOperators authentication.APIKeyOperators
 
-	query := r.db.Table("apikey_operators")
-	if param.APIKey != nil {
-		query = query.Where("api_key = ?", *param.APIKey)
+	query := r.db.Table("apikey_operators").Where("deleted_at IS NULL")
+	if param.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *param.APIKeyID)
 	}
 	if err := query.Find(&apikeyOperators).Error; err != nil {
 		logger.Set(nil).Errorf(err.Error())
@@ -72,9 +258,9 @@ func (r *authRepository) ListAPIKeyOperators(param repository.APIKeyOperatorsPar
 func (r *authRepository) ListCidrs(param repository.APIKeyCidrsParam) (authentication.Cidrs, error) {
 	var cidrs authentication.Cidrs
 
-	query := r.db.Table("cidrs")
-	if param.APIKey != nil {
-		query = query.Where("api_key = ?", *param.APIKey)
+	query := r.db.Table("cidrs").Where("deleted_at IS NULL")
+	if param.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *param.APIKeyID)
 	}
 	if err := query.Find(&cidrs).Error; err != nil {
 		logger.Set(nil).Errorf(err.Error())
@@ -82,5 +268,688 @@ func (r *authRepository) ListCidrs(param repository.APIKeyCidrsParam) (authentic
 		return nil, err
 	}
 	return cidrs, nil
+}
+
+// CreateAPIKeyOperator
+// Summary: This is the function which binds the operator to the api key.
+// The binding deleted before is restored.
+// input: param(repository.APIKeyOperatorParam): apikey operator param
+// output: (error) error object
+func (r *authRepository) CreateAPIKeyOperator(param repository.APIKeyOperatorParam) error {
+	now := time.Now().UTC()
+	apikeyOperator := map[string]interface{}{
+		"api_key_id":      param.APIKeyID,
+		"operator_id":     param.OperatorID,
+		"deleted_at":      nil,
+		"created_at":      now,
+		"created_user_id": param.UserID,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	}
+
+	return r.createBinding("apikey_operators", []string{"api_key_id", "operator_id"}, apikeyOperator)
+}
+
+// DeleteAPIKeyOperator
+// Summary: This is the function which unbinds the operator from the api key by the logical deletion.
+// input: param(repository.APIKeyOperatorParam): apikey operator param
+// output: (error) error object. gorm.ErrRecordNotFound when the operator is not bound to the api key
+func (r *authRepository) DeleteAPIKeyOperator(param repository.APIKeyOperatorParam) error {
+	now := time.Now().UTC()
+
+	return r.updateRows(r.db.Table("apikey_operators").Where("api_key_id = ? AND operator_id = ? AND deleted_at IS NULL", param.APIKeyID, param.OperatorID), map[string]interface{}{
+		"deleted_at":      now,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	})
+}
+
+// CreateCidr
+// Summary: This is the function which adds the cidr rule to the api key.
+// The cidr deleted before is restored, and the action and the priority of the cidr already added are replaced.
+// input: param(repository.APIKeyCidrParam): apikey cidr param
+// output: (error) error object
+func (r *authRepository) CreateCidr(param repository.APIKeyCidrParam) error {
+	now := time.Now().UTC()
+	cidr := map[string]interface{}{
+		"api_key_id":      param.APIKeyID,
+		"cidr":            param.Cidr,
+		"action":          param.Action,
+		"priority":        param.Priority,
+		"deleted_at":      nil,
+		"created_at":      now,
+		"created_user_id": param.UserID,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	}
+
+	return r.createBinding("cidrs", []string{"cidr", "api_key_id"}, cidr)
+}
+
+// DeleteCidr
+// Summary: This is the function which removes the cidr from the api key by the logical deletion.
+// input: param(repository.APIKeyCidrParam): apikey cidr param
+// output: (error) error object. gorm.ErrRecordNotFound when the cidr is not added to the api key
+func (r *authRepository) DeleteCidr(param repository.APIKeyCidrParam) error {
+	now := time.Now().UTC()
+
+	return r.updateRows(r.db.Table("cidrs").Where("api_key_id = ? AND cidr = ? AND deleted_at IS NULL", param.APIKeyID, param.Cidr), map[string]interface{}{
+		"deleted_at":      now,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	})
+}
+
+// ListClientCertificates
+// Summary: This is the function which lists the client certificates mapped to the api keys.
+// input: param(APIKeyClientCertificatesParam): apikey client certificates param
+// output: (authentication.ClientCertificates) client certificates
+// output: (error) error object
+func (r *authRepository) ListClientCertificates(param repository.APIKeyClientCertificatesParam) (authentication.ClientCertificates, error) {
+	var certificates authentication.ClientCertificates
+
+	query := r.db.Table("api_key_certificates").Where("deleted_at IS NULL")
+	if param.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *param.APIKeyID)
+	}
+	if err := query.Find(&certificates).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return certificates, nil
+}
+
+// CreateClientCertificate
+// Summary: This is the function which maps the client certificate to the api key.
+// The certificate deleted before is restored, and the certificate mapped to another api key is moved to the api key.
+// input: param(repository.APIKeyClientCertificateParam): apikey client certificate param
+// output: (error) error object
+func (r *authRepository) CreateClientCertificate(param repository.APIKeyClientCertificateParam) error {
+	now := time.Now().UTC()
+	certificate := map[string]interface{}{
+		"certificate_type": param.Type,
+		"certificate":      param.Certificate,
+		"api_key_id":       param.APIKeyID,
+		"deleted_at":       nil,
+		"created_at":       now,
+		"created_user_id":  param.UserID,
+		"updated_at":       now,
+		"updated_user_id":  param.UserID,
+	}
+
+	return r.createBinding("api_key_certificates", []string{"certificate_type", "certificate"}, certificate)
+}
+
+// DeleteClientCertificate
+// Summary: This is the function which unmaps the client certificate from the api key by the logical deletion.
+// input: param(repository.APIKeyClientCertificateParam): apikey client certificate param
+// output: (error) error object. gorm.ErrRecordNotFound when the certificate is not mapped to the api key
+func (r *authRepository) DeleteClientCertificate(param repository.APIKeyClientCertificateParam) error {
+	now := time.Now().UTC()
+
+	return r.updateRows(r.db.Table("api_key_certificates").Where("api_key_id = ? AND certificate_type = ? AND certificate = ? AND deleted_at IS NULL", param.APIKeyID, param.Type, param.Certificate), map[string]interface{}{
+		"deleted_at":      now,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	})
+}
+
+// createBinding
+// Summary: This is the function which creates the row bound to the api key, or restores it when it has been deleted logically.
+// The creator of the restored row is kept, and the other columns are replaced.
+// input: table(string): table name
+// input: keys([]string): columns of the primary key
+// input: row(map[string]interface{}): columns and values of the row to create
+// output: (error) error object
+func (r *authRepository) createBinding(table string, keys []string, row map[string]interface{}) error {
+	columns := make([]clause.Column, len(keys))
+	for i, key := range keys {
+		columns[i] = clause.Column{Name: key}
+	}
+	var updates []string
+	for column := range row {
+		if !slices.Contains(keys, column) && column != "created_at" && column != "created_user_id" {
+			updates = append(updates, column)
+		}
+	}
+	// the columns are sorted so that the same statement is issued every time
+	slices.Sort(updates)
+
+	if err := r.db.Table(table).Clauses(clause.OnConflict{
+		Columns:   columns,
+		DoUpdates: clause.AssignmentColumns(updates),
+	}).Create(row).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// updateRows
+// Summary: This is the function which updates the rows selected by the query.
+// input: query(*gorm.DB): query selecting the rows
+// input: values(map[string]interface{}): columns and values to update
+// output: (error) error object. gorm.ErrRecordNotFound when no row is selected
+func (r *authRepository) updateRows(query *gorm.DB, values map[string]interface{}) error {
+	result := query.Updates(values)
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		logger.Set(nil).Warnf(gorm.ErrRecordNotFound.Error())
+
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListAPIKeyPermissions
+// Summary: This is the function which lists the route permissions of the api keys.
+// input: param(APIKeyPermissionsParam): apikey permissions param
+// output: (authentication.APIKeyPermissions) apikey permissions
+// output: (error) error object
+func (r *authRepository) ListAPIKeyPermissions(param repository.APIKeyPermissionsParam) (authentication.APIKeyPermissions, error) {
+	var permissions authentication.APIKeyPermissions
+
+	query := r.db.Table("api_key_permissions").Where("deleted_at IS NULL")
+	if param.APIKeyID != nil {
+		query = query.Where("api_key_id = ?", *param.APIKeyID)
+	}
+	if err := query.Find(&permissions).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// CreateAPIKeyPermission
+// Summary: This is the function which grants the route permission to the api key.
+// The permission deleted before is restored.
+// input: param(repository.APIKeyPermissionParam): apikey permission param
+// output: (error) error object
+func (r *authRepository) CreateAPIKeyPermission(param repository.APIKeyPermissionParam) error {
+	now := time.Now().UTC()
+	permission := map[string]interface{}{
+		"api_key_id":      param.APIKeyID,
+		"method":          param.Method,
+		"path":            param.Path,
+		"deleted_at":      nil,
+		"created_at":      now,
+		"created_user_id": param.UserID,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	}
+
+	return r.createBinding("api_key_permissions", []string{"api_key_id", "method", "path"}, permission)
+}
+
+// DeleteAPIKeyPermission
+// Summary: This is the function which revokes the route permission from the api key by the logical deletion.
+// input: param(repository.APIKeyPermissionParam): apikey permission param
+// output: (error) error object. gorm.ErrRecordNotFound when the permission is not granted to the api key
+func (r *authRepository) DeleteAPIKeyPermission(param repository.APIKeyPermissionParam) error {
+	now := time.Now().UTC()
+
+	return r.updateRows(r.db.Table("api_key_permissions").Where("api_key_id = ? AND method = ? AND path = ? AND deleted_at IS NULL", param.APIKeyID, param.Method, param.Path), map[string]interface{}{
+		"deleted_at":      now,
+		"updated_at":      now,
+		"updated_user_id": param.UserID,
+	})
+}
+
+// CountPasswordResetRequests
+// Summary: This is the function which counts the password reset requests of the email since the specified time.
+// input: param(PasswordResetRequestsParam): password reset requests param
+// output: (int64) number of the requests
+// output: (error) error object
+func (r *authRepository) CountPasswordResetRequests(param repository.PasswordResetRequestsParam) (int64, error) {
+	var count int64
+
+	if err := r.db.Table("password_reset_requests").
+		Where("email = ? AND requested_at >= ?", strings.ToLower(param.Email), param.Since.UTC()).
+		Count(&count).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreatePasswordResetRequest
+// Summary: This is the function which records the password reset request of the email.
+// input: email(string): email
+// output: (error) error object
+func (r *authRepository) CreatePasswordResetRequest(email string) error {
+	now := time.Now().UTC()
+	request := authentication.PasswordResetRequest{
+		ID:            uuid.New().String(),
+		Email:         strings.ToLower(email),
+		RequestedAt:   now,
+		CreatedAt:     now,
+		CreatedUserID: passwordResetUserID,
+		UpdatedAt:     now,
+		UpdatedUserID: passwordResetUserID,
+	}
+	if err := r.db.Table("password_reset_requests").Create(&request).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// ListPasswordHistories
+// Summary: This is the function which lists the latest password histories of the email.
+// input: param(PasswordHistoriesParam): password histories param
+// output: (PasswordHistories) password histories in descending order of creation
+// output: (error) error object
+func (r *authRepository) ListPasswordHistories(param repository.PasswordHistoriesParam) (authentication.PasswordHistories, error) {
+	var histories authentication.PasswordHistories
+
+	if err := r.db.Table("password_histories").
+		Where("email = ?", strings.ToLower(param.Email)).
+		Order("created_at DESC").
+		Limit(param.Limit).
+		Find(&histories).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return histories, nil
+}
+
+// CreatePasswordHistory
+// Summary: This is the function which records the password history and deletes the histories older than the kept ones.
+// input: param(CreatePasswordHistoryParam): create password history param
+// output: (error) error object
+func (r *authRepository) CreatePasswordHistory(param repository.CreatePasswordHistoryParam) error {
+	now := time.Now().UTC()
+	history := param.History
+	history.ID = uuid.New().String()
+	history.Email = strings.ToLower(history.Email)
+	history.CreatedAt = now
+	history.CreatedUserID = passwordHistoryUserID
+	history.UpdatedAt = now
+	history.UpdatedUserID = passwordHistoryUserID
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("password_histories").Create(&history).Error; err != nil {
+			return err
+		}
+		kept := tx.Table("password_histories").
+			Select("id").
+			Where("email = ?", history.Email).
+			Order("created_at DESC").
+			Limit(param.Keep)
+
+		return tx.Table("password_histories").
+			Where("email = ? AND id NOT IN (?)", history.Email, kept).
+			Delete(&authentication.PasswordHistory{}).Error
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// ListLoginFailures
+// Summary: This is the function which lists the failed login attempts in descending order of the attempt.
+// input: param(LoginFailuresParam): login failures param
+// output: (LoginAttempts) failed login attempts
+// output: (error) error object
+func (r *authRepository) ListLoginFailures(param repository.LoginFailuresParam) (authentication.LoginAttempts, error) {
+	since := param.Since.UTC()
+	query := r.db.Table("login_attempts").Where("result IN ?", []authentication.LoginAttemptResult{authentication.LoginAttemptResultFailure, authentication.LoginAttemptResultPending})
+
+	if param.Email != "" {
+		email := strings.ToLower(param.Email)
+		var resets authentication.LoginAttempts
+		if err := r.db.Table("login_attempts").
+			Where("email = ? AND result IN ?", email, []authentication.LoginAttemptResult{authentication.LoginAttemptResultSuccess, authentication.LoginAttemptResultUnlock}).
+			Order("attempted_at DESC").
+			Limit(1).
+			Find(&resets).Error; err != nil {
+			logger.Set(nil).Errorf(err.Error())
+
+			return nil, err
+		}
+		if len(resets) > 0 && resets[0].AttemptedAt.After(since) {
+			since = resets[0].AttemptedAt
+			query = query.Where("attempted_at > ?", since)
+		} else {
+			query = query.Where("attempted_at >= ?", since)
+		}
+		query = query.Where("email = ?", email)
+	} else {
+		query = query.Where("attempted_at >= ?", since)
+	}
+	if param.IPAddress != "" {
+		query = query.Where("ip_address = ?", param.IPAddress)
+	}
+	if param.ExcludeID != "" {
+		query = query.Where("id <> ?", param.ExcludeID)
+	}
+	if param.Limit > 0 {
+		query = query.Limit(param.Limit)
+	}
+
+	var attempts authentication.LoginAttempts
+	if err := query.Order("attempted_at DESC").Find(&attempts).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// CreateLoginAttempt
+// Summary: This is the function which records the login attempt.
+// input: param(CreateLoginAttemptParam): create login attempt param
+// output: (string) ID of the login attempt
+// output: (error) error object
+func (r *authRepository) CreateLoginAttempt(param repository.CreateLoginAttemptParam) (string, error) {
+	now := time.Now().UTC()
+	attempt := authentication.LoginAttempt{
+		ID:            uuid.New().String(),
+		Email:         strings.ToLower(param.Email),
+		IPAddress:     param.IPAddress,
+		Result:        param.Result,
+		AttemptedAt:   now,
+		CreatedAt:     now,
+		CreatedUserID: loginAttemptUserID,
+		UpdatedAt:     now,
+		UpdatedUserID: loginAttemptUserID,
+	}
+	if err := r.db.Table("login_attempts").Create(&attempt).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return attempt.ID, nil
+}
+
+// UpdateLoginAttemptResult
+// Summary: This is the function which records the result of the pending login attempt.
+// input: param(UpdateLoginAttemptResultParam): update login attempt result param
+// output: (error) error object
+func (r *authRepository) UpdateLoginAttemptResult(param repository.UpdateLoginAttemptResultParam) error {
+	if err := r.db.Table("login_attempts").
+		Where("id = ? AND result = ?", param.ID, authentication.LoginAttemptResultPending).
+		Updates(map[string]interface{}{
+			"result":          param.Result,
+			"updated_at":      time.Now().UTC(),
+			"updated_user_id": loginAttemptUserID,
+		}).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// GetMFACredential
+// Summary: This is the function which gets the MFA credential of the email.
+// input: email(string): email
+// output: (authentication.MFACredential) MFA credential
+// output: (error) error object. gorm.ErrRecordNotFound when the credential does not exist
+func (r *authRepository) GetMFACredential(email string) (authentication.MFACredential, error) {
+	var credential authentication.MFACredential
+
+	if err := r.db.Table("mfa_credentials").
+		Where("email = ?", strings.ToLower(email)).
+		First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(err.Error())
+		} else {
+			logger.Set(nil).Errorf(err.Error())
+		}
+
+		return authentication.MFACredential{}, err
+	}
+	return credential, nil
+}
+
+// SaveMFACredential
+// Summary: This is the function which creates or updates the MFA credential.
+// The credential is created when its ID is empty.
+// input: credential(authentication.MFACredential): MFA credential
+// output: (error) error object
+func (r *authRepository) SaveMFACredential(credential authentication.MFACredential) error {
+	now := time.Now().UTC()
+	credential.Email = strings.ToLower(credential.Email)
+	credential.UpdatedAt = now
+	credential.UpdatedUserID = mfaUserID
+
+	var err error
+	if credential.ID == "" {
+		credential.ID = uuid.New().String()
+		credential.CreatedAt = now
+		credential.CreatedUserID = mfaUserID
+		err = r.db.Table("mfa_credentials").Create(&credential).Error
+	} else {
+		err = r.db.Table("mfa_credentials").Select("*").Where("id = ?", credential.ID).Updates(&credential).Error
+	}
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// DeleteMFACredential
+// Summary: This is the function which deletes the MFA credential of the email.
+// input: email(string): email
+// output: (error) error object
+func (r *authRepository) DeleteMFACredential(email string) error {
+	if err := r.db.Table("mfa_credentials").
+		Where("email = ?", strings.ToLower(email)).
+		Delete(&authentication.MFACredential{}).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
 
+		return err
+	}
+	return nil
+}
+
+// CreateMFAChallenge
+// Summary: This is the function which records the MFA challenge and deletes the expired ones.
+// input: challenge(authentication.MFAChallenge): MFA challenge
+// output: (error) error object
+func (r *authRepository) CreateMFAChallenge(challenge authentication.MFAChallenge) error {
+	now := time.Now().UTC()
+	challenge.ID = uuid.New().String()
+	challenge.Email = strings.ToLower(challenge.Email)
+	challenge.ExpiresAt = challenge.ExpiresAt.UTC()
+	challenge.CreatedAt = now
+	challenge.CreatedUserID = mfaUserID
+	challenge.UpdatedAt = now
+	challenge.UpdatedUserID = mfaUserID
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("mfa_challenges").
+			Where("expires_at <= ?", now).
+			Delete(&authentication.MFAChallenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Table("mfa_challenges").Create(&challenge).Error
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// GetMFAChallenge
+// Summary: This is the function which gets the MFA challenge by the hash of the challenge token.
+// input: tokenHash(string): hash of the challenge token
+// output: (authentication.MFAChallenge) MFA challenge
+// output: (error) error object. gorm.ErrRecordNotFound when the challenge does not exist
+func (r *authRepository) GetMFAChallenge(tokenHash string) (authentication.MFAChallenge, error) {
+	var challenge authentication.MFAChallenge
+
+	if err := r.db.Table("mfa_challenges").
+		Where("token_hash = ?", tokenHash).
+		First(&challenge).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(err.Error())
+		} else {
+			logger.Set(nil).Errorf(err.Error())
+		}
+
+		return authentication.MFAChallenge{}, err
+	}
+	return challenge, nil
+}
+
+// IncrementMFAChallengeAttempts
+// Summary: This is the function which counts up the failed verifications of the MFA challenge.
+// input: id(string): ID of the MFA challenge
+// output: (error) error object
+func (r *authRepository) IncrementMFAChallengeAttempts(id string) error {
+	if err := r.db.Table("mfa_challenges").
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"updated_at":      time.Now().UTC(),
+			"updated_user_id": mfaUserID,
+		}).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// DeleteMFAChallenge
+// Summary: This is the function which deletes the MFA challenge.
+// input: id(string): ID of the MFA challenge
+// output: (error) error object
+func (r *authRepository) DeleteMFAChallenge(id string) error {
+	if err := r.db.Table("mfa_challenges").
+		Where("id = ?", id).
+		Delete(&authentication.MFAChallenge{}).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// GetOAuthClient
+// Summary: This is the function which gets the OAuth client.
+// input: clientID(string): client ID
+// output: (authentication.OAuthClient) OAuth client
+// output: (error) error object. gorm.ErrRecordNotFound when the client does not exist or is deleted
+func (r *authRepository) GetOAuthClient(clientID string) (authentication.OAuthClient, error) {
+	var client authentication.OAuthClient
+
+	if err := r.db.Table("oauth_clients").
+		Where("client_id = ? AND deleted_at IS NULL", clientID).
+		First(&client).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(err.Error())
+		} else {
+			logger.Set(nil).Errorf(err.Error())
+		}
+
+		return authentication.OAuthClient{}, err
+	}
+	return client, nil
+}
+
+// CreateOAuthClient
+// Summary: This is the function which creates the OAuth client.
+// input: client(authentication.OAuthClient): OAuth client whose secret is already hashed
+// output: (error) error object
+func (r *authRepository) CreateOAuthClient(client authentication.OAuthClient) error {
+	now := time.Now().UTC()
+	client.CreatedAt = now
+	client.CreatedUserID = oauthClientUserID
+	client.UpdatedAt = now
+	client.UpdatedUserID = oauthClientUserID
+
+	if err := r.db.Table("oauth_clients").Create(&client).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// CreateAuthEvent
+// Summary: This is the function which records the authentication event.
+// input: param(repository.CreateAuthEventParam): auth event param
+// output: (error) error object
+func (r *authRepository) CreateAuthEvent(param repository.CreateAuthEventParam) error {
+	// the time is truncated to the precision of the database so that the cursor points to the stored value
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	occurredAt := now
+	if !param.OccurredAt.IsZero() {
+		occurredAt = param.OccurredAt.UTC().Truncate(time.Microsecond)
+	}
+	event := authentication.AuthEvent{
+		ID:                uuid.New().String(),
+		Event:             param.Event,
+		Result:            param.Result,
+		ReasonCode:        param.ReasonCode,
+		IPAddress:         param.IPAddress,
+		APIKeyID:          param.APIKeyID,
+		OperatorID:        param.OperatorID,
+		OperatorAccountID: param.OperatorAccountID,
+		OccurredAt:        occurredAt,
+		CreatedAt:         now,
+		CreatedUserID:     authEventUserID,
+		UpdatedAt:         now,
+		UpdatedUserID:     authEventUserID,
+	}
+	if err := r.db.Table("auth_events").Create(&event).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// ListAuthEvents
+// Summary: This is the function which lists the authentication events.
+// input: param(repository.AuthEventsParam): auth events param
+// output: (authentication.AuthEvents) auth events
+// output: (error) error object
+func (r *authRepository) ListAuthEvents(param repository.AuthEventsParam) (authentication.AuthEvents, error) {
+	events := authentication.AuthEvents{}
+
+	query := r.db.Table("auth_events")
+	if param.From != nil {
+		query = query.Where("occurred_at >= ?", param.From.UTC())
+	}
+	if param.To != nil {
+		query = query.Where("occurred_at < ?", param.To.UTC())
+	}
+	if param.OperatorID != nil {
+		query = query.Where("operator_id = ?", *param.OperatorID)
+	}
+	if len(param.Events) > 0 {
+		query = query.Where("event IN ?", param.Events)
+	}
+	if param.After != nil {
+		occurredAt := param.After.OccurredAt.UTC()
+		query = query.Where("occurred_at < ? OR (occurred_at = ? AND id < ?)", occurredAt, occurredAt, param.After.ID)
+	}
+	if param.Limit > 0 {
+		query = query.Limit(param.Limit)
+	}
+	if err := query.Order("occurred_at DESC, id DESC").Find(&events).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return events, nil
 }
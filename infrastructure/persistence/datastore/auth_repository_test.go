@@ -0,0 +1,1162 @@
+package datastore_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/persistence/datastore"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreatePasswordResetRequest / CountPasswordResetRequests テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：記録した要求件数を返却
+// [x] 1-2: 正常系：メールアドレスの大文字小文字を区別しない場合
+// [x] 1-3: 正常系：集計開始日時より前の要求は含めない場合
+// [x] 1-4: 正常系：別のメールアドレスの要求は含めない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_PasswordResetRequests(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		input  repository.PasswordResetRequestsParam
+		expect int64
+	}{
+		{
+			name:   "1-1: 正常系：記録した要求件数を返却",
+			input:  repository.PasswordResetRequestsParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour)},
+			expect: 2,
+		},
+		{
+			name:   "1-2: 正常系：メールアドレスの大文字小文字を区別しない場合",
+			input:  repository.PasswordResetRequestsParam{Email: "OEM_A@example.com", Since: time.Now().Add(-time.Hour)},
+			expect: 2,
+		},
+		{
+			name:   "1-3: 正常系：集計開始日時より前の要求は含めない場合",
+			input:  repository.PasswordResetRequestsParam{Email: "oem_a@example.com", Since: time.Now().Add(time.Hour)},
+			expect: 0,
+		},
+		{
+			name:   "1-4: 正常系：別のメールアドレスの要求は含めない場合",
+			input:  repository.PasswordResetRequestsParam{Email: "oem_b@example.com", Since: time.Now().Add(-time.Hour)},
+			expect: 0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				for i := 0; i < 2; i++ {
+					if err := r.CreatePasswordResetRequest("oem_a@example.com"); !assert.NoError(t, err) {
+						return
+					}
+				}
+
+				actual, err := r.CountPasswordResetRequests(test.input)
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreatePasswordHistory / ListPasswordHistories テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：保持件数分の履歴を新しい順に返却
+// [x] 1-2: 正常系：取得件数が保持件数より少ない場合
+// [x] 1-3: 正常系：別のメールアドレスの履歴は含めない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_PasswordHistories(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		input  repository.PasswordHistoriesParam
+		expect []string
+	}{
+		{
+			name:   "1-1: 正常系：保持件数分の履歴を新しい順に返却",
+			input:  repository.PasswordHistoriesParam{Email: "OEM_A@example.com", Limit: 5},
+			expect: []string{"Password4!", "Password3!", "Password2!"},
+		},
+		{
+			name:   "1-2: 正常系：取得件数が保持件数より少ない場合",
+			input:  repository.PasswordHistoriesParam{Email: "oem_a@example.com", Limit: 1},
+			expect: []string{"Password4!"},
+		},
+		{
+			name:   "1-3: 正常系：別のメールアドレスの履歴は含めない場合",
+			input:  repository.PasswordHistoriesParam{Email: "oem_b@example.com", Limit: 5},
+			expect: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				for _, password := range []string{"Password1!", "Password2!", "Password3!", "Password4!"} {
+					history, err := authentication.NewPasswordHistory("oem_a@example.com", authentication.Password(password))
+					if !assert.NoError(t, err) {
+						return
+					}
+					if err := r.CreatePasswordHistory(repository.CreatePasswordHistoryParam{History: history, Keep: 3}); !assert.NoError(t, err) {
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+
+				actual, err := r.ListPasswordHistories(test.input)
+				if assert.NoError(t, err) {
+					assert.Len(t, actual, len(test.expect))
+					for i, password := range test.expect {
+						assert.True(t, authentication.PasswordHistories{actual[i]}.Contains(authentication.Password(password)))
+					}
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateLoginAttempt / ListLoginFailures テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：アカウントの失敗を新しい順に返却
+// [x] 1-2: 正常系：ログイン成功以降の失敗のみ返却
+// [x] 1-3: 正常系：ロック解除以降の失敗のみ返却
+// [x] 1-4: 正常系：IPアドレスの失敗はログイン成功後も返却
+// [x] 1-5: 正常系：取得件数を指定した場合
+// [x] 1-6: 正常系：集計開始日時より前の失敗は含めない場合
+// [x] 1-7: 正常系：処理中の試行を失敗として返却し、除外IDの試行は含めない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_LoginAttempts(tt *testing.T) {
+
+	type attempt struct {
+		email  string
+		ip     string
+		result authentication.LoginAttemptResult
+	}
+	failureA := attempt{"oem_a@example.com", "192.0.2.1", authentication.LoginAttemptResultFailure}
+	failureB := attempt{"oem_b@example.com", "192.0.2.1", authentication.LoginAttemptResultFailure}
+
+	tests := []struct {
+		name        string
+		attempts    []attempt
+		input       repository.LoginFailuresParam
+		excludeLast bool
+		expect      []string
+	}{
+		{
+			name:     "1-1: 正常系：アカウントの失敗を新しい順に返却",
+			attempts: []attempt{failureA, failureB, {"oem_a@example.com", "192.0.2.2", authentication.LoginAttemptResultFailure}},
+			input:    repository.LoginFailuresParam{Email: "OEM_A@example.com", Since: time.Now().Add(-time.Hour)},
+			expect:   []string{"192.0.2.2", "192.0.2.1"},
+		},
+		{
+			name:     "1-2: 正常系：ログイン成功以降の失敗のみ返却",
+			attempts: []attempt{failureA, {"oem_a@example.com", "192.0.2.1", authentication.LoginAttemptResultSuccess}, {"oem_a@example.com", "192.0.2.2", authentication.LoginAttemptResultFailure}},
+			input:    repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour)},
+			expect:   []string{"192.0.2.2"},
+		},
+		{
+			name:     "1-3: 正常系：ロック解除以降の失敗のみ返却",
+			attempts: []attempt{failureA, failureA, {"oem_a@example.com", "", authentication.LoginAttemptResultUnlock}},
+			input:    repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour)},
+			expect:   []string{},
+		},
+		{
+			name:     "1-4: 正常系：IPアドレスの失敗はログイン成功後も返却",
+			attempts: []attempt{failureA, failureB, {"oem_a@example.com", "192.0.2.1", authentication.LoginAttemptResultSuccess}, {"oem_a@example.com", "192.0.2.2", authentication.LoginAttemptResultFailure}},
+			input:    repository.LoginFailuresParam{IPAddress: "192.0.2.1", Since: time.Now().Add(-time.Hour)},
+			expect:   []string{"192.0.2.1", "192.0.2.1"},
+		},
+		{
+			name:     "1-5: 正常系：取得件数を指定した場合",
+			attempts: []attempt{failureA, failureA, failureA},
+			input:    repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour), Limit: 2},
+			expect:   []string{"192.0.2.1", "192.0.2.1"},
+		},
+		{
+			name:     "1-6: 正常系：集計開始日時より前の失敗は含めない場合",
+			attempts: []attempt{failureA},
+			input:    repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(time.Hour)},
+			expect:   []string{},
+		},
+		{
+			name:        "1-7: 正常系：処理中の試行を失敗として返却し、除外IDの試行は含めない場合",
+			attempts:    []attempt{failureA, {"oem_a@example.com", "192.0.2.2", authentication.LoginAttemptResultPending}, {"oem_a@example.com", "192.0.2.3", authentication.LoginAttemptResultCanceled}, {"oem_a@example.com", "192.0.2.4", authentication.LoginAttemptResultPending}},
+			input:       repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour)},
+			excludeLast: true,
+			expect:      []string{"192.0.2.2", "192.0.2.1"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				var lastID string
+				for _, a := range test.attempts {
+					if lastID, err = r.CreateLoginAttempt(repository.CreateLoginAttemptParam{Email: a.email, IPAddress: a.ip, Result: a.result}); !assert.NoError(t, err) {
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+				if test.excludeLast {
+					test.input.ExcludeID = lastID
+				}
+
+				actual, err := r.ListLoginFailures(test.input)
+				if assert.NoError(t, err) {
+					ips := make([]string, len(actual))
+					for i, failure := range actual {
+						assert.Contains(t, []authentication.LoginAttemptResult{authentication.LoginAttemptResultFailure, authentication.LoginAttemptResultPending}, failure.Result)
+						ips[i] = failure.IPAddress
+					}
+					assert.Equal(t, test.expect, ips)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth UpdateLoginAttemptResult テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：処理中の試行の結果を記録する場合
+// [x] 1-2: 正常系：結果が記録済みの試行は更新しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_UpdateLoginAttemptResult(tt *testing.T) {
+
+	tests := []struct {
+		name    string
+		results []authentication.LoginAttemptResult
+		expect  int
+	}{
+		{
+			name:    "1-1: 正常系：処理中の試行の結果を記録する場合",
+			results: []authentication.LoginAttemptResult{authentication.LoginAttemptResultCanceled},
+			expect:  0,
+		},
+		{
+			name:    "1-2: 正常系：結果が記録済みの試行は更新しない場合",
+			results: []authentication.LoginAttemptResult{authentication.LoginAttemptResultCanceled, authentication.LoginAttemptResultFailure},
+			expect:  0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				id, err := r.CreateLoginAttempt(repository.CreateLoginAttemptParam{Email: "oem_a@example.com", IPAddress: "192.0.2.1", Result: authentication.LoginAttemptResultPending})
+				if !assert.NoError(t, err) {
+					return
+				}
+				for _, result := range test.results {
+					if err := r.UpdateLoginAttemptResult(repository.UpdateLoginAttemptResultParam{ID: id, Result: result}); !assert.NoError(t, err) {
+						return
+					}
+				}
+
+				actual, err := r.ListLoginFailures(repository.LoginFailuresParam{Email: "oem_a@example.com", Since: time.Now().Add(-time.Hour)})
+				if assert.NoError(t, err) {
+					assert.Len(t, actual, test.expect)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth SaveMFACredential / GetMFACredential / DeleteMFACredential テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：作成したクレデンシャルを返却(メールアドレスの大文字小文字を区別しない)
+// [x] 1-2: 正常系：更新したクレデンシャルを返却
+// [x] 2-1: 異常系：削除したクレデンシャルの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_MFACredential(tt *testing.T) {
+
+	tests := []struct {
+		name        string
+		update      bool
+		delete      bool
+		expectError error
+		expect      authentication.MFACredential
+	}{
+		{
+			name:   "1-1: 正常系：作成したクレデンシャルを返却(メールアドレスの大文字小文字を区別しない)",
+			expect: authentication.MFACredential{Email: "oem_a@example.com", Secret: "encrypted", RecoveryCodeHashes: []string{}},
+		},
+		{
+			name:   "1-2: 正常系：更新したクレデンシャルを返却",
+			update: true,
+			expect: authentication.MFACredential{Email: "oem_a@example.com", Secret: "encrypted", Enabled: true, LastUsedStep: 100, RecoveryCodeHashes: []string{"hash1", "hash2"}},
+		},
+		{
+			name:        "2-1: 異常系：削除したクレデンシャルの場合",
+			delete:      true,
+			expectError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				credential := authentication.MFACredential{Email: "OEM_A@example.com", Secret: "encrypted", RecoveryCodeHashes: []string{}}
+				if err := r.SaveMFACredential(credential); !assert.NoError(t, err) {
+					return
+				}
+				if test.update {
+					credential, err = r.GetMFACredential("oem_a@example.com")
+					if !assert.NoError(t, err) {
+						return
+					}
+					credential.Enabled = true
+					credential.LastUsedStep = 100
+					credential.RecoveryCodeHashes = []string{"hash1", "hash2"}
+					if err := r.SaveMFACredential(credential); !assert.NoError(t, err) {
+						return
+					}
+				}
+				if test.delete {
+					if err := r.DeleteMFACredential("OEM_A@example.com"); !assert.NoError(t, err) {
+						return
+					}
+				}
+
+				actual, err := r.GetMFACredential("OEM_A@example.com")
+				if test.expectError != nil {
+					assert.ErrorIs(t, err, test.expectError)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.NotEmpty(t, actual.ID)
+					assert.Equal(t, test.expect.Email, actual.Email)
+					assert.Equal(t, test.expect.Secret, actual.Secret)
+					assert.Equal(t, test.expect.Enabled, actual.Enabled)
+					assert.Equal(t, test.expect.LastUsedStep, actual.LastUsedStep)
+					assert.Equal(t, test.expect.RecoveryCodeHashes, actual.RecoveryCodeHashes)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateMFAChallenge / GetMFAChallenge / IncrementMFAChallengeAttempts / DeleteMFAChallenge テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：作成したチャレンジを返却
+// [x] 1-2: 正常系：失敗回数を加算した場合
+// [x] 2-1: 異常系：削除したチャレンジの場合
+// [x] 2-2: 異常系：期限切れのチャレンジは作成時に削除される場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_MFAChallenge(tt *testing.T) {
+
+	tests := []struct {
+		name           string
+		expiresAt      time.Time
+		increment      bool
+		delete         bool
+		expectError    error
+		expectAttempts int
+	}{
+		{
+			name:      "1-1: 正常系：作成したチャレンジを返却",
+			expiresAt: time.Now().Add(time.Minute),
+		},
+		{
+			name:           "1-2: 正常系：失敗回数を加算した場合",
+			expiresAt:      time.Now().Add(time.Minute),
+			increment:      true,
+			expectAttempts: 2,
+		},
+		{
+			name:        "2-1: 異常系：削除したチャレンジの場合",
+			expiresAt:   time.Now().Add(time.Minute),
+			delete:      true,
+			expectError: gorm.ErrRecordNotFound,
+		},
+		{
+			name:        "2-2: 異常系：期限切れのチャレンジは作成時に削除される場合",
+			expiresAt:   time.Now().Add(-time.Minute),
+			expectError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				challenge := authentication.MFAChallenge{Email: "OEM_A@example.com", TokenHash: "hash", AccessToken: "access", RefreshToken: "refresh", ExpiresAt: test.expiresAt}
+				if err := r.CreateMFAChallenge(challenge); !assert.NoError(t, err) {
+					return
+				}
+				// 次のチャレンジ作成時に期限切れのチャレンジが削除される
+				if err := r.CreateMFAChallenge(authentication.MFAChallenge{Email: "oem_b@example.com", TokenHash: "other", AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Minute)}); !assert.NoError(t, err) {
+					return
+				}
+				created, err := r.GetMFAChallenge("hash")
+				if test.expectError != nil && !test.delete {
+					assert.ErrorIs(t, err, test.expectError)
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+				if test.increment {
+					for i := 0; i < 2; i++ {
+						if err := r.IncrementMFAChallengeAttempts(created.ID); !assert.NoError(t, err) {
+							return
+						}
+					}
+				}
+				if test.delete {
+					if err := r.DeleteMFAChallenge(created.ID); !assert.NoError(t, err) {
+						return
+					}
+				}
+
+				actual, err := r.GetMFAChallenge("hash")
+				if test.expectError != nil {
+					assert.ErrorIs(t, err, test.expectError)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, "oem_a@example.com", actual.Email)
+					assert.Equal(t, "access", actual.AccessToken)
+					assert.Equal(t, "refresh", actual.RefreshToken)
+					assert.Equal(t, test.expectAttempts, actual.Attempts)
+					assert.False(t, actual.Expired(time.Now()))
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth ListAPIKeyPermissions テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：APIキーに紐づく権限のみ返却(論理削除済みは含めない)
+// [x] 1-2: 正常系：権限が登録されていないAPIキーの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_ListAPIKeyPermissions(tt *testing.T) {
+
+	tests := []struct {
+		name   string
+		input  string
+		expect authentication.APIKeyPermissions
+	}{
+		{
+			name:  "1-1: 正常系：APIキーに紐づく権限のみ返却(論理削除済みは含めない)",
+			input: "00000000-0000-0000-0000-000000000001",
+			expect: authentication.APIKeyPermissions{
+				{APIKeyID: "00000000-0000-0000-0000-000000000001", Method: "GET", Path: "/api/v1/authInfo"},
+			},
+		},
+		{
+			name:   "1-2: 正常系：権限が登録されていないAPIキーの場合",
+			input:  "00000000-0000-0000-0000-000000000002",
+			expect: authentication.APIKeyPermissions{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				queries := []string{
+					// the permissions of every route granted by the seeders are revoked
+					`UPDATE api_key_permissions SET deleted_at = '2024-05-02 00:00:00' WHERE method = '*' AND path = '*'`,
+					`INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES ('00000000-0000-0000-0000-000000000001', 'GET', '/api/v1/authInfo', NULL, '2024-05-01 00:00:00', 'seed', '2024-05-01 00:00:00', 'seed')`,
+					`INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES ('00000000-0000-0000-0000-000000000001', 'PUT', '/dataReset', '2024-05-02 00:00:00', '2024-05-01 00:00:00', 'seed', '2024-05-01 00:00:00', 'seed')`,
+				}
+				for _, query := range queries {
+					if err := db.Exec(query).Error; !assert.NoError(t, err) {
+						return
+					}
+				}
+
+				actual, err := r.ListAPIKeyPermissions(repository.APIKeyPermissionsParam{APIKeyID: &test.input})
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateOAuthClient / GetOAuthClient テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：登録したクライアントを返却
+// [x] 2-1: 異常系：クライアントが存在しない場合
+// [x] 2-2: 異常系：クライアントが論理削除済みの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_OAuthClient(tt *testing.T) {
+
+	tests := []struct {
+		name      string
+		input     string
+		expectErr error
+	}{
+		{
+			name:  "1-1: 正常系：登録したクライアントを返却",
+			input: "client-1",
+		},
+		{
+			name:      "2-1: 異常系：クライアントが存在しない場合",
+			input:     "client-unknown",
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:      "2-2: 異常系：クライアントが論理削除済みの場合",
+			input:     "client-deleted",
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				for _, clientID := range []string{"client-1", "client-deleted"} {
+					err := r.CreateOAuthClient(authentication.OAuthClient{
+						ClientID:         clientID,
+						ClientSecretHash: "hash",
+						APIKeyID:         "00000000-0000-0000-0000-000000000001",
+						Scopes:           []string{authentication.OAuthScopeSystemAuth},
+					})
+					if !assert.NoError(t, err) {
+						return
+					}
+				}
+				if err := db.Exec(`UPDATE oauth_clients SET deleted_at = '2024-05-02 00:00:00' WHERE client_id = 'client-deleted'`).Error; !assert.NoError(t, err) {
+					return
+				}
+
+				actual, err := r.GetOAuthClient(test.input)
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.input, actual.ClientID)
+					assert.Equal(t, "hash", actual.ClientSecretHash)
+					assert.Equal(t, "00000000-0000-0000-0000-000000000001", actual.APIKeyID)
+					assert.Equal(t, []string{authentication.OAuthScopeSystemAuth}, actual.Scopes)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateAuthEvent / ListAuthEvents テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：全てのイベントを新しい順に返却
+// [x] 1-2: 正常系：事業者IDとイベント種別で絞り込む場合
+// [x] 1-3: 正常系：カーソル以降のイベントを返却
+// [x] 1-4: 正常系：期間外のイベントは含めない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_AuthEvents(tt *testing.T) {
+
+	operatorID := "b39e6248-c888-56ca-d9d0-89de1b1adc8e"
+	apiKeyID := "00000000-0000-0000-0000-000000000001"
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		inputFunc   func(events authentication.AuthEvents) repository.AuthEventsParam
+		expectCount int
+		expectFirst string
+	}{
+		{
+			name: "1-1: 正常系：全てのイベントを新しい順に返却",
+			inputFunc: func(events authentication.AuthEvents) repository.AuthEventsParam {
+				return repository.AuthEventsParam{}
+			},
+			expectCount: 3,
+			expectFirst: "operatorLogout",
+		},
+		{
+			name: "1-2: 正常系：事業者IDとイベント種別で絞り込む場合",
+			inputFunc: func(events authentication.AuthEvents) repository.AuthEventsParam {
+				return repository.AuthEventsParam{OperatorID: &operatorID, Events: []string{"operatorLogin"}}
+			},
+			expectCount: 1,
+			expectFirst: "operatorLogin",
+		},
+		{
+			name: "1-3: 正常系：カーソル以降のイベントを返却",
+			inputFunc: func(events authentication.AuthEvents) repository.AuthEventsParam {
+				cursor := authentication.NewAuthEventCursor(events[0])
+				return repository.AuthEventsParam{After: &cursor, Limit: 1}
+			},
+			expectCount: 1,
+			expectFirst: "apiKey",
+		},
+		{
+			name: "1-4: 正常系：期間外のイベントは含めない場合",
+			inputFunc: func(events authentication.AuthEvents) repository.AuthEventsParam {
+				return repository.AuthEventsParam{From: &future}
+			},
+			expectCount: 0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				params := []repository.CreateAuthEventParam{
+					{Event: "operatorLogin", Result: false, ReasonCode: "Invalid credentials", IPAddress: "127.0.0.1", APIKeyID: &apiKeyID, OperatorID: &operatorID},
+					{Event: "apiKey", Result: true, IPAddress: "127.0.0.1"},
+					{Event: "operatorLogout", Result: true, IPAddress: "127.0.0.1", OperatorID: &operatorID},
+				}
+				for _, param := range params {
+					if err := r.CreateAuthEvent(param); !assert.NoError(t, err) {
+						return
+					}
+					// the events are ordered by the occurred time
+					time.Sleep(time.Millisecond)
+				}
+				all, err := r.ListAuthEvents(repository.AuthEventsParam{})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				actual, err := r.ListAuthEvents(test.inputFunc(all))
+				if assert.NoError(t, err) {
+					assert.Len(t, actual, test.expectCount)
+					if test.expectCount > 0 {
+						assert.Equal(t, test.expectFirst, actual[0].Event)
+					}
+				}
+
+				// the API key is recorded as its ID
+				if assert.NotNil(t, all[2].APIKeyID) {
+					assert.Equal(t, apiKeyID, *all[2].APIKeyID)
+				}
+				assert.Nil(t, all[1].APIKeyID)
+				assert.Equal(t, "Invalid credentials", all[2].ReasonCode)
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateAPIKey / GetAPIKey / UpdateAPIKey / DeleteAPIKey テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：作成したAPIキーを返却
+// [x] 1-2: 正常系：アプリケーション名と属性を変更した場合
+// [x] 1-3: 正常系：リクエスト数の上限を変更した場合
+// [x] 1-4: 正常系：APIキー管理権限を付与した場合
+// [x] 2-1: 異常系：論理削除したAPIキーは取得できず、一覧にも含めない場合
+// [x] 2-2: 異常系：存在しないAPIキーを変更する場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_APIKey(tt *testing.T) {
+
+	id := "00000000-0000-0000-0000-000000000010"
+	applicationName := "Renamed-Application"
+	attribute := authentication.ApplicationAttributeTraceability
+	rateLimitPerMinute, rateLimitBurst := 60, 0
+	isAdmin := true
+
+	tests := []struct {
+		name            string
+		update          *repository.UpdateAPIKeyParam
+		delete          bool
+		expectName      string
+		expectAttribute authentication.ApplicationAttribute
+		expectRateLimit authentication.APIKeyRateLimit
+		expectAdmin     bool
+		expectErr       error
+	}{
+		{
+			name:            "1-1: 正常系：作成したAPIキーを返却",
+			expectName:      "New-Application",
+			expectAttribute: authentication.ApplicationAttributeDataSpace,
+		},
+		{
+			name:            "1-2: 正常系：アプリケーション名と属性を変更した場合",
+			update:          &repository.UpdateAPIKeyParam{ID: id, ApplicationName: &applicationName, Attribute: &attribute, UserID: "updater"},
+			expectName:      applicationName,
+			expectAttribute: attribute,
+		},
+		{
+			name: "1-3: 正常系：リクエスト数の上限を変更した場合",
+			update: &repository.UpdateAPIKeyParam{
+				ID:        id,
+				RateLimit: authentication.APIKeyRateLimit{RequestsPerMinute: &rateLimitPerMinute, Burst: &rateLimitBurst},
+				UserID:    "updater",
+			},
+			expectName:      "New-Application",
+			expectAttribute: authentication.ApplicationAttributeDataSpace,
+			expectRateLimit: authentication.APIKeyRateLimit{RequestsPerMinute: &rateLimitPerMinute, Burst: &rateLimitBurst},
+		},
+		{
+			name:            "1-4: 正常系：APIキー管理権限を付与した場合",
+			update:          &repository.UpdateAPIKeyParam{ID: id, IsAdmin: &isAdmin, UserID: "updater"},
+			expectName:      "New-Application",
+			expectAttribute: authentication.ApplicationAttributeDataSpace,
+			expectAdmin:     true,
+		},
+		{
+			name:      "2-1: 異常系：論理削除したAPIキーは取得できず、一覧にも含めない場合",
+			delete:    true,
+			expectErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:      "2-2: 異常系：存在しないAPIキーを変更する場合",
+			update:    &repository.UpdateAPIKeyParam{ID: "00000000-0000-0000-0000-000000000099", ApplicationName: &applicationName, UserID: "updater"},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				err = r.CreateAPIKey(authentication.APIKey{
+					ID:              id,
+					KeyPrefix:       authentication.APIKeyPrefix("New-APIKey"),
+					KeyDigest:       authentication.DigestAPIKey("New-APIKey"),
+					ApplicationName: "New-Application",
+					Attribute:       authentication.ApplicationAttributeDataSpace,
+					CreatedUserID:   "creator",
+					UpdatedUserID:   "creator",
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				if test.update != nil {
+					err = r.UpdateAPIKey(*test.update)
+				}
+				if test.delete {
+					if !assert.NoError(t, r.DeleteAPIKey(repository.DeleteAPIKeyParam{ID: id, UserID: "updater"})) {
+						return
+					}
+					_, err = r.GetAPIKey(id)
+
+					prefix := authentication.APIKeyPrefix("New-APIKey")
+					apiKeys, listErr := r.ListAPIKeys(repository.APIKeysParam{KeyPrefix: &prefix})
+					if assert.NoError(t, listErr) {
+						assert.Empty(t, apiKeys)
+					}
+				}
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				actual, err := r.GetAPIKey(id)
+				if assert.NoError(t, err) {
+					assert.True(t, actual.Matches("New-APIKey"))
+					assert.Equal(t, test.expectName, actual.ApplicationName)
+					assert.Equal(t, test.expectAttribute, actual.Attribute)
+					assert.Equal(t, test.expectRateLimit, actual.RateLimit)
+					assert.Equal(t, test.expectAdmin, actual.IsAdmin)
+					assert.Equal(t, "creator", actual.CreatedUserID)
+					if test.update != nil {
+						assert.Equal(t, "updater", actual.UpdatedUserID)
+					} else {
+						assert.Equal(t, "creator", actual.UpdatedUserID)
+					}
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth UpdateAPIKeySigningSecret テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：署名シークレットを設定した場合
+// [x] 1-2: 正常系：署名シークレットを削除した場合
+// [x] 2-1: 異常系：存在しないAPIキーの場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_UpdateAPIKeySigningSecret(tt *testing.T) {
+
+	id := "00000000-0000-0000-0000-000000000010"
+	secret := "encrypted-secret"
+
+	tests := []struct {
+		name      string
+		steps     []*string
+		id        string
+		expect    *string
+		expectErr error
+	}{
+		{
+			name:   "1-1: 正常系：署名シークレットを設定した場合",
+			steps:  []*string{&secret},
+			id:     id,
+			expect: &secret,
+		},
+		{
+			name:  "1-2: 正常系：署名シークレットを削除した場合",
+			steps: []*string{&secret, nil},
+			id:    id,
+		},
+		{
+			name:      "2-1: 異常系：存在しないAPIキーの場合",
+			steps:     []*string{&secret},
+			id:        "00000000-0000-0000-0000-000000000099",
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				err = r.CreateAPIKey(authentication.APIKey{
+					ID:              id,
+					KeyPrefix:       authentication.APIKeyPrefix("New-APIKey"),
+					KeyDigest:       authentication.DigestAPIKey("New-APIKey"),
+					ApplicationName: "New-Application",
+					Attribute:       authentication.ApplicationAttributeDataSpace,
+					CreatedUserID:   "creator",
+					UpdatedUserID:   "creator",
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				for _, step := range test.steps {
+					err = r.UpdateAPIKeySigningSecret(repository.UpdateAPIKeySigningSecretParam{ID: test.id, SigningSecret: step, UserID: "updater"})
+				}
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				actual, err := r.GetAPIKey(id)
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual.SigningSecret)
+					assert.Equal(t, test.expect != nil, actual.RequiresSignature())
+					assert.Equal(t, "updater", actual.UpdatedUserID)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth RotateAPIKey テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：後継のAPIキーが事業者・CIDR・権限・OAuthクライアントを引き継ぎ、ローテーション前のAPIキーに有効期限を設定
+// [x] 2-1: 異常系：存在しないAPIキーをローテーションする場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_RotateAPIKey(tt *testing.T) {
+
+	successorID := "00000000-0000-0000-0000-000000000010"
+	expiresAt := time.Date(2030, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		input     string
+		expectErr error
+	}{
+		{
+			name:  "1-1: 正常系：後継のAPIキーが事業者・CIDR・権限・OAuthクライアントを引き継ぎ、ローテーション前のAPIキーに有効期限を設定",
+			input: "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:      "2-1: 異常系：存在しないAPIキーをローテーションする場合",
+			input:     "00000000-0000-0000-0000-000000000099",
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+
+				queries := []string{
+					// the permissions of every route granted by the seeders are revoked
+					`UPDATE api_key_permissions SET deleted_at = '2024-05-02 00:00:00' WHERE method = '*' AND path = '*'`,
+					`INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES ('00000000-0000-0000-0000-000000000001', 'GET', '/api/v1/authInfo', NULL, '2024-05-01 00:00:00', 'seed', '2024-05-01 00:00:00', 'seed')`,
+				}
+				for _, query := range queries {
+					if err := db.Exec(query).Error; !assert.NoError(t, err) {
+						return
+					}
+				}
+				err = r.CreateOAuthClient(authentication.OAuthClient{
+					ClientID:         "client-1",
+					ClientSecretHash: "hash",
+					APIKeyID:         "00000000-0000-0000-0000-000000000001",
+					Scopes:           []string{authentication.OAuthScopeSystemAuth},
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+				err = r.CreateClientCertificate(repository.APIKeyClientCertificateParam{
+					APIKeyID:    "00000000-0000-0000-0000-000000000001",
+					Type:        authentication.ClientCertificateTypeSubject,
+					Certificate: "CN=client",
+					UserID:      "creator",
+				})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				err = r.RotateAPIKey(repository.RotateAPIKeyParam{
+					ID:        test.input,
+					ExpiresAt: expiresAt,
+					Successor: authentication.APIKey{
+						ID:              successorID,
+						KeyPrefix:       authentication.APIKeyPrefix("New-APIKey"),
+						KeyDigest:       authentication.DigestAPIKey("New-APIKey"),
+						ApplicationName: "application",
+						Attribute:       authentication.ApplicationAttributeApplication,
+						NotBefore:       time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC),
+						CreatedUserID:   "rotator",
+						UpdatedUserID:   "rotator",
+					},
+					UserID: "rotator",
+				})
+				if test.expectErr != nil {
+					assert.ErrorIs(t, err, test.expectErr)
+
+					// the successor is not created when the rotation fails
+					_, err = r.GetAPIKey(successorID)
+					assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+					return
+				}
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				previous, err := r.GetAPIKey(test.input)
+				if assert.NoError(t, err) && assert.NotNil(t, previous.ExpiresAt) {
+					assert.True(t, expiresAt.Equal(*previous.ExpiresAt))
+					assert.Equal(t, "rotator", previous.UpdatedUserID)
+				}
+				successor, err := r.GetAPIKey(successorID)
+				if assert.NoError(t, err) {
+					assert.True(t, successor.Matches("New-APIKey"))
+					assert.Nil(t, successor.ExpiresAt)
+				}
+
+				operators, err := r.ListAPIKeyOperators(repository.APIKeyOperatorsParam{APIKeyID: &successorID})
+				if assert.NoError(t, err) {
+					assert.Equal(t, []string{"b39e6248-c888-56ca-d9d0-89de1b1adc8e"}, operators.GetOperatorIds())
+				}
+				cidrs, err := r.ListCidrs(repository.APIKeyCidrsParam{APIKeyID: &successorID})
+				if assert.NoError(t, err) {
+					assert.Equal(t, authentication.Cidrs{{APIKeyID: successorID, Cidr: "0.0.0.0/0", Action: authentication.CidrActionAllow, Priority: authentication.DefaultCidrPriority}}, cidrs)
+				}
+				permissions, err := r.ListAPIKeyPermissions(repository.APIKeyPermissionsParam{APIKeyID: &successorID})
+				if assert.NoError(t, err) {
+					assert.Equal(t, authentication.APIKeyPermissions{{APIKeyID: successorID, Method: "GET", Path: "/api/v1/authInfo"}}, permissions)
+				}
+				client, err := r.GetOAuthClient("client-1")
+				if assert.NoError(t, err) {
+					assert.Equal(t, successorID, client.APIKeyID)
+				}
+				certificates, err := r.ListClientCertificates(repository.APIKeyClientCertificatesParam{APIKeyID: &successorID})
+				if assert.NoError(t, err) {
+					assert.Equal(t, authentication.ClientCertificates{{Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client", APIKeyID: successorID}}, certificates)
+				}
+			},
+		)
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// Auth CreateAPIKeyOperator / DeleteAPIKeyOperator / CreateCidr / DeleteCidr / CreateClientCertificate / DeleteClientCertificate / CreateAPIKeyPermission / DeleteAPIKeyPermission テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：事業者とCIDRとクライアント証明書と権限を追加した場合
+// [x] 1-2: 正常系：削除した事業者とCIDRとクライアント証明書と権限を再追加した場合、復元する
+// [x] 2-1: 異常系：追加されていない事業者とCIDRとクライアント証明書と権限を削除する場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_Auth_APIKeyBindings(tt *testing.T) {
+
+	apiKeyID := "00000000-0000-0000-0000-000000000002"
+	operatorID := "00000000-0000-0000-0000-0000000000aa"
+	cidr := "10.0.0.0/8"
+
+	tests := []struct {
+		name      string
+		steps     []string
+		expectErr error
+	}{
+		{
+			name:  "1-1: 正常系：事業者とCIDRとクライアント証明書と権限を追加した場合",
+			steps: []string{"create"},
+		},
+		{
+			name:  "1-2: 正常系：削除した事業者とCIDRとクライアント証明書と権限を再追加した場合、復元する",
+			steps: []string{"create", "delete", "create"},
+		},
+		{
+			name:      "2-1: 異常系：追加されていない事業者とCIDRとクライアント証明書と権限を削除する場合",
+			steps:     []string{"delete"},
+			expectErr: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				db, err := testhelper.NewMockDB()
+				if err != nil {
+					assert.Fail(t, err.Error())
+				}
+				r := datastore.NewAuthRepository(db)
+				operatorParam := repository.APIKeyOperatorParam{APIKeyID: apiKeyID, OperatorID: operatorID, UserID: "updater"}
+				cidrParam := repository.APIKeyCidrParam{APIKeyID: apiKeyID, Cidr: cidr, Action: authentication.CidrActionDeny, Priority: 10, UserID: "updater"}
+				certificateParam := repository.APIKeyClientCertificateParam{APIKeyID: apiKeyID, Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client", UserID: "updater"}
+				permissionParam := repository.APIKeyPermissionParam{APIKeyID: apiKeyID, Method: "PUT", Path: "/dataReset", UserID: "updater"}
+
+				var operatorErr, cidrErr, certificateErr, permissionErr error
+				for _, step := range test.steps {
+					if step == "create" {
+						operatorErr = r.CreateAPIKeyOperator(operatorParam)
+						cidrErr = r.CreateCidr(cidrParam)
+						certificateErr = r.CreateClientCertificate(certificateParam)
+						permissionErr = r.CreateAPIKeyPermission(permissionParam)
+					} else {
+						operatorErr = r.DeleteAPIKeyOperator(operatorParam)
+						cidrErr = r.DeleteCidr(cidrParam)
+						certificateErr = r.DeleteClientCertificate(certificateParam)
+						permissionErr = r.DeleteAPIKeyPermission(permissionParam)
+					}
+				}
+				if test.expectErr != nil {
+					assert.ErrorIs(t, operatorErr, test.expectErr)
+					assert.ErrorIs(t, cidrErr, test.expectErr)
+					assert.ErrorIs(t, certificateErr, test.expectErr)
+					assert.ErrorIs(t, permissionErr, test.expectErr)
+					return
+				}
+				if !assert.NoError(t, operatorErr) || !assert.NoError(t, cidrErr) || !assert.NoError(t, certificateErr) || !assert.NoError(t, permissionErr) {
+					return
+				}
+
+				operators, err := r.ListAPIKeyOperators(repository.APIKeyOperatorsParam{APIKeyID: &apiKeyID})
+				if assert.NoError(t, err) {
+					assert.Contains(t, operators.GetOperatorIds(), operatorID)
+				}
+				cidrs, err := r.ListCidrs(repository.APIKeyCidrsParam{APIKeyID: &apiKeyID})
+				if assert.NoError(t, err) {
+					assert.Contains(t, cidrs, &authentication.Cidr{Cidr: cidr, APIKeyID: apiKeyID, Action: authentication.CidrActionDeny, Priority: 10})
+				}
+				certificates, err := r.ListClientCertificates(repository.APIKeyClientCertificatesParam{APIKeyID: &apiKeyID})
+				if assert.NoError(t, err) {
+					assert.Equal(t, authentication.ClientCertificates{{Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client", APIKeyID: apiKeyID}}, certificates)
+				}
+				permissions, err := r.ListAPIKeyPermissions(repository.APIKeyPermissionsParam{APIKeyID: &apiKeyID})
+				if assert.NoError(t, err) {
+					assert.Contains(t, permissions, authentication.APIKeyPermission{APIKeyID: apiKeyID, Method: "PUT", Path: "/dataReset"})
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,82 @@
+package datastore
+
+import (
+	"sync"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"gorm.io/gorm"
+)
+
+// nonceStoreSweepInterval is the interval the expired nonces are removed
+const nonceStoreSweepInterval = time.Minute
+
+// nonceStore
+// Summary: This is structure which defines the nonces of the signed requests stored in the database.
+// The nonces are shared by all the instances, so the request replayed to another instance is rejected as well.
+type nonceStore struct {
+	db      *gorm.DB
+	mu      sync.Mutex
+	sweptAt time.Time
+}
+
+// NewNonceStore
+// Summary: This is the function which creates the nonces of the signed requests stored in the database.
+// input: db(*gorm.DB): database connection the nonces are stored in
+// output: (repository.NonceStore) nonce store
+func NewNonceStore(db *gorm.DB) repository.NonceStore {
+	return &nonceStore{db: db}
+}
+
+// Use
+// Summary: This is the function which remembers the nonce until it expires.
+// The nonce which has expired is used again, and it is checked and remembered in a statement so that the concurrent requests can not use the same nonce.
+// input: key(string): nonce qualified by the ID of the API key
+// input: expiresAt(time.Time): time until which the nonce is remembered
+// input: now(time.Time): time of the request
+// output: (bool) true if the nonce has not been used, false otherwise
+// output: (error) error object
+func (s *nonceStore) Use(key string, expiresAt time.Time, now time.Time) (bool, error) {
+	if s.sweeps(now) {
+		s.sweep(now)
+	}
+
+	result := s.db.Exec(
+		"INSERT INTO api_key_signature_nonces (nonce_key, expires_at) VALUES (?, ?) "+
+			"ON CONFLICT (nonce_key) DO UPDATE SET expires_at = excluded.expires_at WHERE api_key_signature_nonces.expires_at <= ?",
+		key, expiresAt.UTC(), now.UTC(),
+	)
+	if result.Error != nil {
+		logger.Set(nil).Errorf(result.Error.Error())
+
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// sweeps
+// Summary: This is the function which checks whether the expired nonces are removed at the time.
+// input: now(time.Time): current time
+// output: (bool) true if the expired nonces are removed, false otherwise
+func (s *nonceStore) sweeps(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) < nonceStoreSweepInterval {
+		return false
+	}
+	s.sweptAt = now
+	return true
+}
+
+// sweep
+// Summary: This is the function which removes the expired nonces.
+// The nonces are removed by every instance, and the failure is retried at the next sweep.
+// input: now(time.Time): current time
+func (s *nonceStore) sweep(now time.Time) {
+	if err := s.db.Exec("DELETE FROM api_key_signature_nonces WHERE expires_at <= ?", now.UTC()).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+	}
+}
@@ -0,0 +1,83 @@
+package datastore_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/infrastructure/persistence/datastore"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NonceStore テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：キーごとにノンスを記録する
+// [x] 1-2: 正常系：有効期限を過ぎたノンスは再度使用できる
+// [x] 2-1: 異常系：有効期限内に同じノンスを使用した場合
+// [x] 2-2: 異常系：他のインスタンスで使用されたノンスを使用した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_NonceStore(tt *testing.T) {
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(5 * time.Minute)
+
+	tests := []struct {
+		name   string
+		uses   []string
+		times  []time.Time
+		second bool
+		expect []bool
+	}{
+		{
+			name:   "1-1: 正常系：キーごとにノンスを記録する",
+			uses:   []string{"key1:nonce", "key2:nonce"},
+			times:  []time.Time{now, now},
+			expect: []bool{true, true},
+		},
+		{
+			name:   "1-2: 正常系：有効期限を過ぎたノンスは再度使用できる",
+			uses:   []string{"key1:nonce", "key1:nonce"},
+			times:  []time.Time{now, expiresAt},
+			expect: []bool{true, true},
+		},
+		{
+			name:   "2-1: 異常系：有効期限内に同じノンスを使用した場合",
+			uses:   []string{"key1:nonce", "key1:nonce"},
+			times:  []time.Time{now, now.Add(time.Minute)},
+			expect: []bool{true, false},
+		},
+		{
+			name:   "2-2: 異常系：他のインスタンスで使用されたノンスを使用した場合",
+			uses:   []string{"key1:nonce", "key1:nonce"},
+			times:  []time.Time{now, now.Add(time.Minute)},
+			second: true,
+			expect: []bool{true, false},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, err := testhelper.NewMockDB()
+			if err != nil {
+				assert.Fail(t, err.Error())
+			}
+			stores := []repository.NonceStore{datastore.NewNonceStore(db), datastore.NewNonceStore(db)}
+			for i, key := range test.uses {
+				store := stores[0]
+				if test.second && i > 0 {
+					store = stores[1]
+				}
+				actual, err := store.Use(key, test.times[i].Add(5*time.Minute), test.times[i])
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect[i], actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package datastore
+
+import (
+	"sync"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// rateLimiterSweepInterval is the interval the idle buckets and the daily usages of the past days are removed
+	rateLimiterSweepInterval = time.Hour
+	// rateLimiterIdleAfter is the period after which the bucket is removed.
+	// the bucket idle for a day is full, so it is the same as the new one
+	rateLimiterIdleAfter = 24 * time.Hour
+	// quotaDayFormat is the format of the day the daily usage is counted in
+	quotaDayFormat = "2006-01-02"
+)
+
+// rateLimiter
+// Summary: This is structure which defines the buckets of the requests with the API keys.
+// The tokens are counted in memory, so the limit per minute applies to each instance separately.
+// The daily usage is counted in the database, so the daily quota is shared by all the instances and survives the restart.
+type rateLimiter struct {
+	db      *gorm.DB
+	mu      sync.Mutex
+	buckets map[string]authentication.RateLimitBucket
+	sweptAt time.Time
+}
+
+// NewRateLimiter
+// Summary: This is the function which creates the buckets of the requests with the API keys.
+// input: db(*gorm.DB): database connection the daily usages are counted in
+// output: (repository.RateLimiter) rate limiter
+func NewRateLimiter(db *gorm.DB) repository.RateLimiter {
+	return &rateLimiter{db: db, buckets: map[string]authentication.RateLimitBucket{}}
+}
+
+// Take
+// Summary: This is the function which counts the request in the bucket and the daily usage of the key.
+// The daily usage is counted only when the request is accepted by the tokens, and the token is refunded when the daily quota has been used up.
+// input: key(string): key of the bucket
+// input: limit(authentication.RateLimit): limit of the API key
+// input: now(time.Time): time of the request
+// output: (authentication.RateLimitStatus) status of the request
+// output: (error) error object
+func (r *rateLimiter) Take(key string, limit authentication.RateLimit, now time.Time) (authentication.RateLimitStatus, error) {
+	status, swept := r.take(key, limit, now)
+	if swept {
+		r.deletePastDailyUsages(now)
+	}
+	if !status.Allowed || limit.DailyQuota <= 0 {
+		return status, nil
+	}
+
+	used, err := r.countDailyUsage(key, limit.DailyQuota, now)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.RateLimitStatus{}, err
+	}
+	exceeded := used == 0
+	if exceeded {
+		r.refund(key, limit)
+		used = limit.DailyQuota
+	}
+	return status.WithQuota(limit, used, exceeded, now), nil
+}
+
+// take
+// Summary: This is the function which takes a token from the in-memory bucket of the key.
+// input: key(string): key of the bucket
+// input: limit(authentication.RateLimit): limit of the API key
+// input: now(time.Time): time of the request
+// output: (authentication.RateLimitStatus) status of the request
+// output: (bool) true if the idle buckets have been removed
+func (r *rateLimiter) take(key string, limit authentication.RateLimit, now time.Time) (authentication.RateLimitStatus, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	swept := now.Sub(r.sweptAt) >= rateLimiterSweepInterval
+	if swept {
+		r.sweep(now)
+	}
+
+	bucket, status := r.buckets[key].Take(limit, now)
+	r.buckets[key] = bucket
+	return status, swept
+}
+
+// refund
+// Summary: This is the function which returns the token to the in-memory bucket of the key.
+// input: key(string): key of the bucket
+// input: limit(authentication.RateLimit): limit of the API key
+func (r *rateLimiter) refund(key string, limit authentication.RateLimit) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buckets[key] = r.buckets[key].Refund(limit)
+}
+
+// countDailyUsage
+// Summary: This is the function which counts the request in the daily usage of the key unless the daily quota has been used up.
+// The usage is checked and counted in a statement so that the concurrent requests of the instances do not exceed the quota.
+// input: key(string): key of the bucket
+// input: quota(int): daily quota of the API key
+// input: now(time.Time): time of the request
+// output: (int) number of the requests counted in the day including the request, or 0 when the daily quota has been used up
+// output: (error) error object
+func (r *rateLimiter) countDailyUsage(key string, quota int, now time.Time) (int, error) {
+	var rows []struct{ Used int }
+	if err := r.db.Raw(
+		"INSERT INTO api_key_daily_usages (bucket_key, day, used, updated_at) VALUES (?, ?, 1, ?) "+
+			"ON CONFLICT (bucket_key, day) DO UPDATE SET used = api_key_daily_usages.used + 1, updated_at = excluded.updated_at "+
+			"WHERE api_key_daily_usages.used < ? RETURNING used",
+		key, authentication.QuotaDayOf(now).Format(quotaDayFormat), now.UTC(), quota,
+	).Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Used, nil
+}
+
+// sweep
+// Summary: This is the function which removes the idle buckets. The caller must hold mu.
+// input: now(time.Time): current time
+func (r *rateLimiter) sweep(now time.Time) {
+	for key, bucket := range r.buckets {
+		if now.Sub(bucket.UpdatedAt) >= rateLimiterIdleAfter {
+			delete(r.buckets, key)
+		}
+	}
+	r.sweptAt = now
+}
+
+// deletePastDailyUsages
+// Summary: This is the function which removes the daily usages of the past days.
+// The usages are removed by every instance, and the failure is retried at the next sweep.
+// input: now(time.Time): current time
+func (r *rateLimiter) deletePastDailyUsages(now time.Time) {
+	if err := r.db.Exec("DELETE FROM api_key_daily_usages WHERE day < ?", authentication.QuotaDayOf(now).Format(quotaDayFormat)).Error; err != nil {
+		logger.Set(nil).Errorf(err.Error())
+	}
+}
@@ -0,0 +1,98 @@
+package datastore_test
+
+import (
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/infrastructure/persistence/datastore"
+	testhelper "authenticator-backend/test/test_helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// RateLimiter テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：キーごとにリクエストを数える
+// [x] 1-2: 正常系：日付が変わると1日の上限をリセット
+// [x] 1-3: 正常系：1日のリクエスト数はインスタンス間で共有
+// [x] 2-1: 異常系：1日の上限に達した場合はトークンを消費しない
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectRepository_RateLimiter(tt *testing.T) {
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	limit := authentication.RateLimit{DailyQuota: 1}
+
+	tt.Run("1-1: 正常系：キーごとにリクエストを数える", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		limiter := datastore.NewRateLimiter(db)
+		for _, expected := range []struct {
+			key     string
+			allowed bool
+		}{{"key1", true}, {"key1", false}, {"key2", true}} {
+			status, err := limiter.Take(expected.key, limit, now)
+			if assert.NoError(t, err) {
+				assert.Equal(t, expected.allowed, status.Allowed)
+			}
+		}
+	})
+	tt.Run("1-2: 正常系：日付が変わると1日の上限をリセット", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		limiter := datastore.NewRateLimiter(db)
+		first, _ := limiter.Take("key1", limit, now)
+		second, err := limiter.Take("key1", limit, now.Add(12*time.Hour))
+		if assert.NoError(t, err) {
+			assert.True(t, first.Allowed)
+			assert.True(t, second.Allowed)
+			assert.Equal(t, 24*time.Hour, second.QuotaReset)
+		}
+	})
+	tt.Run("1-3: 正常系：1日のリクエスト数はインスタンス間で共有", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		quota := authentication.RateLimit{DailyQuota: 2}
+		first, _ := datastore.NewRateLimiter(db).Take("key1", quota, now)
+		second, _ := datastore.NewRateLimiter(db).Take("key1", quota, now)
+		third, err := datastore.NewRateLimiter(db).Take("key1", quota, now)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 1, first.QuotaRemaining)
+			assert.Equal(t, 0, second.QuotaRemaining)
+			assert.True(t, third.QuotaExceeded)
+		}
+	})
+	tt.Run("2-1: 異常系：1日の上限に達した場合はトークンを消費しない", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := testhelper.NewMockDB()
+		if err != nil {
+			assert.Fail(t, err.Error())
+		}
+		limiter := datastore.NewRateLimiter(db)
+		both := authentication.RateLimit{RequestsPerMinute: 60, Burst: 2, DailyQuota: 1}
+		_, _ = limiter.Take("key1", both, now)
+		rejected, err := limiter.Take("key1", both, now)
+		if assert.NoError(t, err) {
+			assert.True(t, rejected.QuotaExceeded)
+			assert.Equal(t, 1, rejected.Remaining)
+		}
+		// the token refunded for the rejected request is still available
+		status, _ := limiter.Take("key1", authentication.RateLimit{RequestsPerMinute: 60, Burst: 2}, now)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 0, status.Remaining)
+	})
+}
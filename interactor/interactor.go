@@ -1,9 +1,17 @@
 package interactor
 
 import (
+	"context"
+
 	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	domain_repository "authenticator-backend/domain/repository"
 	firebase_client "authenticator-backend/infrastructure/firebase"
 	"authenticator-backend/infrastructure/firebase/repository"
+	"authenticator-backend/infrastructure/idpclient"
+	localidp_repository "authenticator-backend/infrastructure/localidp/repository"
+	mail_repository "authenticator-backend/infrastructure/mail/repository"
+	oidc_repository "authenticator-backend/infrastructure/oidc/repository"
 	"authenticator-backend/infrastructure/persistence/datastore"
 	"authenticator-backend/presentation/http/echo/handler"
 	"authenticator-backend/presentation/http/echo/middleware"
@@ -18,6 +26,9 @@ import (
 type Interactor interface {
 	NewAppHandler() handler.AppHandler
 	NewAuthMiddleware() middleware.AuthMiddleware
+	WatchAPIKeyCache(ctx context.Context)
+	AuthEventWriter() domain_repository.AuthEventWriter
+	RunAuthEventWriter(ctx context.Context)
 }
 
 // interactor
@@ -26,6 +37,12 @@ type interactor struct {
 	cfg            *config.Config
 	db             *gorm.DB
 	firebaseConfig *firebase.Config
+	// idpClient is shared by the handlers and the middleware so that the circuit breaker sees every call to the identity provider
+	idpClient *idpclient.Client
+	// apiKeyCache is shared by the handlers and the middleware so that the changes by the handlers are seen by the middleware at once
+	apiKeyCache domain_repository.APIKeyCache
+	// authEventWriter is shared by all the middleware so that the auth events are recorded by a single background writer
+	authEventWriter domain_repository.AuthEventWriter
 }
 
 // NewInteractor
@@ -39,10 +56,26 @@ func NewInteractor(
 	db *gorm.DB,
 	fc *firebase.Config,
 ) Interactor {
+	// the changes by the other instances are notified only on PostgreSQL
+	var listenDSN string
+	if db.Name() == "postgres" {
+		listenDSN = config.PostgreSQLDSN(cfg)
+	}
+
 	return &interactor{
 		cfg,
 		db,
 		fc,
+		idpclient.New(idpclient.Policy{
+			Timeout:             cfg.IDPClient.Timeout,
+			MaxRetries:          cfg.IDPClient.MaxRetries,
+			RetryBaseDelay:      cfg.IDPClient.RetryBaseDelay,
+			RetryMaxDelay:       cfg.IDPClient.RetryMaxDelay,
+			BreakerThreshold:    cfg.IDPClient.CircuitBreakerThreshold,
+			BreakerOpenDuration: cfg.IDPClient.CircuitBreakerOpenDuration,
+		}),
+		datastore.NewAPIKeyCache(db, cfg.APIKey.CacheRefreshInterval, listenDSN),
+		datastore.NewAuthEventWriter(db, cfg.AuthEventBufferSize),
 	}
 }
 
@@ -50,6 +83,12 @@ func NewInteractor(
 // Summary: This is structure which defines the fields that the appHandler struct should have.
 type appHandler struct {
 	handler.AuthHandler
+	handler.PasswordResetHandler
+	handler.MFAHandler
+	handler.OAuthHandler
+	handler.UserHandler
+	handler.AuthEventHandler
+	handler.APIKeyHandler
 	handler.OuranosHandler
 }
 
@@ -57,14 +96,26 @@ type appHandler struct {
 // Summary: This is function to create a new appHandler struct.
 // output: handler.AppHandler
 func (i *interactor) NewAppHandler() handler.AppHandler {
-	firebaseCli, _ := firebase_client.NewClient(i.firebaseConfig.ProjectID, i.cfg.FirebaseAuthEmulatorHost)
-
 	ouranosRepository := datastore.NewOuranosRepository(i.db)
 	authRepository := datastore.NewAuthRepository(i.db)
-	firebaseRepository := repository.NewFirebase(firebaseCli, i.cfg.IDPSignInURL, i.cfg.IDPAPIKey, i.cfg.SecureTokenAPIKey, i.cfg.SecureTokenAPI)
+	firebaseRepository := i.newFirebaseRepository()
+
+	passwordPolicy := i.newPasswordPolicy()
+	loginThrottlePolicy := i.newLoginThrottlePolicy()
+	mfaPolicy := i.newMFAPolicy()
+	apiKeyPolicy := i.newAPIKeyPolicy()
+	secretCipher := authentication.NewSecretCipher(i.cfg.MFA.EncryptionKey)
+	signingSecretCipher := authentication.NewSecretCipher(i.cfg.APIKey.SigningEncryptionKey)
+	oauthTokenSigner := i.newOAuthTokenSigner()
 
-	authUsecase := usecase.NewAuthUsecase(firebaseRepository)
-	verifyUsecase := usecase.NewVerifyUsecase(firebaseRepository, authRepository)
+	authUsecase := usecase.NewAuthUsecase(firebaseRepository, authRepository, passwordPolicy, loginThrottlePolicy, mfaPolicy, secretCipher)
+	passwordResetUsecase := usecase.NewPasswordResetUsecase(firebaseRepository, authRepository, i.newMailer(), i.cfg.PasswordReset.URL, i.cfg.PasswordReset.ThrottleLimit, i.cfg.PasswordReset.ThrottleWindow, passwordPolicy)
+	mfaUsecase := usecase.NewMFAUsecase(authRepository, mfaPolicy, secretCipher, loginThrottlePolicy)
+	oauthUsecase := usecase.NewOAuthUsecase(authRepository, oauthTokenSigner)
+	userUsecase := usecase.NewUserUsecase(firebaseRepository, ouranosRepository, authRepository, passwordPolicy)
+	authEventUsecase := usecase.NewAuthEventUsecase(authRepository)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepository, ouranosRepository, i.apiKeyCache, apiKeyPolicy, signingSecretCipher)
+	verifyUsecase := usecase.NewVerifyUsecase(firebaseRepository, i.apiKeyCache, apiKeyPolicy)
 	operatorUsecase := usecase.NewOperatorUsecase(ouranosRepository)
 	plantUsecase := usecase.NewPlantUsecase(ouranosRepository)
 	resetUsecase := usecase.NewResetUsecase(ouranosRepository, authRepository)
@@ -77,6 +128,12 @@ func (i *interactor) NewAppHandler() handler.AppHandler {
 		authUsecase,
 		verifyUsecase,
 	)
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetUsecase)
+	mfaHandler := handler.NewMFAHandler(mfaUsecase)
+	oauthHandler := handler.NewOAuthHandler(oauthUsecase)
+	userHandler := handler.NewUserHandler(userUsecase)
+	authEventHandler := handler.NewAuthEventHandler(authEventUsecase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
 	ouranosHandler := handler.NewOuranosHandler(
 		operatorHandler,
 		plantHandler,
@@ -84,8 +141,14 @@ func (i *interactor) NewAppHandler() handler.AppHandler {
 	)
 
 	appHandler := &appHandler{
-		AuthHandler:    authHandler,
-		OuranosHandler: ouranosHandler,
+		AuthHandler:          authHandler,
+		PasswordResetHandler: passwordResetHandler,
+		MFAHandler:           mfaHandler,
+		OAuthHandler:         oauthHandler,
+		UserHandler:          userHandler,
+		AuthEventHandler:     authEventHandler,
+		APIKeyHandler:        apiKeyHandler,
+		OuranosHandler:       ouranosHandler,
 	}
 	return appHandler
 }
@@ -94,12 +157,140 @@ func (i *interactor) NewAppHandler() handler.AppHandler {
 // Summary: This is function to create a new authMiddleware struct.
 // output: middleware.AuthMiddleware
 func (i *interactor) NewAuthMiddleware() middleware.AuthMiddleware {
-	firebaseCli, _ := firebase_client.NewClient(i.firebaseConfig.ProjectID, i.cfg.FirebaseAuthEmulatorHost)
-
 	authRepository := datastore.NewAuthRepository(i.db)
-	firebaseRepository := repository.NewFirebase(firebaseCli, i.cfg.IDPSignInURL, i.cfg.IDPAPIKey, i.cfg.SecureTokenAPIKey, i.cfg.SecureTokenAPI)
+	firebaseRepository := i.newFirebaseRepository()
+
+	apiKeyPolicy := i.newAPIKeyPolicy()
+
+	verifyUsecase := usecase.NewVerifyUsecase(firebaseRepository, i.apiKeyCache, apiKeyPolicy)
+	oauthUsecase := usecase.NewOAuthUsecase(authRepository, i.newOAuthTokenSigner())
+
+	signingSecretCipher := authentication.NewSecretCipher(i.cfg.APIKey.SigningEncryptionKey)
+
+	return middleware.NewAuthMiddleware(verifyUsecase, oauthUsecase, i.apiKeyCache, datastore.NewRateLimiter(i.db), i.newRateLimitPolicy(), apiKeyPolicy, datastore.NewNonceStore(i.db), signingSecretCipher)
+}
+
+// WatchAPIKeyCache
+// Summary: This is function to keep the in-memory index of the API keys and the CIDRs up to date until the context is done.
+// input: ctx(context.Context) context to stop watching
+func (i *interactor) WatchAPIKeyCache(ctx context.Context) {
+	i.apiKeyCache.Watch(ctx)
+}
+
+// AuthEventWriter
+// Summary: This is function to get the writer of the auth events shared by the middleware.
+// output: domain_repository.AuthEventWriter
+func (i *interactor) AuthEventWriter() domain_repository.AuthEventWriter {
+	return i.authEventWriter
+}
+
+// RunAuthEventWriter
+// Summary: This is function to record the auth events written by the middleware until the context is done.
+// input: ctx(context.Context) context to stop recording
+func (i *interactor) RunAuthEventWriter(ctx context.Context) {
+	i.authEventWriter.Run(ctx)
+}
+
+// newFirebaseRepository
+// Summary: This is function to create the identity provider repository selected by the configuration.
+// output: domain_repository.FirebaseRepository
+func (i *interactor) newFirebaseRepository() domain_repository.FirebaseRepository {
+	switch i.cfg.IDPProvider {
+	case config.IDPProviderLocal:
+		return localidp_repository.NewLocalIDP(i.db, i.cfg.LocalIDP.SigningKey, i.cfg.LocalIDP.Issuer, i.cfg.LocalIDP.IDTokenTTL, i.cfg.LocalIDP.RefreshTokenTTL)
+	case config.IDPProviderOIDC:
+		return oidc_repository.NewOIDC(i.idpClient.HTTPClient(), i.cfg.OIDC.IssuerURL, i.cfg.OIDC.ClientID, i.cfg.OIDC.ClientSecret, i.cfg.OIDC.Scopes, i.cfg.OIDC.OperatorIDClaim)
+	default:
+		firebaseCli, _ := firebase_client.NewClient(i.firebaseConfig.ProjectID, i.cfg.FirebaseAuthEmulatorHost)
+
+		return repository.NewFirebase(firebaseCli, i.idpClient, i.cfg.IDPSignInURL, i.cfg.IDPAPIKey, i.cfg.SecureTokenAPIKey, i.cfg.SecureTokenAPI)
+	}
+}
+
+// newMailer
+// Summary: This is function to create the mailer selected by the configuration.
+// output: domain_repository.Mailer
+func (i *interactor) newMailer() domain_repository.Mailer {
+	switch i.cfg.Mail.Driver {
+	case config.MailDriverSMTP:
+		return mail_repository.NewSMTPMailer(i.cfg.Mail.SMTP.Host, i.cfg.Mail.SMTP.Port, i.cfg.Mail.SMTP.Username, i.cfg.Mail.SMTP.Password, i.cfg.Mail.From)
+	default:
+		return mail_repository.NewSpoolMailer(i.cfg.Mail.SpoolDir, i.cfg.Mail.From)
+	}
+}
 
-	verifyUsecase := usecase.NewVerifyUsecase(firebaseRepository, authRepository)
+// newPasswordPolicy
+// Summary: This is function to create the password policy from the configuration.
+// output: authentication.PasswordPolicy
+func (i *interactor) newPasswordPolicy() authentication.PasswordPolicy {
+	return authentication.PasswordPolicy{
+		MinLength:               i.cfg.PasswordPolicy.MinLength,
+		MaxLength:               i.cfg.PasswordPolicy.MaxLength,
+		RequireUpperCase:        i.cfg.PasswordPolicy.RequireUpperCase,
+		RequireLowerCase:        i.cfg.PasswordPolicy.RequireLowerCase,
+		RequireDigit:            i.cfg.PasswordPolicy.RequireDigit,
+		RequireSpecialCharacter: i.cfg.PasswordPolicy.RequireSpecialCharacter,
+		SpecialCharacters:       i.cfg.PasswordPolicy.SpecialCharacters,
+		DenyList:                authentication.NewPasswordDenyList(i.cfg.PasswordPolicy.DenyList),
+		DisallowEmailLocalPart:  i.cfg.PasswordPolicy.DisallowEmailLocalPart,
+		HistoryCount:            i.cfg.PasswordPolicy.HistoryCount,
+	}
+}
+
+// newLoginThrottlePolicy
+// Summary: This is function to create the policy to throttle the failed login attempts from the configuration.
+// output: authentication.LoginThrottlePolicy
+func (i *interactor) newLoginThrottlePolicy() authentication.LoginThrottlePolicy {
+	return authentication.LoginThrottlePolicy{
+		FailureWindow:      i.cfg.LoginThrottle.FailureWindow,
+		LockoutThreshold:   i.cfg.LoginThrottle.LockoutThreshold,
+		LockoutDuration:    i.cfg.LoginThrottle.LockoutDuration,
+		DelayThreshold:     i.cfg.LoginThrottle.DelayThreshold,
+		BaseDelay:          i.cfg.LoginThrottle.BaseDelay,
+		MaxDelay:           i.cfg.LoginThrottle.MaxDelay,
+		IPFailureThreshold: i.cfg.LoginThrottle.IPFailureThreshold,
+		IPFailureWindow:    i.cfg.LoginThrottle.IPFailureWindow,
+	}
+}
+
+// newMFAPolicy
+// Summary: This is function to create the policy of the multi-factor authentication from the configuration.
+// output: authentication.MFAPolicy
+func (i *interactor) newMFAPolicy() authentication.MFAPolicy {
+	return authentication.MFAPolicy{
+		Issuer:            i.cfg.MFA.Issuer,
+		ChallengeTTL:      i.cfg.MFA.ChallengeTTL,
+		MaxAttempts:       i.cfg.MFA.MaxAttempts,
+		RecoveryCodeCount: i.cfg.MFA.RecoveryCodeCount,
+	}
+}
+
+// newAPIKeyPolicy
+// Summary: This is function to create the policy of the expiry, the rotation, the IP address restriction and the request signing of the API keys from the configuration.
+// output: authentication.APIKeyPolicy
+func (i *interactor) newAPIKeyPolicy() authentication.APIKeyPolicy {
+	return authentication.APIKeyPolicy{
+		TTL:                 i.cfg.APIKey.TTL,
+		RotationGracePeriod: i.cfg.APIKey.RotationGracePeriod,
+		ExpiryWarning:       i.cfg.APIKey.ExpiryWarning,
+		IPRestrictionMode:   authentication.IPRestrictionMode(i.cfg.APIKey.IPRestrictionMode),
+		SignatureClockSkew:  i.cfg.APIKey.SignatureClockSkew,
+	}
+}
+
+// newRateLimitPolicy
+// Summary: This is function to create the policy to limit the requests with the API keys from the configuration.
+// output: authentication.RateLimitPolicy
+func (i *interactor) newRateLimitPolicy() authentication.RateLimitPolicy {
+	return authentication.RateLimitPolicy{
+		Defaults:   authentication.DefaultRateLimits,
+		ByOperator: i.cfg.APIKey.RateLimitByOperator,
+	}
+}
 
-	return middleware.NewAuthMiddleware(verifyUsecase)
+// newOAuthTokenSigner
+// Summary: This is function to create the signer of the OAuth 2.0 access tokens from the configuration.
+// output: authentication.OAuthTokenSigner
+func (i *interactor) newOAuthTokenSigner() authentication.OAuthTokenSigner {
+	return authentication.NewOAuthTokenSigner(i.cfg.OAuth.SigningKey, i.cfg.OAuth.Issuer, i.cfg.OAuth.AccessTokenTTL)
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
 	"authenticator-backend/config"
 	"authenticator-backend/interactor"
@@ -18,7 +20,7 @@ func main() {
 
 	cfg, err := config.NewConfig()
 	if err != nil {
-		e.Logger.Error("config error")
+		e.Logger.Errorf("config error: %v", err)
 
 		return
 	}
@@ -56,12 +58,26 @@ func main() {
 	)
 	h := i.NewAppHandler()
 	authMiddleware := i.NewAuthMiddleware()
+	go i.WatchAPIKeyCache(context.Background())
+	go i.RunAuthEventWriter(context.Background())
 
-	router.SetRouter(e, h, cfg, conn, authMiddleware)
+	router.SetRouter(e, h, cfg, i.AuthEventWriter(), authMiddleware)
 
+	tlsConfig, err := config.NewTLSConfig(cfg)
+	if err != nil {
+		e.Logger.Errorf("tls config error: %v", err)
+
+		return
+	}
+
+	addr := fmt.Sprintf(":%s", cfg.Server.Port)
 	if cfg.Env == "local" {
-		e.Logger.Fatal(e.Start(fmt.Sprintf("0.0.0.0:%s", cfg.Server.Port)))
+		addr = fmt.Sprintf("0.0.0.0:%s", cfg.Server.Port)
+	}
+	if tlsConfig != nil {
+		// the client certificates are verified by the TLS listener of the server
+		e.Logger.Fatal(e.StartServer(&http.Server{Addr: addr, TLSConfig: tlsConfig}))
 	} else {
-		e.Logger.Fatal(e.Start(fmt.Sprintf(":%s", cfg.Server.Port)))
+		e.Logger.Fatal(e.Start(addr))
 	}
 }
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	APIKeyHandler interface {
+		CreateAPIKey(c echo.Context) error
+		ListAPIKeys(c echo.Context) error
+		UpdateAPIKey(c echo.Context) error
+		RevokeAPIKey(c echo.Context) error
+		RotateAPIKey(c echo.Context) error
+		BindAPIKeyOperator(c echo.Context) error
+		UnbindAPIKeyOperator(c echo.Context) error
+		AddAPIKeyCidr(c echo.Context) error
+		RemoveAPIKeyCidr(c echo.Context) error
+		AddAPIKeyClientCertificate(c echo.Context) error
+		RemoveAPIKeyClientCertificate(c echo.Context) error
+		GrantAPIKeyPermission(c echo.Context) error
+		RevokeAPIKeyPermission(c echo.Context) error
+		EnableAPIKeyRequestSigning(c echo.Context) error
+		DisableAPIKeyRequestSigning(c echo.Context) error
+	}
+
+	apiKeyHandler struct {
+		APIKeyUsecase usecase.IAPIKeyUsecase
+	}
+)
+
+func NewAPIKeyHandler(
+	apiKeyUsecase usecase.IAPIKeyUsecase,
+) APIKeyHandler {
+	return &apiKeyHandler{
+		APIKeyUsecase: apiKeyUsecase,
+	}
+}
@@ -3,6 +3,12 @@ package handler
 type (
 	AppHandler interface {
 		AuthHandler
+		PasswordResetHandler
+		MFAHandler
+		OAuthHandler
+		UserHandler
+		AuthEventHandler
+		APIKeyHandler
 		OuranosHandler
 	}
 )
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	AuthEventHandler interface {
+		ListAuthEvents(c echo.Context) error
+	}
+
+	authEventHandler struct {
+		AuthEventUsecase usecase.IAuthEventUsecase
+	}
+)
+
+func NewAuthEventHandler(
+	authEventUsecase usecase.IAuthEventUsecase,
+) AuthEventHandler {
+	return &authEventHandler{
+		AuthEventUsecase: authEventUsecase,
+	}
+}
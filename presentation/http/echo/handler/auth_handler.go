@@ -11,8 +11,11 @@ type (
 		Login(c echo.Context) error
 		Refresh(c echo.Context) error
 		ChangePassword(c echo.Context) error
+		Logout(c echo.Context) error
 		TokenIntrospection(c echo.Context) error
+		IntrospectToken(c echo.Context) error
 		ApiKey(c echo.Context) error
+		UnlockAccount(c echo.Context) error
 	}
 
 	authHandler struct {
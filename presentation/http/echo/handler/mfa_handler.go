@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	MFAHandler interface {
+		EnrollMFA(c echo.Context) error
+		ActivateMFA(c echo.Context) error
+		VerifyMFA(c echo.Context) error
+		DisableMFA(c echo.Context) error
+	}
+
+	mfaHandler struct {
+		MFAUsecase usecase.IMFAUsecase
+	}
+)
+
+func NewMFAHandler(
+	mfaUsecase usecase.IMFAUsecase,
+) MFAHandler {
+	return &mfaHandler{
+		MFAUsecase: mfaUsecase,
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	OAuthHandler interface {
+		Token(c echo.Context) error
+	}
+
+	oauthHandler struct {
+		OAuthUsecase usecase.IOAuthUsecase
+	}
+)
+
+func NewOAuthHandler(
+	oauthUsecase usecase.IOAuthUsecase,
+) OAuthHandler {
+	return &oauthHandler{
+		OAuthUsecase: oauthUsecase,
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
@@ -35,7 +36,9 @@ func (h *authHandler) Login(c echo.Context) error {
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
 	}
 
-	output, err := h.AuthUsecase.Login(param)
+	param.IPAddress = common.ClientIP(c)
+
+	output, err := h.AuthUsecase.Login(c.Request().Context(), param)
 	if err != nil {
 		var customErr *common.CustomError
 		if errors.As(err, &customErr) {
@@ -44,8 +47,11 @@ func (h *authHandler) Login(c echo.Context) error {
 			} else {
 				logger.Set(c).Errorf(err.Error())
 			}
+			if customErr.RetryAfter > 0 {
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(customErr.RetryAfter.Seconds())))
+			}
 
-			return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method))
+			return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Reason))
 		}
 		logger.Set(c).Errorf(err.Error())
 
@@ -77,7 +83,7 @@ func (h *authHandler) Refresh(c echo.Context) error {
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
 	}
 
-	output, err := h.AuthUsecase.Refresh(param)
+	output, err := h.AuthUsecase.Refresh(c.Request().Context(), param)
 	if err != nil {
 		var customErr *common.CustomError
 		if errors.As(err, &customErr) {
@@ -87,7 +93,7 @@ func (h *authHandler) Refresh(c echo.Context) error {
 				logger.Set(c).Errorf(err.Error())
 			}
 
-			return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method))
+			return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Reason))
 		}
 		logger.Set(c).Errorf(err.Error())
 
@@ -115,7 +121,54 @@ func (h *authHandler) ChangePassword(c echo.Context) error {
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
 	}
 	param.UID = uid
+	param.Email = claims.Email()
+	param.IPAddress = common.ClientIP(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+
+	output, err := h.AuthUsecase.ChangePassword(c.Request().Context(), param)
+	if err != nil {
+		var customErr *common.CustomError
+		if errors.As(err, &customErr) {
+			if customErr.IsWarn() {
+				logger.Set(c).Warnf(err.Error())
+			} else {
+				logger.Set(c).Errorf(err.Error())
+			}
+
+			if customErr.RetryAfter > 0 {
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(customErr.RetryAfter.Seconds())))
+			}
+
+			code, httpErr := common.HTTPErrorGenerateWithViolations(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, operatorId, "", method, customErr.Violations)
+			httpErr.Reason = customErr.Reason
+
+			return echo.NewHTTPError(code, httpErr)
+		}
+		logger.Set(c).Errorf(err.Error())
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, operatorId, "", method))
+	}
+
+	return c.JSON(http.StatusCreated, output)
+}
+
+// Logout
+// Summary: This is function which is used to logout by revoking the refresh tokens of the operator
+// input: c(echo.Context): context
+// output: error: error object
+func (h *authHandler) Logout(c echo.Context) error {
+	method := c.Request().Method
+
+	claims := c.Get("operator").(*authentication.Claims)
+	operatorId := claims.OperatorID
 
+	param := input.LogoutParam{UID: claims.UID}
 	if err := param.Validate(); err != nil {
 		logger.Set(c).Warnf(err.Error())
 		errDetails := err.Error()
@@ -123,7 +176,7 @@ func (h *authHandler) ChangePassword(c echo.Context) error {
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
 	}
 
-	if err := h.AuthUsecase.ChangePassword(param); err != nil {
+	if err := h.AuthUsecase.Logout(c.Request().Context(), param); err != nil {
 		logger.Set(c).Errorf(err.Error())
 
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, operatorId, "", method))
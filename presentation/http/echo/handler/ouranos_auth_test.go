@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"authenticator-backend/domain/common"
 	"authenticator-backend/presentation/http/echo/handler"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // /////////////////////////////////////////////////////////////////////////////////
@@ -56,6 +58,7 @@ func TestProjectHandler_Change_Normal(tt *testing.T) {
 			rec := httptest.NewRecorder()
 			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderXForwardedFor, f.IpAddress)
 			c := e.NewContext(req, rec)
 			c.SetPath(endPoint)
 
@@ -69,10 +72,15 @@ func TestProjectHandler_Change_Normal(tt *testing.T) {
 				verifyUsecase,
 			)
 
-			authUsecase.On("ChangePassword", test.inputFunc()).Return(nil)
+			changePasswordModel := output.ChangePasswordResponse{
+				AccessToken:  f.Token,
+				RefreshToken: f.Token,
+			}
+			authUsecase.On("ChangePassword", mock.Anything, test.inputFunc()).Return(changePasswordModel, nil)
 			err := authHandler.ChangePassword(c)
 			if assert.NoError(t, err) {
 				assert.Equal(t, test.expectStatus, rec.Code)
+				assert.JSONEq(t, `{"accessToken":"valid_token","refreshToken":"valid_token"}`, rec.Body.String())
 				authUsecase.AssertExpectations(t)
 			}
 		})
@@ -83,14 +91,12 @@ func TestProjectHandler_Change_Normal(tt *testing.T) {
 // POST /auth/change テストケース
 // /////////////////////////////////////////////////////////////////////////////////
 // [x] 1-2. 400: バリデーションエラー：newPasswordの型がstring以外の場合
-// [x] 1-3. 400: バリデーションエラー：newPasswordの桁数が7文字以下の場合
-// [x] 1-4. 400: バリデーションエラー：newPasswordの桁数が21文字以上の場合
-// [x] 1-5. 400: バリデーションエラー：newPasswordに英大文字を1つも含まない場合
-// [x] 1-6. 400: バリデーションエラー：newPasswordに英子文字を1つも含まない場合
-// [x] 1-7. 400: バリデーションエラー：newPasswordに数字を1つも含まない場合
-// [x] 1-8. 400: バリデーションエラー：newPasswordに特殊文字を1つも含まない場合
-// [x] 1-9. 400: バリデーションエラー：newPasswordに特殊文字を1つも含まない場合
+// [x] 1-3. 400: バリデーションエラー：newPasswordが未指定の場合
+// [x] 1-4. 400: パスワードポリシーエラー：違反したルールを返却
 // [x] 1-10. 500: システムエラー：変更失敗
+// [x] 1-11. 400: バリデーションエラー：currentPasswordが未指定の場合
+// [x] 1-12. 401: 認証エラー：currentPasswordが不一致の場合
+// [x] 1-13. 423: アカウントロック中の場合、Retry-Afterヘッダを返却
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectHandler_Change_Abnormal(tt *testing.T) {
 	var method = "POST"
@@ -102,7 +108,9 @@ func TestProjectHandler_Change_Abnormal(tt *testing.T) {
 		invalidInputFunc func() interface{}
 		receive          error
 		expectError      string
+		expectBody       string
 		expectStatus     int
+		expectRetryAfter string
 	}{
 		{
 			name: "1-2. 400: バリデーションエラー：newPasswordの型がstring以外の場合",
@@ -116,81 +124,72 @@ func TestProjectHandler_Change_Abnormal(tt *testing.T) {
 			expectStatus: http.StatusBadRequest,
 		},
 		{
-			name: "1-3. 400: バリデーションエラー：newPasswordの桁数が7文字以下の場合",
-			inputFunc: func() input.ChangePasswordParam {
-				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "Abc12@"
-				return changePasswordParam
-			},
-			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: the length must be between 8 and 20.",
-			expectStatus: http.StatusBadRequest,
-		},
-		{
-			name: "1-4. 400: バリデーションエラー：newPasswordの桁数が21文字以上の場合",
+			name: "1-3. 400: バリデーションエラー：newPasswordが未指定の場合",
 			inputFunc: func() input.ChangePasswordParam {
 				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "AAAbbbccc111222@@@1112"
+				changePasswordParam.NewPassword = ""
 				return changePasswordParam
 			},
 			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: the length must be between 8 and 20.",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: cannot be blank.",
 			expectStatus: http.StatusBadRequest,
 		},
 		{
-			name: "1-6. 400: バリデーションエラー：newPasswordに英大文字を1つも含まない場合",
+			name: "1-4. 400: パスワードポリシーエラー：違反したルールを返却",
 			inputFunc: func() input.ChangePasswordParam {
 				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "aabbb11@@"
+				changePasswordParam.NewPassword = "Abc12@"
 				return changePasswordParam
 			},
-			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: must include at least one upper case letter.",
+			receive: common.NewCustomErrorWithViolations(common.CustomErrorCode400, common.Err400PasswordPolicy, []common.ErrorViolation{
+				{Field: "newPassword", Rule: "minLength", Message: "must be at least 8 characters"},
+			}, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Password does not satisfy the password policy",
+			expectBody:   `"violations":[{"field":"newPassword","rule":"minLength","message":"must be at least 8 characters"}]`,
 			expectStatus: http.StatusBadRequest,
 		},
 		{
-			name: "1-7. 400: バリデーションエラー：newPasswordに英小文字を1つも含まない場合",
+			name: "1-10. 500: システムエラー：変更失敗",
 			inputFunc: func() input.ChangePasswordParam {
 				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "AABB11@@"
+				changePasswordParam.NewPassword = "xx@&1234Pass"
 				return changePasswordParam
 			},
-			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: must include at least one lower case letter.",
-			expectStatus: http.StatusBadRequest,
+			receive:      fmt.Errorf("password change fail."),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
 		},
 		{
-			name: "1-8. 400: バリデーションエラー：newPasswordに数字を1つも含まない場合",
+			name: "1-11. 400: バリデーションエラー：currentPasswordが未指定の場合",
 			inputFunc: func() input.ChangePasswordParam {
 				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "AAbb@@__"
+				changePasswordParam.CurrentPassword = ""
 				return changePasswordParam
 			},
 			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: must include at least one digit.",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, currentPassword: cannot be blank.",
 			expectStatus: http.StatusBadRequest,
 		},
 		{
-			name: "1-9. 400: バリデーションエラー：newPasswordに特殊文字を1つも含まない場合",
+			name: "1-12. 401: 認証エラー：currentPasswordが不一致の場合",
 			inputFunc: func() input.ChangePasswordParam {
 				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "xx1234Pass"
+				changePasswordParam.CurrentPassword = "xx1234Pass"
 				return changePasswordParam
 			},
-			receive:      nil,
-			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: must include at least one special character.",
-			expectStatus: http.StatusBadRequest,
+			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized Invalid credentials id",
+			expectStatus: http.StatusUnauthorized,
 		},
 		{
-			name: "1-10. 500: システムエラー：変更失敗",
+			name: "1-13. 423: アカウントロック中の場合、Retry-Afterヘッダを返却",
 			inputFunc: func() input.ChangePasswordParam {
-				changePasswordParam := f.NewChangePasswordParam()
-				changePasswordParam.NewPassword = "xx@&1234Pass"
-				return changePasswordParam
+				return f.NewChangePasswordParam()
 			},
-			receive:      fmt.Errorf("password change fail."),
-			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
-			expectStatus: http.StatusInternalServerError,
+			receive:          common.NewCustomErrorWithRetryAfter(common.CustomErrorCode423, common.Err423AccountLocked, 15*time.Minute, common.HTTPErrorSourceAuth),
+			expectError:      "code=423, message={[auth] Locked Account is temporarily locked",
+			expectStatus:     http.StatusLocked,
+			expectRetryAfter: "900",
 		},
 	}
 
@@ -212,6 +211,7 @@ func TestProjectHandler_Change_Abnormal(tt *testing.T) {
 			rec := httptest.NewRecorder()
 			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderXForwardedFor, f.IpAddress)
 			c := e.NewContext(req, rec)
 			c.SetPath(endPoint)
 
@@ -226,13 +226,15 @@ func TestProjectHandler_Change_Abnormal(tt *testing.T) {
 			)
 
 			if test.inputFunc != nil {
-				authUsecase.On("ChangePassword", test.inputFunc()).Return(test.receive)
+				authUsecase.On("ChangePassword", mock.Anything, test.inputFunc()).Return(output.ChangePasswordResponse{}, test.receive)
 			}
 			err := authHandler.ChangePassword(c)
 			e.HTTPErrorHandler(err, c)
 			if assert.Error(t, err) {
 				assert.Equal(t, test.expectStatus, rec.Code)
 				assert.ErrorContains(t, err, test.expectError)
+				assert.Contains(t, rec.Body.String(), test.expectBody)
+				assert.Equal(t, test.expectRetryAfter, rec.Header().Get(echo.HeaderRetryAfter))
 			}
 		})
 	}
@@ -279,6 +281,7 @@ func TestProjectHandler_Login_Normal(tt *testing.T) {
 				rec := httptest.NewRecorder()
 				req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
 				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				req.Header.Set(echo.HeaderXForwardedFor, f.IpAddress+", 10.0.0.1")
 				c := e.NewContext(req, rec)
 				c.SetPath(endPoint)
 
@@ -288,7 +291,7 @@ func TestProjectHandler_Login_Normal(tt *testing.T) {
 					authUsecase,
 					verifyUsecase,
 				)
-				authUsecase.On("Login", test.inputFunc()).Return(loginModel, nil)
+				authUsecase.On("Login", mock.Anything, test.inputFunc()).Return(loginModel, nil)
 
 				err := authHandler.Login(c)
 				if assert.NoError(t, err) {
@@ -311,6 +314,10 @@ func TestProjectHandler_Login_Normal(tt *testing.T) {
 // [x] 2-6. 401: 異常系(認証エラー：accountPasswordが不一致の場合)
 // [x] 2-7. 500: 異常系(システムエラー：ログイン失敗)
 // [x] 2-8. 503: 異常系(サービス利用不可エラー：ログイン失敗)
+// [x] 2-9. 423: 異常系(ロックエラー：アカウントがロックされている場合)
+// [x] 2-10. 429: 異常系(試行回数エラー：ログイン試行が制限されている場合)
+// [x] 2-11. 403: 異常系(IdPエラー：ユーザが無効化されている場合)
+// [x] 2-12. 503: 異常系(IdPエラー：IdPのクォータを超過した場合)
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 	var method = "POST"
@@ -322,6 +329,8 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 		invalidInputFunc func() interface{}
 		receive          error
 		expectError      string
+		expectRetryAfter string
+		expectReason     string
 		expectStatus     int
 	}{
 		{
@@ -386,8 +395,9 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 				loginParam.AccountPassword = "xx1234Pass"
 				return loginParam
 			},
-			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth),
+			receive:      common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth),
 			expectError:  "code=401, message={[auth] Unauthorized Invalid credentials id",
+			expectReason: common.ReasonInvalidCredentials,
 			expectStatus: http.StatusUnauthorized,
 		},
 		{
@@ -410,6 +420,46 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 			expectError:  "code=503, message={[auth] ServiceUnavailable Unexpected error occurred in outer service",
 			expectStatus: http.StatusServiceUnavailable,
 		},
+		{
+			name: "2-9. 423: ロックエラー：アカウントがロックされている場合",
+			inputFunc: func() input.LoginParam {
+				return f.NewLoginParam()
+			},
+			receive:          common.NewCustomErrorWithRetryAfter(common.CustomErrorCode423, common.Err423AccountLocked, 90*time.Second, common.HTTPErrorSourceAuth),
+			expectError:      "code=423, message={[auth] Locked Account is temporarily locked",
+			expectRetryAfter: "90",
+			expectStatus:     http.StatusLocked,
+		},
+		{
+			name: "2-10. 429: 試行回数エラー：ログイン試行が制限されている場合",
+			inputFunc: func() input.LoginParam {
+				return f.NewLoginParam()
+			},
+			receive:          common.NewCustomErrorWithRetryAfter(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, 2*time.Second, common.HTTPErrorSourceAuth),
+			expectError:      "code=429, message={[auth] TooManyRequests Too many login attempts",
+			expectRetryAfter: "2",
+			expectStatus:     http.StatusTooManyRequests,
+		},
+		{
+			name: "2-11. 403: IdPエラー：ユーザが無効化されている場合",
+			inputFunc: func() input.LoginParam {
+				return f.NewLoginParam()
+			},
+			receive:      common.NewCustomErrorWithReason(common.CustomErrorCode403, common.Err403UserDisabled, common.ReasonUserDisabled, common.HTTPErrorSourceAuth),
+			expectError:  "code=403, message={[auth] AccessDenied User is disabled",
+			expectReason: common.ReasonUserDisabled,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name: "2-12. 503: IdPエラー：IdPのクォータを超過した場合",
+			inputFunc: func() input.LoginParam {
+				return f.NewLoginParam()
+			},
+			receive:      common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPQuotaExceeded, common.HTTPErrorSourceAuth),
+			expectError:  "code=503, message={[auth] ServiceUnavailable Unexpected error occurred in outer service",
+			expectReason: common.ReasonIdPQuotaExceeded,
+			expectStatus: http.StatusServiceUnavailable,
+		},
 	}
 
 	for _, test := range tests {
@@ -434,6 +484,7 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 				rec := httptest.NewRecorder()
 				req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
 				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				req.Header.Set(echo.HeaderXForwardedFor, f.IpAddress+", 10.0.0.1")
 				c := e.NewContext(req, rec)
 				c.SetPath(endPoint)
 
@@ -444,7 +495,7 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 					verifyUsecase,
 				)
 				if test.inputFunc != nil {
-					authUsecase.On("Login", test.inputFunc()).Return(loginModel, test.receive)
+					authUsecase.On("Login", mock.Anything, test.inputFunc()).Return(loginModel, test.receive)
 				}
 
 				err := authHandler.Login(c)
@@ -452,6 +503,12 @@ func TestProjectHandler_Login_Abnormal(tt *testing.T) {
 				if assert.Error(t, err) {
 					assert.Equal(t, test.expectStatus, rec.Code)
 					assert.ErrorContains(t, err, test.expectError)
+					assert.Equal(t, test.expectRetryAfter, rec.Header().Get(echo.HeaderRetryAfter))
+
+					var res common.HTTPError
+					if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res)) {
+						assert.Equal(t, test.expectReason, res.Reason)
+					}
 				}
 			},
 		)
@@ -506,7 +563,7 @@ func TestProjectHandler_Refresh_Normal(tt *testing.T) {
 					authUsecase,
 					verifyUsecase,
 				)
-				authUsecase.On("Refresh", test.inputFunc()).Return(refreshModel, nil)
+				authUsecase.On("Refresh", mock.Anything, test.inputFunc()).Return(refreshModel, nil)
 
 				err := authHandler.Refresh(c)
 				if assert.NoError(t, err) {
@@ -615,7 +672,7 @@ func TestProjectHandler_Refresh_Abnormal(tt *testing.T) {
 					verifyUsecase,
 				)
 				if test.inputFunc != nil {
-					authUsecase.On("Refresh", test.inputFunc()).Return(refreshModel, test.receive)
+					authUsecase.On("Refresh", mock.Anything, test.inputFunc()).Return(refreshModel, test.receive)
 				}
 
 				err := authHandler.Refresh(c)
@@ -628,3 +685,74 @@ func TestProjectHandler_Refresh_Abnormal(tt *testing.T) {
 		)
 	}
 }
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/logout テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 500: システムエラー：失効失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_Logout(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/logout"
+
+	tests := []struct {
+		name         string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系",
+			receive:      nil,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 500: システムエラー：失効失敗",
+			receive:      fmt.Errorf("Internal Server Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			operator := f.NewClaims()
+			c.Set("operator", &operator)
+
+			authUsecase := new(mocks.IAuthUsecase)
+			verifyUsecase := new(mocks.IVerifyUsecase)
+			authHandler := handler.NewAuthHandler(
+				authUsecase,
+				verifyUsecase,
+			)
+
+			authUsecase.On("Logout", mock.Anything, f.NewInputLogoutParam()).Return(test.receive)
+			err := authHandler.Logout(c)
+			if test.receive == nil {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					authUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
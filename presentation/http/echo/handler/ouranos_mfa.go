@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EnrollMFA
+// Summary: This is function which is used to generate the TOTP secret of the operator
+// input: c(echo.Context): context
+// output: error: error object
+func (h *mfaHandler) EnrollMFA(c echo.Context) error {
+	method := c.Request().Method
+
+	claims := c.Get("operator").(*authentication.Claims)
+	operatorId := claims.OperatorID
+
+	param := input.EnrollMFAParam{Email: claims.Email()}
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+
+	output, err := h.MFAUsecase.EnrollMFA(param)
+	if err != nil {
+		return mfaError(c, operatorId, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// ActivateMFA
+// Summary: This is function which is used to enable MFA with the code generated by the authenticator application
+// input: c(echo.Context): context
+// output: error: error object
+func (h *mfaHandler) ActivateMFA(c echo.Context) error {
+	method := c.Request().Method
+
+	claims := c.Get("operator").(*authentication.Claims)
+	operatorId := claims.OperatorID
+
+	var param input.ActivateMFAParam
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := common.FormatBindErrMsg(err)
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+	param.Email = claims.Email()
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+
+	output, err := h.MFAUsecase.ActivateMFA(param)
+	if err != nil {
+		return mfaError(c, operatorId, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// VerifyMFA
+// Summary: This is function which is used to exchange the MFA challenge issued on login and the code for the tokens
+// input: c(echo.Context): context
+// output: error: error object
+func (h *mfaHandler) VerifyMFA(c echo.Context) error {
+	method := c.Request().Method
+	param := input.VerifyMFAParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	param.IPAddress = common.ClientIP(c)
+
+	output, err := h.MFAUsecase.VerifyMFA(param)
+	if err != nil {
+		return mfaError(c, "", method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// DisableMFA
+// Summary: This is function which is used to disable MFA with the code
+// input: c(echo.Context): context
+// output: error: error object
+func (h *mfaHandler) DisableMFA(c echo.Context) error {
+	method := c.Request().Method
+
+	claims := c.Get("operator").(*authentication.Claims)
+	operatorId := claims.OperatorID
+
+	var param input.DisableMFAParam
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := common.FormatBindErrMsg(err)
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+	param.Email = claims.Email()
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, operatorId, "", method, errDetails))
+	}
+
+	if err := h.MFAUsecase.DisableMFA(param); err != nil {
+		return mfaError(c, operatorId, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// mfaError
+// Summary: This is function which converts the error of the MFA usecase to the HTTP error
+// input: c(echo.Context): context
+// input: operatorId(string): ID of the operator
+// input: method(string): method of the request
+// input: err(error): error object
+// output: error: HTTP error
+func mfaError(c echo.Context, operatorId string, method string, err error) error {
+	var customErr *common.CustomError
+	if errors.As(err, &customErr) {
+		if customErr.IsWarn() {
+			logger.Set(c).Warnf(err.Error())
+		} else {
+			logger.Set(c).Errorf(err.Error())
+		}
+		if customErr.RetryAfter > 0 {
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(customErr.RetryAfter.Seconds())))
+		}
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, operatorId, "", method))
+	}
+	logger.Set(c).Errorf(err.Error())
+
+	return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, operatorId, "", method))
+}
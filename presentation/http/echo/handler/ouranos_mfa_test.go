@@ -0,0 +1,405 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/mfa/enroll テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: MFAが有効な場合
+// [x] 2-2. 500: システムエラー：登録失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_EnrollMFA(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/mfa/enroll"
+
+	tests := []struct {
+		name         string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 400: MFAが有効な場合",
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400MFAAlreadyEnabled, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest MFA is already enabled",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 500: システムエラー：登録失敗",
+			receive:      fmt.Errorf("DB Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), nil)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+			operator := f.NewClaims()
+			c.Set("operator", &operator)
+
+			mfaUsecase := new(mocks.IMFAUsecase)
+			mfaHandler := handler.NewMFAHandler(mfaUsecase)
+
+			expected := output.EnrollMFAResponse{Secret: f.MFASecret, ProvisioningURI: "otpauth://totp/issuer:" + f.Email + "?secret=" + f.MFASecret}
+			mfaUsecase.On("EnrollMFA", f.NewInputEnrollMFAParam()).Return(expected, test.receive)
+			err := mfaHandler.EnrollMFA(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.EnrollMFAResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/mfa/activate テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：codeが未指定の場合
+// [x] 2-2. 400: MFAが未登録の場合
+// [x] 2-3. 401: コードが誤っている場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_ActivateMFA(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/mfa/activate"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.ActivateMFAParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.ActivateMFAParam {
+				return input.ActivateMFAParam{Email: f.Email, Code: "123456"}
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：codeが未指定の場合",
+			inputFunc: func() input.ActivateMFAParam {
+				return input.ActivateMFAParam{Email: f.Email}
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, code: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: MFAが未登録の場合",
+			inputFunc: func() input.ActivateMFAParam {
+				return input.ActivateMFAParam{Email: f.Email, Code: "123456"}
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest MFA is not enrolled",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 401: コードが誤っている場合",
+			inputFunc: func() input.ActivateMFAParam {
+				return input.ActivateMFAParam{Email: f.Email, Code: "123456"}
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized Invalid MFA code",
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+			operator := f.NewClaims()
+			c.Set("operator", &operator)
+
+			mfaUsecase := new(mocks.IMFAUsecase)
+			mfaHandler := handler.NewMFAHandler(mfaUsecase)
+
+			expected := output.ActivateMFAResponse{RecoveryCodes: []string{f.RecoveryCode}}
+			mfaUsecase.On("ActivateMFA", test.inputFunc()).Return(expected, test.receive)
+			err := mfaHandler.ActivateMFA(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.ActivateMFAResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/mfa/verify テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：challengeTokenが未指定の場合
+// [x] 2-2. 400: バリデーションエラー：codeが未指定の場合
+// [x] 2-3. 401: チャレンジが無効な場合
+// [x] 2-4. 401: コードが誤っている場合
+// [x] 2-5. 423: ロックエラー：アカウントがロックされている場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_VerifyMFA(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/mfa/verify"
+
+	tests := []struct {
+		name             string
+		inputFunc        func() input.VerifyMFAParam
+		receive          error
+		expectError      string
+		expectRetryAfter string
+		expectStatus     int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "123456", IPAddress: f.IpAddress}
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：challengeTokenが未指定の場合",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{Code: "123456", IPAddress: f.IpAddress}
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, challengeToken: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: バリデーションエラー：codeが未指定の場合",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, IPAddress: f.IpAddress}
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, code: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 401: チャレンジが無効な場合",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "123456", IPAddress: f.IpAddress}
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized Invalid or expired MFA challenge",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "2-4. 401: コードが誤っている場合",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "123456", IPAddress: f.IpAddress}
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized Invalid MFA code",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "2-5. 423: ロックエラー：アカウントがロックされている場合",
+			inputFunc: func() input.VerifyMFAParam {
+				return input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "123456", IPAddress: f.IpAddress}
+			},
+			receive:          common.NewCustomErrorWithRetryAfter(common.CustomErrorCode423, common.Err423AccountLocked, 90*time.Second, common.HTTPErrorSourceAuth),
+			expectError:      "code=423, message={[auth] Locked Account is temporarily locked",
+			expectRetryAfter: "90",
+			expectStatus:     http.StatusLocked,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(echo.HeaderXForwardedFor, f.IpAddress+", 10.0.0.1")
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			mfaUsecase := new(mocks.IMFAUsecase)
+			mfaHandler := handler.NewMFAHandler(mfaUsecase)
+
+			expected := output.LoginResponse{AccessToken: f.Token, RefreshToken: f.Token}
+			mfaUsecase.On("VerifyMFA", test.inputFunc()).Return(expected, test.receive)
+			err := mfaHandler.VerifyMFA(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.LoginResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+					mfaUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+					assert.Equal(t, test.expectRetryAfter, rec.Header().Get(echo.HeaderRetryAfter))
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/mfa/disable テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：codeが未指定の場合
+// [x] 2-2. 400: MFAが有効でない場合
+// [x] 2-3. 401: コードが誤っている場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_DisableMFA(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/mfa/disable"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.DisableMFAParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.DisableMFAParam {
+				return f.NewInputDisableMFAParam()
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：codeが未指定の場合",
+			inputFunc: func() input.DisableMFAParam {
+				param := f.NewInputDisableMFAParam()
+				param.Code = ""
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, code: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: MFAが有効でない場合",
+			inputFunc: func() input.DisableMFAParam {
+				return f.NewInputDisableMFAParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest MFA is not enrolled",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 401: コードが誤っている場合",
+			inputFunc: func() input.DisableMFAParam {
+				return f.NewInputDisableMFAParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized Invalid MFA code",
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+			operator := f.NewClaims()
+			c.Set("operator", &operator)
+
+			mfaUsecase := new(mocks.IMFAUsecase)
+			mfaHandler := handler.NewMFAHandler(mfaUsecase)
+
+			mfaUsecase.On("DisableMFA", test.inputFunc()).Return(test.receive)
+			err := mfaHandler.DisableMFA(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					mfaUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
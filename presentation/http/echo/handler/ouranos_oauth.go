@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Token
+// Summary: This is function which is used to issue the OAuth 2.0 access token with the client credentials grant
+// The errors are reported in the format of RFC 6749 so that the OAuth client libraries can handle them.
+// input: c(echo.Context): context
+// output: error: error object
+func (h *oauthHandler) Token(c echo.Context) error {
+	method := c.Request().Method
+	param := input.OAuthTokenParam{}
+
+	// the token response must not be cached
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Pragma", "no-cache")
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return oauthErrorResponse(c, common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrInvalidRequest, common.FormatBindErrMsg(err)))
+	}
+	// the client credentials in the Authorization header take precedence over the request body
+	if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+		param.ClientID = basicAuthUnescape(clientID)
+		param.ClientSecret = basicAuthUnescape(clientSecret)
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return oauthErrorResponse(c, common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrInvalidRequest, err.Error()))
+	}
+
+	output, err := h.OAuthUsecase.Token(param)
+	if err != nil {
+		var oauthErr *common.OAuthError
+		if errors.As(err, &oauthErr) {
+			logger.Set(c).Warnf(err.Error())
+
+			return oauthErrorResponse(c, oauthErr)
+		}
+		logger.Set(c).Errorf(err.Error())
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+	}
+	return c.JSON(http.StatusOK, output)
+}
+
+// oauthErrorResponse
+// Summary: This is function which writes the error response of the OAuth 2.0 token request
+// input: c(echo.Context): context
+// input: err(*common.OAuthError): error object
+// output: error: error object
+func oauthErrorResponse(c echo.Context, err *common.OAuthError) error {
+	if err.Code == common.CustomErrorCode401 {
+		c.Response().Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	return c.JSON(int(err.Code), output.OAuthErrorResponse{
+		Error:            err.ErrorCode,
+		ErrorDescription: err.Description,
+	})
+}
+
+// basicAuthUnescape
+// Summary: This is function which decodes the client credentials in the Authorization header
+// The client credentials are form-urlencoded before they are encoded with Base64 (RFC 6749 section 2.3.1).
+// input: s(string): encoded value
+// output: (string) decoded value
+func basicAuthUnescape(s string) string {
+	decoded, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
@@ -0,0 +1,155 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /oauth/token テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：リクエストボディでクライアント認証する場合
+// [x] 1-2. 200: 正常系：Authorizationヘッダでクライアント認証する場合
+// [x] 2-1. 400: バリデーションエラー：client_secretが未指定の場合
+// [x] 2-2. 401: クライアント認証に失敗した場合
+// [x] 2-3. 500: システムエラー：署名失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_OAuthToken(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/oauth/token"
+
+	tests := []struct {
+		name              string
+		form              url.Values
+		basicAuth         bool
+		receive           error
+		expectError       string
+		expectOAuthError  output.OAuthErrorResponse
+		expectAuthHeader  string
+		expectStatus      int
+		expectUsecaseCall bool
+	}{
+		{
+			name: "1-1. 200: 正常系：リクエストボディでクライアント認証する場合",
+			form: url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {f.OAuthClientID},
+				"client_secret": {f.OAuthClientSecret},
+			},
+			expectStatus:      http.StatusOK,
+			expectUsecaseCall: true,
+		},
+		{
+			name: "1-2. 200: 正常系：Authorizationヘッダでクライアント認証する場合",
+			form: url.Values{
+				"grant_type": {"client_credentials"},
+			},
+			basicAuth:         true,
+			expectStatus:      http.StatusOK,
+			expectUsecaseCall: true,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：client_secretが未指定の場合",
+			form: url.Values{
+				"grant_type": {"client_credentials"},
+				"client_id":  {f.OAuthClientID},
+			},
+			expectOAuthError: output.OAuthErrorResponse{Error: common.OAuthErrInvalidRequest, ErrorDescription: "client_secret: cannot be blank."},
+			expectStatus:     http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 401: クライアント認証に失敗した場合",
+			form: url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {f.OAuthClientID},
+				"client_secret": {f.OAuthClientSecret},
+			},
+			receive:           common.NewOAuthError(common.CustomErrorCode401, common.OAuthErrInvalidClient, "client authentication failed"),
+			expectOAuthError:  output.OAuthErrorResponse{Error: common.OAuthErrInvalidClient, ErrorDescription: "client authentication failed"},
+			expectAuthHeader:  `Basic realm="oauth"`,
+			expectStatus:      http.StatusUnauthorized,
+			expectUsecaseCall: true,
+		},
+		{
+			name: "2-3. 500: システムエラー：署名失敗",
+			form: url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {f.OAuthClientID},
+				"client_secret": {f.OAuthClientSecret},
+			},
+			receive:           fmt.Errorf("signing error"),
+			expectError:       "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus:      http.StatusInternalServerError,
+			expectUsecaseCall: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint, strings.NewReader(test.form.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			if test.basicAuth {
+				req.SetBasicAuth(f.OAuthClientID, f.OAuthClientSecret)
+			}
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			oauthUsecase := new(mocks.IOAuthUsecase)
+			oauthHandler := handler.NewOAuthHandler(oauthUsecase)
+
+			expected := output.OAuthTokenResponse{AccessToken: f.Token, TokenType: "Bearer", ExpiresIn: 900, Scope: "systemAuth"}
+			oauthUsecase.On("Token", f.NewInputOAuthTokenParam()).Return(expected, test.receive)
+			err := oauthHandler.Token(c)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			if test.expectUsecaseCall {
+				oauthUsecase.AssertCalled(t, "Token", f.NewInputOAuthTokenParam())
+			} else {
+				oauthUsecase.AssertNotCalled(t, "Token", mock.Anything)
+			}
+
+			switch test.expectStatus {
+			case http.StatusOK:
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.OAuthTokenResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			case http.StatusInternalServerError:
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			default:
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.Equal(t, test.expectAuthHeader, rec.Header().Get("WWW-Authenticate"))
+					actual := output.OAuthErrorResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, test.expectOAuthError, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestPasswordReset
+// Summary: This is function which is used to send the password reset code to the operator
+// input: c(echo.Context): context
+// output: error: error object
+func (h *passwordResetHandler) RequestPasswordReset(c echo.Context) error {
+	method := c.Request().Method
+	param := input.PasswordResetParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.PasswordResetUsecase.RequestPasswordReset(c.Request().Context(), param); err != nil {
+		return passwordResetError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// ConfirmPasswordReset
+// Summary: This is function which is used to reset the password with the code
+// input: c(echo.Context): context
+// output: error: error object
+func (h *passwordResetHandler) ConfirmPasswordReset(c echo.Context) error {
+	method := c.Request().Method
+	param := input.ConfirmPasswordResetParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.PasswordResetUsecase.ConfirmPasswordReset(c.Request().Context(), param); err != nil {
+		return passwordResetError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// passwordResetError
+// Summary: This is function which converts the error of the password reset usecase to the HTTP error
+// input: c(echo.Context): context
+// input: method(string): method of the request
+// input: err(error): error object
+// output: error: HTTP error
+func passwordResetError(c echo.Context, method string, err error) error {
+	var customErr *common.CustomError
+	if errors.As(err, &customErr) {
+		if customErr.IsWarn() {
+			logger.Set(c).Warnf(err.Error())
+		} else {
+			logger.Set(c).Errorf(err.Error())
+		}
+
+		return echo.NewHTTPError(common.HTTPErrorGenerateWithViolations(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Violations))
+	}
+	logger.Set(c).Errorf(err.Error())
+
+	return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+}
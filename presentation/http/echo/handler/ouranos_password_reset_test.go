@@ -0,0 +1,246 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/passwordReset テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：operatorAccountIdが未指定の場合
+// [x] 2-2. 400: バリデーションエラー：operatorAccountIdがメールアドレス形式でない場合
+// [x] 2-3. 429: 要求回数の上限超過
+// [x] 2-4. 500: システムエラー：メール送信失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_RequestPasswordReset(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/passwordReset"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.PasswordResetParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.PasswordResetParam {
+				return f.NewInputPasswordResetParam()
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：operatorAccountIdが未指定の場合",
+			inputFunc: func() input.PasswordResetParam {
+				param := f.NewInputPasswordResetParam()
+				param.OperatorAccountID = ""
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorAccountId: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: バリデーションエラー：operatorAccountIdがメールアドレス形式でない場合",
+			inputFunc: func() input.PasswordResetParam {
+				param := f.NewInputPasswordResetParam()
+				param.OperatorAccountID = "aaa"
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorAccountId: must be a valid email address.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 429: 要求回数の上限超過",
+			inputFunc: func() input.PasswordResetParam {
+				return f.NewInputPasswordResetParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyRequests, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=429, message={[auth] TooManyRequests Too many requests",
+			expectStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "2-4. 500: システムエラー：メール送信失敗",
+			inputFunc: func() input.PasswordResetParam {
+				return f.NewInputPasswordResetParam()
+			},
+			receive:      fmt.Errorf("SMTP Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			passwordResetUsecase := new(mocks.IPasswordResetUsecase)
+			passwordResetHandler := handler.NewPasswordResetHandler(passwordResetUsecase)
+
+			passwordResetUsecase.On("RequestPasswordReset", mock.Anything, test.inputFunc()).Return(test.receive)
+			err := passwordResetHandler.RequestPasswordReset(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					passwordResetUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /auth/passwordReset/confirm テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：codeが未指定の場合
+// [x] 2-2. 400: バリデーションエラー：newPasswordが未指定の場合
+// [x] 2-3. 400: コードが無効の場合
+// [x] 2-4. 500: システムエラー：再設定失敗
+// [x] 2-5. 400: パスワードポリシーエラー：違反したルールを返却
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_ConfirmPasswordReset(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/auth/passwordReset/confirm"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.ConfirmPasswordResetParam
+		receive      error
+		expectError  string
+		expectBody   string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				return f.NewInputConfirmPasswordResetParam()
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：codeが未指定の場合",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				param := f.NewInputConfirmPasswordResetParam()
+				param.Code = ""
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, code: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: バリデーションエラー：newPasswordが未指定の場合",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				param := f.NewInputConfirmPasswordResetParam()
+				param.NewPassword = ""
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, newPassword: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-5. 400: パスワードポリシーエラー：違反したルールを返却",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				param := f.NewInputConfirmPasswordResetParam()
+				param.NewPassword = "1Aa@1Aa"
+				return param
+			},
+			receive: common.NewCustomErrorWithViolations(common.CustomErrorCode400, common.Err400PasswordPolicy, []common.ErrorViolation{
+				{Field: "newPassword", Rule: "minLength", Message: "must be at least 8 characters"},
+			}, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Password does not satisfy the password policy",
+			expectBody:   `"violations":[{"field":"newPassword","rule":"minLength","message":"must be at least 8 characters"}]`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 400: コードが無効の場合",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				return f.NewInputConfirmPasswordResetParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400InvalidResetCode, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Invalid or expired password reset code",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-4. 500: システムエラー：再設定失敗",
+			inputFunc: func() input.ConfirmPasswordResetParam {
+				return f.NewInputConfirmPasswordResetParam()
+			},
+			receive:      fmt.Errorf("IdP Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			passwordResetUsecase := new(mocks.IPasswordResetUsecase)
+			passwordResetHandler := handler.NewPasswordResetHandler(passwordResetUsecase)
+
+			passwordResetUsecase.On("ConfirmPasswordReset", mock.Anything, test.inputFunc()).Return(test.receive)
+			err := passwordResetHandler.ConfirmPasswordReset(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					passwordResetUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+					assert.Contains(t, rec.Body.String(), test.expectBody)
+				}
+			}
+		})
+	}
+}
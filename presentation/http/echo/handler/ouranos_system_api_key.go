@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateAPIKey
+// Summary: This is function which is used to create the API key generated by the server
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) CreateAPIKey(c echo.Context) error {
+	method := c.Request().Method
+	param := input.CreateAPIKeyParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.APIKeyUsecase.CreateAPIKey(param)
+	if err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// ListAPIKeys
+// Summary: This is function which is used to list the API keys which are not revoked
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) ListAPIKeys(c echo.Context) error {
+	method := c.Request().Method
+
+	output, err := h.APIKeyUsecase.ListAPIKeys()
+	if err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, output)
+}
+
+// UpdateAPIKey
+// Summary: This is function which is used to change the application name and the application attribute of the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) UpdateAPIKey(c echo.Context) error {
+	method := c.Request().Method
+	param := input.UpdateAPIKeyParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.ID = c.Param("id")
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.UpdateAPIKey(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// RevokeAPIKey
+// Summary: This is function which is used to revoke the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) RevokeAPIKey(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyParam{ID: c.Param("id"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.RevokeAPIKey(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// RotateAPIKey
+// Summary: This is function which is used to issue the successor of the API key which expires after the grace period
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) RotateAPIKey(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyParam{ID: c.Param("id"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.APIKeyUsecase.RotateAPIKey(param)
+	if err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// BindAPIKeyOperator
+// Summary: This is function which is used to bind the operator to the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) BindAPIKeyOperator(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyOperatorParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.ID = c.Param("id")
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.BindOperator(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// UnbindAPIKeyOperator
+// Summary: This is function which is used to unbind the operator from the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) UnbindAPIKeyOperator(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyOperatorParam{ID: c.Param("id"), OperatorID: c.Param("operatorId"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.UnbindOperator(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// AddAPIKeyCidr
+// Summary: This is function which is used to add the CIDR to the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) AddAPIKeyCidr(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyCidrParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.ID = c.Param("id")
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.AddCidr(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// RemoveAPIKeyCidr
+// Summary: This is function which is used to remove the CIDR from the API key
+// The CIDR is specified by the query parameter because it contains a slash.
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) RemoveAPIKeyCidr(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyCidrParam{ID: c.Param("id"), Cidr: c.QueryParam("cidr"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.RemoveCidr(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// AddAPIKeyClientCertificate
+// Summary: This is function which is used to map the client certificate to the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) AddAPIKeyClientCertificate(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyClientCertificateParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.ID = c.Param("id")
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.AddClientCertificate(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// RemoveAPIKeyClientCertificate
+// Summary: This is function which is used to unmap the client certificate from the API key
+// The certificate is specified by the query parameters because the subject DN contains commas and slashes.
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) RemoveAPIKeyClientCertificate(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyClientCertificateParam{
+		ID:              c.Param("id"),
+		Type:            authentication.ClientCertificateType(c.QueryParam("type")),
+		Certificate:     c.QueryParam("certificate"),
+		RequestAPIKeyID: requestAPIKeyID(c),
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.RemoveClientCertificate(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// GrantAPIKeyPermission
+// Summary: This is function which is used to grant the route permission to the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) GrantAPIKeyPermission(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyPermissionParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.ID = c.Param("id")
+	param.RequestAPIKeyID = requestAPIKeyID(c)
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.GrantPermission(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// RevokeAPIKeyPermission
+// Summary: This is function which is used to revoke the route permission from the API key
+// The permission is specified by the query parameters because the path contains slashes.
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) RevokeAPIKeyPermission(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyPermissionParam{
+		ID:              c.Param("id"),
+		Method:          c.QueryParam("method"),
+		Path:            c.QueryParam("path"),
+		RequestAPIKeyID: requestAPIKeyID(c),
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.RevokePermission(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// EnableAPIKeyRequestSigning
+// Summary: This is function which is used to generate the signing secret of the API key and require the requests with the API key to be signed
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) EnableAPIKeyRequestSigning(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyParam{ID: c.Param("id"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.APIKeyUsecase.EnableRequestSigning(param)
+	if err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// DisableAPIKeyRequestSigning
+// Summary: This is function which is used to remove the signing secret of the API key
+// input: c(echo.Context): context
+// output: error: error object
+func (h *apiKeyHandler) DisableAPIKeyRequestSigning(c echo.Context) error {
+	method := c.Request().Method
+	param := input.APIKeyParam{ID: c.Param("id"), RequestAPIKeyID: requestAPIKeyID(c)}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.APIKeyUsecase.DisableRequestSigning(param); err != nil {
+		return apiKeyError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// requestAPIKeyID
+// Summary: This is function which returns the ID of the API key of the request set by the API key validator
+// input: c(echo.Context): context
+// output: string: ID of the API key
+func requestAPIKeyID(c echo.Context) string {
+	apiKeyID, _ := c.Get("apiKeyID").(string)
+	return apiKeyID
+}
+
+// apiKeyError
+// Summary: This is function which converts the error of the API key usecase to the HTTP error
+// input: c(echo.Context): context
+// input: method(string): method of the request
+// input: err(error): error object
+// output: error: HTTP error
+func apiKeyError(c echo.Context, method string, err error) error {
+	var customErr *common.CustomError
+	if errors.As(err, &customErr) {
+		if customErr.IsWarn() {
+			logger.Set(c).Warnf(err.Error())
+		} else {
+			logger.Set(c).Errorf(err.Error())
+		}
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method))
+	}
+	logger.Set(c).Errorf(err.Error())
+
+	return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+}
@@ -0,0 +1,904 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const apiKeyID = "00000000-0000-0000-0000-000000000001"
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/apiKeys テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：生成したAPIキーを返却
+// [x] 2-1. 400: バリデーションエラー：applicationNameが未指定の場合
+// [x] 2-2. 400: バリデーションエラー：applicationAttributeが定義外の値の場合
+// [x] 2-3. 400: バリデーションエラー：expiresAtがnotBefore以前の場合
+// [x] 2-4. 500: システムエラー：作成失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_CreateAPIKey(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/apiKeys"
+
+	tests := []struct {
+		name         string
+		inputBody    string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：生成したAPIキーを返却",
+			inputBody:    `{"applicationName": "New-Application", "applicationAttribute": "DataSpace"}`,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：applicationNameが未指定の場合",
+			inputBody:    `{"applicationAttribute": "DataSpace"}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, applicationName: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：applicationAttributeが定義外の値の場合",
+			inputBody:    `{"applicationName": "New-Application", "applicationAttribute": "Unknown"}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, applicationAttribute: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 400: バリデーションエラー：expiresAtがnotBefore以前の場合",
+			inputBody:    `{"applicationName": "New-Application", "applicationAttribute": "DataSpace", "notBefore": "2030-05-02T00:00:00Z", "expiresAt": "2030-05-01T00:00:00Z"}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, expiresAt: must be after notBefore.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-4. 500: システムエラー：作成失敗",
+			inputBody:    `{"applicationName": "New-Application", "applicationAttribute": "DataSpace"}`,
+			receive:      fmt.Errorf("DB Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint, strings.NewReader(test.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(endPoint)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			param := input.CreateAPIKeyParam{
+				ApplicationName:      "New-Application",
+				ApplicationAttribute: authentication.ApplicationAttributeDataSpace,
+				RequestAPIKeyID:      f.ApiKeyID,
+			}
+			expected := output.CreateAPIKeyResponse{
+				ID:                   apiKeyID,
+				APIKey:               "generated",
+				ApplicationName:      param.ApplicationName,
+				ApplicationAttribute: param.ApplicationAttribute,
+			}
+			apiKeyUsecase.On("CreateAPIKey", param).Return(expected, test.receive)
+			err := apiKeyHandler.CreateAPIKey(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.CreateAPIKeyResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// PATCH /api/v1/systemAuth/apiKeys/:id テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：OAuth 2.0のアクセストークンに紐づくAPIキーを変更者とする
+// [x] 1-2. 200: 正常系：リクエスト数の上限のみ変更する場合
+// [x] 2-1. 400: バリデーションエラー：変更内容が未指定の場合
+// [x] 2-2. 400: バリデーションエラー：idがUUID形式でない場合
+// [x] 2-3. 400: バリデーションエラー：rateLimitPerMinuteが負の値の場合
+// [x] 2-4. 404: 変更対象のAPIキーが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_UpdateAPIKey(tt *testing.T) {
+	var method = "PATCH"
+	var endPoint = "/api/v1/systemAuth/apiKeys/:id"
+	applicationName := "Renamed-Application"
+	dailyQuota := 1000
+
+	tests := []struct {
+		name         string
+		id           string
+		inputBody    string
+		receive      error
+		expectInput  input.UpdateAPIKeyParam
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 200: 正常系：OAuth 2.0のアクセストークンに紐づくAPIキーを変更者とする",
+			id:           apiKeyID,
+			inputBody:    `{"applicationName": "Renamed-Application"}`,
+			expectInput:  input.UpdateAPIKeyParam{ID: apiKeyID, ApplicationName: &applicationName, RequestAPIKeyID: f.ApiKeyID},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "1-2. 200: 正常系：リクエスト数の上限のみ変更する場合",
+			id:           apiKeyID,
+			inputBody:    `{"dailyQuota": 1000}`,
+			expectInput:  input.UpdateAPIKeyParam{ID: apiKeyID, DailyQuota: &dailyQuota, RequestAPIKeyID: f.ApiKeyID},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：変更内容が未指定の場合",
+			id:           apiKeyID,
+			inputBody:    `{}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, applicationName: is required.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：idがUUID形式でない場合",
+			id:           f.InvalidUUID,
+			inputBody:    `{"applicationName": "Renamed-Application"}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, id: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 400: バリデーションエラー：rateLimitPerMinuteが負の値の場合",
+			id:           apiKeyID,
+			inputBody:    `{"rateLimitPerMinute": -1}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, rateLimitPerMinute: must be no less than 0.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-4. 404: 変更対象のAPIキーが存在しない場合",
+			id:           apiKeyID,
+			inputBody:    `{"applicationName": "Renamed-Application"}`,
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+			expectInput:  input.UpdateAPIKeyParam{ID: apiKeyID, ApplicationName: &applicationName, RequestAPIKeyID: f.ApiKeyID},
+			expectError:  "code=404, message={[auth] NotFound API key not found",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, strings.Replace(endPoint, ":id", test.id, 1), strings.NewReader(test.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+			c.Set("apiKeyID", f.ApiKeyID)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			apiKeyUsecase.On("UpdateAPIKey", test.expectInput).Return(test.receive)
+			err := apiKeyHandler.UpdateAPIKey(c)
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					apiKeyUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// DELETE /api/v1/systemAuth/apiKeys/:id, /operators/:operatorId, /cidrs テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：失効
+// [x] 1-2. 200: 正常系：事業者の紐付け解除
+// [x] 1-3. 200: 正常系：CIDRの削除
+// [x] 2-1. 400: バリデーションエラー：cidrがCIDR形式でない場合
+// [x] 2-2. 404: 紐付けられていない事業者を解除する場合
+// [x] 2-3. 500: システムエラー：失効失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_RevokeAPIKey(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		endPoint     string
+		query        string
+		usecase      string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 200: 正常系：失効",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id",
+			usecase:      "RevokeAPIKey",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "1-2. 200: 正常系：事業者の紐付け解除",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/operators/:operatorId",
+			usecase:      "UnbindOperator",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "1-3. 200: 正常系：CIDRの削除",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			query:        "10.0.0.0/8",
+			usecase:      "RemoveCidr",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：cidrがCIDR形式でない場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			query:        "10.0.0.0",
+			usecase:      "RemoveCidr",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, cidr: must be a valid CIDR.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 404: 紐付けられていない事業者を解除する場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/operators/:operatorId",
+			usecase:      "UnbindOperator",
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound Resource Not Found",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "2-3. 500: システムエラー：失効失敗",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id",
+			usecase:      "RevokeAPIKey",
+			receive:      fmt.Errorf("DB Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := strings.NewReplacer(":id", apiKeyID, ":operatorId", f.OperatorID).Replace(test.endPoint)
+			if test.query != "" {
+				target += "?cidr=" + test.query
+			}
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", target, nil)
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(test.endPoint)
+			c.SetParamNames("id", "operatorId")
+			c.SetParamValues(apiKeyID, f.OperatorID)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			apiKeyUsecase.On("RevokeAPIKey", input.APIKeyParam{ID: apiKeyID, RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			apiKeyUsecase.On("UnbindOperator", input.APIKeyOperatorParam{ID: apiKeyID, OperatorID: f.OperatorID, RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			apiKeyUsecase.On("RemoveCidr", input.APIKeyCidrParam{ID: apiKeyID, Cidr: test.query, RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			var err error
+			switch test.usecase {
+			case "RevokeAPIKey":
+				err = apiKeyHandler.RevokeAPIKey(c)
+			case "UnbindOperator":
+				err = apiKeyHandler.UnbindAPIKeyOperator(c)
+			case "RemoveCidr":
+				err = apiKeyHandler.RemoveAPIKeyCidr(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					apiKeyUsecase.AssertCalled(t, test.usecase, mock.Anything)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/apiKeys/:id/rotate テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：後継のAPIキーとローテーション前のAPIキーの有効期限を返却
+// [x] 2-1. 400: バリデーションエラー：idがUUID形式でない場合
+// [x] 2-2. 404: ローテーション対象のAPIキーが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_RotateAPIKey(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/apiKeys/:id/rotate"
+
+	tests := []struct {
+		name         string
+		id           string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：後継のAPIキーとローテーション前のAPIキーの有効期限を返却",
+			id:           apiKeyID,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：idがUUID形式でない場合",
+			id:           "invalid",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, id: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 404: ローテーション対象のAPIキーが存在しない場合",
+			id:           apiKeyID,
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound " + common.Err404APIKeyNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, strings.Replace(endPoint, ":id", test.id, 1), nil)
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			expiresAt := time.Date(2030, 5, 31, 0, 0, 0, 0, time.UTC)
+			expected := output.RotateAPIKeyResponse{
+				CreateAPIKeyResponse: output.CreateAPIKeyResponse{
+					ID:                   "00000000-0000-0000-0000-000000000010",
+					APIKey:               "generated",
+					ApplicationName:      "application",
+					ApplicationAttribute: authentication.ApplicationAttributeApplication,
+					NotBefore:            time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC),
+					ExpiresAt:            &expiresAt,
+				},
+				PreviousID:        apiKeyID,
+				PreviousExpiresAt: time.Date(2030, 5, 2, 0, 0, 0, 0, time.UTC),
+			}
+			apiKeyUsecase.On("RotateAPIKey", input.APIKeyParam{ID: test.id, RequestAPIKeyID: f.ApiKeyID}).Return(expected, test.receive)
+			err := apiKeyHandler.RotateAPIKey(c)
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.RotateAPIKeyResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/apiKeys/:id/operators, /cidrs テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：事業者の紐付け
+// [x] 1-2. 201: 正常系：CIDRの追加
+// [x] 1-3. 201: 正常系：IPv6のCIDRの拒否ルールを優先度とともに追加
+// [x] 2-1. 400: バリデーションエラー：operatorIdがUUID形式でない場合
+// [x] 2-2. 400: 事業者が存在しない場合
+// [x] 2-3. 400: バリデーションエラー：actionがallow、deny以外の場合
+// [x] 2-4. 400: バリデーションエラー：priorityが負の値の場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_BindAPIKeyOperator(tt *testing.T) {
+
+	priority := 10
+
+	tests := []struct {
+		name         string
+		endPoint     string
+		inputBody    string
+		usecase      string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：事業者の紐付け",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/operators",
+			inputBody:    fmt.Sprintf(`{"operatorId": "%s"}`, f.OperatorID),
+			usecase:      "BindOperator",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-2. 201: 正常系：CIDRの追加",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			inputBody:    `{"cidr": "10.0.0.0/8"}`,
+			usecase:      "AddCidr",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-3. 201: 正常系：IPv6のCIDRの拒否ルールを優先度とともに追加",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			inputBody:    `{"cidr": "2001:db8::/32", "action": "deny", "priority": 10}`,
+			usecase:      "AddCidr",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：operatorIdがUUID形式でない場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/operators",
+			inputBody:    fmt.Sprintf(`{"operatorId": "%s"}`, f.InvalidUUID),
+			usecase:      "BindOperator",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorId: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: 事業者が存在しない場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/operators",
+			inputBody:    fmt.Sprintf(`{"operatorId": "%s"}`, f.OperatorID),
+			usecase:      "BindOperator",
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Operator does not exist",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 400: バリデーションエラー：actionがallow、deny以外の場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			inputBody:    `{"cidr": "10.0.0.0/8", "action": "block"}`,
+			usecase:      "AddCidr",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, action: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-4. 400: バリデーションエラー：priorityが負の値の場合",
+			endPoint:     "/api/v1/systemAuth/apiKeys/:id/cidrs",
+			inputBody:    `{"cidr": "10.0.0.0/8", "priority": -1}`,
+			usecase:      "AddCidr",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, priority: must be no less than 0.",
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", strings.Replace(test.endPoint, ":id", apiKeyID, 1), strings.NewReader(test.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(test.endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(apiKeyID)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			apiKeyUsecase.On("BindOperator", input.APIKeyOperatorParam{ID: apiKeyID, OperatorID: f.OperatorID, RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			apiKeyUsecase.On("AddCidr", input.APIKeyCidrParam{ID: apiKeyID, Cidr: "10.0.0.0/8", RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			apiKeyUsecase.On("AddCidr", input.APIKeyCidrParam{ID: apiKeyID, Cidr: "2001:db8::/32", Action: authentication.CidrActionDeny, Priority: &priority, RequestAPIKeyID: f.ApiKeyID}).Return(test.receive)
+			var err error
+			switch test.usecase {
+			case "BindOperator":
+				err = apiKeyHandler.BindAPIKeyOperator(c)
+			case "AddCidr":
+				err = apiKeyHandler.AddAPIKeyCidr(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					apiKeyUsecase.AssertCalled(t, test.usecase, mock.Anything)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST, DELETE /api/v1/systemAuth/apiKeys/:id/certificates テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：フィンガープリントの追加
+// [x] 1-2. 200: 正常系：サブジェクトDNの削除
+// [x] 2-1. 400: バリデーションエラー：typeが不正な値の場合
+// [x] 2-2. 400: バリデーションエラー：certificateがSHA-256のフィンガープリントでない場合
+// [x] 2-3. 404: 追加されていないクライアント証明書を削除する場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_APIKeyClientCertificate(tt *testing.T) {
+	var endPoint = "/api/v1/systemAuth/apiKeys/:id/certificates"
+	var fingerprint = strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name         string
+		method       string
+		certType     string
+		certificate  string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：フィンガープリントの追加",
+			method:       "POST",
+			certType:     "fingerprint",
+			certificate:  fingerprint,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-2. 200: 正常系：サブジェクトDNの削除",
+			method:       "DELETE",
+			certType:     "subject",
+			certificate:  "CN=client,O=Example",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：typeが不正な値の場合",
+			method:       "POST",
+			certType:     "serial",
+			certificate:  fingerprint,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, type: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：certificateがSHA-256のフィンガープリントでない場合",
+			method:       "DELETE",
+			certType:     "fingerprint",
+			certificate:  "ab:cd",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, certificate: must be a valid SHA-256 fingerprint.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 404: 追加されていないクライアント証明書を削除する場合",
+			method:       "DELETE",
+			certType:     "subject",
+			certificate:  "CN=client,O=Example",
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound Resource Not Found",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := strings.Replace(endPoint, ":id", apiKeyID, 1)
+			var req *http.Request
+			if test.method == "POST" {
+				body, _ := json.Marshal(map[string]string{"type": test.certType, "certificate": test.certificate})
+				req = httptest.NewRequest(test.method, target, strings.NewReader(string(body)))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			} else {
+				q := make(url.Values)
+				q.Set("type", test.certType)
+				q.Set("certificate", test.certificate)
+				req = httptest.NewRequest(test.method, target+"?"+q.Encode(), nil)
+			}
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(apiKeyID)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			param := input.APIKeyClientCertificateParam{ID: apiKeyID, Type: authentication.ClientCertificateType(test.certType), Certificate: test.certificate, RequestAPIKeyID: f.ApiKeyID}
+			apiKeyUsecase.On("AddClientCertificate", param).Return(test.receive)
+			apiKeyUsecase.On("RemoveClientCertificate", param).Return(test.receive)
+			var err error
+			var usecase string
+			if test.method == "POST" {
+				usecase = "AddClientCertificate"
+				err = apiKeyHandler.AddAPIKeyClientCertificate(c)
+			} else {
+				usecase = "RemoveClientCertificate"
+				err = apiKeyHandler.RemoveAPIKeyClientCertificate(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					apiKeyUsecase.AssertCalled(t, usecase, param)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST, DELETE /api/v1/systemAuth/apiKeys/:id/permissions テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：権限の付与
+// [x] 1-2. 201: 正常系：全てのルートを許可する権限の付与
+// [x] 1-3. 200: 正常系：権限の取消
+// [x] 2-1. 400: バリデーションエラー：methodが定義外の値の場合
+// [x] 2-2. 400: バリデーションエラー：pathがスラッシュで始まらない場合
+// [x] 2-3. 404: 付与されていない権限を取り消す場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_APIKeyPermission(tt *testing.T) {
+	var endPoint = "/api/v1/systemAuth/apiKeys/:id/permissions"
+
+	tests := []struct {
+		name         string
+		method       string
+		permission   string
+		path         string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：権限の付与",
+			method:       "POST",
+			permission:   "GET",
+			path:         "/api/v1/authInfo",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-2. 201: 正常系：全てのルートを許可する権限の付与",
+			method:       "POST",
+			permission:   "*",
+			path:         "*",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-3. 200: 正常系：権限の取消",
+			method:       "DELETE",
+			permission:   "PUT",
+			path:         "/dataReset",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：methodが定義外の値の場合",
+			method:       "POST",
+			permission:   "TRACE",
+			path:         "/api/v1/authInfo",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, method: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：pathがスラッシュで始まらない場合",
+			method:       "DELETE",
+			permission:   "GET",
+			path:         "api/v1/authInfo",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, path: must be a route path starting with a slash or *.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 404: 付与されていない権限を取り消す場合",
+			method:       "DELETE",
+			permission:   "PUT",
+			path:         "/dataReset",
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound Resource Not Found",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := strings.Replace(endPoint, ":id", apiKeyID, 1)
+			var req *http.Request
+			if test.method == "POST" {
+				body, _ := json.Marshal(map[string]string{"method": test.permission, "path": test.path})
+				req = httptest.NewRequest(test.method, target, strings.NewReader(string(body)))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			} else {
+				q := make(url.Values)
+				q.Set("method", test.permission)
+				q.Set("path", test.path)
+				req = httptest.NewRequest(test.method, target+"?"+q.Encode(), nil)
+			}
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(apiKeyID)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			param := input.APIKeyPermissionParam{ID: apiKeyID, Method: test.permission, Path: test.path, RequestAPIKeyID: f.ApiKeyID}
+			apiKeyUsecase.On("GrantPermission", param).Return(test.receive)
+			apiKeyUsecase.On("RevokePermission", param).Return(test.receive)
+			var err error
+			var usecase string
+			if test.method == "POST" {
+				usecase = "GrantPermission"
+				err = apiKeyHandler.GrantAPIKeyPermission(c)
+			} else {
+				usecase = "RevokePermission"
+				err = apiKeyHandler.RevokeAPIKeyPermission(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					apiKeyUsecase.AssertCalled(t, usecase, param)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST, DELETE /api/v1/systemAuth/apiKeys/:id/signingSecret テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：生成した署名シークレットを返却
+// [x] 1-2. 200: 正常系：署名の無効化
+// [x] 2-1. 400: バリデーションエラー：idがUUID形式でない場合
+// [x] 2-2. 404: APIキーが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_APIKeyRequestSigning(tt *testing.T) {
+	var endPoint = "/api/v1/systemAuth/apiKeys/:id/signingSecret"
+
+	tests := []struct {
+		name         string
+		method       string
+		id           string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：生成した署名シークレットを返却",
+			method:       "POST",
+			id:           apiKeyID,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-2. 200: 正常系：署名の無効化",
+			method:       "DELETE",
+			id:           apiKeyID,
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：idがUUID形式でない場合",
+			method:       "POST",
+			id:           "invalid",
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, id: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 404: APIキーが存在しない場合",
+			method:       "DELETE",
+			id:           apiKeyID,
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound " + common.Err404APIKeyNotFound,
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, strings.Replace(endPoint, ":id", test.id, 1), nil)
+			c := e.NewContext(req, rec)
+			c.Set("apiKeyID", f.ApiKeyID)
+			c.SetPath(endPoint)
+			c.SetParamNames("id")
+			c.SetParamValues(test.id)
+
+			apiKeyUsecase := new(mocks.IAPIKeyUsecase)
+			apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUsecase)
+
+			param := input.APIKeyParam{ID: test.id, RequestAPIKeyID: f.ApiKeyID}
+			expected := output.SigningSecretResponse{ID: test.id, SigningSecret: "generated"}
+			apiKeyUsecase.On("EnableRequestSigning", param).Return(expected, test.receive)
+			apiKeyUsecase.On("DisableRequestSigning", param).Return(test.receive)
+			var err error
+			if test.method == "POST" {
+				err = apiKeyHandler.EnableAPIKeyRequestSigning(c)
+			} else {
+				err = apiKeyHandler.DisableAPIKeyRequestSigning(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					if test.method == "POST" {
+						actual := output.SigningSecretResponse{}
+						_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+						assert.Equal(t, expected, actual)
+					} else {
+						apiKeyUsecase.AssertCalled(t, "DisableRequestSigning", param)
+					}
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
@@ -33,13 +33,13 @@ func (h *authHandler) TokenIntrospection(c echo.Context) error {
 		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
 	}
 
-	output, err := h.VerifyUsecase.TokenIntrospection(param)
+	output, err := h.VerifyUsecase.TokenIntrospection(c.Request().Context(), param)
 	if err != nil {
 		var customErr *common.CustomError
 		if errors.As(err, &customErr) {
 			logger.Set(c).Errorf(err.Error())
 
-			return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusServiceUnavailable, common.HTTPErrorSourceAuth, common.Err503OuterService, "", "", method))
+			return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Reason))
 		}
 		logger.Set(c).Errorf(err.Error())
 
@@ -49,6 +49,45 @@ func (h *authHandler) TokenIntrospection(c echo.Context) error {
 	return c.JSON(http.StatusOK, output)
 }
 
+// IntrospectToken
+// Summary: This is the function which introspects the token in the format of RFC 7662.
+// The parameter is accepted in the form-encoded body as well as in JSON, and the inactive token is reported with 200.
+// input: c(echo.Context): echo context
+// output: (error) error object
+func (h *authHandler) IntrospectToken(c echo.Context) error {
+	var param input.IntrospectTokenParam
+	method := c.Request().Method
+
+	// the introspection response must not be cached
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := common.FormatBindErrMsg(err)
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400InvalidRequest, "", "", method, errDetails))
+	}
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.VerifyUsecase.IntrospectToken(c.Request().Context(), param)
+	if err != nil {
+		logger.Set(c).Errorf(err.Error())
+
+		var customErr *common.CustomError
+		if errors.As(err, &customErr) {
+			return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Reason))
+		}
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+	}
+
+	return c.JSON(http.StatusOK, output)
+}
+
 // ApiKey
 // Summary: This is the function which verifies the api key.
 // input: c(echo.Context): echo context
@@ -75,3 +114,33 @@ func (h *authHandler) ApiKey(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, output)
 }
+
+// UnlockAccount
+// Summary: This is the function which unlocks the account locked by the failed login attempts.
+// input: c(echo.Context): echo context
+// output: (error) error object
+func (h *authHandler) UnlockAccount(c echo.Context) error {
+	var param input.UnlockAccountParam
+	method := c.Request().Method
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := common.FormatBindErrMsg(err)
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400InvalidRequest, "", "", method, errDetails))
+	}
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+		errDetails := err.Error()
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.AuthUsecase.UnlockAccount(c.Request().Context(), param); err != nil {
+		logger.Set(c).Errorf(err.Error())
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+	}
+
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListAuthEvents
+// Summary: This is function which is used to list the recorded authentication events
+// input: c(echo.Context): context
+// output: error: error object
+func (h *authEventHandler) ListAuthEvents(c echo.Context) error {
+	method := c.Request().Method
+	param := input.ListAuthEventsParam{
+		From:       c.QueryParam("from"),
+		To:         c.QueryParam("to"),
+		OperatorID: c.QueryParam("operatorId"),
+		Event:      c.QueryParam("event"),
+		Cursor:     c.QueryParam("cursor"),
+	}
+
+	if err := echo.QueryParamsBinder(c).Int("limit", &param.Limit).BindError(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := "limit: must be an integer."
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.AuthEventUsecase.ListAuthEvents(param)
+	if err != nil {
+		logger.Set(c).Errorf(err.Error())
+
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+	}
+	return c.JSON(http.StatusOK, output)
+}
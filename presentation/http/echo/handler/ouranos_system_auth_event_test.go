@@ -0,0 +1,129 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// GET /api/v1/systemAuth/events テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：検索条件指定あり
+// [x] 1-2. 200: 正常系：検索条件指定なし
+// [x] 2-1. 400: バリデーションエラー：fromがRFC3339形式でない場合
+// [x] 2-2. 400: バリデーションエラー：limitが整数でない場合
+// [x] 2-3. 400: バリデーションエラー：cursorが不正な場合
+// [x] 2-4. 500: システムエラー：一覧取得失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_ListAuthEvents(tt *testing.T) {
+	var method = "GET"
+	var endPoint = "/api/v1/systemAuth/events"
+
+	tests := []struct {
+		name         string
+		query        url.Values
+		expectInput  input.ListAuthEventsParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 200: 正常系：検索条件指定あり",
+			query: url.Values{
+				"from":       {"2024-05-01T00:00:00Z"},
+				"to":         {"2024-05-02T00:00:00+09:00"},
+				"operatorId": {f.OperatorID},
+				"event":      {"operatorLogin,apiKey"},
+				"limit":      {"10"},
+			},
+			expectInput: input.ListAuthEventsParam{
+				From:       "2024-05-01T00:00:00Z",
+				To:         "2024-05-02T00:00:00+09:00",
+				OperatorID: f.OperatorID,
+				Event:      "operatorLogin,apiKey",
+				Limit:      10,
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "1-2. 200: 正常系：検索条件指定なし",
+			query:        url.Values{},
+			expectInput:  input.ListAuthEventsParam{},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：fromがRFC3339形式でない場合",
+			query:        url.Values{"from": {"2024-05-01"}},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, from: must be a valid date.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：limitが整数でない場合",
+			query:        url.Values{"limit": {"ten"}},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, limit: must be an integer.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 400: バリデーションエラー：cursorが不正な場合",
+			query:        url.Values{"cursor": {"invalid"}},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, cursor: invalid cursor.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-4. 500: システムエラー：一覧取得失敗",
+			query:        url.Values{},
+			expectInput:  input.ListAuthEventsParam{},
+			receive:      fmt.Errorf("DB Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+test.query.Encode(), nil)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			authEventUsecase := new(mocks.IAuthEventUsecase)
+			authEventHandler := handler.NewAuthEventHandler(authEventUsecase)
+
+			expected := output.NewAuthEventsResponse(f.NewAuthEvents(1), nil)
+			authEventUsecase.On("ListAuthEvents", test.expectInput).Return(expected, test.receive)
+			err := authEventHandler.ListAuthEvents(c)
+			if test.expectStatus == http.StatusOK {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.AuthEventsResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected.Events[0].ID, actual.Events[0].ID)
+					assert.Nil(t, actual.NextCursor)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
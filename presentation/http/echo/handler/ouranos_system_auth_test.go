@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -68,7 +69,7 @@ func TestProjectHandler_SystemAuthToken_Normal(tt *testing.T) {
 					authUsecase,
 					verifyUsecase,
 				)
-				verifyUsecase.On("TokenIntrospection", test.input).Return(res, nil)
+				verifyUsecase.On("TokenIntrospection", mock.Anything, test.input).Return(res, nil)
 
 				err := authHandler.TokenIntrospection(c)
 				if assert.NoError(t, err) {
@@ -85,6 +86,8 @@ func TestProjectHandler_SystemAuthToken_Normal(tt *testing.T) {
 // [x] 1-1. 400: バリデーションエラー: idTokenが含まれていない場合
 // [x] 1-2. 400: バリデーションエラー: idTokenがstring形式でない場合
 // [x] 1-3. 500: システムエラー: トークン処理異常の場合
+// [x] 1-4. 401: 認証エラー: トークンの有効期限切れの場合
+// [x] 1-5. 403: 権限エラー: ユーザが無効化されている場合
 // /////////////////////////////////////////////////////////////////////////////////
 func TestProjectHandler_SystemAuthToken(tt *testing.T) {
 	var method = "POST"
@@ -125,6 +128,24 @@ func TestProjectHandler_SystemAuthToken(tt *testing.T) {
 			expectError:  "code=503, message={[auth] ServiceUnavailable",
 			expectStatus: http.StatusServiceUnavailable,
 		},
+		{
+			name: "1-4. 401: 認証エラー：トークンの有効期限切れの場合",
+			input: input.VerifyTokenParam{
+				IDToken: f.Token,
+			},
+			receive:      common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidToken, common.ReasonTokenExpired, common.HTTPErrorSourceAuth),
+			expectError:  "code=401, message={[auth] Unauthorized",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "1-5. 403: 権限エラー：ユーザが無効化されている場合",
+			input: input.VerifyTokenParam{
+				IDToken: f.Token,
+			},
+			receive:      common.NewCustomErrorWithReason(common.CustomErrorCode403, common.Err403UserDisabled, common.ReasonUserDisabled, common.HTTPErrorSourceAuth),
+			expectError:  "code=403, message={[auth] AccessDenied User is disabled",
+			expectStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, test := range tests {
@@ -158,7 +179,7 @@ func TestProjectHandler_SystemAuthToken(tt *testing.T) {
 					authUsecase,
 					verifyUsecase,
 				)
-				verifyUsecase.On("TokenIntrospection", test.input).Return(res, test.receive)
+				verifyUsecase.On("TokenIntrospection", mock.Anything, test.input).Return(res, test.receive)
 
 				err := authHandler.TokenIntrospection(c)
 				e.HTTPErrorHandler(err, c)
@@ -171,6 +192,124 @@ func TestProjectHandler_SystemAuthToken(tt *testing.T) {
 	}
 }
 
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/token/introspect のテストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：JSON形式
+// [x] 1-2. 200: 正常系：フォーム形式
+// [x] 1-3. 200: 正常系：有効でないトークンの場合はactiveのみ返却
+// [x] 2-1. 400: バリデーションエラー：tokenが含まれていない場合
+// [x] 2-2. 503: 外部システムエラー：接続エラー
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_SystemAuthTokenIntrospect(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/token/introspect"
+
+	active := output.IntrospectTokenResponse{
+		Active:     true,
+		TokenType:  "Bearer",
+		Sub:        "uid",
+		Exp:        1700003600,
+		Iat:        1700000100,
+		Iss:        "https://securetoken.google.com/local",
+		Aud:        "local",
+		OperatorID: f.OperatorId,
+		AuthTime:   1700000000,
+	}
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		receive      output.IntrospectTokenResponse
+		receiveError error
+		expectStatus int
+		expectBody   string
+		expectError  string
+	}{
+		{
+			name:         "1-1. 200: 正常系：JSON形式",
+			contentType:  echo.MIMEApplicationJSON,
+			body:         fmt.Sprintf(`{"token":"%s","token_type_hint":"id_token"}`, f.Token),
+			receive:      active,
+			expectStatus: http.StatusOK,
+			expectBody:   fmt.Sprintf(`{"active":true,"token_type":"Bearer","sub":"uid","exp":1700003600,"iat":1700000100,"iss":"https://securetoken.google.com/local","aud":"local","operator_id":"%s","auth_time":1700000000}`, f.OperatorId),
+		},
+		{
+			name:         "1-2. 200: 正常系：フォーム形式",
+			contentType:  echo.MIMEApplicationForm,
+			body:         url.Values{"token": {f.Token}, "token_type_hint": {"id_token"}}.Encode(),
+			receive:      active,
+			expectStatus: http.StatusOK,
+			expectBody:   fmt.Sprintf(`{"active":true,"token_type":"Bearer","sub":"uid","exp":1700003600,"iat":1700000100,"iss":"https://securetoken.google.com/local","aud":"local","operator_id":"%s","auth_time":1700000000}`, f.OperatorId),
+		},
+		{
+			name:         "1-3. 200: 正常系：有効でないトークンの場合はactiveのみ返却",
+			contentType:  echo.MIMEApplicationForm,
+			body:         url.Values{"token": {f.Token}}.Encode(),
+			receive:      output.IntrospectTokenResponse{Active: false},
+			expectStatus: http.StatusOK,
+			expectBody:   `{"active":false}`,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：tokenが含まれていない場合",
+			contentType:  echo.MIMEApplicationForm,
+			body:         url.Values{"token_type_hint": {"id_token"}}.Encode(),
+			expectStatus: http.StatusBadRequest,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, token: cannot be blank.",
+		},
+		{
+			name:         "2-2. 503: 外部システムエラー：接続エラー",
+			contentType:  echo.MIMEApplicationForm,
+			body:         url.Values{"token": {f.Token}}.Encode(),
+			receiveError: common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable, common.HTTPErrorSourceAuth),
+			expectStatus: http.StatusServiceUnavailable,
+			expectError:  "code=503, message={[auth] ServiceUnavailable",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(method, endPoint, strings.NewReader(test.body))
+				req.Header.Set(echo.HeaderContentType, test.contentType)
+				c := e.NewContext(req, rec)
+				c.SetPath(endPoint)
+
+				authUsecase := new(mocks.IAuthUsecase)
+				verifyUsecase := new(mocks.IVerifyUsecase)
+				authHandler := NewAuthHandler(
+					authUsecase,
+					verifyUsecase,
+				)
+				verifyUsecase.On("IntrospectToken", mock.Anything, mock.MatchedBy(func(param input.IntrospectTokenParam) bool {
+					return param.Token == f.Token
+				})).Return(test.receive, test.receiveError)
+
+				err := authHandler.IntrospectToken(c)
+				if test.expectError != "" {
+					e.HTTPErrorHandler(err, c)
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectStatus, rec.Code)
+						assert.ErrorContains(t, err, test.expectError)
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.JSONEq(t, test.expectBody, rec.Body.String())
+					assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+				}
+			},
+		)
+	}
+}
+
 // /////////////////////////////////////////////////////////////////////////////////
 // POST /api/v1/systemAuth/apiKey のテストケース
 // /////////////////////////////////////////////////////////////////////////////////
@@ -318,3 +457,104 @@ func TestProjectHandler_SystemAuthApiKey(tt *testing.T) {
 		)
 	}
 }
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/unlock のテストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー: operatorAccountIdが含まれていない場合
+// [x] 2-2. 400: バリデーションエラー: operatorAccountIdがメールアドレス形式でない場合
+// [x] 2-3. 500: システムエラー: ロック解除の記録に失敗した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_SystemAuthUnlock(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/unlock"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.UnlockAccountParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.UnlockAccountParam {
+				return f.NewInputUnlockAccountParam()
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：operatorAccountIdが含まれていない場合",
+			inputFunc: func() input.UnlockAccountParam {
+				param := f.NewInputUnlockAccountParam()
+				param.OperatorAccountID = ""
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorAccountId: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: バリデーションエラー：operatorAccountIdがメールアドレス形式でない場合",
+			inputFunc: func() input.UnlockAccountParam {
+				param := f.NewInputUnlockAccountParam()
+				param.OperatorAccountID = "aaa"
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorAccountId: must be a valid email address.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 500: システムエラー：ロック解除の記録に失敗した場合",
+			inputFunc: func() input.UnlockAccountParam {
+				return f.NewInputUnlockAccountParam()
+			},
+			receive:      fmt.Errorf("DB Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				inputJSON, _ := json.Marshal(test.inputFunc())
+				q := make(url.Values)
+
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				c := e.NewContext(req, rec)
+				c.SetPath(endPoint)
+
+				authUsecase := new(mocks.IAuthUsecase)
+				verifyUsecase := new(mocks.IVerifyUsecase)
+				authUsecase.On("UnlockAccount", mock.Anything, test.inputFunc()).Return(test.receive)
+
+				authHandler := NewAuthHandler(
+					authUsecase,
+					verifyUsecase,
+				)
+
+				err := authHandler.UnlockAccount(c)
+				if test.expectStatus == http.StatusCreated {
+					if assert.NoError(t, err) {
+						assert.Equal(t, test.expectStatus, rec.Code)
+						authUsecase.AssertExpectations(t)
+					}
+				} else {
+					e.HTTPErrorHandler(err, c)
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectStatus, rec.Code)
+						assert.ErrorContains(t, err, test.expectError)
+					}
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateUser
+// Summary: This is function which is used to create the user of the identity provider tied to the operator
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) CreateUser(c echo.Context) error {
+	method := c.Request().Method
+	param := input.CreateUserParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.UserUsecase.CreateUser(c.Request().Context(), param)
+	if err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, output)
+}
+
+// ListUsers
+// Summary: This is function which is used to list the users of the identity provider
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) ListUsers(c echo.Context) error {
+	method := c.Request().Method
+	param := input.ListUsersParam{OperatorID: c.QueryParam("operatorId")}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	output, err := h.UserUsecase.ListUsers(c.Request().Context(), param)
+	if err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, output)
+}
+
+// DisableUser
+// Summary: This is function which is used to disable the user of the identity provider
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) DisableUser(c echo.Context) error {
+	method := c.Request().Method
+	param := input.UserParam{UID: c.Param("uid")}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.UserUsecase.DisableUser(c.Request().Context(), param); err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// EnableUser
+// Summary: This is function which is used to enable the disabled user of the identity provider
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) EnableUser(c echo.Context) error {
+	method := c.Request().Method
+	param := input.UserParam{UID: c.Param("uid")}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.UserUsecase.EnableUser(c.Request().Context(), param); err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// SetUserRole
+// Summary: This is function which is used to change the role of the user of the identity provider
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) SetUserRole(c echo.Context) error {
+	method := c.Request().Method
+	param := input.SetUserRoleParam{}
+
+	if err := c.Bind(&param); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := common.FormatBindErrMsg(err)
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+	param.UID = c.Param("uid")
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.UserUsecase.SetUserRole(c.Request().Context(), param); err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusCreated, common.EmptyBody{})
+}
+
+// DeleteUser
+// Summary: This is function which is used to delete the user of the identity provider
+// input: c(echo.Context): context
+// output: error: error object
+func (h *userHandler) DeleteUser(c echo.Context) error {
+	method := c.Request().Method
+	param := input.UserParam{UID: c.Param("uid")}
+
+	if err := param.Validate(); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		errDetails := err.Error()
+		return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method, errDetails))
+	}
+
+	if err := h.UserUsecase.DeleteUser(c.Request().Context(), param); err != nil {
+		return userError(c, method, err)
+	}
+	return c.JSON(http.StatusOK, common.EmptyBody{})
+}
+
+// userError
+// Summary: This is function which converts the error of the user usecase to the HTTP error
+// input: c(echo.Context): context
+// input: method(string): method of the request
+// input: err(error): error object
+// output: error: HTTP error
+func userError(c echo.Context, method string, err error) error {
+	var customErr *common.CustomError
+	if errors.As(err, &customErr) {
+		if customErr.IsWarn() {
+			logger.Set(c).Warnf(err.Error())
+		} else {
+			logger.Set(c).Errorf(err.Error())
+		}
+
+		return echo.NewHTTPError(common.HTTPErrorGenerateWithViolations(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method, customErr.Violations))
+	}
+	logger.Set(c).Errorf(err.Error())
+
+	return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+}
@@ -0,0 +1,455 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/presentation/http/echo/handler"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/users テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：operatorAccountIdがメールアドレス形式でない場合
+// [x] 2-2. 400: バリデーションエラー：operatorIdがUUID形式でない場合
+// [x] 2-3. 400: バリデーションエラー：roleが定義外の値の場合
+// [x] 2-4. 400: パスワードポリシーエラー：違反したルールを返却
+// [x] 2-5. 400: 事業者が存在しない場合
+// [x] 2-6. 409: メールアドレスが登録済みの場合
+// [x] 2-7. 500: システムエラー：作成失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_CreateUser(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/users"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.CreateUserParam
+		receive      error
+		expectError  string
+		expectBody   string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 201: 正常系",
+			inputFunc: func() input.CreateUserParam {
+				return f.NewInputCreateUserParam()
+			},
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：operatorAccountIdがメールアドレス形式でない場合",
+			inputFunc: func() input.CreateUserParam {
+				param := f.NewInputCreateUserParam()
+				param.OperatorAccountID = "aaa"
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorAccountId: must be a valid email address.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 400: バリデーションエラー：operatorIdがUUID形式でない場合",
+			inputFunc: func() input.CreateUserParam {
+				param := f.NewInputCreateUserParam()
+				param.OperatorID = f.InvalidUUID
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorId: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-3. 400: バリデーションエラー：roleが定義外の値の場合",
+			inputFunc: func() input.CreateUserParam {
+				param := f.NewInputCreateUserParam()
+				param.Role = "owner"
+				return param
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, role: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-4. 400: パスワードポリシーエラー：違反したルールを返却",
+			inputFunc: func() input.CreateUserParam {
+				param := f.NewInputCreateUserParam()
+				param.AccountPassword = "1Aa@1Aa"
+				return param
+			},
+			receive: common.NewCustomErrorWithViolations(common.CustomErrorCode400, common.Err400PasswordPolicy, []common.ErrorViolation{
+				{Field: "accountPassword", Rule: "minLength", Message: "must be at least 8 characters"},
+			}, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Password does not satisfy the password policy",
+			expectBody:   `"violations":[{"field":"accountPassword","rule":"minLength","message":"must be at least 8 characters"}]`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-5. 400: 事業者が存在しない場合",
+			inputFunc: func() input.CreateUserParam {
+				return f.NewInputCreateUserParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=400, message={[auth] BadRequest Operator does not exist",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-6. 409: メールアドレスが登録済みの場合",
+			inputFunc: func() input.CreateUserParam {
+				return f.NewInputCreateUserParam()
+			},
+			receive:      common.NewCustomError(common.CustomErrorCode409, common.Err409EmailAlreadyExists, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=409, message={[auth] Conflict Email address is already registered",
+			expectStatus: http.StatusConflict,
+		},
+		{
+			name: "2-7. 500: システムエラー：作成失敗",
+			inputFunc: func() input.CreateUserParam {
+				return f.NewInputCreateUserParam()
+			},
+			receive:      fmt.Errorf("IdP Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			inputJSON, _ := json.Marshal(test.inputFunc())
+
+			q := make(url.Values)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), strings.NewReader(string(inputJSON)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			userUsecase := new(mocks.IUserUsecase)
+			userHandler := handler.NewUserHandler(userUsecase)
+
+			expected := output.UserResponse{UID: f.UID, OperatorAccountID: f.Email, OperatorID: f.OperatorID}
+			userUsecase.On("CreateUser", mock.Anything, test.inputFunc()).Return(expected, test.receive)
+			err := userHandler.CreateUser(c)
+			if test.expectStatus == http.StatusCreated {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.UserResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+					if test.expectBody != "" {
+						assert.Contains(t, rec.Body.String(), test.expectBody)
+					}
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// GET /api/v1/systemAuth/users テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 200: 正常系：operatorId指定あり
+// [x] 1-2. 200: 正常系：operatorId指定なし
+// [x] 2-1. 400: バリデーションエラー：operatorIdがUUID形式でない場合
+// [x] 2-2. 500: システムエラー：一覧取得失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_ListUsers(tt *testing.T) {
+	var method = "GET"
+	var endPoint = "/api/v1/systemAuth/users"
+
+	tests := []struct {
+		name         string
+		inputFunc    func() input.ListUsersParam
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name: "1-1. 200: 正常系：operatorId指定あり",
+			inputFunc: func() input.ListUsersParam {
+				return input.ListUsersParam{OperatorID: f.OperatorID}
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "1-2. 200: 正常系：operatorId指定なし",
+			inputFunc: func() input.ListUsersParam {
+				return input.ListUsersParam{}
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name: "2-1. 400: バリデーションエラー：operatorIdがUUID形式でない場合",
+			inputFunc: func() input.ListUsersParam {
+				return input.ListUsersParam{OperatorID: f.InvalidUUID}
+			},
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, operatorId: must be a valid UUID.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "2-2. 500: システムエラー：一覧取得失敗",
+			inputFunc: func() input.ListUsersParam {
+				return input.ListUsersParam{OperatorID: f.OperatorID}
+			},
+			receive:      fmt.Errorf("IdP Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := make(url.Values)
+			if operatorID := test.inputFunc().OperatorID; operatorID != "" {
+				q.Set("operatorId", operatorID)
+			}
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), nil)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+
+			userUsecase := new(mocks.IUserUsecase)
+			userHandler := handler.NewUserHandler(userUsecase)
+
+			expected := output.UsersResponse{{UID: f.UID, OperatorAccountID: f.Email, OperatorID: f.OperatorID}}
+			userUsecase.On("ListUsers", mock.Anything, test.inputFunc()).Return(expected, test.receive)
+			err := userHandler.ListUsers(c)
+			if test.expectStatus == http.StatusOK {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					actual := output.UsersResponse{}
+					_ = json.Unmarshal(rec.Body.Bytes(), &actual)
+					assert.Equal(t, expected, actual)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/users/:uid/disable, /enable, DELETE /api/v1/systemAuth/users/:uid テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系：無効化
+// [x] 1-2. 201: 正常系：有効化
+// [x] 1-3. 200: 正常系：削除
+// [x] 2-1. 404: 無効化対象のユーザが存在しない場合
+// [x] 2-2. 404: 削除対象のユーザが存在しない場合
+// [x] 2-3. 500: システムエラー：有効化失敗
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_DisableUser(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		method       string
+		endPoint     string
+		usecase      string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系：無効化",
+			method:       "POST",
+			endPoint:     "/api/v1/systemAuth/users/:uid/disable",
+			usecase:      "DisableUser",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-2. 201: 正常系：有効化",
+			method:       "POST",
+			endPoint:     "/api/v1/systemAuth/users/:uid/enable",
+			usecase:      "EnableUser",
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "1-3. 200: 正常系：削除",
+			method:       "DELETE",
+			endPoint:     "/api/v1/systemAuth/users/:uid",
+			usecase:      "DeleteUser",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1. 404: 無効化対象のユーザが存在しない場合",
+			method:       "POST",
+			endPoint:     "/api/v1/systemAuth/users/:uid/disable",
+			usecase:      "DisableUser",
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound User not found",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "2-2. 404: 削除対象のユーザが存在しない場合",
+			method:       "DELETE",
+			endPoint:     "/api/v1/systemAuth/users/:uid",
+			usecase:      "DeleteUser",
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound User not found",
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "2-3. 500: システムエラー：有効化失敗",
+			method:       "POST",
+			endPoint:     "/api/v1/systemAuth/users/:uid/enable",
+			usecase:      "EnableUser",
+			receive:      fmt.Errorf("IdP Error"),
+			expectError:  "code=500, message={[auth] InternalServerError Unexpected error occurred",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, strings.Replace(test.endPoint, ":uid", f.UID, 1), nil)
+			c := e.NewContext(req, rec)
+			c.SetPath(test.endPoint)
+			c.SetParamNames("uid")
+			c.SetParamValues(f.UID)
+
+			userUsecase := new(mocks.IUserUsecase)
+			userHandler := handler.NewUserHandler(userUsecase)
+
+			userUsecase.On(test.usecase, mock.Anything, input.UserParam{UID: f.UID}).Return(test.receive)
+			var err error
+			switch test.usecase {
+			case "DisableUser":
+				err = userHandler.DisableUser(c)
+			case "EnableUser":
+				err = userHandler.EnableUser(c)
+			case "DeleteUser":
+				err = userHandler.DeleteUser(c)
+			}
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					userUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
+
+// /////////////////////////////////////////////////////////////////////////////////
+// POST /api/v1/systemAuth/users/:uid/role テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: バリデーションエラー：roleが未指定の場合
+// [x] 2-2. 400: バリデーションエラー：roleが定義外の値の場合
+// [x] 2-3. 404: ロール変更対象のユーザが存在しない場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestProjectHandler_SetUserRole(tt *testing.T) {
+	var method = "POST"
+	var endPoint = "/api/v1/systemAuth/users/:uid/role"
+
+	tests := []struct {
+		name         string
+		inputBody    string
+		receive      error
+		expectError  string
+		expectStatus int
+	}{
+		{
+			name:         "1-1. 201: 正常系",
+			inputBody:    `{"role": "editor"}`,
+			expectStatus: http.StatusCreated,
+		},
+		{
+			name:         "2-1. 400: バリデーションエラー：roleが未指定の場合",
+			inputBody:    `{}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, role: cannot be blank.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-2. 400: バリデーションエラー：roleが定義外の値の場合",
+			inputBody:    `{"role": "owner"}`,
+			expectError:  "code=400, message={[auth] BadRequest Validation failed, role: must be a valid value.",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "2-3. 404: ロール変更対象のユーザが存在しない場合",
+			inputBody:    `{"role": "editor"}`,
+			receive:      common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+			expectError:  "code=404, message={[auth] NotFound User not found",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(method, strings.Replace(endPoint, ":uid", f.UID, 1), strings.NewReader(test.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath(endPoint)
+			c.SetParamNames("uid")
+			c.SetParamValues(f.UID)
+
+			userUsecase := new(mocks.IUserUsecase)
+			userHandler := handler.NewUserHandler(userUsecase)
+
+			userUsecase.On("SetUserRole", mock.Anything, input.SetUserRoleParam{UID: f.UID, Role: authentication.RoleEditor}).Return(test.receive)
+			err := userHandler.SetUserRole(c)
+			if test.expectError == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					userUsecase.AssertExpectations(t)
+				}
+			} else {
+				e.HTTPErrorHandler(err, c)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expectStatus, rec.Code)
+					assert.ErrorContains(t, err, test.expectError)
+				}
+			}
+		})
+	}
+}
@@ -46,10 +46,10 @@ func (h *ouranosHandler) Reset(c echo.Context) error {
 // output: (error) error object
 func (h *resetHandler) Reset(c echo.Context) error {
 	method := c.Request().Method
-	apikey := c.Request().Header.Get("apiKey")
+	apiKeyID := requestAPIKeyID(c)
 	operatorID := c.Get("operatorID").(string)
 
-	err := h.resetUsecase.Reset(apikey)
+	err := h.resetUsecase.Reset(apiKeyID)
 	if err != nil {
 		logger.Set(c).Error(err.Error())
 
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	PasswordResetHandler interface {
+		RequestPasswordReset(c echo.Context) error
+		ConfirmPasswordReset(c echo.Context) error
+	}
+
+	passwordResetHandler struct {
+		PasswordResetUsecase usecase.IPasswordResetUsecase
+	}
+)
+
+func NewPasswordResetHandler(
+	passwordResetUsecase usecase.IPasswordResetUsecase,
+) PasswordResetHandler {
+	return &passwordResetHandler{
+		PasswordResetUsecase: passwordResetUsecase,
+	}
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"authenticator-backend/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	UserHandler interface {
+		CreateUser(c echo.Context) error
+		ListUsers(c echo.Context) error
+		DisableUser(c echo.Context) error
+		EnableUser(c echo.Context) error
+		SetUserRole(c echo.Context) error
+		DeleteUser(c echo.Context) error
+	}
+
+	userHandler struct {
+		UserUsecase usecase.IUserUsecase
+	}
+)
+
+func NewUserHandler(
+	userUsecase usecase.IUserUsecase,
+) UserHandler {
+	return &userHandler{
+		UserUsecase: userUsecase,
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyAdminValidator
+// Summary: This is the function which validates that the API key is allowed to manage the API keys.
+// It must be used after the API key validators which set the ID of the API key to the echo context.
+// The API key which is not marked as the admin in the in-memory index is denied even if its permissions allow the route.
+// output: (echo.MiddlewareFunc) middleware function
+func (m AuthMiddleware) APIKeyAdminValidator() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+			apiKey, ok := index.GetAPIKey(requestAPIKeyID(c))
+			if !ok || !apiKey.IsAdmin {
+				logger.Set(c).Warnf(common.Err403APIKeyNotAdmin)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403APIKeyNotAdmin, "", "", method))
+			}
+			return next(c)
+		}
+	}
+}
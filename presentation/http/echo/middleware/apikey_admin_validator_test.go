@@ -0,0 +1,116 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/presentation/http/echo/middleware"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyAdminValidator テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：管理者のAPIキーの場合
+// [x] 2-1: 異常系：管理者でないAPIキーで他のAPIキーを操作する場合
+// [x] 2-2: 異常系：管理者でないAPIキーで自身のAPIキーを操作する場合
+// [x] 2-3: 異常系：インデックスに存在しないAPIキーの場合
+// [x] 2-4: 異常系：インデックスの取得に失敗した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyAdminValidator(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		isAdmin      bool
+		apiKeyID     string
+		receiveError error
+		method       string
+		path         string
+		expectStatus int
+	}{
+		{
+			name:         "1-1: 正常系：管理者のAPIキーの場合",
+			isAdmin:      true,
+			apiKeyID:     f.ApiKeyID,
+			method:       "DELETE",
+			path:         "/api/v1/systemAuth/apiKeys/00000000-0000-0000-0000-000000000002",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1: 異常系：管理者でないAPIキーで他のAPIキーを操作する場合",
+			apiKeyID:     f.ApiKeyID,
+			method:       "DELETE",
+			path:         "/api/v1/systemAuth/apiKeys/00000000-0000-0000-0000-000000000002",
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "2-2: 異常系：管理者でないAPIキーで自身のAPIキーを操作する場合",
+			apiKeyID:     f.ApiKeyID,
+			method:       "POST",
+			path:         "/api/v1/systemAuth/apiKeys/" + f.ApiKeyID + "/permissions",
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "2-3: 異常系：インデックスに存在しないAPIキーの場合",
+			isAdmin:      true,
+			apiKeyID:     "00000000-0000-0000-0000-000000000000",
+			method:       "GET",
+			path:         "/api/v1/systemAuth/apiKeys",
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "2-4: 異常系：インデックスの取得に失敗した場合",
+			apiKeyID:     f.ApiKeyID,
+			receiveError: errors.New("DB Error"),
+			method:       "GET",
+			path:         "/api/v1/systemAuth/apiKeys",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiKey := f.NewAPIKey(authentication.ApplicationAttributeDataSpace)
+			apiKey.IsAdmin = test.isAdmin
+			index := authentication.NewAPIKeyIndex(authentication.APIKeys{apiKey}, nil, nil, nil)
+			apiKeyCacheMock := new(mocks.APIKeyCache)
+			apiKeyCacheMock.On("Index").Return(index, test.receiveError)
+			m := middleware.NewAuthMiddleware(nil, nil, apiKeyCacheMock, nil, authentication.RateLimitPolicy{}, authentication.APIKeyPolicy{}, nil, f.NewSecretCipher())
+
+			e := echo.New()
+			validator := m.APIKeyAdminValidator()(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(test.method, test.path, nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.Set("apiKeyID", test.apiKeyID)
+			actual := validator(c)
+
+			if test.expectStatus == http.StatusOK {
+				assert.NoError(t, actual)
+				return
+			}
+			var httpErr *echo.HTTPError
+			if assert.ErrorAs(t, actual, &httpErr) {
+				assert.Equal(t, test.expectStatus, httpErr.Code)
+				if test.expectStatus == http.StatusForbidden {
+					if model, ok := httpErr.Message.(common.HTTPError); assert.True(t, ok) {
+						assert.Equal(t, common.Err403APIKeyNotAdmin, model.Message)
+					}
+				}
+			}
+		})
+	}
+}
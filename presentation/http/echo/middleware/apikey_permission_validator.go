@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIKeyPermissionValidator
+// Summary: This is the function which validates that the API key is permitted to call the route.
+// It must be used after the API key validators which set the ID of the API key to the echo context.
+// The permissions of the API key are looked up in the in-memory index, and the API key without any permission is denied every route.
+// output: (echo.MiddlewareFunc) middleware function
+func (m AuthMiddleware) APIKeyPermissionValidator() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			apiKeyID := requestAPIKeyID(c)
+
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+			// the route path such as "/api/v1/systemAuth/users/:uid/disable" is checked instead of the request path
+			if !index.AllowsRoute(apiKeyID, method, c.Path()) {
+				logger.Set(c).Warnf(common.Err403RouteNotAuthorizedForKey)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403RouteNotAuthorizedForKey, "", "", method))
+			}
+			return next(c)
+		}
+	}
+}
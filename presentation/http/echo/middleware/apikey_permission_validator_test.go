@@ -0,0 +1,111 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/presentation/http/echo/middleware"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeyPermissionValidator テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：APIキーの権限で許可されたルートの場合
+// [x] 1-2: 正常系：全てのルートを許可する権限の場合
+// [x] 2-1: 異常系：APIキーの権限で許可されていないルートの場合
+// [x] 2-2: 異常系：権限が登録されていないAPIキーの場合
+// [x] 2-3: 異常系：インデックスの取得に失敗した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeyPermissionValidator(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		permissions  authentication.APIKeyPermissions
+		receiveError error
+		method       string
+		path         string
+		expectStatus int
+	}{
+		{
+			name:         "1-1: 正常系：APIキーの権限で許可されたルートの場合",
+			permissions:  authentication.APIKeyPermissions{{APIKeyID: f.ApiKeyID, Method: "POST", Path: "/api/v1/systemAuth/users/*"}},
+			method:       "POST",
+			path:         "/api/v1/systemAuth/users/:uid/disable",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "1-2: 正常系：全てのルートを許可する権限の場合",
+			permissions:  authentication.APIKeyPermissions{{APIKeyID: f.ApiKeyID, Method: "*", Path: "*"}},
+			method:       "PUT",
+			path:         "/dataReset",
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:         "2-1: 異常系：APIキーの権限で許可されていないルートの場合",
+			permissions:  authentication.APIKeyPermissions{{APIKeyID: f.ApiKeyID, Method: "GET", Path: "/api/v1/authInfo"}},
+			method:       "PUT",
+			path:         "/dataReset",
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "2-2: 異常系：権限が登録されていないAPIキーの場合",
+			permissions:  authentication.APIKeyPermissions{{APIKeyID: "00000000-0000-0000-0000-000000000000", Method: "*", Path: "*"}},
+			method:       "GET",
+			path:         "/api/v1/authInfo",
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "2-3: 異常系：インデックスの取得に失敗した場合",
+			receiveError: errors.New("DB Error"),
+			method:       "GET",
+			path:         "/api/v1/authInfo",
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			index := authentication.NewAPIKeyIndex(authentication.APIKeys{f.NewAPIKey(authentication.ApplicationAttributeApplication)}, nil, nil, test.permissions)
+			apiKeyCacheMock := new(mocks.APIKeyCache)
+			apiKeyCacheMock.On("Index").Return(index, test.receiveError)
+			m := middleware.NewAuthMiddleware(nil, nil, apiKeyCacheMock, nil, authentication.RateLimitPolicy{}, authentication.APIKeyPolicy{}, nil, f.NewSecretCipher())
+
+			e := echo.New()
+			validator := m.APIKeyPermissionValidator()(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(test.method, "/", nil)
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetPath(test.path)
+			c.Set("apiKeyID", f.ApiKeyID)
+			actual := validator(c)
+
+			if test.expectStatus == http.StatusOK {
+				assert.NoError(t, actual)
+				return
+			}
+			var httpErr *echo.HTTPError
+			if assert.ErrorAs(t, actual, &httpErr) {
+				assert.Equal(t, test.expectStatus, httpErr.Code)
+				if test.expectStatus == http.StatusForbidden {
+					if model, ok := httpErr.Message.(common.HTTPError); assert.True(t, ok) {
+						assert.Equal(t, common.Err403RouteNotAuthorizedForKey, model.Message)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/extension/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerRateLimitLimit          = "X-RateLimit-Limit"
+	headerRateLimitRemaining      = "X-RateLimit-Remaining"
+	headerRateLimitReset          = "X-RateLimit-Reset"
+	headerRateLimitQuotaLimit     = "X-RateLimit-Quota-Limit"
+	headerRateLimitQuotaRemaining = "X-RateLimit-Quota-Remaining"
+	headerRateLimitQuotaReset     = "X-RateLimit-Quota-Reset"
+)
+
+// APIKeyRateLimiter
+// Summary: This is the function which limits the requests with the API key.
+// It must be used after the API key validators which set the ID of the API key to the echo context.
+// The requests are counted for each operator when the policy limits them by the operator and the AuthJWT middleware has set the operator.
+// The rest of the limits are reported by the X-RateLimit-* headers, and the rejected request is not recorded in the auth events
+// so that the flood of the requests does not flood the database.
+// output: (echo.MiddlewareFunc) middleware function
+func (m AuthMiddleware) APIKeyRateLimiter() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			apiKeyID := requestAPIKeyID(c)
+			operatorID, _ := c.Get("operatorID").(string)
+
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, operatorID, "", method))
+			}
+			apiKey, ok := index.GetAPIKey(apiKeyID)
+			if !ok {
+				// the API key validators have rejected the request without the valid API key
+				return next(c)
+			}
+			limit := m.rateLimitPolicy.LimitOf(apiKey)
+			if limit.Unlimited() {
+				return next(c)
+			}
+
+			status, err := m.rateLimiter.Take(m.rateLimitPolicy.BucketKey(apiKey.ID, operatorID), limit, time.Now())
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, operatorID, "", method))
+			}
+			header := c.Response().Header()
+			if status.Limit > 0 {
+				header.Set(headerRateLimitLimit, strconv.Itoa(status.Limit))
+				header.Set(headerRateLimitRemaining, strconv.Itoa(status.Remaining))
+				header.Set(headerRateLimitReset, strconv.Itoa(int(status.Reset.Seconds())))
+			}
+			if status.QuotaLimit > 0 {
+				header.Set(headerRateLimitQuotaLimit, strconv.Itoa(status.QuotaLimit))
+				header.Set(headerRateLimitQuotaRemaining, strconv.Itoa(status.QuotaRemaining))
+				header.Set(headerRateLimitQuotaReset, strconv.Itoa(int(status.QuotaReset.Seconds())))
+			}
+
+			if !status.Allowed {
+				message, reason := common.Err429RateLimitExceeded, common.ReasonRateLimitExceeded
+				if status.QuotaExceeded {
+					message, reason = common.Err429QuotaExceeded, common.ReasonQuotaExceeded
+				}
+				logger.Set(c).Warnf(message)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(int(status.RetryAfter.Seconds())))
+
+				return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(http.StatusTooManyRequests, common.HTTPErrorSourceAuth, message, operatorID, "", method, reason))
+			}
+
+			return next(c)
+		}
+	}
+}
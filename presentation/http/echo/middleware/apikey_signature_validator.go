@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerSignature          = "X-Signature"
+	headerSignatureTimestamp = "X-Signature-Timestamp"
+	headerSignatureNonce     = "X-Signature-Nonce"
+)
+
+// APIKeySignatureValidator
+// Summary: This is the function which verifies the signature of the request with the API key which requires the request signing.
+// It must be used after the API key validators which set the ID of the API key to the echo context, so that the signing is required
+// whichever credential, the API key header, the OAuth 2.0 access token or the client certificate, the API key is resolved from.
+// The signature is the HMAC-SHA256 of the method, the path, the digest of the body, the timestamp and the nonce signed with the signing secret.
+// The request whose timestamp is out of the clock skew or whose nonce has been used in any instance is rejected so that it can not be replayed.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
+// output: (echo.MiddlewareFunc) middleware function
+func (m AuthMiddleware) APIKeySignatureValidator(authEvents repository.AuthEventWriter) echo.MiddlewareFunc {
+	d := newAuthDumper(authEvents)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			apiKey := c.Request().Header.Get(apiKeyHeader)
+
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+			validAPIKey, ok := index.GetAPIKey(requestAPIKeyID(c))
+			if !ok || !validAPIKey.RequiresSignature() {
+				return next(c)
+			}
+			secret, err := m.secretCipher.Decrypt(*validAPIKey.SigningSecret)
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+
+			reject := func(reason string) error {
+				logger.Set(c).Warnf(common.Err403InvalidSignature)
+				d.apiKeyFailureDump(c, apiKey, reason)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403InvalidSignature, "", "", method, reason))
+			}
+
+			signature := c.Request().Header.Get(headerSignature)
+			nonce := c.Request().Header.Get(headerSignatureNonce)
+			timestamp, err := strconv.ParseInt(c.Request().Header.Get(headerSignatureTimestamp), 10, 64)
+			if signature == "" || nonce == "" || len(nonce) > authentication.MaxSignatureNonceLength || err != nil {
+				return reject(common.ReasonSignatureRequired)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				logger.Set(c).Warnf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusBadRequest, common.HTTPErrorSourceAuth, common.Err400Validation, "", "", method))
+			}
+			// the body is restored for the handlers
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			request := authentication.SignedRequest{
+				Method:    method,
+				Path:      c.Request().URL.RequestURI(),
+				Body:      body,
+				Timestamp: timestamp,
+				Nonce:     nonce,
+			}
+			now := time.Now()
+			if !m.apiKeyPolicy.AcceptsSignedAt(request.SignedAt(), now) {
+				return reject(common.ReasonSignatureExpired)
+			}
+			if !request.Verify(secret, signature) {
+				return reject(common.ReasonSignatureInvalid)
+			}
+			// the nonce is recorded only after the signature is verified so that the nonces can not be used up by the forged requests
+			unused, err := m.nonceStore.Use(validAPIKey.ID+":"+nonce, m.apiKeyPolicy.NonceExpiresAt(request.SignedAt()), now)
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+			if !unused {
+				return reject(common.ReasonNonceReused)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package middleware_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/presentation/http/echo/middleware"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// APIKeySignatureValidator テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：署名が不要なAPIキーの場合は検証しない
+// [x] 1-2: 正常系：署名シークレットで署名したリクエストの場合
+// [x] 2-1: 異常系：署名のヘッダがない場合
+// [x] 2-2: 異常系：タイムスタンプが許容する時刻のずれを超える場合
+// [x] 2-3: 異常系：署名後にボディを改ざんした場合
+// [x] 2-4: 異常系：同じノンスのリクエストを再送した場合
+// [x] 2-5: 異常系：APIキーのヘッダ以外の認証情報で署名のないリクエストの場合
+// [x] 2-6: 異常系：ノンスの記録に失敗した場合
+// /////////////////////////////////////////////////////////////////////////////////
+func TestAPIKeySignatureValidator(tt *testing.T) {
+
+	secret := "signing-secret"
+	cipher := f.NewSecretCipher()
+	encrypted, _ := cipher.Encrypt(secret)
+	policy := authentication.APIKeyPolicy{SignatureClockSkew: 5 * time.Minute}
+	path := "/auth/login?lang=ja"
+	body := `{"operatorAccountId":"user@example.com"}`
+
+	tests := []struct {
+		name    string
+		signing bool
+		headers func(now time.Time) map[string]string
+		body    string
+		repeat  int
+		// withoutAPIKey is true when the API key is resolved from the access token or the client certificate
+		withoutAPIKey bool
+		receiveError  error
+		expectReason  string
+		expectStatus  int
+	}{
+		{
+			name:    "1-1: 正常系：署名が不要なAPIキーの場合は検証しない",
+			headers: func(now time.Time) map[string]string { return map[string]string{} },
+			body:    body,
+		},
+		{
+			name:    "1-2: 正常系：署名シークレットで署名したリクエストの場合",
+			signing: true,
+			headers: func(now time.Time) map[string]string { return signedHeaders(secret, path, body, now, "nonce-1") },
+			body:    body,
+		},
+		{
+			name:         "2-1: 異常系：署名のヘッダがない場合",
+			signing:      true,
+			headers:      func(now time.Time) map[string]string { return map[string]string{} },
+			body:         body,
+			expectReason: common.ReasonSignatureRequired,
+		},
+		{
+			name:    "2-2: 異常系：タイムスタンプが許容する時刻のずれを超える場合",
+			signing: true,
+			headers: func(now time.Time) map[string]string {
+				return signedHeaders(secret, path, body, now.Add(-6*time.Minute), "nonce-1")
+			},
+			body:         body,
+			expectReason: common.ReasonSignatureExpired,
+		},
+		{
+			name:         "2-3: 異常系：署名後にボディを改ざんした場合",
+			signing:      true,
+			headers:      func(now time.Time) map[string]string { return signedHeaders(secret, path, body, now, "nonce-1") },
+			body:         `{"operatorAccountId":"admin@example.com"}`,
+			expectReason: common.ReasonSignatureInvalid,
+		},
+		{
+			name:         "2-4: 異常系：同じノンスのリクエストを再送した場合",
+			signing:      true,
+			headers:      func(now time.Time) map[string]string { return signedHeaders(secret, path, body, now, "nonce-1") },
+			body:         body,
+			repeat:       1,
+			expectReason: common.ReasonNonceReused,
+		},
+		{
+			name:          "2-5: 異常系：APIキーのヘッダ以外の認証情報で署名のないリクエストの場合",
+			signing:       true,
+			headers:       func(now time.Time) map[string]string { return map[string]string{"Authorization": "Bearer " + f.Token} },
+			body:          body,
+			withoutAPIKey: true,
+			expectReason:  common.ReasonSignatureRequired,
+		},
+		{
+			name:         "2-6: 異常系：ノンスの記録に失敗した場合",
+			signing:      true,
+			headers:      func(now time.Time) map[string]string { return signedHeaders(secret, path, body, now, "nonce-1") },
+			body:         body,
+			receiveError: errors.New("DB Error"),
+			expectStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			// the auth events are not asserted
+			authEventWriterMock := new(mocks.AuthEventWriter)
+			authEventWriterMock.On("Write", mock.Anything).Return()
+
+			apiKey := f.NewAPIKey(authentication.ApplicationAttributeApplication)
+			if test.signing {
+				apiKey.SigningSecret = &encrypted
+			}
+			apiKeyCacheMock := new(mocks.APIKeyCache)
+			apiKeyCacheMock.On("Index").Return(authentication.NewAPIKeyIndex(authentication.APIKeys{apiKey}, nil, nil, nil), nil)
+			// the nonce can be used only once
+			nonceStoreMock := new(mocks.NonceStore)
+			nonceStoreMock.On("Use", f.ApiKeyID+":nonce-1", mock.Anything, mock.Anything).Return(true, test.receiveError).Once()
+			nonceStoreMock.On("Use", f.ApiKeyID+":nonce-1", mock.Anything, mock.Anything).Return(false, nil)
+			m := middleware.NewAuthMiddleware(nil, nil, apiKeyCacheMock, nil, authentication.RateLimitPolicy{}, policy, nonceStoreMock, cipher)
+
+			e := echo.New()
+			validator := m.APIKeySignatureValidator(authEventWriterMock)(func(c echo.Context) error {
+				// the body is restored for the handlers
+				b, _ := io.ReadAll(c.Request().Body)
+				assert.Equal(t, test.body, string(b))
+
+				return c.NoContent(http.StatusOK)
+			})
+
+			now := time.Now()
+			headers := test.headers(now)
+			var actual error
+			for i := 0; i <= test.repeat; i++ {
+				req := httptest.NewRequest("POST", path, strings.NewReader(test.body))
+				if !test.withoutAPIKey {
+					req.Header.Set("apiKey", f.ApiKey)
+				}
+				for key, value := range headers {
+					req.Header.Set(key, value)
+				}
+				c := e.NewContext(req, httptest.NewRecorder())
+				// the API key validators have resolved the API key
+				c.Set("apiKeyID", f.ApiKeyID)
+				actual = validator(c)
+			}
+
+			if test.expectStatus == 0 && test.expectReason == "" {
+				assert.NoError(t, actual)
+				return
+			}
+			var httpErr *echo.HTTPError
+			if test.expectStatus != 0 {
+				if assert.ErrorAs(t, actual, &httpErr) {
+					assert.Equal(t, test.expectStatus, httpErr.Code)
+				}
+				return
+			}
+			if assert.ErrorAs(t, actual, &httpErr) {
+				assert.Equal(t, http.StatusForbidden, httpErr.Code)
+				if model, ok := httpErr.Message.(common.HTTPError); assert.True(t, ok) {
+					assert.Equal(t, test.expectReason, model.Reason)
+				}
+			}
+		})
+	}
+}
+
+// signedHeaders
+// Summary: This is function which returns the headers of the request signed with the signing secret.
+// input: secret(string): signing secret
+// input: path(string): path with the query string
+// input: body(string): request body
+// input: signedAt(time.Time): time of the signing
+// input: nonce(string): nonce
+// output: (map[string]string) headers of the signature
+func signedHeaders(secret string, path string, body string, signedAt time.Time, nonce string) map[string]string {
+	request := authentication.SignedRequest{Method: "POST", Path: path, Body: []byte(body), Timestamp: signedAt.Unix(), Nonce: nonce}
+	return map[string]string{
+		"X-Signature":           request.Sign(secret),
+		"X-Signature-Timestamp": strconv.FormatInt(signedAt.Unix(), 10),
+		"X-Signature-Nonce":     nonce,
+	}
+}
@@ -1,94 +1,208 @@
 package middleware
 
 import (
+	"crypto/x509"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
-	"authenticator-backend/infrastructure/persistence/datastore"
+	"authenticator-backend/usecase/input"
 
 	"github.com/labstack/echo/v4"
-	"gorm.io/gorm"
 )
 
-const apiKeyHeader = "apiKey"
+const (
+	apiKeyHeader       = "apiKey"
+	apiKeyIDContextKey = "apiKeyID"
+	bearerPrefix       = "Bearer "
+)
+
+// systemAPIKeyAttributes is the application attributes of the API keys which can call the system APIs
+var systemAPIKeyAttributes = []authentication.ApplicationAttribute{authentication.ApplicationAttributeTraceability, authentication.ApplicationAttributeDataSpace}
 
 // APIKeyValidator
 // Summary: This is the function which validates the API key.
-// input: db(*gorm.DB): database
+// The API key out of its validity period is rejected with the reason code.
+// The ID of the valid API key is set to the echo context.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
 // output: (echo.MiddlewareFunc) middleware function
-func APIKeyValidator(db *gorm.DB) echo.MiddlewareFunc {
+func (m AuthMiddleware) APIKeyValidator(authEvents repository.AuthEventWriter) echo.MiddlewareFunc {
+	d := newAuthDumper(authEvents)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			authRepository := datastore.NewAuthRepository(db)
 			method := c.Request().Method
 			apiKey := c.Request().Header.Get(apiKeyHeader)
 
-			validAPIKeys, err := authRepository.ListAPIKeys(repository.APIKeysParam{})
-			if err != nil {
-				logger.Set(c).Errorf(common.Err500Unexpected)
-
-				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
-			}
-
 			if apiKey == "" {
 				logger.Set(c).Warnf(common.Err403AccessDenied)
+				d.apiKeyFailureDump(c, apiKey, common.Err403AccessDenied)
 
 				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403AccessDenied, "", "", method))
 			}
 
-			// check if the API key is in the ValidAPIKeys
-			if !validAPIKeys.ContainsAPIKey(apiKey) {
+			// the API keys are looked up by the digest in the in-memory index
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+
+			validAPIKey, ok := index.FindAPIKey(apiKey)
+			if !ok {
 				logger.Set(c).Warnf(common.Err403InvalidKey)
+				d.apiKeyFailureDump(c, apiKey, common.Err403InvalidKey)
 
 				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403InvalidKey, "", "", method))
 			}
+			if now := time.Now(); !validAPIKey.IsActive(now) {
+				reason := inactiveAPIKeyReason(validAPIKey, now)
+				logger.Set(c).Warnf(reason)
+				d.apiKeyFailureDump(c, apiKey, reason)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403InvalidKey, "", "", method, reason))
+			}
+			c.Set(apiKeyIDContextKey, validAPIKey.ID)
 
 			return next(c)
 		}
 	}
 }
 
-// ApplicationAttributeがDataSpaceとTraceabilityのAPIKEYのみ許可
-
 // SystemAPIKeyValidator
 // Summary: This is the function which validates the system API key.
-// input: db(*gorm.DB): database
+// The OAuth 2.0 access token in the Authorization header or the client certificate verified in the TLS handshake
+// is accepted instead of the API key header, and the API key linked to the client or mapped to the certificate is validated.
+// The client which presents the certificate is authenticated only by the certificate,
+// and only the client certificate is accepted when requireClientCertificate is true.
+// The API key out of its validity period is rejected with the reason code.
+// The ID of the valid API key is set to the echo context.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
+// input: requireClientCertificate(bool): true if the API key header and the access token are not accepted
 // output: (echo.MiddlewareFunc) middleware function
-func SystemAPIKeyValidator(db *gorm.DB) echo.MiddlewareFunc {
+func (m AuthMiddleware) SystemAPIKeyValidator(authEvents repository.AuthEventWriter, requireClientCertificate bool) echo.MiddlewareFunc {
+	d := newAuthDumper(authEvents)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			authRepository := datastore.NewAuthRepository(db)
 			method := c.Request().Method
 			apiKey := c.Request().Header.Get(apiKeyHeader)
+			certificate := verifiedClientCertificate(c)
+			var tokenAPIKeyID *string
 
-			// Only "DataSpace" and "Traceability" Attribute API keys are valid
-			systemUserAttributes := []authentication.ApplicationAttribute{authentication.ApplicationAttributeTraceability, authentication.ApplicationAttributeDataSpace}
-			validAPIKeys, err := authRepository.ListAPIKeys(repository.APIKeysParam{
-				Attributes: systemUserAttributes,
-			})
-			if err != nil {
-				logger.Set(c).Errorf(common.Err500Unexpected)
+			authorization := c.Request().Header.Get("Authorization")
+			if certificate == nil && requireClientCertificate {
+				logger.Set(c).Warnf(common.Err403ClientCertificateRequired)
+				d.apiKeyFailureDump(c, apiKey, common.Err403ClientCertificateRequired)
 
-				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403ClientCertificateRequired, "", "", method))
 			}
-
-			if apiKey == "" {
+			if certificate != nil {
+				// the API key header and the access token are ignored because the certificate is verified by the CA bundle
+				apiKey = ""
+			} else if apiKey == "" && strings.HasPrefix(authorization, bearerPrefix) {
+				token, err := m.oauthUsecase.VerifyAccessToken(input.VerifyAccessTokenParam{AccessToken: strings.TrimPrefix(authorization, bearerPrefix)})
+				if err != nil {
+					var customErr *common.CustomError
+					if errors.As(err, &customErr) {
+						logger.Set(c).Warnf(customErr.Message)
+						d.apiKeyFailureDump(c, apiKey, customErr.Message)
+
+						return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", "", method))
+					}
+					logger.Set(c).Errorf(err.Error())
+
+					return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+				}
+				if !token.HasScope(authentication.OAuthScopeSystemAuth) {
+					logger.Set(c).Warnf(common.Err403AccessDenied)
+					c.Set(apiKeyIDContextKey, token.APIKeyID)
+					d.apiKeyFailureDump(c, apiKey, common.Err403AccessDenied)
+
+					return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403AccessDenied, "", "", method))
+				}
+				// the API key linked to the client is validated instead of the API key header
+				tokenAPIKeyID = &token.APIKeyID
+			} else if apiKey == "" {
 				logger.Set(c).Warnf(common.Err403AccessDenied)
+				d.apiKeyFailureDump(c, apiKey, common.Err403AccessDenied)
 
 				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403AccessDenied, "", "", method))
 			}
 
-			// check if the API key is in the ValidAPIKeys
-			if !validAPIKeys.ContainsAPIKey(apiKey) {
+			index, err := m.apiKeyCache.Index()
+			if err != nil {
+				logger.Set(c).Errorf(err.Error())
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusInternalServerError, common.HTTPErrorSourceAuth, common.Err500Unexpected, "", "", method))
+			}
+
+			var validAPIKey authentication.APIKey
+			var ok bool
+			if certificate != nil {
+				validAPIKey, ok = index.FindAPIKeyByCertificate(certificate)
+			} else if tokenAPIKeyID != nil {
+				validAPIKey, ok = index.GetAPIKey(*tokenAPIKeyID)
+			} else {
+				validAPIKey, ok = index.FindAPIKey(apiKey)
+			}
+			// Only "DataSpace" and "Traceability" Attribute API keys are valid
+			if !ok || !slices.Contains(systemAPIKeyAttributes, validAPIKey.Attribute) {
 				logger.Set(c).Warnf(common.Err403InvalidKey)
+				d.apiKeyFailureDump(c, apiKey, common.Err403InvalidKey)
 
 				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403InvalidKey, "", "", method))
 			}
+			if now := time.Now(); !validAPIKey.IsActive(now) {
+				reason := inactiveAPIKeyReason(validAPIKey, now)
+				logger.Set(c).Warnf(reason)
+				d.apiKeyFailureDump(c, apiKey, reason)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403InvalidKey, "", "", method, reason))
+			}
+			// the following middlewares and the auth dump refer to the ID of the API key
+			c.Set(apiKeyIDContextKey, validAPIKey.ID)
 
 			return next(c)
 		}
 	}
 }
+
+// verifiedClientCertificate
+// Summary: This is the function which returns the client certificate verified with the CA bundle in the TLS handshake.
+// input: c(echo.Context): echo context
+// output: (*x509.Certificate) client certificate, or nil when the client has not presented the verified certificate
+func verifiedClientCertificate(c echo.Context) *x509.Certificate {
+	state := c.Request().TLS
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
+// inactiveAPIKeyReason
+// Summary: This is the function which returns the reason code why the API key is not valid at the time.
+// input: apiKey(authentication.APIKey): API key out of its validity period
+// input: now(time.Time): current time
+// output: (string) reason code
+func inactiveAPIKeyReason(apiKey authentication.APIKey, now time.Time) string {
+	if apiKey.IsExpired(now) {
+		return common.ReasonAPIKeyExpired
+	}
+	return common.ReasonAPIKeyNotYetValid
+}
+
+// requestAPIKeyID
+// Summary: This is the function which returns the ID of the API key of the request.
+// The ID is set by the API key validators, and the empty string is returned before the API key is validated.
+// input: c(echo.Context): echo context
+// output: (string) ID of the API key
+func requestAPIKeyID(c echo.Context) string {
+	apiKeyID, _ := c.Get(apiKeyIDContextKey).(string)
+	return apiKeyID
+}
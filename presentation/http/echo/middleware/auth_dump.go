@@ -2,10 +2,15 @@ package middleware
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 	"authenticator-backend/usecase/input"
 	"authenticator-backend/usecase/output"
@@ -16,26 +21,93 @@ import (
 )
 
 const (
-	systemAuthResourceToken    = "token"
-	systemAuthResourceAPIKey   = "apiKey"
-	authResourceLogin          = "login"
-	authResourceRefresh        = "refresh"
-	authResourceChangePassword = "change"
-
-	eventToken          = "operatorToken"
-	eventAPIKey         = "apiKey"
-	eventLogin          = "operatorLogin"
-	eventRefresh        = "operatorRefreshToken"
-	eventChangePassword = "operatorChangePassword"
+	systemAuthResourceToken      = "token"
+	systemAuthResourceIntrospect = "introspect"
+	systemAuthResourceAPIKey     = "apiKey"
+	authResourceLogin            = "login"
+	authResourceRefresh          = "refresh"
+	authResourceChangePassword   = "change"
+	authResourceLogout           = "logout"
+	authResourcePasswordReset    = "passwordReset"
+	authResourceConfirmReset     = "confirm"
+	systemAuthResourceUnlock     = "unlock"
+	authResourceMFAEnroll        = "enroll"
+	authResourceMFAActivate      = "activate"
+	authResourceMFAVerify        = "verify"
+	authResourceMFADisable       = "disable"
+	systemAuthUsersPath          = "/api/v1/systemAuth/users"
+	systemAuthResourceDisable    = "disable"
+	systemAuthResourceEnable     = "enable"
+	systemAuthResourceRole       = "role"
+	systemAuthAPIKeysPath        = "/api/v1/systemAuth/apiKeys"
+	systemAuthAPIKeyPath         = "/api/v1/systemAuth/apiKeys/:id"
+	systemAuthResourceOperator   = "operators"
+	systemAuthResourceCidr       = "cidrs"
+	systemAuthResourceCert       = "certificates"
+	systemAuthResourceSigning    = "signingSecret"
+	systemAuthResourceRotate     = "rotate"
+	oauthTokenPath               = "/oauth/token"
+
+	eventToken                = "operatorToken"
+	eventTokenIntrospect      = "operatorTokenIntrospect"
+	eventAPIKey               = "apiKey"
+	eventLogin                = "operatorLogin"
+	eventRefresh              = "operatorRefreshToken"
+	eventChangePassword       = "operatorChangePassword"
+	eventLogout               = "operatorLogout"
+	eventPasswordReset        = "operatorPasswordReset"
+	eventConfirmReset         = "operatorConfirmPasswordReset"
+	eventUnlock               = "operatorUnlock"
+	eventMFAEnroll            = "operatorMFAEnroll"
+	eventMFAActivate          = "operatorMFAActivate"
+	eventMFAVerify            = "operatorMFAVerify"
+	eventMFADisable           = "operatorMFADisable"
+	eventUserCreate           = "operatorUserCreate"
+	eventUserList             = "operatorUserList"
+	eventUserDisable          = "operatorUserDisable"
+	eventUserEnable           = "operatorUserEnable"
+	eventUserRole             = "operatorUserRole"
+	eventUserDelete           = "operatorUserDelete"
+	eventOAuthToken           = "oauthToken"
+	eventAPIKeyCreate         = "apiKeyCreate"
+	eventAPIKeyList           = "apiKeyList"
+	eventAPIKeyUpdate         = "apiKeyUpdate"
+	eventAPIKeyRevoke         = "apiKeyRevoke"
+	eventAPIKeyRotate         = "apiKeyRotate"
+	eventAPIKeyOperatorBind   = "apiKeyOperatorBind"
+	eventAPIKeyOperatorUnbind = "apiKeyOperatorUnbind"
+	eventAPIKeyCidrAdd        = "apiKeyCidrAdd"
+	eventAPIKeyCidrRemove     = "apiKeyCidrRemove"
+	eventAPIKeyCertAdd        = "apiKeyCertificateAdd"
+	eventAPIKeyCertRemove     = "apiKeyCertificateRemove"
+	eventAPIKeySigningEnable  = "apiKeySigningEnable"
+	eventAPIKeySigningDisable = "apiKeySigningDisable"
+	eventAPIKeyIPReportOnly   = "apiKeyIpReportOnly"
 )
 
+// authDumper
+// Summary: This is the structure which dumps the authentication information and records it as the auth event.
+type authDumper struct {
+	authEvents repository.AuthEventWriter
+}
+
+// newAuthDumper
+// Summary: This is the function which creates the authDumper.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
+// output: (authDumper) authDumper
+func newAuthDumper(authEvents repository.AuthEventWriter) authDumper {
+	return authDumper{authEvents}
+}
+
 // AuthDump
 // Summary: This is the function which dumps the authentication information.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
 // output: (echo.MiddlewareFunc) echo middleware function
-func AuthDump() echo.MiddlewareFunc {
+func AuthDump(authEvents repository.AuthEventWriter) echo.MiddlewareFunc {
+	d := newAuthDumper(authEvents)
 	return echo_middleware.BodyDumpWithConfig(echo_middleware.BodyDumpConfig{
 		Skipper: authDumpSkipper,
-		Handler: authDumpHandler,
+		Handler: d.authDumpHandler,
 	})
 }
 
@@ -44,20 +116,60 @@ func AuthDump() echo.MiddlewareFunc {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func authDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) authDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	if c.Response().Status >= http.StatusBadRequest {
+		setAuthEventReason(c, errorReason(resBody))
+	}
+
+	// the user administration shares the resource names with the MFA settings, so it is dispatched by the route
+	if strings.HasPrefix(c.Path(), systemAuthUsersPath) {
+		d.userDumpHandler(c, reqBody, resBody)
+
+		return
+	}
+	if strings.HasPrefix(c.Path(), systemAuthAPIKeysPath) {
+		d.apiKeyAdminDumpHandler(c, reqBody, resBody)
+
+		return
+	}
+	// the token endpoint of OAuth 2.0 shares the resource name with the token introspection
+	if c.Path() == oauthTokenPath {
+		d.oauthTokenDumpHandler(c, reqBody, resBody)
+
+		return
+	}
+
 	resource := path.Base(c.Request().URL.Path)
 
 	switch resource {
 	case systemAuthResourceToken:
-		tokenDumpHandler(c, reqBody, resBody)
+		d.tokenDumpHandler(c, reqBody, resBody)
+	case systemAuthResourceIntrospect:
+		d.introspectDumpHandler(c, reqBody, resBody)
 	case systemAuthResourceAPIKey:
-		apiKeyDumpHandler(c, reqBody, resBody)
+		d.apiKeyDumpHandler(c, reqBody, resBody)
 	case authResourceLogin:
-		loginDumpHandler(c, reqBody, resBody)
+		d.loginDumpHandler(c, reqBody, resBody)
 	case authResourceRefresh:
-		refreshDumpHandler(c, reqBody, resBody)
+		d.refreshDumpHandler(c, reqBody, resBody)
 	case authResourceChangePassword:
-		changeDumpHandler(c, reqBody, resBody)
+		d.changeDumpHandler(c, reqBody, resBody)
+	case authResourceLogout:
+		d.logoutDumpHandler(c, reqBody, resBody)
+	case authResourcePasswordReset:
+		d.passwordResetDumpHandler(c, reqBody, resBody)
+	case authResourceConfirmReset:
+		d.confirmPasswordResetDumpHandler(c, reqBody, resBody)
+	case systemAuthResourceUnlock:
+		d.unlockDumpHandler(c, reqBody, resBody)
+	case authResourceMFAEnroll:
+		d.mfaEnrollDumpHandler(c, reqBody, resBody)
+	case authResourceMFAActivate:
+		d.mfaActivateDumpHandler(c, reqBody, resBody)
+	case authResourceMFAVerify:
+		d.mfaVerifyDumpHandler(c, reqBody, resBody)
+	case authResourceMFADisable:
+		d.mfaDisableDumpHandler(c, reqBody, resBody)
 	}
 }
 
@@ -66,7 +178,7 @@ func authDumpHandler(c echo.Context, reqBody, resBody []byte) {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func tokenDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) tokenDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	var req input.VerifyTokenParam
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -80,7 +192,40 @@ func tokenDumpHandler(c echo.Context, reqBody, resBody []byte) {
 		return
 	}
 	result := res.OperatorID != nil
-	authDump(c, req, res, eventToken, result)
+	d.authDump(c, req, res, eventToken, result)
+}
+
+// introspectDumpHandler
+// Summary: This is the function which dumps the token introspection information.
+// The request body is either form-encoded or JSON, and the token is masked.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) introspectDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.IntrospectTokenParam
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+		form, err := url.ParseQuery(string(reqBody))
+		if err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.Token = form.Get("token")
+		req.TokenTypeHint = form.Get("token_type_hint")
+	} else if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req.Mask()
+
+	var res output.IntrospectTokenResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	d.authDump(c, req, res, eventTokenIntrospect, res.Active)
 }
 
 // apiKeyDumpHandler
@@ -88,7 +233,7 @@ func tokenDumpHandler(c echo.Context, reqBody, resBody []byte) {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func apiKeyDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) apiKeyDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	var req input.VerifyAPIKeyParam
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -101,8 +246,10 @@ func apiKeyDumpHandler(c echo.Context, reqBody, resBody []byte) {
 
 		return
 	}
+	req.Mask()
+
 	result := res.IsAPIKeyValid && res.IsIPAddressValid
-	authDump(c, req, res, eventAPIKey, result)
+	d.authDump(c, req, res, eventAPIKey, result)
 }
 
 // loginDumpHandler
@@ -110,7 +257,7 @@ func apiKeyDumpHandler(c echo.Context, reqBody, resBody []byte) {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func loginDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) loginDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	var req input.LoginParam
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -128,7 +275,7 @@ func loginDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	res.Mask()
 
 	result := c.Response().Status == 201
-	authDump(c, req, res, eventLogin, result)
+	d.authDump(c, req, res, eventLogin, result)
 }
 
 // refreshDumpHandler
@@ -136,7 +283,7 @@ func loginDumpHandler(c echo.Context, reqBody, resBody []byte) {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func refreshDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) refreshDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	var req input.RefreshParam
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -153,7 +300,7 @@ func refreshDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	}
 
 	result := c.Response().Status == 201
-	authDump(c, req, res, eventRefresh, result)
+	d.authDump(c, req, res, eventRefresh, result)
 }
 
 // changeDumpHandler
@@ -161,7 +308,7 @@ func refreshDumpHandler(c echo.Context, reqBody, resBody []byte) {
 // input: c(echo.Context): echo context
 // input: reqBody([]byte): request body
 // input: resBody([]byte): response body
-func changeDumpHandler(c echo.Context, reqBody, resBody []byte) {
+func (d authDumper) changeDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	var req input.ChangePasswordParam
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -170,6 +317,192 @@ func changeDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	}
 	req.Mask()
 
+	var res output.ChangePasswordResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	res.Mask()
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventChangePassword, result)
+}
+
+// logoutDumpHandler
+// Summary: This is the function which dumps the logout authentication information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) logoutDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var res common.EmptyBody
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	result := c.Response().Status == 201
+	d.authDump(c, nil, res, eventLogout, result)
+}
+
+// passwordResetDumpHandler
+// Summary: This is the function which dumps the password reset request information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) passwordResetDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.PasswordResetParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	var res common.EmptyBody
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventPasswordReset, result)
+}
+
+// confirmPasswordResetDumpHandler
+// Summary: This is the function which dumps the password reset confirmation information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) confirmPasswordResetDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.ConfirmPasswordResetParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req.Mask()
+
+	var res common.EmptyBody
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventConfirmReset, result)
+}
+
+// unlockDumpHandler
+// Summary: This is the function which dumps the account unlock information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) unlockDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.UnlockAccountParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	var res common.EmptyBody
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventUnlock, result)
+}
+
+// mfaEnrollDumpHandler
+// Summary: This is the function which dumps the MFA enrollment information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) mfaEnrollDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var res output.EnrollMFAResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	res.Mask()
+
+	result := c.Response().Status == 201
+	d.authDump(c, nil, res, eventMFAEnroll, result)
+}
+
+// mfaActivateDumpHandler
+// Summary: This is the function which dumps the MFA activation information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) mfaActivateDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.ActivateMFAParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req.Mask()
+
+	var res output.ActivateMFAResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	res.Mask()
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventMFAActivate, result)
+}
+
+// mfaVerifyDumpHandler
+// Summary: This is the function which dumps the MFA verification information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) mfaVerifyDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.VerifyMFAParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req.Mask()
+
+	var res output.LoginResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	res.Mask()
+
+	result := c.Response().Status == 201
+	d.authDump(c, req, res, eventMFAVerify, result)
+}
+
+// mfaDisableDumpHandler
+// Summary: This is the function which dumps the MFA disabling information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) mfaDisableDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	var req input.DisableMFAParam
+	if err := json.Unmarshal(reqBody, &req); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req.Mask()
+
 	var res common.EmptyBody
 	if err := json.Unmarshal(resBody, &res); err != nil {
 		logger.Set(c).Warnf(err.Error())
@@ -178,7 +511,249 @@ func changeDumpHandler(c echo.Context, reqBody, resBody []byte) {
 	}
 
 	result := c.Response().Status == 201
-	authDump(c, req, res, eventChangePassword, result)
+	d.authDump(c, req, res, eventMFADisable, result)
+}
+
+// userDumpHandler
+// Summary: This is the function which dumps the user administration information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) userDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	switch {
+	case c.Request().Method == http.MethodGet:
+		req := input.ListUsersParam{OperatorID: c.QueryParam("operatorId")}
+
+		result := c.Response().Status == 200
+		var res output.UsersResponse
+		if result {
+			if err := json.Unmarshal(resBody, &res); err != nil {
+				logger.Set(c).Warnf(err.Error())
+
+				return
+			}
+		}
+		d.authDump(c, req, res, eventUserList, result)
+	case c.Path() == systemAuthUsersPath:
+		var req input.CreateUserParam
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.Mask()
+
+		var res output.UserResponse
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+
+		result := c.Response().Status == 201
+		d.authDump(c, req, res, eventUserCreate, result)
+	case path.Base(c.Path()) == systemAuthResourceRole:
+		var req input.SetUserRoleParam
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.UID = c.Param("uid")
+
+		var res common.EmptyBody
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+
+		result := c.Response().Status == 201
+		d.authDump(c, req, res, eventUserRole, result)
+	default:
+		req := input.UserParam{UID: c.Param("uid")}
+
+		var res common.EmptyBody
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+
+		switch {
+		case c.Request().Method == http.MethodDelete:
+			d.authDump(c, req, res, eventUserDelete, c.Response().Status == 200)
+		case path.Base(c.Path()) == systemAuthResourceDisable:
+			d.authDump(c, req, res, eventUserDisable, c.Response().Status == 201)
+		case path.Base(c.Path()) == systemAuthResourceEnable:
+			d.authDump(c, req, res, eventUserEnable, c.Response().Status == 201)
+		}
+	}
+}
+
+// apiKeyAdminDumpHandler
+// Summary: This is the function which dumps the API key administration information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) apiKeyAdminDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	method := c.Request().Method
+	switch {
+	case c.Path() == systemAuthAPIKeysPath && method == http.MethodGet:
+		d.authDump(c, common.EmptyBody{}, common.EmptyBody{}, eventAPIKeyList, c.Response().Status == 200)
+	case c.Path() == systemAuthAPIKeysPath:
+		var req input.CreateAPIKeyParam
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+
+		var res output.CreateAPIKeyResponse
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		res.Mask()
+
+		d.authDump(c, req, res, eventAPIKeyCreate, c.Response().Status == 201)
+	case c.Path() == systemAuthAPIKeyPath && method == http.MethodPatch:
+		var req input.UpdateAPIKeyParam
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.ID = c.Param("id")
+
+		d.authDump(c, req, common.EmptyBody{}, eventAPIKeyUpdate, c.Response().Status == 200)
+	case c.Path() == systemAuthAPIKeyPath:
+		req := input.APIKeyParam{ID: c.Param("id")}
+
+		d.authDump(c, req, common.EmptyBody{}, eventAPIKeyRevoke, c.Response().Status == 200)
+	case path.Base(c.Path()) == systemAuthResourceRotate:
+		req := input.APIKeyParam{ID: c.Param("id")}
+
+		var res output.RotateAPIKeyResponse
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		res.Mask()
+
+		d.authDump(c, req, res, eventAPIKeyRotate, c.Response().Status == 201)
+	case strings.Contains(c.Path(), systemAuthResourceOperator):
+		req := input.APIKeyOperatorParam{ID: c.Param("id"), OperatorID: c.Param("operatorId")}
+		if method == http.MethodDelete {
+			d.authDump(c, req, common.EmptyBody{}, eventAPIKeyOperatorUnbind, c.Response().Status == 200)
+
+			return
+		}
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.ID = c.Param("id")
+
+		d.authDump(c, req, common.EmptyBody{}, eventAPIKeyOperatorBind, c.Response().Status == 201)
+	case path.Base(c.Path()) == systemAuthResourceCidr:
+		req := input.APIKeyCidrParam{ID: c.Param("id"), Cidr: c.QueryParam("cidr")}
+		if method == http.MethodDelete {
+			d.authDump(c, req, common.EmptyBody{}, eventAPIKeyCidrRemove, c.Response().Status == 200)
+
+			return
+		}
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.ID = c.Param("id")
+
+		d.authDump(c, req, common.EmptyBody{}, eventAPIKeyCidrAdd, c.Response().Status == 201)
+	case path.Base(c.Path()) == systemAuthResourceCert:
+		req := input.APIKeyClientCertificateParam{ID: c.Param("id"), Type: authentication.ClientCertificateType(c.QueryParam("type")), Certificate: c.QueryParam("certificate")}
+		if method == http.MethodDelete {
+			d.authDump(c, req, common.EmptyBody{}, eventAPIKeyCertRemove, c.Response().Status == 200)
+
+			return
+		}
+		if err := json.Unmarshal(reqBody, &req); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		req.ID = c.Param("id")
+
+		d.authDump(c, req, common.EmptyBody{}, eventAPIKeyCertAdd, c.Response().Status == 201)
+	case path.Base(c.Path()) == systemAuthResourceSigning:
+		req := input.APIKeyParam{ID: c.Param("id")}
+		if method == http.MethodDelete {
+			d.authDump(c, req, common.EmptyBody{}, eventAPIKeySigningDisable, c.Response().Status == 200)
+
+			return
+		}
+
+		var res output.SigningSecretResponse
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		res.Mask()
+
+		d.authDump(c, req, res, eventAPIKeySigningEnable, c.Response().Status == 201)
+	}
+}
+
+// oauthTokenDumpHandler
+// Summary: This is the function which dumps the OAuth 2.0 token request information.
+// input: c(echo.Context): echo context
+// input: reqBody([]byte): request body
+// input: resBody([]byte): response body
+func (d authDumper) oauthTokenDumpHandler(c echo.Context, reqBody, resBody []byte) {
+	form, err := url.ParseQuery(string(reqBody))
+	if err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	req := input.OAuthTokenParam{
+		GrantType:    form.Get("grant_type"),
+		ClientID:     form.Get("client_id"),
+		ClientSecret: form.Get("client_secret"),
+		Scope:        form.Get("scope"),
+	}
+	if clientID, _, ok := c.Request().BasicAuth(); ok {
+		req.ClientID = clientID
+	}
+	req.Mask()
+
+	result := c.Response().Status == 200
+	if !result {
+		var res output.OAuthErrorResponse
+		if err := json.Unmarshal(resBody, &res); err != nil {
+			logger.Set(c).Warnf(err.Error())
+
+			return
+		}
+		d.authDump(c, req, res, eventOAuthToken, result)
+
+		return
+	}
+
+	var res output.OAuthTokenResponse
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		logger.Set(c).Warnf(err.Error())
+
+		return
+	}
+	res.Mask()
+
+	d.authDump(c, req, res, eventOAuthToken, result)
 }
 
 // authDumpInfo
@@ -190,17 +765,18 @@ type authDumpInfo struct {
 	ResponseBody     interface{} `json:"responseBody"`
 	TimeStamp        time.Time   `json:"timeStamp"`
 	RequestIpAddress string      `json:"requestIpAddress"`
-	RequestApiKey    string      `json:"requestApiKey"`
+	RequestApiKeyID  string      `json:"requestApiKeyId"`
 }
 
 // authDump
-// Summary: This is the function which dumps the authentication information.
+// Summary: This is the function which dumps the authentication information and records it as the auth event.
 // input: c(echo.Context): echo context
 // input: reqBody(interface{}): request body
 // input: resBody(interface{}): response body
 // input: event(string): event type to dump
 // input: isRequestResult(bool): is request successful
-func authDump(c echo.Context, reqBody, resBody interface{}, event string, isRequestResult bool) {
+func (d authDumper) authDump(c echo.Context, reqBody, resBody interface{}, event string, isRequestResult bool) {
+	d.recordAuthEvent(c, reqBody, resBody, event, isRequestResult)
 
 	tempReqBody := reqBody
 	tempResBody := resBody
@@ -215,8 +791,8 @@ func authDump(c echo.Context, reqBody, resBody interface{}, event string, isRequ
 		RequestBody:      tempReqBody,
 		ResponseBody:     tempResBody,
 		TimeStamp:        time.Now(),
-		RequestIpAddress: c.RealIP(),
-		RequestApiKey:    c.Request().Header.Get("apiKey"),
+		RequestIpAddress: common.ClientIP(c),
+		RequestApiKeyID:  requestAPIKeyID(c),
 	}
 
 	b, err := json.Marshal(dump)
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/labstack/echo/v4"
+)
+
+const authEventReasonContextKey = "authEventReason"
+
+// recordAuthEvent
+// Summary: This is the function which records the authentication information as the auth event.
+// The event is recorded in the background, so the request is neither delayed nor failed by the database.
+// input: c(echo.Context): echo context
+// input: reqBody(interface{}): request body
+// input: resBody(interface{}): response body
+// input: event(string): event type to record
+// input: isRequestResult(bool): is request successful
+func (d authDumper) recordAuthEvent(c echo.Context, reqBody, resBody interface{}, event string, isRequestResult bool) {
+	operatorID, operatorAccountID := authEventOperator(c, reqBody, resBody)
+	param := repository.CreateAuthEventParam{
+		Event:             event,
+		Result:            isRequestResult,
+		IPAddress:         common.ClientIP(c),
+		APIKeyID:          nonEmpty(requestAPIKeyID(c)),
+		OperatorID:        operatorID,
+		OperatorAccountID: operatorAccountID,
+		OccurredAt:        time.Now(),
+	}
+	if !isRequestResult {
+		param.ReasonCode, _ = c.Get(authEventReasonContextKey).(string)
+	}
+
+	d.authEvents.Write(param)
+}
+
+// apiKeyFailureDump
+// Summary: This is the function which dumps the API key rejected by the validator.
+// input: c(echo.Context): echo context
+// input: apiKey(string): rejected API key
+// input: reason(string): reason of the failure
+func (d authDumper) apiKeyFailureDump(c echo.Context, apiKey string, reason string) {
+	setAuthEventReason(c, reason)
+	d.authDump(c, dummyBodyApikeyIp{APIKey: authentication.MaskAPIKey(apiKey), IP: common.ClientIP(c)}, nil, eventAPIKey, false)
+}
+
+// setAuthEventReason
+// Summary: This is the function which sets the reason of the failure recorded with the auth event.
+// input: c(echo.Context): echo context
+// input: reason(string): reason of the failure
+func setAuthEventReason(c echo.Context, reason string) {
+	c.Set(authEventReasonContextKey, reason)
+}
+
+// errorReason
+// Summary: This is the function which extracts the reason of the failure from the error response.
+// The reason code is preferred, and the details appended to the error message are not included.
+// input: resBody([]byte): response body
+// output: (string) reason of the failure
+func errorReason(resBody []byte) string {
+	var oauthErr output.OAuthErrorResponse
+	if err := json.Unmarshal(resBody, &oauthErr); err == nil && oauthErr.Error != "" {
+		return oauthErr.Error
+	}
+	var httpErr common.HTTPError
+	if err := json.Unmarshal(resBody, &httpErr); err != nil {
+		return ""
+	}
+	if httpErr.Reason != "" {
+		return httpErr.Reason
+	}
+	reason, _, _ := strings.Cut(httpErr.Message, ", ")
+	return reason
+}
+
+// authEventOperator
+// Summary: This is the function which extracts the operator of the auth event.
+// The authenticated operator takes precedence over the operator in the request and the response.
+// input: c(echo.Context): echo context
+// input: reqBody(interface{}): request body
+// input: resBody(interface{}): response body
+// output: (*string) operator ID
+// output: (*string) operator account ID
+func authEventOperator(c echo.Context, reqBody, resBody interface{}) (*string, *string) {
+	if claims, ok := c.Get("operator").(*authentication.Claims); ok {
+		email := claims.Email()
+		return &claims.OperatorID, &email
+	}
+
+	var operatorID, operatorAccountID string
+	switch req := reqBody.(type) {
+	case input.LoginParam:
+		operatorAccountID = req.OperatorAccountID
+	case input.PasswordResetParam:
+		operatorAccountID = req.OperatorAccountID
+	case input.UnlockAccountParam:
+		operatorAccountID = req.OperatorAccountID
+	case input.CreateUserParam:
+		operatorID = req.OperatorID
+		operatorAccountID = req.OperatorAccountID
+	case input.APIKeyOperatorParam:
+		operatorID = req.OperatorID
+	}
+	if res, ok := resBody.(output.VerifyTokenResponse); ok && res.OperatorID != nil {
+		operatorID = *res.OperatorID
+	}
+
+	return nonEmpty(operatorID), nonEmpty(operatorAccountID)
+}
+
+// nonEmpty
+// Summary: This is the function which converts the empty string to nil.
+// input: s(string): string
+// output: (*string) nil if the string is empty
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 	"authenticator-backend/usecase"
 	"authenticator-backend/usecase/input"
@@ -16,21 +18,56 @@ import (
 // AuthMiddleware
 // Summary: This is the structure which defines the auth middleware.
 type AuthMiddleware struct {
-	verifyUsecase usecase.IVerifyUsecase
+	verifyUsecase   usecase.IVerifyUsecase
+	oauthUsecase    usecase.IOAuthUsecase
+	apiKeyCache     repository.APIKeyCache
+	rateLimiter     repository.RateLimiter
+	rateLimitPolicy authentication.RateLimitPolicy
+	apiKeyPolicy    authentication.APIKeyPolicy
+	nonceStore      repository.NonceStore
+	secretCipher    authentication.SecretCipher
 }
 
 // NewAuthMiddleware
 // Summary: This is the function which creates the auth middleware.
 // input: u(usecase.IVerifyUsecase): verify usecase
+// input: o(usecase.IOAuthUsecase): OAuth usecase
+// input: c(repository.APIKeyCache): in-memory index of the API keys and the CIDRs
+// input: l(repository.RateLimiter): counter of the requests with the API keys
+// input: p(authentication.RateLimitPolicy): policy to limit the requests with the API keys
+// input: a(authentication.APIKeyPolicy): policy of the API keys which has the default mode of the IP address restriction and the clock skew of the signed requests
+// input: n(repository.NonceStore): nonces of the signed requests
+// input: s(authentication.SecretCipher): cipher to decrypt the signing secrets of the API keys
 // output: (AuthMiddleware) auth middleware
-func NewAuthMiddleware(u usecase.IVerifyUsecase) AuthMiddleware {
-	return AuthMiddleware{u}
+func NewAuthMiddleware(u usecase.IVerifyUsecase, o usecase.IOAuthUsecase, c repository.APIKeyCache, l repository.RateLimiter, p authentication.RateLimitPolicy, a authentication.APIKeyPolicy, n repository.NonceStore, s authentication.SecretCipher) AuthMiddleware {
+	return AuthMiddleware{u, o, c, l, p, a, n, s}
+}
+
+// AuthJWTConfig
+// Summary: This is the structure which defines the config of the AuthJWT middleware.
+type AuthJWTConfig struct {
+	// CheckRevoked rejects the ID token whose refresh tokens have been revoked (e.g. by logout)
+	CheckRevoked bool
+}
+
+// DefaultAuthJWTConfig
+// Summary: This is the default config of the AuthJWT middleware.
+var DefaultAuthJWTConfig = AuthJWTConfig{
+	CheckRevoked: false,
 }
 
 // AuthJWT
 // Summary: This is the function which authenticates the JWT.
 // output: (echo.MiddlewareFunc) echo middleware function
 func (m AuthMiddleware) AuthJWT() echo.MiddlewareFunc {
+	return m.AuthJWTWithConfig(DefaultAuthJWTConfig)
+}
+
+// AuthJWTWithConfig
+// Summary: This is the function which authenticates the JWT with the config.
+// input: config(AuthJWTConfig): config
+// output: (echo.MiddlewareFunc) echo middleware function
+func (m AuthMiddleware) AuthJWTWithConfig(config AuthJWTConfig) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// prepare
@@ -46,13 +83,13 @@ func (m AuthMiddleware) AuthJWT() echo.MiddlewareFunc {
 			idToken := strings.TrimPrefix(idTokenRaw, "Bearer ")
 
 			// verify idToken
-			claim, err := m.verifyUsecase.IDToken(input.VerifyIDTokenParam{IDToken: idToken})
+			claim, err := m.verifyUsecase.IDToken(c.Request().Context(), input.VerifyIDTokenParam{IDToken: idToken, CheckRevoked: config.CheckRevoked})
 			if err != nil {
 				var customErr *common.CustomError
 				if errors.As(err, &customErr) {
 					logger.Set(c).Warnf(customErr.Message)
 
-					return echo.NewHTTPError(common.HTTPErrorGenerate(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", dataTarget, method))
+					return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(int(customErr.Code), common.HTTPErrorSourceAuth, customErr.Message, "", dataTarget, method, customErr.Reason))
 				}
 
 				logger.Set(c).Error(err.Error())
@@ -68,3 +105,33 @@ func (m AuthMiddleware) AuthJWT() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequireRole
+// Summary: This is the function which allows the request only when the user has one of the roles.
+// It must be used after the AuthJWT middleware which sets the claims to the context.
+// input: roles(...authentication.Role): allowed roles
+// output: (echo.MiddlewareFunc) echo middleware function
+func (m AuthMiddleware) RequireRole(roles ...authentication.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// prepare
+			method := c.Request().Method
+			dataTarget := c.QueryParam("dataTarget")
+
+			claims, ok := c.Get("operator").(*authentication.Claims)
+			if !ok {
+				logger.Set(c).Warnf(common.Err401Authentication)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusUnauthorized, common.HTTPErrorSourceAuth, common.Err401Authentication, "", dataTarget, method))
+			}
+
+			if !claims.HasRole(roles...) {
+				logger.Set(c).Warnf(common.Err403AccessDenied)
+
+				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403AccessDenied, claims.OperatorID, dataTarget, method))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ClientIPSource
+// Summary: This is enum which defines where the IP address of the client is taken from.
+type ClientIPSource string
+
+const (
+	// ClientIPSourceXForwardedFor takes the address from X-Forwarded-For appended by the trusted proxies
+	ClientIPSourceXForwardedFor ClientIPSource = "x-forwarded-for"
+	// ClientIPSourceForwarded takes the address from the for parameter of Forwarded defined in RFC 7239
+	ClientIPSourceForwarded ClientIPSource = "forwarded"
+	// ClientIPSourceDirect takes the address of the peer which connects to the server
+	ClientIPSourceDirect ClientIPSource = "direct"
+)
+
+const headerForwarded = "Forwarded"
+
+// clientIPResolver
+// Summary: This is structure which defines the resolver of the IP address of the client behind the trusted proxies.
+type clientIPResolver struct {
+	source         ClientIPSource
+	trustedProxies []*net.IPNet
+}
+
+// NewClientIPExtractor
+// Summary: This is the function which creates the extractor of the IP address of the client for echo.Echo.IPExtractor.
+// The forwarding headers are read only when the peer is one of the trusted proxies, and they are read from the nearest hop
+// so that the addresses which are set by the client can not be used unless all the proxies after them are trusted.
+// input: source(ClientIPSource): where the IP address of the client is taken from
+// input: trustedProxies([]*net.IPNet): networks of the trusted proxies
+// output: (echo.IPExtractor) extractor of the IP address of the client
+func NewClientIPExtractor(source ClientIPSource, trustedProxies []*net.IPNet) echo.IPExtractor {
+	return clientIPResolver{source, trustedProxies}.extract
+}
+
+// extract
+// Summary: This is the function which returns the IP address of the client of the request.
+// The address of the nearest hop which is not trusted is returned. The address of the farthest trusted hop is returned
+// when all the hops are trusted or the next hop can not be parsed, such as "unknown" of Forwarded.
+// input: req(*http.Request): request
+// output: (string) IP address of the client
+func (r clientIPResolver) extract(req *http.Request) string {
+	peer := parseHop(req.RemoteAddr)
+	if peer == nil {
+		return req.RemoteAddr
+	}
+	if r.source == ClientIPSourceDirect || !r.trusts(peer) {
+		return peer.String()
+	}
+
+	var hops []string
+	switch r.source {
+	case ClientIPSourceXForwardedFor:
+		hops = forwardedForHops(req.Header.Values(echo.HeaderXForwardedFor))
+	case ClientIPSourceForwarded:
+		hops = forwardedHops(req.Header.Values(headerForwarded))
+	}
+
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := parseHop(hops[i])
+		if hop == nil {
+			break
+		}
+		client = hop
+		if !r.trusts(hop) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// trusts
+// Summary: This is the function which checks whether the IP address is one of the trusted proxies.
+// input: ip(net.IP): IP address
+// output: (bool) true if the IP address is trusted, false otherwise
+func (r clientIPResolver) trusts(ip net.IP) bool {
+	for _, network := range r.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedForHops
+// Summary: This is the function which returns the hops of X-Forwarded-For from the farthest to the nearest.
+// input: values([]string): values of X-Forwarded-For
+// output: ([]string) hops of the request
+func forwardedForHops(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	return hops
+}
+
+// forwardedHops
+// Summary: This is the function which returns the for parameters of Forwarded from the farthest to the nearest.
+// The element without the for parameter is an empty hop, which is not parsed as the IP address.
+// input: values([]string): values of Forwarded
+// output: ([]string) hops of the request
+func forwardedHops(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			var hop string
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hop = val
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// parseHop
+// Summary: This is the function which parses the hop which may be quoted and have the port, such as "[2001:db8::1]:4711".
+// input: hop(string): hop of the request
+// output: (net.IP) IP address of the hop, or nil when it is not the IP address
+func parseHop(hop string) net.IP {
+	hop = strings.Trim(strings.TrimSpace(hop), `"`)
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	}
+	return net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]"))
+}
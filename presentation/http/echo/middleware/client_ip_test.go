@@ -0,0 +1,104 @@
+package middleware_test
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"authenticator-backend/presentation/http/echo/middleware"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /////////////////////////////////////////////////////////////////////////////////
+// NewClientIPExtractor テストケース
+// /////////////////////////////////////////////////////////////////////////////////
+// [x] 1-1: 正常系：directの場合はヘッダに関わらず接続元のIPアドレス
+// [x] 1-2: 正常系：信頼するプロキシからのX-Forwarded-Forの場合は信頼しない最も近いIPアドレス
+// [x] 1-3: 正常系：複数のX-Forwarded-Forヘッダは順に連結
+// [x] 1-4: 正常系：信頼するプロキシからのForwardedの場合はforパラメータのIPアドレス
+// [x] 1-5: 正常系：全てのIPアドレスが信頼するプロキシの場合は最も遠いIPアドレス
+// [x] 2-1: 異常系：接続元が信頼するプロキシでない場合はヘッダを無視
+// [x] 2-2: 異常系：IPアドレスでないホップの場合は直前の信頼するプロキシのIPアドレス
+// /////////////////////////////////////////////////////////////////////////////////
+func TestNewClientIPExtractor(tt *testing.T) {
+
+	_, trusted, _ := net.ParseCIDR("10.0.0.0/8")
+	trustedProxies := []*net.IPNet{trusted}
+
+	tests := []struct {
+		name       string
+		source     middleware.ClientIPSource
+		remoteAddr string
+		headers    map[string][]string
+		expect     string
+	}{
+		{
+			name:       "1-1: 正常系：directの場合はヘッダに関わらず接続元のIPアドレス",
+			source:     middleware.ClientIPSourceDirect,
+			remoteAddr: "10.0.0.1:50000",
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.5"}},
+			expect:     "10.0.0.1",
+		},
+		{
+			name:       "1-2: 正常系：信頼するプロキシからのX-Forwarded-Forの場合は信頼しない最も近いIPアドレス",
+			source:     middleware.ClientIPSourceXForwardedFor,
+			remoteAddr: "10.0.0.1:50000",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, 203.0.113.5, 10.0.0.2"}},
+			expect:     "203.0.113.5",
+		},
+		{
+			name:       "1-3: 正常系：複数のX-Forwarded-Forヘッダは順に連結",
+			source:     middleware.ClientIPSourceXForwardedFor,
+			remoteAddr: "10.0.0.1:50000",
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.5", "10.0.0.2"}},
+			expect:     "203.0.113.5",
+		},
+		{
+			name:       "1-4: 正常系：信頼するプロキシからのForwardedの場合はforパラメータのIPアドレス",
+			source:     middleware.ClientIPSourceForwarded,
+			remoteAddr: "[::ffff:10.0.0.1]:50000",
+			headers:    map[string][]string{"Forwarded": {`for=192.0.2.60;proto=https, For="[2001:db8::1]:4711";by=10.0.0.2`}},
+			expect:     "2001:db8::1",
+		},
+		{
+			name:       "1-5: 正常系：全てのIPアドレスが信頼するプロキシの場合は最も遠いIPアドレス",
+			source:     middleware.ClientIPSourceXForwardedFor,
+			remoteAddr: "10.0.0.1:50000",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			expect:     "10.0.0.3",
+		},
+		{
+			name:       "2-1: 異常系：接続元が信頼するプロキシでない場合はヘッダを無視",
+			source:     middleware.ClientIPSourceXForwardedFor,
+			remoteAddr: "198.51.100.1:50000",
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.5"}},
+			expect:     "198.51.100.1",
+		},
+		{
+			name:       "2-2: 異常系：IPアドレスでないホップの場合は直前の信頼するプロキシのIPアドレス",
+			source:     middleware.ClientIPSourceForwarded,
+			remoteAddr: "10.0.0.1:50000",
+			headers:    map[string][]string{"Forwarded": {"for=203.0.113.5, for=unknown, for=10.0.0.2"}},
+			expect:     "10.0.0.2",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			for key, values := range test.headers {
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+
+			extract := middleware.NewClientIPExtractor(test.source, trustedProxies)
+			assert.Equal(t, test.expect, extract(req))
+		})
+	}
+}
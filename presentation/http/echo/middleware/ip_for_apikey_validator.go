@@ -2,66 +2,69 @@ package middleware
 
 import (
 	"net/http"
-	"os"
-	"strings"
 
 	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
-	"authenticator-backend/infrastructure/persistence/datastore"
 
 	"github.com/labstack/echo/v4"
-	"gorm.io/gorm"
 )
 
 // IPForAPIKeyValidator
 // Summary: This is the function which validates the IP address related to the APIkey.
-// input: db(*gorm.DB): database
+// The CIDR rules of the API key are looked up in the in-memory index and applied by the mode of the IP address restriction of the API key.
+// The IP address which is not allowed is only logged and audited in the report-only mode.
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
 // output: (echo.MiddlewareFunc) middleware function
-func IPForAPIKeyValidator(db *gorm.DB) echo.MiddlewareFunc {
+func (m AuthMiddleware) IPForAPIKeyValidator(authEvents repository.AuthEventWriter) echo.MiddlewareFunc {
+	d := newAuthDumper(authEvents)
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			authRepository := datastore.NewAuthRepository(db)
 			method := c.Request().Method
-			apiKey := c.Request().Header.Get(apiKeyHeader)
+			apiKeyID := requestAPIKeyID(c)
 
-			env := os.Getenv("GO_ENV")
-			var ip string
-			if env == "local" {
-				ip = c.RealIP()
-			} else {
-				xff := c.Request().Header.Get("X-Forwarded-For") // X-Forwarded-For: APP_IP, ALB_IP
-				ips := strings.Split(xff, ", ")
-				if len(ips) < 2 {
-					return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403IPNotAuthorizedForKey, "", "", method))
-				}
-				ip = ips[len(ips)-2]
-			}
-
-			dummyBody := dummyBodyApikeyIp{APIKey: apiKey, IP: ip}
-
-			cidrs, err := authRepository.ListCidrs(repository.APIKeyCidrsParam{APIKey: &apiKey})
+			index, err := m.apiKeyCache.Index()
 			if err != nil {
 				logger.Set(c).Warnf(common.Err403IPNotAuthorizedForKey)
-				authDump(c, dummyBody, nil, eventAPIKey, false)
+				setAuthEventReason(c, common.Err403IPNotAuthorizedForKey)
+				d.authDump(c, dummyBodyApikeyIp{APIKey: authentication.MaskAPIKey(c.Request().Header.Get(apiKeyHeader))}, nil, eventAPIKey, false)
 
 				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403IPNotAuthorizedForKey, "", "", method))
 			}
-			if !cidrs.Contains(ip) {
-				logger.Set(c).Warnf(common.Err403IPNotAuthorizedForKey)
-				authDump(c, dummyBody, nil, eventAPIKey, false)
+			apiKey, _ := index.GetAPIKey(apiKeyID)
+			mode := m.apiKeyPolicy.IPRestrictionModeOf(apiKey)
+			if mode == authentication.IPRestrictionModeOff {
+				return next(c)
+			}
 
-				return echo.NewHTTPError(common.HTTPErrorGenerate(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403IPNotAuthorizedForKey, "", "", method))
+			ip := common.ClientIP(c)
+			dummyBody := dummyBodyApikeyIp{APIKey: authentication.MaskAPIKey(c.Request().Header.Get(apiKeyHeader)), IP: ip}
+			if index.Allows(apiKeyID, ip) {
+				d.authDump(c, dummyBody, nil, eventAPIKey, true)
+
+				return next(c)
+			}
+
+			logger.Set(c).Warnf(common.Err403IPNotAuthorizedForKey)
+			setAuthEventReason(c, common.ReasonIPNotAuthorized)
+			if mode == authentication.IPRestrictionModeReportOnly {
+				// the request is accepted, so the violation is recorded as the separate event from the rejection
+				d.authDump(c, dummyBody, nil, eventAPIKeyIPReportOnly, false)
+				setAuthEventReason(c, "")
+
+				return next(c)
 			}
+			d.authDump(c, dummyBody, nil, eventAPIKey, false)
 
-			authDump(c, dummyBody, nil, eventAPIKey, true)
-			return next(c)
+			return echo.NewHTTPError(common.HTTPErrorGenerateWithReason(http.StatusForbidden, common.HTTPErrorSourceAuth, common.Err403IPNotAuthorizedForKey, "", "", method, common.ReasonIPNotAuthorized))
 		}
 	}
 }
 
 // dummyBodyApikeyIp
 // Summary: This is the structure which defines the dummy body for API key and IP address.
+// The API key is masked except for the prefix.
 type dummyBodyApikeyIp struct {
 	APIKey string `json:"apiKey"`
 	IP     string `json:"ipAddress"`
@@ -2,13 +2,14 @@ package router
 
 import (
 	"authenticator-backend/config"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	"authenticator-backend/presentation/http/echo/handler"
 	custom_middleware "authenticator-backend/presentation/http/echo/middleware"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	"gorm.io/gorm"
 )
 
 // SetRouter
@@ -16,9 +17,9 @@ import (
 // input: e(*echo.Echo): echo
 // input: h(handler.AppHandler): handler
 // input: config(*config.Config): config
-// input: conn(*gorm.DB): gorm
+// input: authEvents(repository.AuthEventWriter): writer of the auth events
 // input: authMiddleware(custom_middleware.AuthMiddleware): auth middleware
-func SetRouter(e *echo.Echo, h handler.AppHandler, config *config.Config, conn *gorm.DB, authMiddleware custom_middleware.AuthMiddleware) {
+func SetRouter(e *echo.Echo, h handler.AppHandler, config *config.Config, authEvents repository.AuthEventWriter, authMiddleware custom_middleware.AuthMiddleware) {
 	if config.Env == "local" {
 		e.GET("/swagger/*", echoSwagger.WrapHandler)
 		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -34,30 +35,102 @@ func SetRouter(e *echo.Echo, h handler.AppHandler, config *config.Config, conn *
 	e.Use(middleware.BodyLimit("25M"))
 
 	e.HTTPErrorHandler = handler.CustomHTTPErrorHandler
+	// c.RealIP() returns the IP address of the client resolved with the trusted proxies
+	e.IPExtractor = custom_middleware.NewClientIPExtractor(custom_middleware.ClientIPSource(config.ClientIP.Source), config.ClientIP.TrustedProxies)
 
 	e.GET("/api/v1/authInfo/health", func(c echo.Context) error { return h.HealthCheck(c) })
+	if config.OAuth.Enabled {
+		e.POST("/oauth/token", func(c echo.Context) error { return h.Token(c) }, custom_middleware.AuthDump(authEvents))
+	}
+
+	authJWT := authMiddleware.AuthJWTWithConfig(custom_middleware.AuthJWTConfig{CheckRevoked: config.CheckRevokedTokens})
+	// logout, password change and MFA settings always reject the ID token of a revoked session
+	authJWTCheckRevoked := authMiddleware.AuthJWTWithConfig(custom_middleware.AuthJWTConfig{CheckRevoked: true})
+	requireAdmin := authMiddleware.RequireRole(authentication.RoleAdmin)
+	requireEditor := authMiddleware.RequireRole(authentication.RoleAdmin, authentication.RoleEditor)
+	// the API keys are managed only with the admin API key so that a system API key can not grant itself or the other keys any permission
+	requireAdminAPIKey := authMiddleware.APIKeyAdminValidator()
+	// the requests are limited after the API key is validated
+	rateLimit := authMiddleware.APIKeyRateLimiter()
+	// the signature is verified after the API key is resolved so that the signing is required with every credential of the API key
+	signature := authMiddleware.APIKeySignatureValidator(authEvents)
 
+	// the IP address restriction is applied by the mode of each API key
 	authGroup := e.Group("")
-	authGroup.Use(custom_middleware.APIKeyValidator(conn))
-	if config.EnableIpRestriction {
-		authGroup.Use(custom_middleware.IPForAPIKeyValidator(conn))
-	}
-	authGroup.PUT("/dataReset", func(c echo.Context) error { return h.Reset(c) }, authMiddleware.AuthJWT())
+	authGroup.Use(authMiddleware.APIKeyValidator(authEvents))
+	authGroup.Use(signature)
+	authGroup.Use(authMiddleware.IPForAPIKeyValidator(authEvents))
+	authGroup.Use(authMiddleware.APIKeyPermissionValidator())
+	authGroup.PUT("/dataReset", func(c echo.Context) error { return h.Reset(c) }, authJWT, requireAdmin)
 
 	auth := authGroup.Group("/auth")
-	auth.Use(custom_middleware.AuthDump())
+	if config.APIKey.RateLimitEnabled {
+		auth.Use(rateLimit)
+	}
+	auth.Use(custom_middleware.AuthDump(authEvents))
 	auth.POST("/login", func(c echo.Context) error { return h.Login(c) })
 	auth.POST("/refresh", func(c echo.Context) error { return h.Refresh(c) })
-	auth.POST("/change", func(c echo.Context) error { return h.ChangePassword(c) }, authMiddleware.AuthJWT())
+	auth.POST("/change", func(c echo.Context) error { return h.ChangePassword(c) }, authJWTCheckRevoked)
+	auth.POST("/logout", func(c echo.Context) error { return h.Logout(c) }, authJWTCheckRevoked)
+	auth.POST("/passwordReset", func(c echo.Context) error { return h.RequestPasswordReset(c) })
+	auth.POST("/passwordReset/confirm", func(c echo.Context) error { return h.ConfirmPasswordReset(c) })
+	// the enrollment is closed when MFA is disabled, but the users who have already enrolled can still verify and disable it
+	if config.MFA.Enabled {
+		auth.POST("/mfa/enroll", func(c echo.Context) error { return h.EnrollMFA(c) }, authJWTCheckRevoked)
+		auth.POST("/mfa/activate", func(c echo.Context) error { return h.ActivateMFA(c) }, authJWTCheckRevoked)
+	}
+	auth.POST("/mfa/disable", func(c echo.Context) error { return h.DisableMFA(c) }, authJWTCheckRevoked)
+	auth.POST("/mfa/verify", func(c echo.Context) error { return h.VerifyMFA(c) })
 
-	systemAuth := authGroup.Group("/api/v1/systemAuth")
-	systemAuth.Use(custom_middleware.SystemAPIKeyValidator(conn))
-	systemAuth.Use(custom_middleware.AuthDump())
+	// the system APIs accept the OAuth 2.0 access token and the client certificate instead of the API key header, so they are not under authGroup
+	systemAuth := e.Group("/api/v1/systemAuth")
+	systemAuth.Use(authMiddleware.SystemAPIKeyValidator(authEvents, config.RequireClientCertificate))
+	systemAuth.Use(signature)
+	systemAuth.Use(authMiddleware.IPForAPIKeyValidator(authEvents))
+	systemAuth.Use(authMiddleware.APIKeyPermissionValidator())
+	if config.APIKey.RateLimitEnabled {
+		systemAuth.Use(rateLimit)
+	}
+	systemAuth.Use(custom_middleware.AuthDump(authEvents))
 	systemAuth.POST("/token", func(c echo.Context) error { return h.TokenIntrospection(c) })
+	systemAuth.POST("/token/introspect", func(c echo.Context) error { return h.IntrospectToken(c) })
 	systemAuth.POST("/apiKey", func(c echo.Context) error { return h.ApiKey(c) })
+	systemAuth.POST("/unlock", func(c echo.Context) error { return h.UnlockAccount(c) })
+	systemAuth.POST("/users", func(c echo.Context) error { return h.CreateUser(c) })
+	systemAuth.GET("/users", func(c echo.Context) error { return h.ListUsers(c) })
+	systemAuth.POST("/users/:uid/disable", func(c echo.Context) error { return h.DisableUser(c) })
+	systemAuth.POST("/users/:uid/enable", func(c echo.Context) error { return h.EnableUser(c) })
+	systemAuth.POST("/users/:uid/role", func(c echo.Context) error { return h.SetUserRole(c) })
+	systemAuth.DELETE("/users/:uid", func(c echo.Context) error { return h.DeleteUser(c) })
+	systemAuth.GET("/events", func(c echo.Context) error { return h.ListAuthEvents(c) })
+	systemAuth.POST("/apiKeys", func(c echo.Context) error { return h.CreateAPIKey(c) }, requireAdminAPIKey)
+	systemAuth.GET("/apiKeys", func(c echo.Context) error { return h.ListAPIKeys(c) }, requireAdminAPIKey)
+	systemAuth.PATCH("/apiKeys/:id", func(c echo.Context) error { return h.UpdateAPIKey(c) }, requireAdminAPIKey)
+	systemAuth.DELETE("/apiKeys/:id", func(c echo.Context) error { return h.RevokeAPIKey(c) }, requireAdminAPIKey)
+	systemAuth.POST("/apiKeys/:id/rotate", func(c echo.Context) error { return h.RotateAPIKey(c) }, requireAdminAPIKey)
+	systemAuth.POST("/apiKeys/:id/operators", func(c echo.Context) error { return h.BindAPIKeyOperator(c) }, requireAdminAPIKey)
+	systemAuth.DELETE("/apiKeys/:id/operators/:operatorId", func(c echo.Context) error { return h.UnbindAPIKeyOperator(c) }, requireAdminAPIKey)
+	systemAuth.POST("/apiKeys/:id/cidrs", func(c echo.Context) error { return h.AddAPIKeyCidr(c) }, requireAdminAPIKey)
+	systemAuth.DELETE("/apiKeys/:id/cidrs", func(c echo.Context) error { return h.RemoveAPIKeyCidr(c) }, requireAdminAPIKey)
+	systemAuth.POST("/apiKeys/:id/certificates", func(c echo.Context) error { return h.AddAPIKeyClientCertificate(c) }, requireAdminAPIKey)
+	systemAuth.DELETE("/apiKeys/:id/certificates", func(c echo.Context) error { return h.RemoveAPIKeyClientCertificate(c) }, requireAdminAPIKey)
+	systemAuth.POST("/apiKeys/:id/permissions", func(c echo.Context) error { return h.GrantAPIKeyPermission(c) }, requireAdminAPIKey)
+	systemAuth.DELETE("/apiKeys/:id/permissions", func(c echo.Context) error { return h.RevokeAPIKeyPermission(c) }, requireAdminAPIKey)
+	// the request signing can be disabled but not enabled when the signing secrets can not be encrypted
+	if config.APIKey.RequestSigningEnabled {
+		systemAuth.POST("/apiKeys/:id/signingSecret", func(c echo.Context) error { return h.EnableAPIKeyRequestSigning(c) }, requireAdminAPIKey)
+	}
+	systemAuth.DELETE("/apiKeys/:id/signingSecret", func(c echo.Context) error { return h.DisableAPIKeyRequestSigning(c) }, requireAdminAPIKey)
 
 	authInfo := authGroup.Group("/api/v1/authInfo")
-	authInfo.Use(authMiddleware.AuthJWT())
+	// the requests are limited by the operator only after the ID token is verified
+	if config.APIKey.RateLimitEnabled && !config.APIKey.RateLimitByOperator {
+		authInfo.Use(rateLimit)
+	}
+	authInfo.Use(authJWT)
+	if config.APIKey.RateLimitEnabled && config.APIKey.RateLimitByOperator {
+		authInfo.Use(rateLimit)
+	}
 	authInfo.GET("", func(c echo.Context) error { return h.GetAuthInfo(c) })
-	authInfo.PUT("", func(c echo.Context) error { return h.PutAuthInfo(c) })
+	authInfo.PUT("", func(c echo.Context) error { return h.PutAuthInfo(c) }, requireEditor)
 }
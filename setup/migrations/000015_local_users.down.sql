@@ -0,0 +1 @@
+DROP TABLE IF EXISTS local_users;
\ No newline at end of file
@@ -0,0 +1,28 @@
+CREATE TABLE public.local_users (
+    uid character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    password_hash text NOT NULL,
+    operator_id character varying(256) NOT NULL,
+    disabled boolean DEFAULT false NOT NULL,
+    deleted_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.local_users IS 'ローカルIdPユーザテーブル';
+COMMENT ON COLUMN public.local_users.uid IS 'ユーザID';
+COMMENT ON COLUMN public.local_users.email IS 'メールアドレス';
+COMMENT ON COLUMN public.local_users.password_hash IS 'パスワードハッシュ(bcrypt)';
+COMMENT ON COLUMN public.local_users.operator_id IS '事業者識別子（外部Key）';
+COMMENT ON COLUMN public.local_users.disabled IS '無効化フラグ';
+COMMENT ON COLUMN public.local_users.deleted_at IS '論理削除日時';
+COMMENT ON COLUMN public.local_users.created_at IS '作成日時';
+COMMENT ON COLUMN public.local_users.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.local_users.updated_at IS '更新日時';
+COMMENT ON COLUMN public.local_users.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.local_users ADD CONSTRAINT local_users_pkey PRIMARY KEY (uid);
+ALTER TABLE ONLY public.local_users ADD CONSTRAINT unique_local_users_email UNIQUE (email);
+ALTER TABLE ONLY public.local_users ADD CONSTRAINT local_users_operator_id_fkey FOREIGN KEY (operator_id) REFERENCES public.operators(operator_id) ON UPDATE CASCADE ON DELETE CASCADE;
\ No newline at end of file
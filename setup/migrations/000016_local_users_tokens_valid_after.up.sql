@@ -0,0 +1,2 @@
+ALTER TABLE local_users ADD COLUMN tokens_valid_after timestamp without time zone;
+COMMENT ON COLUMN local_users.tokens_valid_after IS 'トークン有効開始日時（これより前に発行されたトークンは失効）';
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE public.password_reset_requests (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    requested_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.password_reset_requests IS 'パスワード再設定要求テーブル';
+COMMENT ON COLUMN public.password_reset_requests.id IS 'ID';
+COMMENT ON COLUMN public.password_reset_requests.email IS 'メールアドレス';
+COMMENT ON COLUMN public.password_reset_requests.requested_at IS '要求日時';
+COMMENT ON COLUMN public.password_reset_requests.created_at IS '作成日時';
+COMMENT ON COLUMN public.password_reset_requests.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.password_reset_requests.updated_at IS '更新日時';
+COMMENT ON COLUMN public.password_reset_requests.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.password_reset_requests ADD CONSTRAINT password_reset_requests_pkey PRIMARY KEY (id);
+CREATE INDEX idx_password_reset_requests_email_requested_at ON public.password_reset_requests USING btree (email, requested_at);
\ No newline at end of file
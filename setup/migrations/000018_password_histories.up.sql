@@ -0,0 +1,21 @@
+CREATE TABLE public.password_histories (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    password_hash text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.password_histories IS 'パスワード履歴テーブル';
+COMMENT ON COLUMN public.password_histories.id IS 'ID';
+COMMENT ON COLUMN public.password_histories.email IS 'メールアドレス';
+COMMENT ON COLUMN public.password_histories.password_hash IS 'パスワードハッシュ';
+COMMENT ON COLUMN public.password_histories.created_at IS '作成日時';
+COMMENT ON COLUMN public.password_histories.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.password_histories.updated_at IS '更新日時';
+COMMENT ON COLUMN public.password_histories.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.password_histories ADD CONSTRAINT password_histories_pkey PRIMARY KEY (id);
+CREATE INDEX idx_password_histories_email_created_at ON public.password_histories USING btree (email, created_at);
\ No newline at end of file
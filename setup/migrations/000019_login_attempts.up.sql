@@ -0,0 +1,26 @@
+CREATE TABLE public.login_attempts (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    ip_address character varying(256) NOT NULL,
+    result character varying(256) NOT NULL,
+    attempted_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.login_attempts IS 'ログイン試行テーブル';
+COMMENT ON COLUMN public.login_attempts.id IS 'ID';
+COMMENT ON COLUMN public.login_attempts.email IS 'メールアドレス';
+COMMENT ON COLUMN public.login_attempts.ip_address IS 'クライアントIPアドレス';
+COMMENT ON COLUMN public.login_attempts.result IS '結果(failure/success/unlock)';
+COMMENT ON COLUMN public.login_attempts.attempted_at IS '試行日時';
+COMMENT ON COLUMN public.login_attempts.created_at IS '作成日時';
+COMMENT ON COLUMN public.login_attempts.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.login_attempts.updated_at IS '更新日時';
+COMMENT ON COLUMN public.login_attempts.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.login_attempts ADD CONSTRAINT login_attempts_pkey PRIMARY KEY (id);
+CREATE INDEX idx_login_attempts_email_attempted_at ON public.login_attempts USING btree (email, attempted_at);
+CREATE INDEX idx_login_attempts_ip_address_attempted_at ON public.login_attempts USING btree (ip_address, attempted_at);
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE public.mfa_credentials (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    secret text NOT NULL,
+    enabled boolean DEFAULT false NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    recovery_code_hashes text,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.mfa_credentials IS '多要素認証クレデンシャルテーブル';
+COMMENT ON COLUMN public.mfa_credentials.id IS 'ID';
+COMMENT ON COLUMN public.mfa_credentials.email IS 'メールアドレス';
+COMMENT ON COLUMN public.mfa_credentials.secret IS 'TOTPシークレット(暗号化)';
+COMMENT ON COLUMN public.mfa_credentials.enabled IS '有効化フラグ';
+COMMENT ON COLUMN public.mfa_credentials.last_used_step IS '最後に使用したTOTPのタイムステップ';
+COMMENT ON COLUMN public.mfa_credentials.recovery_code_hashes IS 'リカバリーコードハッシュ(JSON配列)';
+COMMENT ON COLUMN public.mfa_credentials.created_at IS '作成日時';
+COMMENT ON COLUMN public.mfa_credentials.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.mfa_credentials.updated_at IS '更新日時';
+COMMENT ON COLUMN public.mfa_credentials.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.mfa_credentials ADD CONSTRAINT mfa_credentials_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.mfa_credentials ADD CONSTRAINT unique_mfa_credentials_email UNIQUE (email);
\ No newline at end of file
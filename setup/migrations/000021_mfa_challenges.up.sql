@@ -0,0 +1,30 @@
+CREATE TABLE public.mfa_challenges (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    email character varying(256) NOT NULL,
+    token_hash character varying(256) NOT NULL,
+    access_token text NOT NULL,
+    refresh_token text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.mfa_challenges IS '多要素認証チャレンジテーブル';
+COMMENT ON COLUMN public.mfa_challenges.id IS 'ID';
+COMMENT ON COLUMN public.mfa_challenges.email IS 'メールアドレス';
+COMMENT ON COLUMN public.mfa_challenges.token_hash IS 'チャレンジトークンハッシュ';
+COMMENT ON COLUMN public.mfa_challenges.access_token IS 'アクセストークン(暗号化)';
+COMMENT ON COLUMN public.mfa_challenges.refresh_token IS 'リフレッシュトークン(暗号化)';
+COMMENT ON COLUMN public.mfa_challenges.attempts IS '検証失敗回数';
+COMMENT ON COLUMN public.mfa_challenges.expires_at IS '有効期限';
+COMMENT ON COLUMN public.mfa_challenges.created_at IS '作成日時';
+COMMENT ON COLUMN public.mfa_challenges.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.mfa_challenges.updated_at IS '更新日時';
+COMMENT ON COLUMN public.mfa_challenges.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.mfa_challenges ADD CONSTRAINT mfa_challenges_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.mfa_challenges ADD CONSTRAINT unique_mfa_challenges_token_hash UNIQUE (token_hash);
+CREATE INDEX idx_mfa_challenges_expires_at ON public.mfa_challenges USING btree (expires_at);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE local_users DROP COLUMN role;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE local_users ADD COLUMN role character varying(32) DEFAULT '' NOT NULL;
+COMMENT ON COLUMN local_users.role IS 'ロール（admin/editor/viewer、未設定の場合はadmin扱い）';
\ No newline at end of file
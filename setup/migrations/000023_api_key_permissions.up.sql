@@ -0,0 +1,23 @@
+CREATE TABLE public.api_key_permissions (
+    api_key character varying(256) NOT NULL,
+    method character varying(16) NOT NULL,
+    path character varying(256) NOT NULL,
+    deleted_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.api_key_permissions IS 'APIキー権限テーブル（登録がないAPIキーは全てのエンドポイントを許可）';
+COMMENT ON COLUMN public.api_key_permissions.api_key IS 'APIキー(外部Key)';
+COMMENT ON COLUMN public.api_key_permissions.method IS 'HTTPメソッド（*は全てのメソッド）';
+COMMENT ON COLUMN public.api_key_permissions.path IS 'ルートパス（末尾の*は前方一致）';
+COMMENT ON COLUMN public.api_key_permissions.deleted_at IS '論理削除日時';
+COMMENT ON COLUMN public.api_key_permissions.created_at IS '作成日時';
+COMMENT ON COLUMN public.api_key_permissions.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.api_key_permissions.updated_at IS '更新日時';
+COMMENT ON COLUMN public.api_key_permissions.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_pkey PRIMARY KEY (api_key, method, path);
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE public.oauth_clients (
+    client_id character varying(256) NOT NULL,
+    client_secret_hash text NOT NULL,
+    api_key character varying(256) NOT NULL,
+    scopes text NOT NULL,
+    deleted_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.oauth_clients IS 'OAuthクライアントテーブル';
+COMMENT ON COLUMN public.oauth_clients.client_id IS 'クライアントID';
+COMMENT ON COLUMN public.oauth_clients.client_secret_hash IS 'クライアントシークレットハッシュ(bcrypt)';
+COMMENT ON COLUMN public.oauth_clients.api_key IS 'APIキー(外部Key)';
+COMMENT ON COLUMN public.oauth_clients.scopes IS '許可スコープ(JSON配列)';
+COMMENT ON COLUMN public.oauth_clients.deleted_at IS '論理削除日時';
+COMMENT ON COLUMN public.oauth_clients.created_at IS '作成日時';
+COMMENT ON COLUMN public.oauth_clients.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.oauth_clients.updated_at IS '更新日時';
+COMMENT ON COLUMN public.oauth_clients.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.oauth_clients ADD CONSTRAINT oauth_clients_pkey PRIMARY KEY (client_id);
+ALTER TABLE ONLY public.oauth_clients ADD CONSTRAINT oauth_clients_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,34 @@
+CREATE TABLE public.auth_events (
+    id character varying(256) DEFAULT gen_random_uuid() NOT NULL,
+    event character varying(256) NOT NULL,
+    result boolean NOT NULL,
+    reason_code character varying(256) NOT NULL,
+    ip_address character varying(256) NOT NULL,
+    api_key_id character varying(256),
+    operator_id character varying(256),
+    operator_account_id character varying(256),
+    occurred_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL
+);
+
+COMMENT ON TABLE public.auth_events IS '認証イベントテーブル';
+COMMENT ON COLUMN public.auth_events.id IS 'ID';
+COMMENT ON COLUMN public.auth_events.event IS 'イベント種別';
+COMMENT ON COLUMN public.auth_events.result IS '結果';
+COMMENT ON COLUMN public.auth_events.reason_code IS '失敗理由';
+COMMENT ON COLUMN public.auth_events.ip_address IS 'クライアントIPアドレス';
+COMMENT ON COLUMN public.auth_events.api_key_id IS 'APIKEYID';
+COMMENT ON COLUMN public.auth_events.operator_id IS '事業者ID';
+COMMENT ON COLUMN public.auth_events.operator_account_id IS '事業者アカウントID(メールアドレス)';
+COMMENT ON COLUMN public.auth_events.occurred_at IS '発生日時';
+COMMENT ON COLUMN public.auth_events.created_at IS '作成日時';
+COMMENT ON COLUMN public.auth_events.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.auth_events.updated_at IS '更新日時';
+COMMENT ON COLUMN public.auth_events.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.auth_events ADD CONSTRAINT auth_events_pkey PRIMARY KEY (id);
+CREATE INDEX idx_auth_events_occurred_at ON public.auth_events USING btree (occurred_at, id);
+CREATE INDEX idx_auth_events_operator_id_occurred_at ON public.auth_events USING btree (operator_id, occurred_at);
\ No newline at end of file
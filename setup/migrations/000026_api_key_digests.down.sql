@@ -0,0 +1,49 @@
+-- the plaintext API keys cannot be restored from the digests, so the digests are stored as the keys and the keys must be reissued
+ALTER TABLE public.api_keys ADD COLUMN api_key character varying(256);
+UPDATE public.api_keys SET api_key = key_digest;
+ALTER TABLE public.api_keys ALTER COLUMN api_key SET NOT NULL;
+COMMENT ON COLUMN public.api_keys.api_key IS 'APIKEY';
+ALTER TABLE ONLY public.api_keys ADD CONSTRAINT api_key_unique UNIQUE (api_key);
+
+ALTER TABLE public.oauth_clients ADD COLUMN api_key character varying(256);
+UPDATE public.oauth_clients SET api_key = api_keys.api_key FROM public.api_keys WHERE api_keys.id = oauth_clients.api_key_id;
+ALTER TABLE public.oauth_clients ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE public.oauth_clients DROP CONSTRAINT oauth_clients_api_key_id_fkey;
+ALTER TABLE public.oauth_clients DROP COLUMN api_key_id;
+COMMENT ON COLUMN public.oauth_clients.api_key IS 'APIキー(外部Key)';
+ALTER TABLE ONLY public.oauth_clients ADD CONSTRAINT oauth_clients_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.api_key_permissions ADD COLUMN api_key character varying(256);
+UPDATE public.api_key_permissions SET api_key = api_keys.api_key FROM public.api_keys WHERE api_keys.id = api_key_permissions.api_key_id;
+ALTER TABLE public.api_key_permissions ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE public.api_key_permissions DROP CONSTRAINT api_key_permissions_pkey;
+ALTER TABLE public.api_key_permissions DROP CONSTRAINT api_key_permissions_api_key_id_fkey;
+ALTER TABLE public.api_key_permissions DROP COLUMN api_key_id;
+COMMENT ON COLUMN public.api_key_permissions.api_key IS 'APIキー(外部Key)';
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_pkey PRIMARY KEY (api_key, method, path);
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.apikey_operators ADD COLUMN api_key character varying(256);
+UPDATE public.apikey_operators SET api_key = api_keys.api_key FROM public.api_keys WHERE api_keys.id = apikey_operators.api_key_id;
+ALTER TABLE public.apikey_operators ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE public.apikey_operators DROP CONSTRAINT apikey_operators_pkey;
+ALTER TABLE public.apikey_operators DROP CONSTRAINT apikey_operators_api_key_id_fkey;
+ALTER TABLE public.apikey_operators DROP COLUMN api_key_id;
+COMMENT ON COLUMN public.apikey_operators.api_key IS 'APIキー(外部Key)';
+ALTER TABLE ONLY public.apikey_operators ADD CONSTRAINT apikey_operators_pkey PRIMARY KEY (api_key, operator_id);
+ALTER TABLE ONLY public.apikey_operators ADD CONSTRAINT apikey_operators_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.cidrs ADD COLUMN api_key character varying(256);
+UPDATE public.cidrs SET api_key = api_keys.api_key FROM public.api_keys WHERE api_keys.id = cidrs.api_key_id;
+ALTER TABLE public.cidrs ALTER COLUMN api_key SET NOT NULL;
+ALTER TABLE public.cidrs DROP CONSTRAINT cidrs_pkey;
+ALTER TABLE public.cidrs DROP CONSTRAINT cidrs_api_key_id_fkey;
+ALTER TABLE public.cidrs DROP COLUMN api_key_id;
+COMMENT ON COLUMN public.cidrs.api_key IS 'APIキー(外部Key)';
+ALTER TABLE ONLY public.cidrs ADD CONSTRAINT cidrs_pkey PRIMARY KEY (cidr, api_key);
+ALTER TABLE ONLY public.cidrs ADD CONSTRAINT cidrs_api_key_fkey FOREIGN KEY (api_key) REFERENCES public.api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS public.idx_api_keys_key_prefix;
+ALTER TABLE public.api_keys DROP CONSTRAINT api_key_digest_unique;
+ALTER TABLE public.api_keys DROP COLUMN key_digest;
+ALTER TABLE public.api_keys DROP COLUMN key_prefix;
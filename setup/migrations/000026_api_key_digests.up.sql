@@ -0,0 +1,53 @@
+ALTER TABLE public.api_keys ADD COLUMN key_prefix character varying(8);
+ALTER TABLE public.api_keys ADD COLUMN key_digest character varying(64);
+-- the prefix of the existing keys is a part of the secret, so the keys issued before this migration should be rotated (see 000040)
+UPDATE public.api_keys SET key_prefix = left(api_key, 8), key_digest = encode(sha256(convert_to(api_key, 'UTF8')), 'hex');
+ALTER TABLE public.api_keys ALTER COLUMN key_prefix SET NOT NULL;
+ALTER TABLE public.api_keys ALTER COLUMN key_digest SET NOT NULL;
+
+COMMENT ON COLUMN public.api_keys.key_prefix IS 'APIKEYの先頭8文字（検索用）';
+COMMENT ON COLUMN public.api_keys.key_digest IS 'APIKEYのダイジェスト(SHA-256)';
+
+ALTER TABLE ONLY public.api_keys ADD CONSTRAINT api_key_digest_unique UNIQUE (key_digest);
+CREATE INDEX idx_api_keys_key_prefix ON public.api_keys USING btree (key_prefix);
+
+ALTER TABLE public.cidrs ADD COLUMN api_key_id character varying(256);
+UPDATE public.cidrs SET api_key_id = api_keys.id FROM public.api_keys WHERE api_keys.api_key = cidrs.api_key;
+ALTER TABLE public.cidrs ALTER COLUMN api_key_id SET NOT NULL;
+ALTER TABLE public.cidrs DROP CONSTRAINT cidrs_pkey;
+ALTER TABLE public.cidrs DROP CONSTRAINT cidrs_api_key_fkey;
+ALTER TABLE public.cidrs DROP COLUMN api_key;
+COMMENT ON COLUMN public.cidrs.api_key_id IS 'APIKEYID(外部Key)';
+ALTER TABLE ONLY public.cidrs ADD CONSTRAINT cidrs_pkey PRIMARY KEY (cidr, api_key_id);
+ALTER TABLE ONLY public.cidrs ADD CONSTRAINT cidrs_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES public.api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.apikey_operators ADD COLUMN api_key_id character varying(256);
+UPDATE public.apikey_operators SET api_key_id = api_keys.id FROM public.api_keys WHERE api_keys.api_key = apikey_operators.api_key;
+ALTER TABLE public.apikey_operators ALTER COLUMN api_key_id SET NOT NULL;
+ALTER TABLE public.apikey_operators DROP CONSTRAINT apikey_operators_pkey;
+ALTER TABLE public.apikey_operators DROP CONSTRAINT apikey_operators_api_key_fkey;
+ALTER TABLE public.apikey_operators DROP COLUMN api_key;
+COMMENT ON COLUMN public.apikey_operators.api_key_id IS 'APIKEYID(外部Key)';
+ALTER TABLE ONLY public.apikey_operators ADD CONSTRAINT apikey_operators_pkey PRIMARY KEY (api_key_id, operator_id);
+ALTER TABLE ONLY public.apikey_operators ADD CONSTRAINT apikey_operators_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES public.api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.api_key_permissions ADD COLUMN api_key_id character varying(256);
+UPDATE public.api_key_permissions SET api_key_id = api_keys.id FROM public.api_keys WHERE api_keys.api_key = api_key_permissions.api_key;
+ALTER TABLE public.api_key_permissions ALTER COLUMN api_key_id SET NOT NULL;
+ALTER TABLE public.api_key_permissions DROP CONSTRAINT api_key_permissions_pkey;
+ALTER TABLE public.api_key_permissions DROP CONSTRAINT api_key_permissions_api_key_fkey;
+ALTER TABLE public.api_key_permissions DROP COLUMN api_key;
+COMMENT ON COLUMN public.api_key_permissions.api_key_id IS 'APIKEYID(外部Key)';
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_pkey PRIMARY KEY (api_key_id, method, path);
+ALTER TABLE ONLY public.api_key_permissions ADD CONSTRAINT api_key_permissions_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES public.api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.oauth_clients ADD COLUMN api_key_id character varying(256);
+UPDATE public.oauth_clients SET api_key_id = api_keys.id FROM public.api_keys WHERE api_keys.api_key = oauth_clients.api_key;
+ALTER TABLE public.oauth_clients ALTER COLUMN api_key_id SET NOT NULL;
+ALTER TABLE public.oauth_clients DROP CONSTRAINT oauth_clients_api_key_fkey;
+ALTER TABLE public.oauth_clients DROP COLUMN api_key;
+COMMENT ON COLUMN public.oauth_clients.api_key_id IS 'APIKEYID(外部Key)';
+ALTER TABLE ONLY public.oauth_clients ADD CONSTRAINT oauth_clients_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES public.api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE public.api_keys DROP CONSTRAINT api_key_unique;
+ALTER TABLE public.api_keys DROP COLUMN api_key;
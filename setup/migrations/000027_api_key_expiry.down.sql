@@ -0,0 +1,2 @@
+ALTER TABLE public.api_keys DROP COLUMN expires_at;
+ALTER TABLE public.api_keys DROP COLUMN not_before;
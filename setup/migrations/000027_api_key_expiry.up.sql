@@ -0,0 +1,7 @@
+ALTER TABLE public.api_keys ADD COLUMN not_before timestamp without time zone;
+ALTER TABLE public.api_keys ADD COLUMN expires_at timestamp without time zone;
+UPDATE public.api_keys SET not_before = created_at;
+ALTER TABLE public.api_keys ALTER COLUMN not_before SET NOT NULL;
+
+COMMENT ON COLUMN public.api_keys.not_before IS '有効期間開始日時';
+COMMENT ON COLUMN public.api_keys.expires_at IS '有効期限（NULLは無期限）';
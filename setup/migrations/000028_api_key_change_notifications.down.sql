@@ -0,0 +1,3 @@
+DROP TRIGGER cidrs_changed ON public.cidrs;
+DROP TRIGGER api_keys_changed ON public.api_keys;
+DROP FUNCTION public.notify_api_keys_changed();
@@ -0,0 +1,15 @@
+CREATE FUNCTION public.notify_api_keys_changed() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('api_keys_changed', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER api_keys_changed AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON public.api_keys
+    FOR EACH STATEMENT EXECUTE FUNCTION public.notify_api_keys_changed();
+CREATE TRIGGER cidrs_changed AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON public.cidrs
+    FOR EACH STATEMENT EXECUTE FUNCTION public.notify_api_keys_changed();
+
+COMMENT ON FUNCTION public.notify_api_keys_changed() IS 'APIキー・CIDRの変更をインメモリキャッシュに通知';
@@ -0,0 +1,3 @@
+ALTER TABLE public.api_keys DROP COLUMN daily_quota;
+ALTER TABLE public.api_keys DROP COLUMN rate_limit_burst;
+ALTER TABLE public.api_keys DROP COLUMN rate_limit_per_minute;
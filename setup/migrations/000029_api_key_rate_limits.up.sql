@@ -0,0 +1,7 @@
+ALTER TABLE public.api_keys ADD COLUMN rate_limit_per_minute integer CHECK (rate_limit_per_minute >= 0);
+ALTER TABLE public.api_keys ADD COLUMN rate_limit_burst integer CHECK (rate_limit_burst >= 0);
+ALTER TABLE public.api_keys ADD COLUMN daily_quota integer CHECK (daily_quota >= 0);
+
+COMMENT ON COLUMN public.api_keys.rate_limit_per_minute IS '1分あたりのリクエスト数上限（NULLはアプリケーション属性の既定値、0は無制限）';
+COMMENT ON COLUMN public.api_keys.rate_limit_burst IS '同時に受け付けるリクエスト数（NULLはアプリケーション属性の既定値、0は1分あたりの上限と同じ）';
+COMMENT ON COLUMN public.api_keys.daily_quota IS '1日（UTC）あたりのリクエスト数上限（NULLはアプリケーション属性の既定値、0は無制限）';
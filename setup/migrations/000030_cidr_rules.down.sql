@@ -0,0 +1,7 @@
+ALTER TABLE public.api_keys DROP COLUMN ip_restriction_mode;
+
+DELETE FROM public.cidrs WHERE action = 'deny' OR length(cidr) > 18;
+ALTER TABLE public.cidrs DROP COLUMN priority;
+ALTER TABLE public.cidrs DROP COLUMN action;
+ALTER TABLE public.cidrs ALTER COLUMN cidr TYPE character varying(18);
+COMMENT ON COLUMN public.cidrs.cidr IS 'CIDR';
@@ -0,0 +1,11 @@
+ALTER TABLE public.cidrs ALTER COLUMN cidr TYPE character varying(43);
+ALTER TABLE public.cidrs ADD COLUMN action character varying(8) NOT NULL DEFAULT 'allow' CHECK (action IN ('allow', 'deny'));
+ALTER TABLE public.cidrs ADD COLUMN priority integer NOT NULL DEFAULT 100 CHECK (priority >= 0);
+
+COMMENT ON COLUMN public.cidrs.cidr IS 'CIDR(IPv4またはIPv6)';
+COMMENT ON COLUMN public.cidrs.action IS '許可(allow)または拒否(deny)';
+COMMENT ON COLUMN public.cidrs.priority IS '評価順（昇順に評価し、最初に一致したルールを適用）';
+
+ALTER TABLE public.api_keys ADD COLUMN ip_restriction_mode character varying(16) CHECK (ip_restriction_mode IN ('off', 'report-only', 'enforce'));
+
+COMMENT ON COLUMN public.api_keys.ip_restriction_mode IS 'IPアドレス制限のモード（NULLは既定のモード）';
@@ -0,0 +1,2 @@
+DROP TRIGGER api_key_certificates_changed ON public.api_key_certificates;
+DROP TABLE IF EXISTS public.api_key_certificates;
@@ -0,0 +1,28 @@
+CREATE TABLE public.api_key_certificates (
+    certificate_type character varying(16) NOT NULL,
+    certificate character varying(1024) NOT NULL,
+    api_key_id character varying(256) NOT NULL,
+    deleted_at timestamp without time zone,
+    created_at timestamp without time zone NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp without time zone NOT NULL,
+    updated_user_id text NOT NULL,
+    CHECK (certificate_type IN ('fingerprint', 'subject'))
+);
+
+COMMENT ON TABLE public.api_key_certificates IS 'APIキークライアント証明書テーブル';
+COMMENT ON COLUMN public.api_key_certificates.certificate_type IS '証明書の識別方法（fingerprint: SHA-256フィンガープリント、subject: サブジェクトDN）';
+COMMENT ON COLUMN public.api_key_certificates.certificate IS 'SHA-256フィンガープリント(16進数小文字)またはサブジェクトDN(RFC 2253)';
+COMMENT ON COLUMN public.api_key_certificates.api_key_id IS 'APIKEYID(外部Key)';
+COMMENT ON COLUMN public.api_key_certificates.deleted_at IS '論理削除日時';
+COMMENT ON COLUMN public.api_key_certificates.created_at IS '作成日時';
+COMMENT ON COLUMN public.api_key_certificates.created_user_id IS '作成ユーザ';
+COMMENT ON COLUMN public.api_key_certificates.updated_at IS '更新日時';
+COMMENT ON COLUMN public.api_key_certificates.updated_user_id IS '更新ユーザ';
+
+ALTER TABLE ONLY public.api_key_certificates ADD CONSTRAINT api_key_certificates_pkey PRIMARY KEY (certificate_type, certificate);
+ALTER TABLE ONLY public.api_key_certificates ADD CONSTRAINT api_key_certificates_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES public.api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE;
+CREATE INDEX idx_api_key_certificates_api_key_id ON public.api_key_certificates USING btree (api_key_id);
+
+CREATE TRIGGER api_key_certificates_changed AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON public.api_key_certificates
+    FOR EACH STATEMENT EXECUTE FUNCTION public.notify_api_keys_changed();
@@ -0,0 +1 @@
+ALTER TABLE public.api_keys DROP COLUMN signing_secret;
@@ -0,0 +1,3 @@
+ALTER TABLE public.api_keys ADD COLUMN signing_secret text;
+
+COMMENT ON COLUMN public.api_keys.signing_secret IS 'リクエスト署名の共有シークレット（暗号化済み、NULLは署名不要）';
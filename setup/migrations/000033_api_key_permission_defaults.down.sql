@@ -0,0 +1,6 @@
+COMMENT ON COLUMN public.api_key_permissions.path IS 'ルートパス（末尾の*は前方一致）';
+COMMENT ON TABLE public.api_key_permissions IS 'APIキー権限テーブル（登録がないAPIキーは全てのエンドポイントを許可）';
+
+DROP TRIGGER api_key_permissions_changed ON public.api_key_permissions;
+
+DELETE FROM public.api_key_permissions WHERE method = '*' AND path = '*' AND created_user_id = 'migration';
@@ -0,0 +1,11 @@
+-- the api keys without any permission were allowed every route, so they are granted every route explicitly before the permissions deny by default
+INSERT INTO public.api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id)
+    SELECT api_keys.id, '*', '*', NULL, CURRENT_TIMESTAMP AT TIME ZONE 'UTC', 'migration', CURRENT_TIMESTAMP AT TIME ZONE 'UTC', 'migration' FROM public.api_keys
+    WHERE api_keys.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM public.api_key_permissions WHERE api_key_permissions.api_key_id = api_keys.id AND api_key_permissions.deleted_at IS NULL)
+    ON CONFLICT (api_key_id, method, path) DO UPDATE SET deleted_at = NULL, updated_at = EXCLUDED.updated_at, updated_user_id = EXCLUDED.updated_user_id;
+
+CREATE TRIGGER api_key_permissions_changed AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON public.api_key_permissions
+    FOR EACH STATEMENT EXECUTE FUNCTION public.notify_api_keys_changed();
+
+COMMENT ON TABLE public.api_key_permissions IS 'APIキー権限テーブル（登録がないAPIキーは全てのエンドポイントを拒否）';
+COMMENT ON COLUMN public.api_key_permissions.path IS 'ルートパス（末尾の*は前方一致、*は全てのルート）';
@@ -0,0 +1,5 @@
+-- no API key is the admin after the migration, so the admin API key must be granted in the database, e.g.
+-- UPDATE public.api_keys SET is_admin = true WHERE id = '<id of the API key>';
+ALTER TABLE public.api_keys ADD COLUMN is_admin boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN public.api_keys.is_admin IS 'APIキー管理権限（trueのAPIキーのみAPIキーの発行、更新、失効、権限付与が可能）';
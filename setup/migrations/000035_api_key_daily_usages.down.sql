@@ -0,0 +1,5 @@
+COMMENT ON COLUMN public.api_keys.rate_limit_per_minute IS '1分あたりのリクエスト数上限（NULLはアプリケーション属性の既定値、0は無制限）';
+COMMENT ON COLUMN public.api_keys.rate_limit_burst IS '同時に受け付けるリクエスト数（NULLはアプリケーション属性の既定値、0は1分あたりの上限と同じ）';
+COMMENT ON COLUMN public.api_keys.daily_quota IS '1日（UTC）あたりのリクエスト数上限（NULLはアプリケーション属性の既定値、0は無制限）';
+
+DROP TABLE IF EXISTS public.api_key_daily_usages;
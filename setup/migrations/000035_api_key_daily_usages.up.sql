@@ -0,0 +1,18 @@
+CREATE TABLE public.api_key_daily_usages (
+    bucket_key text NOT NULL,
+    day date NOT NULL,
+    used integer NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+COMMENT ON TABLE public.api_key_daily_usages IS 'APIキー日次リクエスト数テーブル（全インスタンスで共有）';
+COMMENT ON COLUMN public.api_key_daily_usages.bucket_key IS 'APIKEYID（オペレータ単位で制限する場合はAPIKEYID/オペレータID）';
+COMMENT ON COLUMN public.api_key_daily_usages.day IS 'リクエストを数える日（UTC）';
+COMMENT ON COLUMN public.api_key_daily_usages.used IS '受け付けたリクエスト数';
+COMMENT ON COLUMN public.api_key_daily_usages.updated_at IS '更新日時';
+
+ALTER TABLE ONLY public.api_key_daily_usages ADD CONSTRAINT api_key_daily_usages_pkey PRIMARY KEY (bucket_key, day);
+
+COMMENT ON COLUMN public.api_keys.rate_limit_per_minute IS '1分あたりのリクエスト数上限（インスタンスごとに適用、NULLはアプリケーション属性の既定値、0は無制限）';
+COMMENT ON COLUMN public.api_keys.rate_limit_burst IS '同時に受け付けるリクエスト数（インスタンスごとに適用、NULLはアプリケーション属性の既定値、0は1分あたりの上限と同じ）';
+COMMENT ON COLUMN public.api_keys.daily_quota IS '1日（UTC）あたりのリクエスト数上限（全インスタンスの合計、NULLはアプリケーション属性の既定値、0は無制限）';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.api_key_signature_nonces;
@@ -0,0 +1,11 @@
+CREATE TABLE public.api_key_signature_nonces (
+    nonce_key text NOT NULL,
+    expires_at timestamp without time zone NOT NULL
+);
+
+COMMENT ON TABLE public.api_key_signature_nonces IS 'リクエスト署名ノンステーブル（全インスタンスで共有）';
+COMMENT ON COLUMN public.api_key_signature_nonces.nonce_key IS 'APIKEYID:ノンス';
+COMMENT ON COLUMN public.api_key_signature_nonces.expires_at IS '有効期限（期限後は削除）';
+
+ALTER TABLE ONLY public.api_key_signature_nonces ADD CONSTRAINT api_key_signature_nonces_pkey PRIMARY KEY (nonce_key);
+CREATE INDEX idx_api_key_signature_nonces_expires_at ON public.api_key_signature_nonces USING btree (expires_at);
@@ -0,0 +1 @@
+COMMENT ON COLUMN local_users.role IS 'ロール（admin/editor/viewer、未設定の場合はadmin扱い）';
\ No newline at end of file
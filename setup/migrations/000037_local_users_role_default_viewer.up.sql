@@ -0,0 +1,2 @@
+-- the users without the role are treated as the viewer; grant the role with cmd/backfill_user_roles to keep their access
+COMMENT ON COLUMN local_users.role IS 'ロール（admin/editor/viewer、未設定の場合はviewer扱い）';
\ No newline at end of file
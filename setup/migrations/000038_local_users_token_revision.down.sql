@@ -0,0 +1 @@
+ALTER TABLE local_users DROP COLUMN token_revision;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE local_users ADD COLUMN token_revision integer DEFAULT 0 NOT NULL;
+COMMENT ON COLUMN local_users.token_revision IS 'トークン失効回数（トークンの値と異なる場合は失効）';
\ No newline at end of file
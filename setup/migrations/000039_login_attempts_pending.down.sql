@@ -0,0 +1 @@
+COMMENT ON COLUMN public.login_attempts.result IS '結果(failure/success/unlock)';
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- the pending attempts are counted as the failures until their result is recorded
+COMMENT ON COLUMN public.login_attempts.result IS '結果(pending/failure/success/unlock/canceled)';
\ No newline at end of file
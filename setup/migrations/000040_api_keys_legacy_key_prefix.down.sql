@@ -0,0 +1 @@
+COMMENT ON COLUMN public.api_keys.key_prefix IS 'APIKEYの先頭8文字（検索用）';
\ No newline at end of file
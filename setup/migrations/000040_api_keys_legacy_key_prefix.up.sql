@@ -0,0 +1,2 @@
+-- the prefix of the API keys issued before 000026 is the plaintext part of the secret which may be short; rotate them with POST /api/v1/systemAuth/apiKeys/:id/rotate
+COMMENT ON COLUMN public.api_keys.key_prefix IS 'APIKEYの先頭8文字（検索用、000026以前に発行したAPIKEYはローテーションすること）';
\ No newline at end of file
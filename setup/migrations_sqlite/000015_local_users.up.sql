@@ -0,0 +1,15 @@
+CREATE TABLE local_users (
+    uid character varying(256) NOT NULL,
+    email character varying(256) NOT NULL,
+    password_hash text NOT NULL,
+    operator_id character varying(256) NOT NULL,
+    disabled boolean DEFAULT false NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    UNIQUE(email),
+    PRIMARY KEY (uid),
+    FOREIGN KEY (operator_id) REFERENCES operators(operator_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE local_users ADD COLUMN tokens_valid_after timestamp;
\ No newline at end of file
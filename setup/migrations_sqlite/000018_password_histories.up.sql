@@ -0,0 +1,10 @@
+CREATE TABLE password_histories (
+    id character varying(256) NOT NULL,
+    email character varying(256) NOT NULL,
+    password_hash text NOT NULL,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (id)
+);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE login_attempts (
+    id character varying(256) NOT NULL,
+    email character varying(256) NOT NULL,
+    ip_address character varying(256) NOT NULL,
+    result character varying(256) NOT NULL,
+    attempted_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mfa_credentials;
\ No newline at end of file
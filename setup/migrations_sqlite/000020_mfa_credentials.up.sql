@@ -0,0 +1,14 @@
+CREATE TABLE mfa_credentials (
+    id character varying(256) NOT NULL,
+    email character varying(256) NOT NULL,
+    secret text NOT NULL,
+    enabled boolean DEFAULT false NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    recovery_code_hashes text,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (email)
+);
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE mfa_challenges (
+    id character varying(256) NOT NULL,
+    email character varying(256) NOT NULL,
+    token_hash character varying(256) NOT NULL,
+    access_token text NOT NULL,
+    refresh_token text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (token_hash)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE local_users ADD COLUMN role character varying(32) DEFAULT '' NOT NULL;
\ No newline at end of file
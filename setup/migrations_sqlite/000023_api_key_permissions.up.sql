@@ -0,0 +1,12 @@
+CREATE TABLE api_key_permissions (
+    api_key character varying(256) NOT NULL,
+    method character varying(16) NOT NULL,
+    path character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (api_key, method, path),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
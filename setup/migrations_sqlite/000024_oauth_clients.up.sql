@@ -0,0 +1,13 @@
+CREATE TABLE oauth_clients (
+    client_id character varying(256) NOT NULL,
+    client_secret_hash text NOT NULL,
+    api_key character varying(256) NOT NULL,
+    scopes text NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (client_id),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE auth_events (
+    id character varying(256) NOT NULL,
+    event character varying(256) NOT NULL,
+    result boolean NOT NULL,
+    reason_code character varying(256) NOT NULL,
+    ip_address character varying(256) NOT NULL,
+    api_key_id character varying(256),
+    operator_id character varying(256),
+    operator_account_id character varying(256),
+    occurred_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (id)
+);
\ No newline at end of file
@@ -0,0 +1,66 @@
+DROP TABLE IF EXISTS oauth_clients;
+DROP TABLE IF EXISTS api_key_permissions;
+DROP TABLE IF EXISTS apikey_operators;
+DROP TABLE IF EXISTS cidrs;
+DROP TABLE IF EXISTS api_keys;
+CREATE TABLE api_keys (
+    id character varying(256) NOT NULL,
+    api_key character varying(256) NOT NULL,
+    application_name character varying(256) NOT NULL,
+    application_attribute character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    UNIQUE(api_key),
+    PRIMARY KEY (id)
+);
+CREATE TABLE cidrs (
+    cidr character varying(18) NOT NULL,
+    api_key character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp zone NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (cidr, api_key),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE apikey_operators (
+    api_key character varying(256) NOT NULL,
+    operator_id character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (api_key, operator_id),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (operator_id) REFERENCES operators(operator_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE api_key_permissions (
+    api_key character varying(256) NOT NULL,
+    method character varying(16) NOT NULL,
+    path character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (api_key, method, path),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE oauth_clients (
+    client_id character varying(256) NOT NULL,
+    client_secret_hash text NOT NULL,
+    api_key character varying(256) NOT NULL,
+    scopes text NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (client_id),
+    FOREIGN KEY (api_key) REFERENCES api_keys(api_key) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,68 @@
+DROP TABLE IF EXISTS oauth_clients;
+DROP TABLE IF EXISTS api_key_permissions;
+DROP TABLE IF EXISTS apikey_operators;
+DROP TABLE IF EXISTS cidrs;
+DROP TABLE IF EXISTS api_keys;
+CREATE TABLE api_keys (
+    id character varying(256) NOT NULL,
+    key_prefix character varying(8) NOT NULL,
+    key_digest character varying(64) NOT NULL,
+    application_name character varying(256) NOT NULL,
+    application_attribute character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    UNIQUE(key_digest),
+    PRIMARY KEY (id)
+);
+CREATE INDEX idx_api_keys_key_prefix ON api_keys (key_prefix);
+CREATE TABLE cidrs (
+    cidr character varying(18) NOT NULL,
+    api_key_id character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (cidr, api_key_id),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE apikey_operators (
+    api_key_id character varying(256) NOT NULL,
+    operator_id character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (api_key_id, operator_id),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    FOREIGN KEY (operator_id) REFERENCES operators(operator_id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE api_key_permissions (
+    api_key_id character varying(256) NOT NULL,
+    method character varying(16) NOT NULL,
+    path character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (api_key_id, method, path),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE oauth_clients (
+    client_id character varying(256) NOT NULL,
+    client_secret_hash text NOT NULL,
+    api_key_id character varying(256) NOT NULL,
+    scopes text NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (client_id),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys ADD COLUMN not_before timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE api_keys ADD COLUMN expires_at timestamp;
+UPDATE api_keys SET not_before = created_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys DROP COLUMN daily_quota;
+ALTER TABLE api_keys DROP COLUMN rate_limit_burst;
+ALTER TABLE api_keys DROP COLUMN rate_limit_per_minute;
\ No newline at end of file
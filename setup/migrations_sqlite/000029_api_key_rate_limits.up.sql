@@ -0,0 +1,3 @@
+ALTER TABLE api_keys ADD COLUMN rate_limit_per_minute integer CHECK (rate_limit_per_minute >= 0);
+ALTER TABLE api_keys ADD COLUMN rate_limit_burst integer CHECK (rate_limit_burst >= 0);
+ALTER TABLE api_keys ADD COLUMN daily_quota integer CHECK (daily_quota >= 0);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE api_keys DROP COLUMN ip_restriction_mode;
+DELETE FROM cidrs WHERE action = 'deny' OR length(cidr) > 18;
+ALTER TABLE cidrs DROP COLUMN priority;
+ALTER TABLE cidrs DROP COLUMN action;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE cidrs ADD COLUMN action character varying(8) NOT NULL DEFAULT 'allow' CHECK (action IN ('allow', 'deny'));
+ALTER TABLE cidrs ADD COLUMN priority integer NOT NULL DEFAULT 100 CHECK (priority >= 0);
+ALTER TABLE api_keys ADD COLUMN ip_restriction_mode character varying(16) CHECK (ip_restriction_mode IN ('off', 'report-only', 'enforce'));
\ No newline at end of file
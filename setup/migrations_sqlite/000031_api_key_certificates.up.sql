@@ -0,0 +1,12 @@
+CREATE TABLE api_key_certificates (
+    certificate_type character varying(16) NOT NULL,
+    certificate character varying(1024) NOT NULL,
+    api_key_id character varying(256) NOT NULL,
+    deleted_at timestamp,
+    created_at timestamp NOT NULL,
+    created_user_id text NOT NULL,
+    updated_at timestamp NOT NULL,
+    updated_user_id text NOT NULL,
+    PRIMARY KEY (certificate_type, certificate),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE api_keys DROP COLUMN signing_secret;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE api_keys ADD COLUMN signing_secret text;
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM api_key_permissions WHERE method = '*' AND path = '*' AND created_user_id = 'migration';
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) SELECT id, '*', '*', NULL, CURRENT_TIMESTAMP, 'migration', CURRENT_TIMESTAMP, 'migration' FROM api_keys WHERE deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM api_key_permissions WHERE api_key_permissions.api_key_id = api_keys.id AND api_key_permissions.deleted_at IS NULL);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE api_keys DROP COLUMN is_admin;
\ No newline at end of file
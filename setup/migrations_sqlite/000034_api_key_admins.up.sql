@@ -0,0 +1 @@
+ALTER TABLE api_keys ADD COLUMN is_admin boolean NOT NULL DEFAULT false;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS api_key_daily_usages;
\ No newline at end of file
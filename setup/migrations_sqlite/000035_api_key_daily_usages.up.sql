@@ -0,0 +1,7 @@
+CREATE TABLE api_key_daily_usages (
+    bucket_key text NOT NULL,
+    day date NOT NULL,
+    used integer NOT NULL,
+    updated_at timestamp NOT NULL,
+    PRIMARY KEY (bucket_key, day)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS api_key_signature_nonces;
\ No newline at end of file
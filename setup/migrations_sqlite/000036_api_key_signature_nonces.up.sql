@@ -0,0 +1,5 @@
+CREATE TABLE api_key_signature_nonces (
+    nonce_key text NOT NULL,
+    expires_at timestamp NOT NULL,
+    PRIMARY KEY (nonce_key)
+);
\ No newline at end of file
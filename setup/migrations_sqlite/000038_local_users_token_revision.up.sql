@@ -0,0 +1 @@
+ALTER TABLE local_users ADD COLUMN token_revision integer DEFAULT 0 NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE public.api_keys SET is_admin = true WHERE id = '00000000-0000-0000-0000-000000000002';
\ No newline at end of file
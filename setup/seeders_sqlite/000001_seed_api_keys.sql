@@ -1,2 +1,2 @@
-INSERT INTO api_keys (id, api_key, application_name, application_attribute, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000001', 'Sample-APIKey1','Application-Vendor-A', 'Application', NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
-INSERT INTO api_keys (id, api_key, application_name, application_attribute, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000002', 'Sample-APIKey2','DataPlatform', 'DataSpace', NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
\ No newline at end of file
+INSERT INTO api_keys (id, key_prefix, key_digest, application_name, application_attribute, not_before, expires_at, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000001', 'Sample-A', '573c80f4c4528c7f473ed65197b92efc15c0f3af02038c519b25512580e6469d','Application-Vendor-A', 'Application', '2024-05-01 00:00:00.000000', NULL, NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
+INSERT INTO api_keys (id, key_prefix, key_digest, application_name, application_attribute, not_before, expires_at, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000002', 'Sample-A', 'c8041f725161853bd4778f845d231b42d9290d714552f66b320d3d676a381378','DataPlatform', 'DataSpace', '2024-05-01 00:00:00.000000', NULL, NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
\ No newline at end of file
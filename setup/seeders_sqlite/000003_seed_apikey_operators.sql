@@ -1,2 +1,2 @@
-INSERT INTO apikey_operators(api_key, operator_id, deleted_at, created_at, created_user_id, updated_at, updated_user_id)VALUES('Sample-APIKey1', 'b39e6248-c888-56ca-d9d0-89de1b1adc8e',  NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
-INSERT INTO apikey_operators(api_key, operator_id, deleted_at, created_at, created_user_id, updated_at, updated_user_id)VALUES('Sample-APIKey2', '15572d1c-ec13-0d78-7f92-dd4278871373',  NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
\ No newline at end of file
+INSERT INTO apikey_operators(api_key_id, operator_id, deleted_at, created_at, created_user_id, updated_at, updated_user_id)VALUES('00000000-0000-0000-0000-000000000001', 'b39e6248-c888-56ca-d9d0-89de1b1adc8e',  NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
+INSERT INTO apikey_operators(api_key_id, operator_id, deleted_at, created_at, created_user_id, updated_at, updated_user_id)VALUES('00000000-0000-0000-0000-000000000002', '15572d1c-ec13-0d78-7f92-dd4278871373',  NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
\ No newline at end of file
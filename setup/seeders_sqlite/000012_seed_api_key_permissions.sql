@@ -0,0 +1,2 @@
+INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000001', '*', '*', NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
+INSERT INTO api_key_permissions (api_key_id, method, path, deleted_at, created_at, created_user_id, updated_at, updated_user_id) VALUES('00000000-0000-0000-0000-000000000002', '*', '*', NULL, '2024-05-01 00:00:00.000000', 'seed', '2024-05-01 00:00:00.000000', 'seed');
\ No newline at end of file
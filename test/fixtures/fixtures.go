@@ -1,6 +1,8 @@
 package fixtures
 
 import (
+	"time"
+
 	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/model/traceability"
 	"authenticator-backend/usecase/input"
@@ -14,6 +16,7 @@ var (
 	AccountPassword    = "123456"
 	AccountPasswordNew = "1Aa@1Aa@1Aa@"
 	ApiKey             = "36cfd2a8-9f45-0766-77d3-7098c1336a32"
+	ApiKeyID           = "00000000-0000-0000-0000-000000000001"
 	AssertMessage      = "比較対象の２つの値は定義順に関係なく、一致する必要があります。"
 	Email              = "testaccount_user122@example.com"
 	GlobalOperatorId   = "GlobalOperatorId"
@@ -24,6 +27,13 @@ var (
 	InvalidUUID        = "invalid_uuid"
 	InvalidEnum        = "invalid_enum"
 	IpAddress          = "127.0.0.1"
+	LoginAttemptID     = "00000000-0000-0000-0000-0000000000a1"
+	MFAChallengeToken  = "mfa_challenge_token"
+	MFAEncryptionKey   = "mfa_encryption_key"
+	MFASecret          = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+	OAuthClientID      = "oauth_client_id"
+	OAuthClientSecret  = "oauth_client_secret"
+	OAuthSigningKey    = "oauth_signing_key"
 	OpenOperatorID     = "AAAA-123456"
 	OpenPlantID        = "AAAA-123456"
 	OperatorAccountID  = "aaa@bbb.com"
@@ -31,10 +41,12 @@ var (
 	OperatorID         = "e03cc699-7234-31ed-86be-cc18c92208e5"
 	OperatorId         = "e03cc699-7234-31ed-86be-cc18c92208e5"
 	OperatorName       = "A株式会社"
+	PasswordResetCode  = "password_reset_code"
 	PlantAddress       = "東京都"
 	PlantID            = "eedf264e-cace-4414-8bd3-e10ce1c090e0"
 	PlantId            = "eedf264e-cace-4414-8bd3-e10ce1c090e0"
 	PlantName          = "A工場"
+	RecoveryCode       = "abcde-fghij"
 	Token              = "valid_token" // 実際には無効。有効なtokenを定義することはできないので、ダミー
 	UID                = "uid"
 )
@@ -42,8 +54,12 @@ var (
 func NewClaims() authentication.Claims {
 	return authentication.Claims{
 		OperatorID: OperatorId,
+		Role:       authentication.RoleAdmin,
 		Token: auth.Token{
 			UID: UID,
+			Claims: map[string]interface{}{
+				authentication.EmailClaim: Email,
+			},
 		},
 	}
 }
@@ -58,6 +74,7 @@ func NewInputLoginParam() input.LoginParam {
 	return input.LoginParam{
 		OperatorAccountID: OperatorAccountID,
 		AccountPassword:   AccountPassword,
+		IPAddress:         IpAddress,
 	}
 }
 
@@ -69,11 +86,216 @@ func NewInputRefreshParam() input.RefreshParam {
 
 func NewInputChangePasswordParam() input.ChangePasswordParam {
 	return input.ChangePasswordParam{
-		UID:         UID,
+		UID:             UID,
+		Email:           Email,
+		CurrentPassword: AccountPassword,
+		NewPassword:     authentication.Password(AccountPasswordNew),
+		IPAddress:       IpAddress,
+	}
+}
+
+func NewInputLogoutParam() input.LogoutParam {
+	return input.LogoutParam{
+		UID: UID,
+	}
+}
+
+func NewInputPasswordResetParam() input.PasswordResetParam {
+	return input.PasswordResetParam{
+		OperatorAccountID: Email,
+	}
+}
+
+func NewInputConfirmPasswordResetParam() input.ConfirmPasswordResetParam {
+	return input.ConfirmPasswordResetParam{
+		Code:        PasswordResetCode,
 		NewPassword: authentication.Password(AccountPasswordNew),
 	}
 }
 
+func NewPasswordPolicy() authentication.PasswordPolicy {
+	return authentication.PasswordPolicy{
+		MinLength:               8,
+		MaxLength:               20,
+		RequireUpperCase:        true,
+		RequireLowerCase:        true,
+		RequireDigit:            true,
+		RequireSpecialCharacter: true,
+		SpecialCharacters:       "!@#$%^&*",
+		DenyList:                authentication.NewPasswordDenyList([]string{"Password1!"}),
+		DisallowEmailLocalPart:  true,
+	}
+}
+
+func NewLoginThrottlePolicy() authentication.LoginThrottlePolicy {
+	return authentication.LoginThrottlePolicy{
+		FailureWindow:      15 * time.Minute,
+		LockoutThreshold:   5,
+		LockoutDuration:    15 * time.Minute,
+		DelayThreshold:     3,
+		BaseDelay:          time.Second,
+		MaxDelay:           30 * time.Second,
+		IPFailureThreshold: 20,
+		IPFailureWindow:    15 * time.Minute,
+	}
+}
+
+func NewLoginFailures(count int, last time.Time) authentication.LoginAttempts {
+	failures := make(authentication.LoginAttempts, count)
+	for i := range failures {
+		failures[i] = authentication.LoginAttempt{
+			Email:       OperatorAccountID,
+			IPAddress:   IpAddress,
+			Result:      authentication.LoginAttemptResultFailure,
+			AttemptedAt: last.Add(-time.Duration(i) * time.Second),
+		}
+	}
+	return failures
+}
+
+func NewInputUnlockAccountParam() input.UnlockAccountParam {
+	return input.UnlockAccountParam{
+		OperatorAccountID: Email,
+	}
+}
+
+func NewMFAPolicy() authentication.MFAPolicy {
+	return authentication.MFAPolicy{
+		Issuer:            "authenticator-backend",
+		ChallengeTTL:      5 * time.Minute,
+		MaxAttempts:       5,
+		RecoveryCodeCount: 10,
+	}
+}
+
+func NewSecretCipher() authentication.SecretCipher {
+	return authentication.NewSecretCipher(MFAEncryptionKey)
+}
+
+func NewTOTPCode() string {
+	code, _ := authentication.TOTPCode(MFASecret, time.Now())
+	return code
+}
+
+func NewMFACredential(enabled bool) authentication.MFACredential {
+	secret, _ := NewSecretCipher().Encrypt(MFASecret)
+	credential := authentication.MFACredential{
+		ID:                 uuid.New().String(),
+		Email:              Email,
+		Secret:             secret,
+		Enabled:            enabled,
+		RecoveryCodeHashes: []string{},
+	}
+	if enabled {
+		credential.RecoveryCodeHashes = []string{authentication.HashRecoveryCode(RecoveryCode)}
+	}
+	return credential
+}
+
+func NewMFAChallenge() authentication.MFAChallenge {
+	accessToken, _ := NewSecretCipher().Encrypt(Token)
+	refreshToken, _ := NewSecretCipher().Encrypt(Token)
+	return authentication.MFAChallenge{
+		ID:           uuid.New().String(),
+		Email:        Email,
+		TokenHash:    authentication.HashMFAChallengeToken(MFAChallengeToken),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(5 * time.Minute),
+	}
+}
+
+func NewInputEnrollMFAParam() input.EnrollMFAParam {
+	return input.EnrollMFAParam{
+		Email: Email,
+	}
+}
+
+func NewInputActivateMFAParam() input.ActivateMFAParam {
+	return input.ActivateMFAParam{
+		Email: Email,
+		Code:  NewTOTPCode(),
+	}
+}
+
+func NewInputVerifyMFAParam() input.VerifyMFAParam {
+	return input.VerifyMFAParam{
+		ChallengeToken: MFAChallengeToken,
+		Code:           NewTOTPCode(),
+		IPAddress:      IpAddress,
+	}
+}
+
+func NewInputDisableMFAParam() input.DisableMFAParam {
+	return input.DisableMFAParam{
+		Email: Email,
+		Code:  RecoveryCode,
+	}
+}
+
+func NewInputCreateUserParam() input.CreateUserParam {
+	return input.CreateUserParam{
+		OperatorAccountID: Email,
+		AccountPassword:   authentication.Password(AccountPasswordNew),
+		OperatorID:        OperatorID,
+	}
+}
+
+func NewIdPUsers() authentication.IdPUsers {
+	return authentication.IdPUsers{
+		{UID: UID, Email: Email, OperatorID: OperatorID, Role: authentication.RoleAdmin},
+		{UID: "uid2", Email: OperatorAccountID, OperatorID: OperatorID, Role: authentication.RoleViewer, Disabled: true},
+	}
+}
+
+func NewOAuthTokenSigner() authentication.OAuthTokenSigner {
+	return authentication.NewOAuthTokenSigner(OAuthSigningKey, "authenticator-backend", 15*time.Minute)
+}
+
+func NewAPIKey(attribute authentication.ApplicationAttribute) authentication.APIKey {
+	return authentication.APIKey{
+		ID:              ApiKeyID,
+		KeyPrefix:       authentication.APIKeyPrefix(ApiKey),
+		KeyDigest:       authentication.DigestAPIKey(ApiKey),
+		ApplicationName: "application",
+		Attribute:       attribute,
+	}
+}
+
+func NewOAuthClient() authentication.OAuthClient {
+	hash, _ := authentication.HashOAuthClientSecret(OAuthClientSecret)
+	return authentication.OAuthClient{
+		ClientID:         OAuthClientID,
+		ClientSecretHash: hash,
+		APIKeyID:         ApiKeyID,
+		Scopes:           []string{authentication.OAuthScopeSystemAuth},
+	}
+}
+
+func NewInputOAuthTokenParam() input.OAuthTokenParam {
+	return input.OAuthTokenParam{
+		GrantType:    authentication.OAuthGrantTypeClientCredentials,
+		ClientID:     OAuthClientID,
+		ClientSecret: OAuthClientSecret,
+	}
+}
+
+func NewAuthEvents(n int) authentication.AuthEvents {
+	events := make(authentication.AuthEvents, n)
+	now := time.Now().UTC()
+	for i := range events {
+		events[i] = authentication.AuthEvent{
+			ID:         uuid.New().String(),
+			Event:      "operatorLogin",
+			Result:     true,
+			IPAddress:  IpAddress,
+			OperatorID: &OperatorID,
+			OccurredAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return events
+}
+
 func NewInputVerifyTokenParam() input.VerifyTokenParam {
 	return input.VerifyTokenParam{
 		IDToken: Token,
@@ -151,15 +373,19 @@ func NewPutOperatorInterface() interface{} {
 
 func NewChangePasswordParam() input.ChangePasswordParam {
 	return input.ChangePasswordParam{
-		UID:         UID,
-		NewPassword: authentication.Password(AccountPasswordNew),
+		UID:             UID,
+		Email:           Email,
+		CurrentPassword: AccountPassword,
+		NewPassword:     authentication.Password(AccountPasswordNew),
+		IPAddress:       IpAddress,
 	}
 }
 
 func NewChangePasswordInterface() interface{} {
 	return map[string]interface{}{
-		"uid":         UID,
-		"newPassword": AccountPasswordNew,
+		"uid":             UID,
+		"currentPassword": AccountPassword,
+		"newPassword":     AccountPasswordNew,
 	}
 }
 
@@ -167,6 +393,7 @@ func NewLoginParam() input.LoginParam {
 	return input.LoginParam{
 		OperatorAccountID: Email,
 		AccountPassword:   AccountPassword,
+		IPAddress:         IpAddress,
 	}
 }
 
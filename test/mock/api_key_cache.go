@@ -0,0 +1,67 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	authentication "authenticator-backend/domain/model/authentication"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyCache is an autogenerated mock type for the APIKeyCache type
+type APIKeyCache struct {
+	mock.Mock
+}
+
+// Index provides a mock function with given fields:
+func (_m *APIKeyCache) Index() (authentication.APIKeyIndex, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Index")
+	}
+
+	var r0 authentication.APIKeyIndex
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (authentication.APIKeyIndex, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() authentication.APIKeyIndex); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(authentication.APIKeyIndex)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Invalidate provides a mock function with given fields:
+func (_m *APIKeyCache) Invalidate() {
+	_m.Called()
+}
+
+// Watch provides a mock function with given fields: ctx
+func (_m *APIKeyCache) Watch(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewAPIKeyCache creates a new instance of APIKeyCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyCache {
+	mock := &APIKeyCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
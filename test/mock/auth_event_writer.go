@@ -0,0 +1,39 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "authenticator-backend/domain/repository"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthEventWriter is an autogenerated mock type for the AuthEventWriter type
+type AuthEventWriter struct {
+	mock.Mock
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *AuthEventWriter) Run(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// Write provides a mock function with given fields: param
+func (_m *AuthEventWriter) Write(param repository.CreateAuthEventParam) {
+	_m.Called(param)
+}
+
+// NewAuthEventWriter creates a new instance of AuthEventWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthEventWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthEventWriter {
+	mock := &AuthEventWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
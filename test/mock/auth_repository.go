@@ -15,6 +15,498 @@ type AuthRepository struct {
 	mock.Mock
 }
 
+// CountPasswordResetRequests provides a mock function with given fields: param
+func (_m *AuthRepository) CountPasswordResetRequests(param repository.PasswordResetRequestsParam) (int64, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPasswordResetRequests")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.PasswordResetRequestsParam) (int64, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.PasswordResetRequestsParam) int64); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.PasswordResetRequestsParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: apiKey
+func (_m *AuthRepository) CreateAPIKey(apiKey authentication.APIKey) error {
+	ret := _m.Called(apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(authentication.APIKey) error); ok {
+		r0 = rf(apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAPIKeyOperator provides a mock function with given fields: param
+func (_m *AuthRepository) CreateAPIKeyOperator(param repository.APIKeyOperatorParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKeyOperator")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyOperatorParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAPIKeyPermission provides a mock function with given fields: param
+func (_m *AuthRepository) CreateAPIKeyPermission(param repository.APIKeyPermissionParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKeyPermission")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyPermissionParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAuthEvent provides a mock function with given fields: param
+func (_m *AuthRepository) CreateAuthEvent(param repository.CreateAuthEventParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuthEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.CreateAuthEventParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCidr provides a mock function with given fields: param
+func (_m *AuthRepository) CreateCidr(param repository.APIKeyCidrParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCidr")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyCidrParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateClientCertificate provides a mock function with given fields: param
+func (_m *AuthRepository) CreateClientCertificate(param repository.APIKeyClientCertificateParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClientCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyClientCertificateParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLoginAttempt provides a mock function with given fields: param
+func (_m *AuthRepository) CreateLoginAttempt(param repository.CreateLoginAttemptParam) (string, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoginAttempt")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.CreateLoginAttemptParam) (string, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.CreateLoginAttemptParam) string); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.CreateLoginAttemptParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateMFAChallenge provides a mock function with given fields: challenge
+func (_m *AuthRepository) CreateMFAChallenge(challenge authentication.MFAChallenge) error {
+	ret := _m.Called(challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMFAChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(authentication.MFAChallenge) error); ok {
+		r0 = rf(challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOAuthClient provides a mock function with given fields: client
+func (_m *AuthRepository) CreateOAuthClient(client authentication.OAuthClient) error {
+	ret := _m.Called(client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOAuthClient")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(authentication.OAuthClient) error); ok {
+		r0 = rf(client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePasswordHistory provides a mock function with given fields: param
+func (_m *AuthRepository) CreatePasswordHistory(param repository.CreatePasswordHistoryParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.CreatePasswordHistoryParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePasswordResetRequest provides a mock function with given fields: email
+func (_m *AuthRepository) CreatePasswordResetRequest(email string) error {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordResetRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAPIKey provides a mock function with given fields: param
+func (_m *AuthRepository) DeleteAPIKey(param repository.DeleteAPIKeyParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.DeleteAPIKeyParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAPIKeyOperator provides a mock function with given fields: param
+func (_m *AuthRepository) DeleteAPIKeyOperator(param repository.APIKeyOperatorParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKeyOperator")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyOperatorParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAPIKeyPermission provides a mock function with given fields: param
+func (_m *AuthRepository) DeleteAPIKeyPermission(param repository.APIKeyPermissionParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKeyPermission")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyPermissionParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCidr provides a mock function with given fields: param
+func (_m *AuthRepository) DeleteCidr(param repository.APIKeyCidrParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCidr")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyCidrParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteClientCertificate provides a mock function with given fields: param
+func (_m *AuthRepository) DeleteClientCertificate(param repository.APIKeyClientCertificateParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteClientCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyClientCertificateParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFAChallenge provides a mock function with given fields: id
+func (_m *AuthRepository) DeleteMFAChallenge(id string) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMFAChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMFACredential provides a mock function with given fields: email
+func (_m *AuthRepository) DeleteMFACredential(email string) error {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMFACredential")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAPIKey provides a mock function with given fields: id
+func (_m *AuthRepository) GetAPIKey(id string) (authentication.APIKey, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKey")
+	}
+
+	var r0 authentication.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (authentication.APIKey, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) authentication.APIKey); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(authentication.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFAChallenge provides a mock function with given fields: tokenHash
+func (_m *AuthRepository) GetMFAChallenge(tokenHash string) (authentication.MFAChallenge, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFAChallenge")
+	}
+
+	var r0 authentication.MFAChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (authentication.MFAChallenge, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) authentication.MFAChallenge); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(authentication.MFAChallenge)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMFACredential provides a mock function with given fields: email
+func (_m *AuthRepository) GetMFACredential(email string) (authentication.MFACredential, error) {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMFACredential")
+	}
+
+	var r0 authentication.MFACredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (authentication.MFACredential, error)); ok {
+		return rf(email)
+	}
+	if rf, ok := ret.Get(0).(func(string) authentication.MFACredential); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Get(0).(authentication.MFACredential)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOAuthClient provides a mock function with given fields: clientID
+func (_m *AuthRepository) GetOAuthClient(clientID string) (authentication.OAuthClient, error) {
+	ret := _m.Called(clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOAuthClient")
+	}
+
+	var r0 authentication.OAuthClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (authentication.OAuthClient, error)); ok {
+		return rf(clientID)
+	}
+	if rf, ok := ret.Get(0).(func(string) authentication.OAuthClient); ok {
+		r0 = rf(clientID)
+	} else {
+		r0 = ret.Get(0).(authentication.OAuthClient)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementMFAChallengeAttempts provides a mock function with given fields: id
+func (_m *AuthRepository) IncrementMFAChallengeAttempts(id string) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementMFAChallengeAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListAPIKeyOperators provides a mock function with given fields: param
 func (_m *AuthRepository) ListAPIKeyOperators(param repository.APIKeyOperatorsParam) (authentication.APIKeyOperators, error) {
 	ret := _m.Called(param)
@@ -45,6 +537,36 @@ func (_m *AuthRepository) ListAPIKeyOperators(param repository.APIKeyOperatorsPa
 	return r0, r1
 }
 
+// ListAPIKeyPermissions provides a mock function with given fields: param
+func (_m *AuthRepository) ListAPIKeyPermissions(param repository.APIKeyPermissionsParam) (authentication.APIKeyPermissions, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeyPermissions")
+	}
+
+	var r0 authentication.APIKeyPermissions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyPermissionsParam) (authentication.APIKeyPermissions, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.APIKeyPermissionsParam) authentication.APIKeyPermissions); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.APIKeyPermissions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.APIKeyPermissionsParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListAPIKeys provides a mock function with given fields: param
 func (_m *AuthRepository) ListAPIKeys(param repository.APIKeysParam) (authentication.APIKeys, error) {
 	ret := _m.Called(param)
@@ -75,6 +597,36 @@ func (_m *AuthRepository) ListAPIKeys(param repository.APIKeysParam) (authentica
 	return r0, r1
 }
 
+// ListAuthEvents provides a mock function with given fields: param
+func (_m *AuthRepository) ListAuthEvents(param repository.AuthEventsParam) (authentication.AuthEvents, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuthEvents")
+	}
+
+	var r0 authentication.AuthEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.AuthEventsParam) (authentication.AuthEvents, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.AuthEventsParam) authentication.AuthEvents); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.AuthEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.AuthEventsParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListCidrs provides a mock function with given fields: param
 func (_m *AuthRepository) ListCidrs(param repository.APIKeyCidrsParam) (authentication.Cidrs, error) {
 	ret := _m.Called(param)
@@ -105,6 +657,186 @@ func (_m *AuthRepository) ListCidrs(param repository.APIKeyCidrsParam) (authenti
 	return r0, r1
 }
 
+// ListClientCertificates provides a mock function with given fields: param
+func (_m *AuthRepository) ListClientCertificates(param repository.APIKeyClientCertificatesParam) (authentication.ClientCertificates, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListClientCertificates")
+	}
+
+	var r0 authentication.ClientCertificates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.APIKeyClientCertificatesParam) (authentication.ClientCertificates, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.APIKeyClientCertificatesParam) authentication.ClientCertificates); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.ClientCertificates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.APIKeyClientCertificatesParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLoginFailures provides a mock function with given fields: param
+func (_m *AuthRepository) ListLoginFailures(param repository.LoginFailuresParam) (authentication.LoginAttempts, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLoginFailures")
+	}
+
+	var r0 authentication.LoginAttempts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.LoginFailuresParam) (authentication.LoginAttempts, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.LoginFailuresParam) authentication.LoginAttempts); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.LoginAttempts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.LoginFailuresParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPasswordHistories provides a mock function with given fields: param
+func (_m *AuthRepository) ListPasswordHistories(param repository.PasswordHistoriesParam) (authentication.PasswordHistories, error) {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPasswordHistories")
+	}
+
+	var r0 authentication.PasswordHistories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(repository.PasswordHistoriesParam) (authentication.PasswordHistories, error)); ok {
+		return rf(param)
+	}
+	if rf, ok := ret.Get(0).(func(repository.PasswordHistoriesParam) authentication.PasswordHistories); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.PasswordHistories)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(repository.PasswordHistoriesParam) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateAPIKey provides a mock function with given fields: param
+func (_m *AuthRepository) RotateAPIKey(param repository.RotateAPIKeyParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.RotateAPIKeyParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveMFACredential provides a mock function with given fields: credential
+func (_m *AuthRepository) SaveMFACredential(credential authentication.MFACredential) error {
+	ret := _m.Called(credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMFACredential")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(authentication.MFACredential) error); ok {
+		r0 = rf(credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAPIKey provides a mock function with given fields: param
+func (_m *AuthRepository) UpdateAPIKey(param repository.UpdateAPIKeyParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.UpdateAPIKeyParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAPIKeySigningSecret provides a mock function with given fields: param
+func (_m *AuthRepository) UpdateAPIKeySigningSecret(param repository.UpdateAPIKeySigningSecretParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAPIKeySigningSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.UpdateAPIKeySigningSecretParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLoginAttemptResult provides a mock function with given fields: param
+func (_m *AuthRepository) UpdateLoginAttemptResult(param repository.UpdateLoginAttemptResultParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoginAttemptResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.UpdateLoginAttemptResultParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAuthRepository creates a new instance of AuthRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAuthRepository(t interface {
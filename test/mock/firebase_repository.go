@@ -4,6 +4,7 @@ package mocks
 
 import (
 	authentication "authenticator-backend/domain/model/authentication"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,17 +14,17 @@ type FirebaseRepository struct {
 	mock.Mock
 }
 
-// ChangePassword provides a mock function with given fields: uid, newPassword
-func (_m *FirebaseRepository) ChangePassword(uid string, newPassword authentication.Password) error {
-	ret := _m.Called(uid, newPassword)
+// ChangePassword provides a mock function with given fields: ctx, uid, newPassword
+func (_m *FirebaseRepository) ChangePassword(ctx context.Context, uid string, newPassword authentication.Password) error {
+	ret := _m.Called(ctx, uid, newPassword)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChangePassword")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, authentication.Password) error); ok {
-		r0 = rf(uid, newPassword)
+	if rf, ok := ret.Get(0).(func(context.Context, string, authentication.Password) error); ok {
+		r0 = rf(ctx, uid, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,9 +32,131 @@ func (_m *FirebaseRepository) ChangePassword(uid string, newPassword authenticat
 	return r0
 }
 
-// RefreshToken provides a mock function with given fields: refreshToken
-func (_m *FirebaseRepository) RefreshToken(refreshToken string) (string, error) {
-	ret := _m.Called(refreshToken)
+// ConfirmPasswordReset provides a mock function with given fields: ctx, code, newPassword
+func (_m *FirebaseRepository) ConfirmPasswordReset(ctx context.Context, code string, newPassword authentication.Password) error {
+	ret := _m.Called(ctx, code, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, authentication.Password) error); ok {
+		r0 = rf(ctx, code, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: ctx, email, password, operatorID, role
+func (_m *FirebaseRepository) CreateUser(ctx context.Context, email string, password authentication.Password, operatorID string, role authentication.Role) (string, error) {
+	ret := _m.Called(ctx, email, password, operatorID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, authentication.Password, string, authentication.Role) (string, error)); ok {
+		return rf(ctx, email, password, operatorID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, authentication.Password, string, authentication.Role) string); ok {
+		r0 = rf(ctx, email, password, operatorID, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, authentication.Password, string, authentication.Role) error); ok {
+		r1 = rf(ctx, email, password, operatorID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, uid
+func (_m *FirebaseRepository) DeleteUser(ctx context.Context, uid string) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GeneratePasswordResetCode provides a mock function with given fields: ctx, email
+func (_m *FirebaseRepository) GeneratePasswordResetCode(ctx context.Context, email string) (string, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GeneratePasswordResetCode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, operatorID
+func (_m *FirebaseRepository) ListUsers(ctx context.Context, operatorID string) (authentication.IdPUsers, error) {
+	ret := _m.Called(ctx, operatorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 authentication.IdPUsers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (authentication.IdPUsers, error)); ok {
+		return rf(ctx, operatorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) authentication.IdPUsers); ok {
+		r0 = rf(ctx, operatorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(authentication.IdPUsers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, operatorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *FirebaseRepository) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
+	ret := _m.Called(ctx, refreshToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RefreshToken")
@@ -41,17 +164,17 @@ func (_m *FirebaseRepository) RefreshToken(refreshToken string) (string, error)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, refreshToken)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, refreshToken)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(refreshToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,9 +182,63 @@ func (_m *FirebaseRepository) RefreshToken(refreshToken string) (string, error)
 	return r0, r1
 }
 
-// SignInWithPassword provides a mock function with given fields: email, password
-func (_m *FirebaseRepository) SignInWithPassword(email string, password string) (authentication.LoginResult, error) {
-	ret := _m.Called(email, password)
+// RevokeRefreshTokens provides a mock function with given fields: ctx, uid
+func (_m *FirebaseRepository) RevokeRefreshTokens(ctx context.Context, uid string) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, uid, disabled
+func (_m *FirebaseRepository) SetUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	ret := _m.Called(ctx, uid, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, uid, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: ctx, uid, role
+func (_m *FirebaseRepository) SetUserRole(ctx context.Context, uid string, role authentication.Role) error {
+	ret := _m.Called(ctx, uid, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, authentication.Role) error); ok {
+		r0 = rf(ctx, uid, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SignInWithPassword provides a mock function with given fields: ctx, email, password
+func (_m *FirebaseRepository) SignInWithPassword(ctx context.Context, email string, password string) (authentication.LoginResult, error) {
+	ret := _m.Called(ctx, email, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SignInWithPassword")
@@ -69,17 +246,17 @@ func (_m *FirebaseRepository) SignInWithPassword(email string, password string)
 
 	var r0 authentication.LoginResult
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (authentication.LoginResult, error)); ok {
-		return rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (authentication.LoginResult, error)); ok {
+		return rf(ctx, email, password)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) authentication.LoginResult); ok {
-		r0 = rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) authentication.LoginResult); ok {
+		r0 = rf(ctx, email, password)
 	} else {
 		r0 = ret.Get(0).(authentication.LoginResult)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, email, password)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -87,9 +264,9 @@ func (_m *FirebaseRepository) SignInWithPassword(email string, password string)
 	return r0, r1
 }
 
-// VerifyIDToken provides a mock function with given fields: idToken
-func (_m *FirebaseRepository) VerifyIDToken(idToken string) (authentication.Claims, error) {
-	ret := _m.Called(idToken)
+// VerifyIDToken provides a mock function with given fields: ctx, idToken
+func (_m *FirebaseRepository) VerifyIDToken(ctx context.Context, idToken string) (authentication.Claims, error) {
+	ret := _m.Called(ctx, idToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for VerifyIDToken")
@@ -97,17 +274,73 @@ func (_m *FirebaseRepository) VerifyIDToken(idToken string) (authentication.Clai
 
 	var r0 authentication.Claims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (authentication.Claims, error)); ok {
-		return rf(idToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (authentication.Claims, error)); ok {
+		return rf(ctx, idToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) authentication.Claims); ok {
+		r0 = rf(ctx, idToken)
+	} else {
+		r0 = ret.Get(0).(authentication.Claims)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyIDTokenAndCheckRevoked provides a mock function with given fields: ctx, idToken
+func (_m *FirebaseRepository) VerifyIDTokenAndCheckRevoked(ctx context.Context, idToken string) (authentication.Claims, error) {
+	ret := _m.Called(ctx, idToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyIDTokenAndCheckRevoked")
+	}
+
+	var r0 authentication.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (authentication.Claims, error)); ok {
+		return rf(ctx, idToken)
 	}
-	if rf, ok := ret.Get(0).(func(string) authentication.Claims); ok {
-		r0 = rf(idToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) authentication.Claims); ok {
+		r0 = rf(ctx, idToken)
 	} else {
 		r0 = ret.Get(0).(authentication.Claims)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(idToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyPasswordResetCode provides a mock function with given fields: ctx, code
+func (_m *FirebaseRepository) VerifyPasswordResetCode(ctx context.Context, code string) (string, error) {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPasswordResetCode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	input "authenticator-backend/usecase/input"
+
+	mock "github.com/stretchr/testify/mock"
+
+	output "authenticator-backend/usecase/output"
+)
+
+// IAuthEventUsecase is an autogenerated mock type for the IAuthEventUsecase type
+type IAuthEventUsecase struct {
+	mock.Mock
+}
+
+// ListAuthEvents provides a mock function with given fields: _a0
+func (_m *IAuthEventUsecase) ListAuthEvents(_a0 input.ListAuthEventsParam) (output.AuthEventsResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuthEvents")
+	}
+
+	var r0 output.AuthEventsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.ListAuthEventsParam) (output.AuthEventsResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.ListAuthEventsParam) output.AuthEventsResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.AuthEventsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.ListAuthEventsParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAuthEventUsecase creates a new instance of IAuthEventUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAuthEventUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAuthEventUsecase {
+	mock := &IAuthEventUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
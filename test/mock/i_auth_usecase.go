@@ -4,6 +4,7 @@ package mocks
 
 import (
 	input "authenticator-backend/usecase/input"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -15,27 +16,37 @@ type IAuthUsecase struct {
 	mock.Mock
 }
 
-// ChangePassword provides a mock function with given fields: _a0
-func (_m *IAuthUsecase) ChangePassword(_a0 input.ChangePasswordParam) error {
-	ret := _m.Called(_a0)
+// ChangePassword provides a mock function with given fields: ctx, _a1
+func (_m *IAuthUsecase) ChangePassword(ctx context.Context, _a1 input.ChangePasswordParam) (output.ChangePasswordResponse, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChangePassword")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(input.ChangePasswordParam) error); ok {
-		r0 = rf(_a0)
+	var r0 output.ChangePasswordResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.ChangePasswordParam) (output.ChangePasswordResponse, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, input.ChangePasswordParam) output.ChangePasswordResponse); ok {
+		r0 = rf(ctx, _a1)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(output.ChangePasswordResponse)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, input.ChangePasswordParam) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Login provides a mock function with given fields: _a0
-func (_m *IAuthUsecase) Login(_a0 input.LoginParam) (output.LoginResponse, error) {
-	ret := _m.Called(_a0)
+// Login provides a mock function with given fields: ctx, _a1
+func (_m *IAuthUsecase) Login(ctx context.Context, _a1 input.LoginParam) (output.LoginResponse, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -43,17 +54,17 @@ func (_m *IAuthUsecase) Login(_a0 input.LoginParam) (output.LoginResponse, error
 
 	var r0 output.LoginResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(input.LoginParam) (output.LoginResponse, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.LoginParam) (output.LoginResponse, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(input.LoginParam) output.LoginResponse); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.LoginParam) output.LoginResponse); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(output.LoginResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(input.LoginParam) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, input.LoginParam) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,9 +72,27 @@ func (_m *IAuthUsecase) Login(_a0 input.LoginParam) (output.LoginResponse, error
 	return r0, r1
 }
 
-// Refresh provides a mock function with given fields: _a0
-func (_m *IAuthUsecase) Refresh(_a0 input.RefreshParam) (output.RefreshResponse, error) {
-	ret := _m.Called(_a0)
+// Logout provides a mock function with given fields: ctx, _a1
+func (_m *IAuthUsecase) Logout(ctx context.Context, _a1 input.LogoutParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.LogoutParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, _a1
+func (_m *IAuthUsecase) Refresh(ctx context.Context, _a1 input.RefreshParam) (output.RefreshResponse, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Refresh")
@@ -71,17 +100,17 @@ func (_m *IAuthUsecase) Refresh(_a0 input.RefreshParam) (output.RefreshResponse,
 
 	var r0 output.RefreshResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(input.RefreshParam) (output.RefreshResponse, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.RefreshParam) (output.RefreshResponse, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(input.RefreshParam) output.RefreshResponse); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.RefreshParam) output.RefreshResponse); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(output.RefreshResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(input.RefreshParam) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, input.RefreshParam) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,6 +118,24 @@ func (_m *IAuthUsecase) Refresh(_a0 input.RefreshParam) (output.RefreshResponse,
 	return r0, r1
 }
 
+// UnlockAccount provides a mock function with given fields: ctx, _a1
+func (_m *IAuthUsecase) UnlockAccount(ctx context.Context, _a1 input.UnlockAccountParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.UnlockAccountParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewIAuthUsecase creates a new instance of IAuthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIAuthUsecase(t interface {
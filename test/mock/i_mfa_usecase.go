@@ -0,0 +1,132 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	input "authenticator-backend/usecase/input"
+
+	mock "github.com/stretchr/testify/mock"
+
+	output "authenticator-backend/usecase/output"
+)
+
+// IMFAUsecase is an autogenerated mock type for the IMFAUsecase type
+type IMFAUsecase struct {
+	mock.Mock
+}
+
+// ActivateMFA provides a mock function with given fields: _a0
+func (_m *IMFAUsecase) ActivateMFA(_a0 input.ActivateMFAParam) (output.ActivateMFAResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateMFA")
+	}
+
+	var r0 output.ActivateMFAResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.ActivateMFAParam) (output.ActivateMFAResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.ActivateMFAParam) output.ActivateMFAResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.ActivateMFAResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.ActivateMFAParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableMFA provides a mock function with given fields: _a0
+func (_m *IMFAUsecase) DisableMFA(_a0 input.DisableMFAParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.DisableMFAParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollMFA provides a mock function with given fields: _a0
+func (_m *IMFAUsecase) EnrollMFA(_a0 input.EnrollMFAParam) (output.EnrollMFAResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 output.EnrollMFAResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.EnrollMFAParam) (output.EnrollMFAResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.EnrollMFAParam) output.EnrollMFAResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.EnrollMFAResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.EnrollMFAParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: _a0
+func (_m *IMFAUsecase) VerifyMFA(_a0 input.VerifyMFAParam) (output.LoginResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 output.LoginResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.VerifyMFAParam) (output.LoginResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.VerifyMFAParam) output.LoginResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.LoginResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.VerifyMFAParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIMFAUsecase creates a new instance of IMFAUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMFAUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMFAUsecase {
+	mock := &IMFAUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	input "authenticator-backend/usecase/input"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPasswordResetUsecase is an autogenerated mock type for the IPasswordResetUsecase type
+type IPasswordResetUsecase struct {
+	mock.Mock
+}
+
+// ConfirmPasswordReset provides a mock function with given fields: ctx, _a1
+func (_m *IPasswordResetUsecase) ConfirmPasswordReset(ctx context.Context, _a1 input.ConfirmPasswordResetParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.ConfirmPasswordResetParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestPasswordReset provides a mock function with given fields: ctx, _a1
+func (_m *IPasswordResetUsecase) RequestPasswordReset(ctx context.Context, _a1 input.PasswordResetParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestPasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.PasswordResetParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIPasswordResetUsecase creates a new instance of IPasswordResetUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPasswordResetUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPasswordResetUsecase {
+	mock := &IPasswordResetUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	input "authenticator-backend/usecase/input"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	output "authenticator-backend/usecase/output"
+)
+
+// IUserUsecase is an autogenerated mock type for the IUserUsecase type
+type IUserUsecase struct {
+	mock.Mock
+}
+
+// CreateUser provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) CreateUser(ctx context.Context, _a1 input.CreateUserParam) (output.UserResponse, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 output.UserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.CreateUserParam) (output.UserResponse, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, input.CreateUserParam) output.UserResponse); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(output.UserResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, input.CreateUserParam) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) DeleteUser(ctx context.Context, _a1 input.UserParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.UserParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableUser provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) DisableUser(ctx context.Context, _a1 input.UserParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.UserParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableUser provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) EnableUser(ctx context.Context, _a1 input.UserParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.UserParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListUsers provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) ListUsers(ctx context.Context, _a1 input.ListUsersParam) (output.UsersResponse, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 output.UsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.ListUsersParam) (output.UsersResponse, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, input.ListUsersParam) output.UsersResponse); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(output.UsersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, input.ListUsersParam) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUserRole provides a mock function with given fields: ctx, _a1
+func (_m *IUserUsecase) SetUserRole(ctx context.Context, _a1 input.SetUserRoleParam) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.SetUserRoleParam) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIUserUsecase creates a new instance of IUserUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIUserUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IUserUsecase {
+	mock := &IUserUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
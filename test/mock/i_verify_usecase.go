@@ -4,6 +4,8 @@ package mocks
 
 import (
 	authentication "authenticator-backend/domain/model/authentication"
+	context "context"
+
 	input "authenticator-backend/usecase/input"
 
 	mock "github.com/stretchr/testify/mock"
@@ -34,9 +36,9 @@ func (_m *IVerifyUsecase) ApiKey(_a0 input.VerifyAPIKeyParam) output.VerifyApiKe
 	return r0
 }
 
-// IDToken provides a mock function with given fields: _a0
-func (_m *IVerifyUsecase) IDToken(_a0 input.VerifyIDTokenParam) (authentication.Claims, error) {
-	ret := _m.Called(_a0)
+// IDToken provides a mock function with given fields: ctx, _a1
+func (_m *IVerifyUsecase) IDToken(ctx context.Context, _a1 input.VerifyIDTokenParam) (authentication.Claims, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IDToken")
@@ -44,17 +46,17 @@ func (_m *IVerifyUsecase) IDToken(_a0 input.VerifyIDTokenParam) (authentication.
 
 	var r0 authentication.Claims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(input.VerifyIDTokenParam) (authentication.Claims, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.VerifyIDTokenParam) (authentication.Claims, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(input.VerifyIDTokenParam) authentication.Claims); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.VerifyIDTokenParam) authentication.Claims); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(authentication.Claims)
 	}
 
-	if rf, ok := ret.Get(1).(func(input.VerifyIDTokenParam) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, input.VerifyIDTokenParam) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,9 +64,37 @@ func (_m *IVerifyUsecase) IDToken(_a0 input.VerifyIDTokenParam) (authentication.
 	return r0, r1
 }
 
-// TokenIntrospection provides a mock function with given fields: _a0
-func (_m *IVerifyUsecase) TokenIntrospection(_a0 input.VerifyTokenParam) (output.VerifyTokenResponse, error) {
-	ret := _m.Called(_a0)
+// IntrospectToken provides a mock function with given fields: ctx, _a1
+func (_m *IVerifyUsecase) IntrospectToken(ctx context.Context, _a1 input.IntrospectTokenParam) (output.IntrospectTokenResponse, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IntrospectToken")
+	}
+
+	var r0 output.IntrospectTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, input.IntrospectTokenParam) (output.IntrospectTokenResponse, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, input.IntrospectTokenParam) output.IntrospectTokenResponse); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Get(0).(output.IntrospectTokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, input.IntrospectTokenParam) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenIntrospection provides a mock function with given fields: ctx, _a1
+func (_m *IVerifyUsecase) TokenIntrospection(ctx context.Context, _a1 input.VerifyTokenParam) (output.VerifyTokenResponse, error) {
+	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TokenIntrospection")
@@ -72,17 +102,17 @@ func (_m *IVerifyUsecase) TokenIntrospection(_a0 input.VerifyTokenParam) (output
 
 	var r0 output.VerifyTokenResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(input.VerifyTokenParam) (output.VerifyTokenResponse, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.VerifyTokenParam) (output.VerifyTokenResponse, error)); ok {
+		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(input.VerifyTokenParam) output.VerifyTokenResponse); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, input.VerifyTokenParam) output.VerifyTokenResponse); ok {
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(output.VerifyTokenResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func(input.VerifyTokenParam) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, input.VerifyTokenParam) error); ok {
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
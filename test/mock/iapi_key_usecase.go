@@ -0,0 +1,342 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	input "authenticator-backend/usecase/input"
+
+	mock "github.com/stretchr/testify/mock"
+
+	output "authenticator-backend/usecase/output"
+)
+
+// IAPIKeyUsecase is an autogenerated mock type for the IAPIKeyUsecase type
+type IAPIKeyUsecase struct {
+	mock.Mock
+}
+
+// AddCidr provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) AddCidr(_a0 input.APIKeyCidrParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCidr")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyCidrParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddClientCertificate provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) AddClientCertificate(_a0 input.APIKeyClientCertificateParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddClientCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyClientCertificateParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BindOperator provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) BindOperator(_a0 input.APIKeyOperatorParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BindOperator")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyOperatorParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAPIKey provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) CreateAPIKey(_a0 input.CreateAPIKeyParam) (output.CreateAPIKeyResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 output.CreateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.CreateAPIKeyParam) (output.CreateAPIKeyResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.CreateAPIKeyParam) output.CreateAPIKeyResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.CreateAPIKeyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.CreateAPIKeyParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableRequestSigning provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) DisableRequestSigning(_a0 input.APIKeyParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableRequestSigning")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableRequestSigning provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) EnableRequestSigning(_a0 input.APIKeyParam) (output.SigningSecretResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableRequestSigning")
+	}
+
+	var r0 output.SigningSecretResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) (output.SigningSecretResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) output.SigningSecretResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.SigningSecretResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.APIKeyParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantPermission provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) GrantPermission(_a0 input.APIKeyPermissionParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantPermission")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyPermissionParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAPIKeys provides a mock function with given fields:
+func (_m *IAPIKeyUsecase) ListAPIKeys() (output.APIKeysResponse, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAPIKeys")
+	}
+
+	var r0 output.APIKeysResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (output.APIKeysResponse, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() output.APIKeysResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(output.APIKeysResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveCidr provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) RemoveCidr(_a0 input.APIKeyCidrParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveCidr")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyCidrParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveClientCertificate provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) RemoveClientCertificate(_a0 input.APIKeyClientCertificateParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveClientCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyClientCertificateParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAPIKey provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) RevokeAPIKey(_a0 input.APIKeyParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokePermission provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) RevokePermission(_a0 input.APIKeyPermissionParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokePermission")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyPermissionParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateAPIKey provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) RotateAPIKey(_a0 input.APIKeyParam) (output.RotateAPIKeyResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateAPIKey")
+	}
+
+	var r0 output.RotateAPIKeyResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) (output.RotateAPIKeyResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.APIKeyParam) output.RotateAPIKeyResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.RotateAPIKeyResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.APIKeyParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnbindOperator provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) UnbindOperator(_a0 input.APIKeyOperatorParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnbindOperator")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.APIKeyOperatorParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAPIKey provides a mock function with given fields: _a0
+func (_m *IAPIKeyUsecase) UpdateAPIKey(_a0 input.UpdateAPIKeyParam) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(input.UpdateAPIKeyParam) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAPIKeyUsecase creates a new instance of IAPIKeyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAPIKeyUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAPIKeyUsecase {
+	mock := &IAPIKeyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	authentication "authenticator-backend/domain/model/authentication"
+	input "authenticator-backend/usecase/input"
+
+	mock "github.com/stretchr/testify/mock"
+
+	output "authenticator-backend/usecase/output"
+)
+
+// IOAuthUsecase is an autogenerated mock type for the IOAuthUsecase type
+type IOAuthUsecase struct {
+	mock.Mock
+}
+
+// Token provides a mock function with given fields: _a0
+func (_m *IOAuthUsecase) Token(_a0 input.OAuthTokenParam) (output.OAuthTokenResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Token")
+	}
+
+	var r0 output.OAuthTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.OAuthTokenParam) (output.OAuthTokenResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.OAuthTokenParam) output.OAuthTokenResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(output.OAuthTokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.OAuthTokenParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyAccessToken provides a mock function with given fields: _a0
+func (_m *IOAuthUsecase) VerifyAccessToken(_a0 input.VerifyAccessTokenParam) (authentication.OAuthAccessToken, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAccessToken")
+	}
+
+	var r0 authentication.OAuthAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(input.VerifyAccessTokenParam) (authentication.OAuthAccessToken, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(input.VerifyAccessTokenParam) authentication.OAuthAccessToken); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(authentication.OAuthAccessToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(input.VerifyAccessTokenParam) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIOAuthUsecase creates a new instance of IOAuthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOAuthUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOAuthUsecase {
+	mock := &IOAuthUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	repository "authenticator-backend/domain/repository"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Mailer is an autogenerated mock type for the Mailer type
+type Mailer struct {
+	mock.Mock
+}
+
+// Send provides a mock function with given fields: param
+func (_m *Mailer) Send(param repository.MailParam) error {
+	ret := _m.Called(param)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(repository.MailParam) error); ok {
+		r0 = rf(param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMailer creates a new instance of Mailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMailer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Mailer {
+	mock := &Mailer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
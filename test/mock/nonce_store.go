@@ -0,0 +1,56 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// NonceStore is an autogenerated mock type for the NonceStore type
+type NonceStore struct {
+	mock.Mock
+}
+
+// Use provides a mock function with given fields: key, expiresAt, now
+func (_m *NonceStore) Use(key string, expiresAt time.Time, now time.Time) (bool, error) {
+	ret := _m.Called(key, expiresAt, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Use")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (bool, error)); ok {
+		return rf(key, expiresAt, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) bool); ok {
+		r0 = rf(key, expiresAt, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(key, expiresAt, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewNonceStore creates a new instance of NonceStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNonceStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NonceStore {
+	mock := &NonceStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.42.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	authentication "authenticator-backend/domain/model/authentication"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RateLimiter is an autogenerated mock type for the RateLimiter type
+type RateLimiter struct {
+	mock.Mock
+}
+
+// Take provides a mock function with given fields: key, limit, now
+func (_m *RateLimiter) Take(key string, limit authentication.RateLimit, now time.Time) (authentication.RateLimitStatus, error) {
+	ret := _m.Called(key, limit, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 authentication.RateLimitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, authentication.RateLimit, time.Time) (authentication.RateLimitStatus, error)); ok {
+		return rf(key, limit, now)
+	}
+	if rf, ok := ret.Get(0).(func(string, authentication.RateLimit, time.Time) authentication.RateLimitStatus); ok {
+		r0 = rf(key, limit, now)
+	} else {
+		r0 = ret.Get(0).(authentication.RateLimitStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, authentication.RateLimit, time.Time) error); ok {
+		r1 = rf(key, limit, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRateLimiter creates a new instance of RateLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimiter {
+	mock := &RateLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
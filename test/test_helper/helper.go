@@ -56,8 +56,9 @@ func initTable(db *gorm.DB) error {
 	}
 
 	// read DDL
+	// the database is created for each test, so only the up DDL is applied.
+	// the down DDL of the migrations which alter the tables can not be applied to the empty database
 	var upQueries []string
-	var downQueries []string
 	for _, file := range files {
 		b, err := os.ReadFile(fmt.Sprintf("%s/%s", setupDir, file.Name()))
 		if err != nil {
@@ -68,21 +69,11 @@ func initTable(db *gorm.DB) error {
 		qs := strings.Split(l, ";")
 		if file.Name()[len(file.Name())-6:] == "up.sql" {
 			upQueries = append(upQueries, qs[:len(qs)-1]...)
-		} else if file.Name()[len(file.Name())-8:] == "down.sql" {
-			downQueries = append(downQueries, qs[:len(qs)-1]...)
-		} else {
+		} else if file.Name()[len(file.Name())-8:] != "down.sql" {
 			return fmt.Errorf("unknown file(%s) is founded", file.Name())
 		}
 	}
 
-	// execute down DDL by reverse order
-	for idx := 0; idx < len(downQueries); idx++ {
-		err := db.Exec(fmt.Sprintf("%s;", downQueries[len(downQueries)-idx-1])).Error
-		if err != nil {
-			return err
-		}
-	}
-
 	// execute up DDL by normal order
 	for idx := 0; idx < len(upQueries); idx++ {
 		err := db.Exec(fmt.Sprintf("%s;", upQueries[idx])).Error
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// IAPIKeyUsecase
+// Summary: This is interface which defines IAPIKeyUsecase
+//
+//go:generate mockery --name IAPIKeyUsecase --output ../test/mock --case underscore
+type IAPIKeyUsecase interface {
+	CreateAPIKey(input input.CreateAPIKeyParam) (output.CreateAPIKeyResponse, error)
+	ListAPIKeys() (output.APIKeysResponse, error)
+	UpdateAPIKey(input input.UpdateAPIKeyParam) error
+	RevokeAPIKey(input input.APIKeyParam) error
+	RotateAPIKey(input input.APIKeyParam) (output.RotateAPIKeyResponse, error)
+	BindOperator(input input.APIKeyOperatorParam) error
+	UnbindOperator(input input.APIKeyOperatorParam) error
+	AddCidr(input input.APIKeyCidrParam) error
+	RemoveCidr(input input.APIKeyCidrParam) error
+	AddClientCertificate(input input.APIKeyClientCertificateParam) error
+	RemoveClientCertificate(input input.APIKeyClientCertificateParam) error
+	GrantPermission(input input.APIKeyPermissionParam) error
+	RevokePermission(input input.APIKeyPermissionParam) error
+	EnableRequestSigning(input input.APIKeyParam) (output.SigningSecretResponse, error)
+	DisableRequestSigning(input input.APIKeyParam) error
+}
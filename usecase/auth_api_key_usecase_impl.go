@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"gorm.io/gorm"
+)
+
+// apiKeyUsecase
+// Summary: This is the structure which defines the usecase for the lifecycle management of the API keys.
+type apiKeyUsecase struct {
+	authRepository    repository.AuthRepository
+	ouranosRepository repository.OuranosRepository
+	apiKeyCache       repository.APIKeyCache
+	policy            authentication.APIKeyPolicy
+	cipher            authentication.SecretCipher
+}
+
+// NewAPIKeyUsecase
+// Summary: This is the function which creates the API key usecase.
+// input: a(repository.AuthRepository) auth repository
+// input: o(repository.OuranosRepository) ouranos repository
+// input: c(repository.APIKeyCache) in-memory index of the API keys and the CIDRs, which is invalidated on the changes
+// input: policy(authentication.APIKeyPolicy) policy of the expiry and the rotation of the API keys
+// input: cipher(authentication.SecretCipher) cipher to encrypt the signing secrets of the API keys
+// output: (IAPIKeyUsecase) API key usecase
+func NewAPIKeyUsecase(a repository.AuthRepository, o repository.OuranosRepository, c repository.APIKeyCache, policy authentication.APIKeyPolicy, cipher authentication.SecretCipher) IAPIKeyUsecase {
+	return &apiKeyUsecase{a, o, c, policy, cipher}
+}
+
+// CreateAPIKey
+// Summary: This is the function which creates the API key generated by the server.
+// The API key is valid from now and expires with the TTL of the policy unless the validity period is specified.
+// input: input(input.CreateAPIKeyParam): input parameter
+// output: (output.CreateAPIKeyResponse) created API key
+// output: (error) error object
+func (u apiKeyUsecase) CreateAPIKey(input input.CreateAPIKeyParam) (output.CreateAPIKeyResponse, error) {
+	notBefore := time.Now().UTC()
+	if input.NotBefore != nil {
+		notBefore = input.NotBefore.UTC()
+	}
+	expiresAt := u.policy.ExpiresAt(notBefore)
+	if input.ExpiresAt != nil {
+		e := input.ExpiresAt.UTC()
+		expiresAt = &e
+	}
+
+	apiKey, key, err := authentication.NewAPIKey(input.ApplicationName, input.ApplicationAttribute, input.RequestAPIKeyID, notBefore, expiresAt)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.CreateAPIKeyResponse{}, err
+	}
+	apiKey.RateLimit = input.RateLimit()
+	apiKey.IPRestrictionMode = input.IPRestrictionMode
+	if err := u.authRepository.CreateAPIKey(apiKey); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.CreateAPIKeyResponse{}, err
+	}
+	u.apiKeyCache.Invalidate()
+
+	return output.NewCreateAPIKeyResponse(apiKey, key), nil
+}
+
+// RotateAPIKey
+// Summary: This is the function which issues the successor of the API key and lets the API key expire after the grace period.
+// The successor takes over the operators, the CIDRs, the permissions, the OAuth clients and the client certificates of the API key.
+// input: input(input.APIKeyParam): input parameter
+// output: (output.RotateAPIKeyResponse) successor of the API key
+// output: (error) error object
+func (u apiKeyUsecase) RotateAPIKey(input input.APIKeyParam) (output.RotateAPIKeyResponse, error) {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return output.RotateAPIKeyResponse{}, err
+	}
+
+	now := time.Now().UTC()
+	successor, key, err := authentication.NewAPIKey(apiKey.ApplicationName, apiKey.Attribute, input.RequestAPIKeyID, now, u.policy.ExpiresAt(now))
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.RotateAPIKeyResponse{}, err
+	}
+	successor.RateLimit = apiKey.RateLimit
+	successor.IPRestrictionMode = apiKey.IPRestrictionMode
+	// the signing secret is taken over so that the caller can keep signing the requests with the successor
+	successor.SigningSecret = apiKey.SigningSecret
+	successor.IsAdmin = apiKey.IsAdmin
+
+	param := repository.RotateAPIKeyParam{
+		ID:        apiKey.ID,
+		ExpiresAt: u.policy.RotatedExpiresAt(apiKey, now),
+		Successor: successor,
+		UserID:    input.RequestAPIKeyID,
+	}
+	if err := u.authRepository.RotateAPIKey(param); err != nil {
+		return output.RotateAPIKeyResponse{}, apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return output.RotateAPIKeyResponse{
+		CreateAPIKeyResponse: output.NewCreateAPIKeyResponse(successor, key),
+		PreviousID:           apiKey.ID,
+		PreviousExpiresAt:    param.ExpiresAt,
+	}, nil
+}
+
+// ListAPIKeys
+// Summary: This is the function which lists the API keys which are not revoked with the bound operators, the CIDRs, the client certificates and the permissions.
+// output: (output.APIKeysResponse) API keys
+// output: (error) error object
+func (u apiKeyUsecase) ListAPIKeys() (output.APIKeysResponse, error) {
+	apiKeys, err := u.authRepository.ListAPIKeys(repository.APIKeysParam{})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	operators, err := u.authRepository.ListAPIKeyOperators(repository.APIKeyOperatorsParam{})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	cidrs, err := u.authRepository.ListCidrs(repository.APIKeyCidrsParam{})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	certificates, err := u.authRepository.ListClientCertificates(repository.APIKeyClientCertificatesParam{})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	permissions, err := u.authRepository.ListAPIKeyPermissions(repository.APIKeyPermissionsParam{})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, err
+	}
+	return output.NewAPIKeysResponse(apiKeys, operators, cidrs, certificates, permissions), nil
+}
+
+// UpdateAPIKey
+// Summary: This is the function which changes the application name, the application attribute, the limits of the requests and the mode of the IP address restriction of the API key.
+// input: input(input.UpdateAPIKeyParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) UpdateAPIKey(input input.UpdateAPIKeyParam) error {
+	param := repository.UpdateAPIKeyParam{
+		ID:                input.ID,
+		ApplicationName:   input.ApplicationName,
+		Attribute:         input.ApplicationAttribute,
+		RateLimit:         input.RateLimit(),
+		IPRestrictionMode: input.IPRestrictionMode,
+		UserID:            input.RequestAPIKeyID,
+	}
+	if err := u.authRepository.UpdateAPIKey(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// RevokeAPIKey
+// Summary: This is the function which revokes the API key by the logical deletion.
+// The revoked API key is no longer accepted.
+// input: input(input.APIKeyParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) RevokeAPIKey(input input.APIKeyParam) error {
+	if err := u.authRepository.DeleteAPIKey(repository.DeleteAPIKeyParam{ID: input.ID, UserID: input.RequestAPIKeyID}); err != nil {
+		return apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// BindOperator
+// Summary: This is the function which binds the operator to the API key.
+// input: input(input.APIKeyOperatorParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) BindOperator(input input.APIKeyOperatorParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.ouranosRepository.GetOperator(input.OperatorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(common.Err400OperatorNotFound)
+
+			return common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth)
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+
+	param := repository.APIKeyOperatorParam{APIKeyID: apiKey.ID, OperatorID: input.OperatorID, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.CreateAPIKeyOperator(param); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// UnbindOperator
+// Summary: This is the function which unbinds the operator from the API key.
+// input: input(input.APIKeyOperatorParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) UnbindOperator(input input.APIKeyOperatorParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+
+	param := repository.APIKeyOperatorParam{APIKeyID: apiKey.ID, OperatorID: input.OperatorID, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.DeleteAPIKeyOperator(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404ResourceNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// AddCidr
+// Summary: This is the function which adds the CIDR rule to the API key.
+// The CIDR is stored as the network address, and the action and the priority of the CIDR rule which has been added are changed.
+// input: input(input.APIKeyCidrParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) AddCidr(input input.APIKeyCidrParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+	cidr, err := authentication.NewCidr(input.Cidr)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400Validation, nil, common.HTTPErrorSourceAuth)
+	}
+
+	action, priority := input.Rule()
+	param := repository.APIKeyCidrParam{APIKeyID: apiKey.ID, Cidr: cidr.Cidr, Action: action, Priority: priority, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.CreateCidr(param); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// RemoveCidr
+// Summary: This is the function which removes the CIDR from the API key.
+// input: input(input.APIKeyCidrParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) RemoveCidr(input input.APIKeyCidrParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+	cidr, err := authentication.NewCidr(input.Cidr)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400Validation, nil, common.HTTPErrorSourceAuth)
+	}
+
+	param := repository.APIKeyCidrParam{APIKeyID: apiKey.ID, Cidr: cidr.Cidr, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.DeleteCidr(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404ResourceNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// AddClientCertificate
+// Summary: This is the function which maps the client certificate to the API key.
+// The certificate mapped to another API key is moved to the API key, because a certificate authenticates only one API key.
+// input: input(input.APIKeyClientCertificateParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) AddClientCertificate(input input.APIKeyClientCertificateParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+	certificate, err := authentication.NewClientCertificate(input.Type, input.Certificate)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400Validation, nil, common.HTTPErrorSourceAuth)
+	}
+
+	param := repository.APIKeyClientCertificateParam{APIKeyID: apiKey.ID, Type: certificate.Type, Certificate: certificate.Certificate, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.CreateClientCertificate(param); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// RemoveClientCertificate
+// Summary: This is the function which unmaps the client certificate from the API key.
+// input: input(input.APIKeyClientCertificateParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) RemoveClientCertificate(input input.APIKeyClientCertificateParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+	certificate, err := authentication.NewClientCertificate(input.Type, input.Certificate)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400Validation, nil, common.HTTPErrorSourceAuth)
+	}
+
+	param := repository.APIKeyClientCertificateParam{APIKeyID: apiKey.ID, Type: certificate.Type, Certificate: certificate.Certificate, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.DeleteClientCertificate(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404ResourceNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// GrantPermission
+// Summary: This is the function which grants the route permission to the API key.
+// The API key is denied every route until any permission is granted.
+// input: input(input.APIKeyPermissionParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) GrantPermission(input input.APIKeyPermissionParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+
+	param := repository.APIKeyPermissionParam{APIKeyID: apiKey.ID, Method: input.Method, Path: input.Path, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.CreateAPIKeyPermission(param); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// RevokePermission
+// Summary: This is the function which revokes the route permission from the API key.
+// input: input(input.APIKeyPermissionParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) RevokePermission(input input.APIKeyPermissionParam) error {
+	apiKey, err := u.getAPIKey(input.ID)
+	if err != nil {
+		return err
+	}
+
+	param := repository.APIKeyPermissionParam{APIKeyID: apiKey.ID, Method: input.Method, Path: input.Path, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.DeleteAPIKeyPermission(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404ResourceNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// EnableRequestSigning
+// Summary: This is the function which generates the signing secret of the API key and requires the requests with the API key to be signed.
+// The signing secret is returned only here because it is stored encrypted, and the previous secret is replaced when it is called again.
+// input: input(input.APIKeyParam): input parameter
+// output: (output.SigningSecretResponse) generated signing secret
+// output: (error) error object
+func (u apiKeyUsecase) EnableRequestSigning(input input.APIKeyParam) (output.SigningSecretResponse, error) {
+	secret, err := authentication.GenerateSigningSecret()
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.SigningSecretResponse{}, err
+	}
+	encrypted, err := u.cipher.Encrypt(secret)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.SigningSecretResponse{}, err
+	}
+
+	param := repository.UpdateAPIKeySigningSecretParam{ID: input.ID, SigningSecret: &encrypted, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.UpdateAPIKeySigningSecret(param); err != nil {
+		return output.SigningSecretResponse{}, apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return output.SigningSecretResponse{ID: input.ID, SigningSecret: secret}, nil
+}
+
+// DisableRequestSigning
+// Summary: This is the function which removes the signing secret of the API key so that the requests with the API key need not be signed.
+// input: input(input.APIKeyParam): input parameter
+// output: (error) error object
+func (u apiKeyUsecase) DisableRequestSigning(input input.APIKeyParam) error {
+	param := repository.UpdateAPIKeySigningSecretParam{ID: input.ID, UserID: input.RequestAPIKeyID}
+	if err := u.authRepository.UpdateAPIKeySigningSecret(param); err != nil {
+		return apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	u.apiKeyCache.Invalidate()
+
+	return nil
+}
+
+// getAPIKey
+// Summary: This is the function which gets the API key which is not revoked.
+// input: id(string): ID of the API key
+// output: (authentication.APIKey) API key
+// output: (error) error object
+func (u apiKeyUsecase) getAPIKey(id string) (authentication.APIKey, error) {
+	apiKey, err := u.authRepository.GetAPIKey(id)
+	if err != nil {
+		return authentication.APIKey{}, apiKeyNotFoundError(err, common.Err404APIKeyNotFound)
+	}
+	return apiKey, nil
+}
+
+// apiKeyNotFoundError
+// Summary: This is the function which converts the error of the record not found to the 404 error.
+// input: err(error): error object
+// input: message(string): message of the 404 error
+// output: (error) error object
+func apiKeyNotFoundError(err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Set(nil).Warnf(message)
+
+		return common.NewCustomError(common.CustomErrorCode404, message, nil, common.HTTPErrorSourceAuth)
+	}
+	logger.Set(nil).Errorf(err.Error())
+
+	return err
+}
@@ -0,0 +1,956 @@
+package usecase_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/model/traceability"
+	"authenticator-backend/domain/repository"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const (
+	requestAPIKeyID = "00000000-0000-0000-0000-000000000002"
+	targetAPIKeyID  = "00000000-0000-0000-0000-000000000001"
+)
+
+// newAPIKeyAuthRepositoryMock
+// Summary: This is function which creates the auth repository mock returning the target API key.
+// output: (*mocks.AuthRepository) auth repository mock
+func newAPIKeyAuthRepositoryMock() *mocks.AuthRepository {
+	authRepositoryMock := new(mocks.AuthRepository)
+	authRepositoryMock.On("GetAPIKey", targetAPIKeyID).Return(authentication.APIKey{ID: targetAPIKeyID}, nil)
+	authRepositoryMock.On("GetAPIKey", mock.Anything).Return(authentication.APIKey{}, gorm.ErrRecordNotFound)
+
+	return authRepositoryMock
+}
+
+// newAPIKeyCacheMock
+// Summary: This is function which creates the API key cache mock accepting the invalidation.
+// output: (*mocks.APIKeyCache) API key cache mock
+func newAPIKeyCacheMock() *mocks.APIKeyCache {
+	apiKeyCacheMock := new(mocks.APIKeyCache)
+	apiKeyCacheMock.On("Invalidate").Return()
+
+	return apiKeyCacheMock
+}
+
+// TestProjectUsecase_CreateAPIKey
+// Summary: This is test class which confirm the operation of API CreateAPIKey.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：APIキーを生成してダイジェストを保存し、リクエストのAPIキーを作成者として記録
+// [x] 1-2. 201: 正常系：有効期間を指定した場合
+// [x] 1-3. 201: 正常系：リクエスト数の上限を指定した場合
+// [x] 2-1. 500: APIキー作成エラー
+func TestProjectUsecase_CreateAPIKey(tt *testing.T) {
+
+	policy := authentication.APIKeyPolicy{TTL: 24 * time.Hour}
+	notBefore := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := notBefore.Add(time.Hour)
+	rateLimitPerMinute, dailyQuota := 60, 1000
+
+	tests := []struct {
+		name         string
+		notBefore    *time.Time
+		expiresAt    *time.Time
+		rateLimit    authentication.APIKeyRateLimit
+		receiveErr   error
+		expectErr    error
+		expectCreate bool
+	}{
+		{
+			name:         "1-1. 201: 正常系：APIキーを生成してダイジェストを保存し、リクエストのAPIキーを作成者として記録",
+			expectCreate: true,
+		},
+		{
+			name:         "1-2. 201: 正常系：有効期間を指定した場合",
+			notBefore:    &notBefore,
+			expiresAt:    &expiresAt,
+			expectCreate: true,
+		},
+		{
+			name:         "1-3. 201: 正常系：リクエスト数の上限を指定した場合",
+			rateLimit:    authentication.APIKeyRateLimit{RequestsPerMinute: &rateLimitPerMinute, DailyQuota: &dailyQuota},
+			expectCreate: true,
+		},
+		{
+			name:         "2-1. 500: APIキー作成エラー",
+			receiveErr:   fmt.Errorf("DB Error"),
+			expectErr:    fmt.Errorf("DB Error"),
+			expectCreate: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("CreateAPIKey", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, policy, f.NewSecretCipher())
+
+				param := input.CreateAPIKeyParam{
+					ApplicationName:      "New-Application",
+					ApplicationAttribute: authentication.ApplicationAttributeDataSpace,
+					NotBefore:            test.notBefore,
+					ExpiresAt:            test.expiresAt,
+					RateLimitPerMinute:   test.rateLimit.RequestsPerMinute,
+					RateLimitBurst:       test.rateLimit.Burst,
+					DailyQuota:           test.rateLimit.DailyQuota,
+					RequestAPIKeyID:      requestAPIKeyID,
+				}
+				actual, err := apiKeyUsecase.CreateAPIKey(param)
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					assert.NotEmpty(t, actual.ID)
+					assert.NotEmpty(t, actual.APIKey)
+					assert.Equal(t, "New-Application", actual.ApplicationName)
+					assert.Equal(t, authentication.ApplicationAttributeDataSpace, actual.ApplicationAttribute)
+					if test.notBefore != nil {
+						assert.Equal(t, *test.notBefore, actual.NotBefore)
+						assert.Equal(t, test.expiresAt, actual.ExpiresAt)
+					} else if assert.NotNil(t, actual.ExpiresAt) {
+						assert.Equal(t, actual.NotBefore.Add(policy.TTL), *actual.ExpiresAt)
+					}
+					authRepositoryMock.AssertCalled(t, "CreateAPIKey", mock.MatchedBy(func(apiKey authentication.APIKey) bool {
+						return apiKey.ID == actual.ID && apiKey.KeyDigest != actual.APIKey && apiKey.Matches(actual.APIKey) &&
+							apiKey.CreatedUserID == requestAPIKeyID && apiKey.UpdatedUserID == requestAPIKeyID &&
+							apiKey.NotBefore.Equal(actual.NotBefore) && apiKey.ExpiresAt == actual.ExpiresAt &&
+							apiKey.RateLimit == test.rateLimit
+					}))
+					assert.Equal(t, test.rateLimit.RequestsPerMinute, actual.RateLimitPerMinute)
+					assert.Equal(t, test.rateLimit.DailyQuota, actual.DailyQuota)
+				}
+				if !test.expectCreate {
+					authRepositoryMock.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+				}
+
+				// the in-memory index is invalidated only when the API key or the CIDRs are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_ListAPIKeys
+// Summary: This is test class which confirm the operation of API ListAPIKeys.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系：APIキーごとに事業者とCIDRとクライアント証明書と権限をまとめて返却
+// [x] 2-1. 500: APIキー取得エラー
+func TestProjectUsecase_ListAPIKeys(tt *testing.T) {
+
+	tests := []struct {
+		name       string
+		receiveErr error
+		expect     output.APIKeysResponse
+		expectErr  error
+	}{
+		{
+			name: "1-1. 200: 正常系：APIキーごとに事業者とCIDRとクライアント証明書と権限をまとめて返却",
+			expect: output.APIKeysResponse{
+				{ID: targetAPIKeyID, KeyPrefix: "Sample-A", ApplicationName: "App1", ApplicationAttribute: authentication.ApplicationAttributeApplication, OperatorIDs: []string{f.OperatorID}, Cidrs: []output.CidrRuleResponse{{Cidr: "10.0.0.0/8", Action: authentication.CidrActionAllow, Priority: 100}}, ClientCertificates: []output.ClientCertificateResponse{}, Permissions: []output.PermissionResponse{{Method: "GET", Path: "/api/v1/authInfo"}}},
+				{ID: requestAPIKeyID, KeyPrefix: "Sample-B", ApplicationName: "App2", ApplicationAttribute: authentication.ApplicationAttributeDataSpace, OperatorIDs: []string{}, Cidrs: []output.CidrRuleResponse{}, ClientCertificates: []output.ClientCertificateResponse{{Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client,O=Example"}}, Permissions: []output.PermissionResponse{}},
+			},
+		},
+		{
+			name:       "2-1. 500: APIキー取得エラー",
+			receiveErr: fmt.Errorf("DB Error"),
+			expectErr:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("ListAPIKeys", repository.APIKeysParam{}).Return(authentication.APIKeys{
+					{ID: targetAPIKeyID, KeyPrefix: "Sample-A", ApplicationName: "App1", Attribute: authentication.ApplicationAttributeApplication},
+					{ID: requestAPIKeyID, KeyPrefix: "Sample-B", ApplicationName: "App2", Attribute: authentication.ApplicationAttributeDataSpace},
+				}, test.receiveErr)
+				authRepositoryMock.On("ListAPIKeyOperators", repository.APIKeyOperatorsParam{}).Return(authentication.APIKeyOperators{{APIKeyID: targetAPIKeyID, OperatorID: f.OperatorID}}, nil)
+				authRepositoryMock.On("ListCidrs", repository.APIKeyCidrsParam{}).Return(authentication.Cidrs{{APIKeyID: targetAPIKeyID, Cidr: "10.0.0.0/8", Action: authentication.CidrActionAllow, Priority: 100}}, nil)
+				authRepositoryMock.On("ListClientCertificates", repository.APIKeyClientCertificatesParam{}).Return(authentication.ClientCertificates{{APIKeyID: requestAPIKeyID, Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client,O=Example"}}, nil)
+				authRepositoryMock.On("ListAPIKeyPermissions", repository.APIKeyPermissionsParam{}).Return(authentication.APIKeyPermissions{{APIKeyID: targetAPIKeyID, Method: "GET", Path: "/api/v1/authInfo"}}, nil)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				actual, err := apiKeyUsecase.ListAPIKeys()
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_UpdateAPIKey
+// Summary: This is test class which confirm the operation of API UpdateAPIKey and RevokeAPIKey.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系(変更)
+// [x] 1-2. 200: 正常系(失効)
+// [x] 1-3. 200: 正常系(リクエスト数の上限の変更)
+// [x] 2-1. 404: 変更対象のAPIキーが存在しない場合
+// [x] 2-2. 404: 失効対象のAPIキーが存在しない場合
+// [x] 2-3. 500: 失効エラー
+func TestProjectUsecase_UpdateAPIKey(tt *testing.T) {
+
+	applicationName := "Renamed-Application"
+	dailyQuota := 1000
+
+	tests := []struct {
+		name       string
+		method     string
+		receiveErr error
+		expectErr  error
+	}{
+		{
+			name:   "1-1. 200: 正常系(変更)",
+			method: "UpdateAPIKey",
+		},
+		{
+			name:   "1-2. 200: 正常系(失効)",
+			method: "RevokeAPIKey",
+		},
+		{
+			name:   "1-3. 200: 正常系(リクエスト数の上限の変更)",
+			method: "UpdateRateLimit",
+		},
+		{
+			name:       "2-1. 404: 変更対象のAPIキーが存在しない場合",
+			method:     "UpdateAPIKey",
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 404: 失効対象のAPIキーが存在しない場合",
+			method:     "RevokeAPIKey",
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-3. 500: 失効エラー",
+			method:     "RevokeAPIKey",
+			receiveErr: fmt.Errorf("DB Error"),
+			expectErr:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("UpdateAPIKey", mock.Anything).Return(test.receiveErr)
+				authRepositoryMock.On("DeleteAPIKey", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				var err error
+				switch test.method {
+				case "UpdateAPIKey":
+					err = apiKeyUsecase.UpdateAPIKey(input.UpdateAPIKeyParam{ID: targetAPIKeyID, ApplicationName: &applicationName, RequestAPIKeyID: requestAPIKeyID})
+					authRepositoryMock.AssertCalled(t, "UpdateAPIKey", repository.UpdateAPIKeyParam{ID: targetAPIKeyID, ApplicationName: &applicationName, UserID: requestAPIKeyID})
+				case "UpdateRateLimit":
+					err = apiKeyUsecase.UpdateAPIKey(input.UpdateAPIKeyParam{ID: targetAPIKeyID, DailyQuota: &dailyQuota, RequestAPIKeyID: requestAPIKeyID})
+					authRepositoryMock.AssertCalled(t, "UpdateAPIKey", repository.UpdateAPIKeyParam{ID: targetAPIKeyID, RateLimit: authentication.APIKeyRateLimit{DailyQuota: &dailyQuota}, UserID: requestAPIKeyID})
+				case "RevokeAPIKey":
+					err = apiKeyUsecase.RevokeAPIKey(input.APIKeyParam{ID: targetAPIKeyID, RequestAPIKeyID: requestAPIKeyID})
+					authRepositoryMock.AssertCalled(t, "DeleteAPIKey", repository.DeleteAPIKeyParam{ID: targetAPIKeyID, UserID: requestAPIKeyID})
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else {
+					assert.NoError(t, err)
+				}
+
+				// the in-memory index is invalidated only when the API key or the CIDRs are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_RotateAPIKey
+// Summary: This is test class which confirm the operation of API RotateAPIKey.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：後継のAPIキーを発行し、ローテーション前のAPIキーは猶予期間の終了時に失効
+// [x] 2-1. 404: ローテーション対象のAPIキーが存在しない場合
+// [x] 2-2. 404: ローテーション中にAPIキーが失効された場合
+// [x] 2-3. 500: ローテーションエラー
+func TestProjectUsecase_RotateAPIKey(tt *testing.T) {
+
+	policy := authentication.APIKeyPolicy{TTL: 30 * 24 * time.Hour, RotationGracePeriod: 24 * time.Hour}
+
+	tests := []struct {
+		name         string
+		id           string
+		receiveErr   error
+		expectErr    error
+		expectRotate bool
+	}{
+		{
+			name:         "1-1. 201: 正常系：後継のAPIキーを発行し、ローテーション前のAPIキーは猶予期間の終了時に失効",
+			id:           targetAPIKeyID,
+			expectRotate: true,
+		},
+		{
+			name:      "2-1. 404: ローテーション対象のAPIキーが存在しない場合",
+			id:        "00000000-0000-0000-0000-000000000009",
+			expectErr: common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:         "2-2. 404: ローテーション中にAPIキーが失効された場合",
+			id:           targetAPIKeyID,
+			receiveErr:   gorm.ErrRecordNotFound,
+			expectErr:    common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+			expectRotate: true,
+		},
+		{
+			name:         "2-3. 500: ローテーションエラー",
+			id:           targetAPIKeyID,
+			receiveErr:   fmt.Errorf("DB Error"),
+			expectErr:    fmt.Errorf("DB Error"),
+			expectRotate: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("RotateAPIKey", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, policy, f.NewSecretCipher())
+
+				actual, err := apiKeyUsecase.RotateAPIKey(input.APIKeyParam{ID: test.id, RequestAPIKeyID: requestAPIKeyID})
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					assert.NotEqual(t, targetAPIKeyID, actual.ID)
+					assert.NotEmpty(t, actual.APIKey)
+					assert.Equal(t, targetAPIKeyID, actual.PreviousID)
+					assert.Equal(t, actual.NotBefore.Add(policy.RotationGracePeriod), actual.PreviousExpiresAt)
+					if assert.NotNil(t, actual.ExpiresAt) {
+						assert.Equal(t, actual.NotBefore.Add(policy.TTL), *actual.ExpiresAt)
+					}
+					authRepositoryMock.AssertCalled(t, "RotateAPIKey", mock.MatchedBy(func(param repository.RotateAPIKeyParam) bool {
+						return param.ID == targetAPIKeyID && param.ExpiresAt.Equal(actual.PreviousExpiresAt) && param.UserID == requestAPIKeyID &&
+							param.Successor.ID == actual.ID && param.Successor.Matches(actual.APIKey) && param.Successor.CreatedUserID == requestAPIKeyID
+					}))
+				}
+				if !test.expectRotate {
+					authRepositoryMock.AssertNotCalled(t, "RotateAPIKey", mock.Anything)
+				}
+
+				// the in-memory index is invalidated only when the API key or the CIDRs are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_BindOperator
+// Summary: This is test class which confirm the operation of API BindOperator and UnbindOperator.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(紐付け)
+// [x] 1-2. 200: 正常系(紐付け解除)
+// [x] 2-1. 404: APIキーが存在しない場合
+// [x] 2-2. 400: 事業者が存在しない場合
+// [x] 2-3. 404: 紐付けられていない事業者を解除する場合
+// [x] 2-4. 500: 事業者取得エラー
+func TestProjectUsecase_BindOperator(tt *testing.T) {
+
+	tests := []struct {
+		name               string
+		method             string
+		id                 string
+		receiveOperatorErr error
+		receiveErr         error
+		expectErr          error
+	}{
+		{
+			name:   "1-1. 201: 正常系(紐付け)",
+			method: "BindOperator",
+			id:     targetAPIKeyID,
+		},
+		{
+			name:   "1-2. 200: 正常系(紐付け解除)",
+			method: "UnbindOperator",
+			id:     targetAPIKeyID,
+		},
+		{
+			name:      "2-1. 404: APIキーが存在しない場合",
+			method:    "BindOperator",
+			id:        "00000000-0000-0000-0000-000000000099",
+			expectErr: common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:               "2-2. 400: 事業者が存在しない場合",
+			method:             "BindOperator",
+			id:                 targetAPIKeyID,
+			receiveOperatorErr: gorm.ErrRecordNotFound,
+			expectErr:          common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-3. 404: 紐付けられていない事業者を解除する場合",
+			method:     "UnbindOperator",
+			id:         targetAPIKeyID,
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:               "2-4. 500: 事業者取得エラー",
+			method:             "BindOperator",
+			id:                 targetAPIKeyID,
+			receiveOperatorErr: fmt.Errorf("DB Error"),
+			expectErr:          fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("CreateAPIKeyOperator", mock.Anything).Return(test.receiveErr)
+				authRepositoryMock.On("DeleteAPIKeyOperator", mock.Anything).Return(test.receiveErr)
+				ouranosRepositoryMock := new(mocks.OuranosRepository)
+				ouranosRepositoryMock.On("GetOperator", f.OperatorID).Return(traceability.OperatorEntityModel{}, test.receiveOperatorErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, ouranosRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				param := input.APIKeyOperatorParam{ID: test.id, OperatorID: f.OperatorID, RequestAPIKeyID: requestAPIKeyID}
+				expectParam := repository.APIKeyOperatorParam{APIKeyID: targetAPIKeyID, OperatorID: f.OperatorID, UserID: requestAPIKeyID}
+				var err error
+				switch test.method {
+				case "BindOperator":
+					err = apiKeyUsecase.BindOperator(param)
+				case "UnbindOperator":
+					err = apiKeyUsecase.UnbindOperator(param)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					switch test.method {
+					case "BindOperator":
+						authRepositoryMock.AssertCalled(t, "CreateAPIKeyOperator", expectParam)
+					case "UnbindOperator":
+						authRepositoryMock.AssertCalled(t, "DeleteAPIKeyOperator", expectParam)
+					}
+				}
+
+				// the in-memory index is invalidated only when the operators are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_AddCidr
+// Summary: This is test class which confirm the operation of API AddCidr and RemoveCidr.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：ネットワークアドレスに正規化して追加
+// [x] 1-2. 200: 正常系(削除)
+// [x] 1-3. 201: 正常系：IPv6のCIDRの拒否ルールを優先度とともに追加
+// [x] 2-1. 404: APIキーが存在しない場合
+// [x] 2-2. 404: 追加されていないCIDRを削除する場合
+// [x] 2-3. 500: CIDR追加エラー
+func TestProjectUsecase_AddCidr(tt *testing.T) {
+
+	priority := 10
+	defaultInput := input.APIKeyCidrParam{Cidr: "192.168.1.10/24", RequestAPIKeyID: requestAPIKeyID}
+	defaultExpect := repository.APIKeyCidrParam{APIKeyID: targetAPIKeyID, Cidr: "192.168.1.0/24", Action: authentication.CidrActionAllow, Priority: authentication.DefaultCidrPriority, UserID: requestAPIKeyID}
+
+	tests := []struct {
+		name        string
+		method      string
+		id          string
+		input       input.APIKeyCidrParam
+		receiveErr  error
+		expectParam repository.APIKeyCidrParam
+		expectErr   error
+	}{
+		{
+			name:        "1-1. 201: 正常系：ネットワークアドレスに正規化して追加",
+			method:      "AddCidr",
+			id:          targetAPIKeyID,
+			input:       defaultInput,
+			expectParam: defaultExpect,
+		},
+		{
+			name:        "1-2. 200: 正常系(削除)",
+			method:      "RemoveCidr",
+			id:          targetAPIKeyID,
+			input:       defaultInput,
+			expectParam: repository.APIKeyCidrParam{APIKeyID: targetAPIKeyID, Cidr: "192.168.1.0/24", UserID: requestAPIKeyID},
+		},
+		{
+			name:        "1-3. 201: 正常系：IPv6のCIDRの拒否ルールを優先度とともに追加",
+			method:      "AddCidr",
+			id:          targetAPIKeyID,
+			input:       input.APIKeyCidrParam{Cidr: "2001:db8::1/32", Action: authentication.CidrActionDeny, Priority: &priority, RequestAPIKeyID: requestAPIKeyID},
+			expectParam: repository.APIKeyCidrParam{APIKeyID: targetAPIKeyID, Cidr: "2001:db8::/32", Action: authentication.CidrActionDeny, Priority: 10, UserID: requestAPIKeyID},
+		},
+		{
+			name:      "2-1. 404: APIキーが存在しない場合",
+			method:    "AddCidr",
+			id:        "00000000-0000-0000-0000-000000000099",
+			input:     defaultInput,
+			expectErr: common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 404: 追加されていないCIDRを削除する場合",
+			method:     "RemoveCidr",
+			id:         targetAPIKeyID,
+			input:      defaultInput,
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-3. 500: CIDR追加エラー",
+			method:     "AddCidr",
+			id:         targetAPIKeyID,
+			input:      defaultInput,
+			receiveErr: fmt.Errorf("DB Error"),
+			expectErr:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("CreateCidr", mock.Anything).Return(test.receiveErr)
+				authRepositoryMock.On("DeleteCidr", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				param := test.input
+				param.ID = test.id
+				expectParam := test.expectParam
+				var err error
+				switch test.method {
+				case "AddCidr":
+					err = apiKeyUsecase.AddCidr(param)
+				case "RemoveCidr":
+					err = apiKeyUsecase.RemoveCidr(param)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					switch test.method {
+					case "AddCidr":
+						authRepositoryMock.AssertCalled(t, "CreateCidr", expectParam)
+					case "RemoveCidr":
+						authRepositoryMock.AssertCalled(t, "DeleteCidr", expectParam)
+					}
+				}
+
+				// the in-memory index is invalidated only when the API key or the CIDRs are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_AddClientCertificate
+// Summary: This is test class which confirm the operation of API AddClientCertificate and RemoveClientCertificate.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：コロン区切りのフィンガープリントを正規化して追加
+// [x] 1-2. 200: 正常系(削除)
+// [x] 1-3. 201: 正常系：サブジェクトDNを追加
+// [x] 2-1. 404: APIキーが存在しない場合
+// [x] 2-2. 404: 追加されていないクライアント証明書を削除する場合
+// [x] 2-3. 400: フィンガープリントがSHA-256でない場合
+// [x] 2-4. 500: クライアント証明書追加エラー
+func TestProjectUsecase_AddClientCertificate(tt *testing.T) {
+
+	fingerprint := strings.Repeat("ab", 32)
+	defaultInput := input.APIKeyClientCertificateParam{Type: authentication.ClientCertificateTypeFingerprint, Certificate: strings.Repeat("AB:", 31) + "AB", RequestAPIKeyID: requestAPIKeyID}
+	defaultExpect := repository.APIKeyClientCertificateParam{APIKeyID: targetAPIKeyID, Type: authentication.ClientCertificateTypeFingerprint, Certificate: fingerprint, UserID: requestAPIKeyID}
+
+	tests := []struct {
+		name        string
+		method      string
+		id          string
+		input       input.APIKeyClientCertificateParam
+		receiveErr  error
+		expectParam repository.APIKeyClientCertificateParam
+		expectErr   error
+	}{
+		{
+			name:        "1-1. 201: 正常系：コロン区切りのフィンガープリントを正規化して追加",
+			method:      "AddClientCertificate",
+			id:          targetAPIKeyID,
+			input:       defaultInput,
+			expectParam: defaultExpect,
+		},
+		{
+			name:        "1-2. 200: 正常系(削除)",
+			method:      "RemoveClientCertificate",
+			id:          targetAPIKeyID,
+			input:       defaultInput,
+			expectParam: defaultExpect,
+		},
+		{
+			name:        "1-3. 201: 正常系：サブジェクトDNを追加",
+			method:      "AddClientCertificate",
+			id:          targetAPIKeyID,
+			input:       input.APIKeyClientCertificateParam{Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client,O=Example", RequestAPIKeyID: requestAPIKeyID},
+			expectParam: repository.APIKeyClientCertificateParam{APIKeyID: targetAPIKeyID, Type: authentication.ClientCertificateTypeSubject, Certificate: "CN=client,O=Example", UserID: requestAPIKeyID},
+		},
+		{
+			name:      "2-1. 404: APIキーが存在しない場合",
+			method:    "AddClientCertificate",
+			id:        "00000000-0000-0000-0000-000000000099",
+			input:     defaultInput,
+			expectErr: common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 404: 追加されていないクライアント証明書を削除する場合",
+			method:     "RemoveClientCertificate",
+			id:         targetAPIKeyID,
+			input:      defaultInput,
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:      "2-3. 400: フィンガープリントがSHA-256でない場合",
+			method:    "AddClientCertificate",
+			id:        targetAPIKeyID,
+			input:     input.APIKeyClientCertificateParam{Type: authentication.ClientCertificateTypeFingerprint, Certificate: "ab:cd", RequestAPIKeyID: requestAPIKeyID},
+			expectErr: common.NewCustomError(common.CustomErrorCode400, common.Err400Validation, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-4. 500: クライアント証明書追加エラー",
+			method:     "AddClientCertificate",
+			id:         targetAPIKeyID,
+			input:      defaultInput,
+			receiveErr: fmt.Errorf("DB Error"),
+			expectErr:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("CreateClientCertificate", mock.Anything).Return(test.receiveErr)
+				authRepositoryMock.On("DeleteClientCertificate", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				param := test.input
+				param.ID = test.id
+				var err error
+				switch test.method {
+				case "AddClientCertificate":
+					err = apiKeyUsecase.AddClientCertificate(param)
+				case "RemoveClientCertificate":
+					err = apiKeyUsecase.RemoveClientCertificate(param)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					switch test.method {
+					case "AddClientCertificate":
+						authRepositoryMock.AssertCalled(t, "CreateClientCertificate", test.expectParam)
+					case "RemoveClientCertificate":
+						authRepositoryMock.AssertCalled(t, "DeleteClientCertificate", test.expectParam)
+					}
+				}
+
+				// the in-memory index is invalidated only when the client certificates are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_GrantPermission
+// Summary: This is test class which confirm the operation of API GrantPermission and RevokePermission.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(付与)
+// [x] 1-2. 200: 正常系(取消)
+// [x] 2-1. 404: APIキーが存在しない場合
+// [x] 2-2. 404: 付与されていない権限を取り消す場合
+// [x] 2-3. 500: 権限付与エラー
+func TestProjectUsecase_GrantPermission(tt *testing.T) {
+
+	defaultInput := input.APIKeyPermissionParam{Method: "GET", Path: "/api/v1/authInfo", RequestAPIKeyID: requestAPIKeyID}
+	defaultExpect := repository.APIKeyPermissionParam{APIKeyID: targetAPIKeyID, Method: "GET", Path: "/api/v1/authInfo", UserID: requestAPIKeyID}
+
+	tests := []struct {
+		name       string
+		method     string
+		id         string
+		receiveErr error
+		expectErr  error
+	}{
+		{
+			name:   "1-1. 201: 正常系(付与)",
+			method: "GrantPermission",
+			id:     targetAPIKeyID,
+		},
+		{
+			name:   "1-2. 200: 正常系(取消)",
+			method: "RevokePermission",
+			id:     targetAPIKeyID,
+		},
+		{
+			name:      "2-1. 404: APIキーが存在しない場合",
+			method:    "GrantPermission",
+			id:        "00000000-0000-0000-0000-000000000099",
+			expectErr: common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 404: 付与されていない権限を取り消す場合",
+			method:     "RevokePermission",
+			id:         targetAPIKeyID,
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404ResourceNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-3. 500: 権限付与エラー",
+			method:     "GrantPermission",
+			id:         targetAPIKeyID,
+			receiveErr: fmt.Errorf("DB Error"),
+			expectErr:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("CreateAPIKeyPermission", mock.Anything).Return(test.receiveErr)
+				authRepositoryMock.On("DeleteAPIKeyPermission", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, f.NewSecretCipher())
+
+				param := defaultInput
+				param.ID = test.id
+				var err error
+				switch test.method {
+				case "GrantPermission":
+					err = apiKeyUsecase.GrantPermission(param)
+				case "RevokePermission":
+					err = apiKeyUsecase.RevokePermission(param)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					switch test.method {
+					case "GrantPermission":
+						authRepositoryMock.AssertCalled(t, "CreateAPIKeyPermission", defaultExpect)
+					case "RevokePermission":
+						authRepositoryMock.AssertCalled(t, "DeleteAPIKeyPermission", defaultExpect)
+					}
+				}
+
+				// the in-memory index is invalidated only when the permissions are changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_EnableRequestSigning
+// Summary: This is test class which confirm the operation of API EnableRequestSigning and DisableRequestSigning.
+// Target: auth_api_key_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：署名シークレットを生成し、暗号化して保存
+// [x] 1-2. 200: 正常系(無効化)
+// [x] 2-1. 404: APIキーが存在しない場合
+// [x] 2-2. 500: 暗号鍵が設定されていない場合
+func TestProjectUsecase_EnableRequestSigning(tt *testing.T) {
+
+	tests := []struct {
+		name       string
+		method     string
+		cipher     authentication.SecretCipher
+		receiveErr error
+		expectErr  error
+		expectSave bool
+	}{
+		{
+			name:       "1-1. 201: 正常系：署名シークレットを生成し、暗号化して保存",
+			method:     "EnableRequestSigning",
+			cipher:     f.NewSecretCipher(),
+			expectSave: true,
+		},
+		{
+			name:       "1-2. 200: 正常系(無効化)",
+			method:     "DisableRequestSigning",
+			cipher:     f.NewSecretCipher(),
+			expectSave: true,
+		},
+		{
+			name:       "2-1. 404: APIキーが存在しない場合",
+			method:     "EnableRequestSigning",
+			cipher:     f.NewSecretCipher(),
+			receiveErr: gorm.ErrRecordNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404APIKeyNotFound, nil, common.HTTPErrorSourceAuth),
+			expectSave: true,
+		},
+		{
+			name:      "2-2. 500: 暗号鍵が設定されていない場合",
+			method:    "EnableRequestSigning",
+			cipher:    authentication.NewSecretCipher(""),
+			expectErr: authentication.ErrSecretCipherKeyNotConfigured,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := newAPIKeyAuthRepositoryMock()
+				authRepositoryMock.On("UpdateAPIKeySigningSecret", mock.Anything).Return(test.receiveErr)
+				apiKeyCacheMock := newAPIKeyCacheMock()
+				apiKeyUsecase := usecase.NewAPIKeyUsecase(authRepositoryMock, new(mocks.OuranosRepository), apiKeyCacheMock, authentication.APIKeyPolicy{}, test.cipher)
+
+				param := input.APIKeyParam{ID: targetAPIKeyID, RequestAPIKeyID: requestAPIKeyID}
+				var actual output.SigningSecretResponse
+				var err error
+				switch test.method {
+				case "EnableRequestSigning":
+					actual, err = apiKeyUsecase.EnableRequestSigning(param)
+				case "DisableRequestSigning":
+					err = apiKeyUsecase.DisableRequestSigning(param)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					switch test.method {
+					case "EnableRequestSigning":
+						assert.Equal(t, targetAPIKeyID, actual.ID)
+						assert.NotEmpty(t, actual.SigningSecret)
+						// the signing secret is stored encrypted
+						authRepositoryMock.AssertCalled(t, "UpdateAPIKeySigningSecret", mock.MatchedBy(func(p repository.UpdateAPIKeySigningSecretParam) bool {
+							if p.ID != targetAPIKeyID || p.UserID != requestAPIKeyID || p.SigningSecret == nil {
+								return false
+							}
+							secret, err := test.cipher.Decrypt(*p.SigningSecret)
+							return err == nil && secret == actual.SigningSecret
+						}))
+					case "DisableRequestSigning":
+						authRepositoryMock.AssertCalled(t, "UpdateAPIKeySigningSecret", repository.UpdateAPIKeySigningSecretParam{ID: targetAPIKeyID, UserID: requestAPIKeyID})
+					}
+				}
+				if !test.expectSave {
+					authRepositoryMock.AssertNotCalled(t, "UpdateAPIKeySigningSecret", mock.Anything)
+				}
+
+				// the in-memory index is invalidated only when the signing secret is changed
+				if test.expectErr != nil {
+					apiKeyCacheMock.AssertNotCalled(t, "Invalidate")
+				} else {
+					apiKeyCacheMock.AssertCalled(t, "Invalidate")
+				}
+			},
+		)
+	}
+}
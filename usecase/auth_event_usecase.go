@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// IAuthEventUsecase
+// Summary: This is interface which defines IAuthEventUsecase
+//
+//go:generate mockery --name IAuthEventUsecase --output ../test/mock --case underscore
+type IAuthEventUsecase interface {
+	ListAuthEvents(input input.ListAuthEventsParam) (output.AuthEventsResponse, error)
+}
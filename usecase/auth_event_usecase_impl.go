@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// defaultAuthEventLimit is the number of the events listed when the limit is not specified
+const defaultAuthEventLimit = 100
+
+// authEventUsecase
+// Summary: This is the structure which defines the usecase for the authentication events.
+type authEventUsecase struct {
+	authRepository repository.AuthRepository
+}
+
+// NewAuthEventUsecase
+// Summary: This is the function which creates the auth event usecase.
+// input: a(repository.AuthRepository) auth repository
+// output: (IAuthEventUsecase) auth event usecase
+func NewAuthEventUsecase(a repository.AuthRepository) IAuthEventUsecase {
+	return &authEventUsecase{a}
+}
+
+// ListAuthEvents
+// Summary: This is the function which lists the authentication events in descending order of the occurred time.
+// input: input(input.ListAuthEventsParam): input parameter
+// output: (output.AuthEventsResponse) auth events and the cursor of the next page
+// output: (error) error object
+func (u authEventUsecase) ListAuthEvents(input input.ListAuthEventsParam) (output.AuthEventsResponse, error) {
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultAuthEventLimit
+	}
+
+	// one more event is listed to know whether the next page exists
+	param := repository.AuthEventsParam{Events: input.Events(), Limit: limit + 1}
+	if input.From != "" {
+		from, _ := time.Parse(time.RFC3339, input.From)
+		param.From = &from
+	}
+	if input.To != "" {
+		to, _ := time.Parse(time.RFC3339, input.To)
+		param.To = &to
+	}
+	if input.OperatorID != "" {
+		param.OperatorID = &input.OperatorID
+	}
+	if input.Cursor != "" {
+		cursor, _ := authentication.ParseAuthEventCursor(input.Cursor)
+		param.After = &cursor
+	}
+
+	events, err := u.authRepository.ListAuthEvents(param)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.AuthEventsResponse{}, err
+	}
+
+	var nextCursor *string
+	if len(events) > limit {
+		events = events[:limit]
+		cursor := authentication.NewAuthEventCursor(events[limit-1]).Encode()
+		nextCursor = &cursor
+	}
+	return output.NewAuthEventsResponse(events, nextCursor), nil
+}
@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestProjectUsecase_ListAuthEvents
+// Summary: This is test class which confirm the operation of API ListAuthEvents.
+// Target: auth_event_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系(次のページがある場合、カーソルを返却)
+// [x] 1-2. 200: 正常系(次のページがない場合)
+// [x] 1-3. 200: 正常系(検索条件を指定した場合)
+// [x] 2-1. 500: イベント取得エラー
+func TestProjectUsecase_ListAuthEvents(tt *testing.T) {
+
+	events := f.NewAuthEvents(3)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	cursor := authentication.NewAuthEventCursor(events[0])
+
+	tests := []struct {
+		name             string
+		input            input.ListAuthEventsParam
+		expectParam      repository.AuthEventsParam
+		receive          authentication.AuthEvents
+		receiveError     error
+		expectCount      int
+		expectNextCursor *string
+		expect           error
+	}{
+		{
+			name:             "1-1. 200: 正常系(次のページがある場合、カーソルを返却)",
+			input:            input.ListAuthEventsParam{Limit: 2},
+			expectParam:      repository.AuthEventsParam{Limit: 3},
+			receive:          events,
+			expectCount:      2,
+			expectNextCursor: func() *string { s := authentication.NewAuthEventCursor(events[1]).Encode(); return &s }(),
+		},
+		{
+			name:        "1-2. 200: 正常系(次のページがない場合)",
+			input:       input.ListAuthEventsParam{},
+			expectParam: repository.AuthEventsParam{Limit: 101},
+			receive:     events,
+			expectCount: 3,
+		},
+		{
+			name: "1-3. 200: 正常系(検索条件を指定した場合)",
+			input: input.ListAuthEventsParam{
+				From:       "2024-05-01T00:00:00Z",
+				To:         "2024-05-02T00:00:00Z",
+				OperatorID: f.OperatorID,
+				Event:      "operatorLogin, operatorLogout",
+				Cursor:     cursor.Encode(),
+				Limit:      10,
+			},
+			expectParam: repository.AuthEventsParam{
+				From:       &from,
+				To:         &to,
+				OperatorID: &f.OperatorID,
+				Events:     []string{"operatorLogin", "operatorLogout"},
+				After:      &cursor,
+				Limit:      11,
+			},
+			receive:     events[1:],
+			expectCount: 2,
+		},
+		{
+			name:         "2-1. 500: イベント取得エラー",
+			input:        input.ListAuthEventsParam{},
+			expectParam:  repository.AuthEventsParam{Limit: 101},
+			receiveError: fmt.Errorf("DB Error"),
+			expect:       fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("ListAuthEvents", mock.Anything).Return(test.receive, test.receiveError)
+				authEventUsecase := usecase.NewAuthEventUsecase(authRepositoryMock)
+
+				actual, err := authEventUsecase.ListAuthEvents(test.input)
+				authRepositoryMock.AssertCalled(t, "ListAuthEvents", mock.MatchedBy(func(param repository.AuthEventsParam) bool {
+					return assert.ObjectsAreEqual(test.expectParam.Events, param.Events) &&
+						assert.ObjectsAreEqual(test.expectParam.OperatorID, param.OperatorID) &&
+						assert.ObjectsAreEqual(test.expectParam.After, param.After) &&
+						test.expectParam.Limit == param.Limit &&
+						(test.expectParam.From == nil) == (param.From == nil) &&
+						(test.expectParam.From == nil || test.expectParam.From.Equal(*param.From)) &&
+						(test.expectParam.To == nil) == (param.To == nil) &&
+						(test.expectParam.To == nil || test.expectParam.To.Equal(*param.To))
+				}))
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Len(t, actual.Events, test.expectCount)
+					assert.Equal(t, test.expectNextCursor, actual.NextCursor)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/repository"
+)
+
+// idpErrorResponses
+// Summary: This is the map from the kind of the identity provider error to the error reported to the client.
+var idpErrorResponses = map[repository.IdPErrorKind]struct {
+	code    common.CustomErrorCode
+	message string
+	reason  string
+}{
+	repository.IdPErrorUserDisabled:    {common.CustomErrorCode403, common.Err403UserDisabled, common.ReasonUserDisabled},
+	repository.IdPErrorTooManyAttempts: {common.CustomErrorCode429, common.Err429TooManyLoginAttempts, common.ReasonTooManyAttempts},
+	repository.IdPErrorTokenExpired:    {common.CustomErrorCode401, common.Err401InvalidToken, common.ReasonTokenExpired},
+	repository.IdPErrorQuotaExceeded:   {common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPQuotaExceeded},
+	repository.IdPErrorUnavailable:     {common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable},
+}
+
+// convertIdPError
+// Summary: This is the function which converts the error of the identity provider to the CustomError with the reason code.
+// input: err(error) error returned by the identity provider
+// output: (error) CustomError when the error is repository.IdPError, the error itself otherwise
+func convertIdPError(err error) error {
+	var idpErr repository.IdPError
+	if !errors.As(err, &idpErr) {
+		return err
+	}
+	res, ok := idpErrorResponses[idpErr.Kind]
+	if !ok {
+		return err
+	}
+
+	return common.NewCustomErrorWithReason(res.code, res.message, res.reason, common.HTTPErrorSourceAuth)
+}
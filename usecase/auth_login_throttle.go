@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+)
+
+// loginThrottle
+// Summary: This is the structure which throttles the failed login attempts of the account and the client IP address.
+type loginThrottle struct {
+	authRepository repository.AuthRepository
+	policy         authentication.LoginThrottlePolicy
+}
+
+// check
+// Summary: This is the function which records the pending login attempt and checks whether the attempt is accepted.
+// The attempt is recorded before the failures are listed, so that the concurrent attempts count each other as the failures.
+// The pending attempt is canceled when the attempt is rejected.
+// input: email(string) email of the account
+// input: ipAddress(string) client IP address
+// output: (string) ID of the pending attempt whose result must be recorded
+// output: (error) error object. CustomError with the period to wait when the attempt is rejected
+func (t loginThrottle) check(email string, ipAddress string) (string, error) {
+	attemptID, err := t.authRepository.CreateLoginAttempt(repository.CreateLoginAttemptParam{
+		Email:     email,
+		IPAddress: ipAddress,
+		Result:    authentication.LoginAttemptResultPending,
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	status, err := t.status(attemptID, email, ipAddress)
+	if err != nil {
+		t.cancel(attemptID)
+
+		return "", err
+	}
+	if status.Locked {
+		logger.Set(nil).Warnf(common.Err423AccountLocked)
+		t.cancel(attemptID)
+
+		return "", common.NewCustomErrorWithRetryAfter(common.CustomErrorCode423, common.Err423AccountLocked, status.RetryAfter, common.HTTPErrorSourceAuth)
+	}
+	if status.Throttled {
+		logger.Set(nil).Warnf(common.Err429TooManyLoginAttempts)
+		t.cancel(attemptID)
+
+		return "", common.NewCustomErrorWithRetryAfter(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, status.RetryAfter, common.HTTPErrorSourceAuth)
+	}
+	return attemptID, nil
+}
+
+// status
+// Summary: This is the function which checks the failures of the account and the client IP address other than the pending attempt.
+// input: attemptID(string) ID of the pending attempt
+// input: email(string) email of the account
+// input: ipAddress(string) client IP address
+// output: (authentication.LoginThrottleStatus) status of the attempt
+// output: (error) error object
+func (t loginThrottle) status(attemptID string, email string, ipAddress string) (authentication.LoginThrottleStatus, error) {
+	now := time.Now()
+
+	accountFailures, err := t.authRepository.ListLoginFailures(repository.LoginFailuresParam{
+		Email:     email,
+		Since:     t.policy.AccountFailuresSince(now),
+		Limit:     t.policy.LockoutThreshold,
+		ExcludeID: attemptID,
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.LoginThrottleStatus{}, err
+	}
+	var ipFailures authentication.LoginAttempts
+	if t.policy.IPFailureThreshold > 0 {
+		ipFailures, err = t.authRepository.ListLoginFailures(repository.LoginFailuresParam{
+			IPAddress: ipAddress,
+			Since:     t.policy.IPFailuresSince(now),
+			Limit:     t.policy.IPFailureThreshold,
+			ExcludeID: attemptID,
+		})
+		if err != nil {
+			logger.Set(nil).Errorf(err.Error())
+
+			return authentication.LoginThrottleStatus{}, err
+		}
+	}
+	return t.policy.Check(accountFailures, ipFailures, now), nil
+}
+
+// recordFailure
+// Summary: This is the function which records the failure of the pending login attempt.
+// input: attemptID(string) ID of the pending attempt
+// output: (error) error object
+func (t loginThrottle) recordFailure(attemptID string) error {
+	return t.record(attemptID, authentication.LoginAttemptResultFailure)
+}
+
+// recordSuccess
+// Summary: This is the function which records the success of the pending login attempt to reset the consecutive failures of the account.
+// input: attemptID(string) ID of the pending attempt
+// output: (error) error object
+func (t loginThrottle) recordSuccess(attemptID string) error {
+	return t.record(attemptID, authentication.LoginAttemptResultSuccess)
+}
+
+// cancel
+// Summary: This is the function which cancels the pending login attempt which ends without the result of the credentials.
+// The error is only logged because the attempt is canceled on the way to return another result.
+// The pending attempt which fails to be canceled is counted as the failure until it leaves the window.
+// input: attemptID(string) ID of the pending attempt
+func (t loginThrottle) cancel(attemptID string) {
+	_ = t.record(attemptID, authentication.LoginAttemptResultCanceled)
+}
+
+// record
+// Summary: This is the function which records the result of the pending login attempt.
+// input: attemptID(string) ID of the pending attempt
+// input: result(authentication.LoginAttemptResult) result of the attempt
+// output: (error) error object
+func (t loginThrottle) record(attemptID string, result authentication.LoginAttemptResult) error {
+	if err := t.authRepository.UpdateLoginAttemptResult(repository.UpdateLoginAttemptResultParam{
+		ID:     attemptID,
+		Result: result,
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
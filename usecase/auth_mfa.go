@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+
+	"gorm.io/gorm"
+)
+
+// mfaAuthenticator
+// Summary: This is the structure which manages the MFA credentials and the challenges issued on login.
+type mfaAuthenticator struct {
+	authRepository repository.AuthRepository
+	policy         authentication.MFAPolicy
+	cipher         authentication.SecretCipher
+}
+
+// credential
+// Summary: This is the function which gets the MFA credential of the account.
+// input: email(string) email of the account
+// output: (authentication.MFACredential) MFA credential
+// output: (bool) true if the account has enrolled MFA, false otherwise
+// output: (error) error object
+func (m mfaAuthenticator) credential(email string) (authentication.MFACredential, bool, error) {
+	credential, err := m.authRepository.GetMFACredential(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return authentication.MFACredential{}, false, nil
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.MFACredential{}, false, err
+	}
+	return credential, true, nil
+}
+
+// challenge
+// Summary: This is the function which keeps the tokens issued on login until the second factor is verified.
+// input: email(string) email of the account
+// input: res(authentication.LoginResult) tokens issued by the IdP
+// output: (string) challenge token to be exchanged for the tokens
+// output: (error) error object
+func (m mfaAuthenticator) challenge(email string, res authentication.LoginResult) (string, error) {
+	accessToken, err := m.cipher.Encrypt(res.AccessToken)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	refreshToken, err := m.cipher.Encrypt(res.RefreshToken)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	token, tokenHash, err := authentication.NewMFAChallengeToken()
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+
+	if err := m.authRepository.CreateMFAChallenge(authentication.MFAChallenge{
+		Email:        email,
+		TokenHash:    tokenHash,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(m.policy.ChallengeTTL),
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return "", err
+	}
+	return token, nil
+}
+
+// verify
+// Summary: This is the function which verifies the TOTP code or the recovery code.
+// The use of the code is recorded in the credential, which has to be saved by the caller.
+// input: credential(*authentication.MFACredential) MFA credential
+// input: code(string) TOTP code or recovery code
+// output: (bool) true if the code is valid, false otherwise
+// output: (error) error object
+func (m mfaAuthenticator) verify(credential *authentication.MFACredential, code string) (bool, error) {
+	secret, err := m.cipher.Decrypt(credential.Secret)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return false, err
+	}
+	return credential.Verify(secret, code, time.Now()), nil
+}
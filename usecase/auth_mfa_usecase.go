@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// IMFAUsecase
+// Summary: This is interface which defines IMFAUsecase
+//
+//go:generate mockery --name IMFAUsecase --output ../test/mock --case underscore
+type IMFAUsecase interface {
+	EnrollMFA(input input.EnrollMFAParam) (output.EnrollMFAResponse, error)
+	ActivateMFA(input input.ActivateMFAParam) (output.ActivateMFAResponse, error)
+	VerifyMFA(input input.VerifyMFAParam) (output.LoginResponse, error)
+	DisableMFA(input input.DisableMFAParam) error
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"gorm.io/gorm"
+)
+
+// mfaUsecase
+// Summary: This is the structure which defines the usecase for the multi-factor authentication.
+type mfaUsecase struct {
+	authRepository   repository.AuthRepository
+	mfaAuthenticator mfaAuthenticator
+	loginThrottle    loginThrottle
+}
+
+// NewMFAUsecase
+// Summary: This is the function which creates the MFA usecase.
+// input: a(repository.AuthRepository) auth repository
+// input: policy(authentication.MFAPolicy) policy of the multi-factor authentication
+// input: cipher(authentication.SecretCipher) cipher to encrypt the TOTP secrets and the tokens kept by the challenges
+// input: throttlePolicy(authentication.LoginThrottlePolicy) policy to throttle the failed login attempts
+// output: (IMFAUsecase) MFA usecase
+func NewMFAUsecase(
+	a repository.AuthRepository,
+	policy authentication.MFAPolicy,
+	cipher authentication.SecretCipher,
+	throttlePolicy authentication.LoginThrottlePolicy,
+) IMFAUsecase {
+	return &mfaUsecase{a, mfaAuthenticator{a, policy, cipher}, loginThrottle{a, throttlePolicy}}
+}
+
+// EnrollMFA
+// Summary: This is the function which generates the TOTP secret of the operator.
+// MFA is not enabled until the secret is activated with the code.
+// input: input(input.EnrollMFAParam): input parameter
+// output: (output.EnrollMFAResponse) secret and the URI to be registered to the authenticator application
+// output: (error) error object
+func (u mfaUsecase) EnrollMFA(input input.EnrollMFAParam) (output.EnrollMFAResponse, error) {
+	credential, _, err := u.mfaAuthenticator.credential(input.Email)
+	if err != nil {
+		return output.EnrollMFAResponse{}, err
+	}
+	if credential.Enabled {
+		logger.Set(nil).Warnf(common.Err400MFAAlreadyEnabled)
+
+		return output.EnrollMFAResponse{}, common.NewCustomError(common.CustomErrorCode400, common.Err400MFAAlreadyEnabled, nil, common.HTTPErrorSourceAuth)
+	}
+
+	secret, err := authentication.NewTOTPSecret()
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.EnrollMFAResponse{}, err
+	}
+	encrypted, err := u.mfaAuthenticator.cipher.Encrypt(secret)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.EnrollMFAResponse{}, err
+	}
+	credential.Email = input.Email
+	credential.Secret = encrypted
+	credential.LastUsedStep = 0
+	credential.RecoveryCodeHashes = []string{}
+	if err := u.authRepository.SaveMFACredential(credential); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.EnrollMFAResponse{}, err
+	}
+
+	return output.EnrollMFAResponse{
+		Secret:          secret,
+		ProvisioningURI: authentication.TOTPProvisioningURI(u.mfaAuthenticator.policy.Issuer, input.Email, secret),
+	}, nil
+}
+
+// ActivateMFA
+// Summary: This is the function which enables MFA after verifying the code generated with the enrolled secret.
+// input: input(input.ActivateMFAParam): input parameter
+// output: (output.ActivateMFAResponse) recovery codes. they are shown only once
+// output: (error) error object
+func (u mfaUsecase) ActivateMFA(input input.ActivateMFAParam) (output.ActivateMFAResponse, error) {
+	credential, enrolled, err := u.mfaAuthenticator.credential(input.Email)
+	if err != nil {
+		return output.ActivateMFAResponse{}, err
+	}
+	if !enrolled {
+		logger.Set(nil).Warnf(common.Err400MFANotEnrolled)
+
+		return output.ActivateMFAResponse{}, common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth)
+	}
+	if credential.Enabled {
+		logger.Set(nil).Warnf(common.Err400MFAAlreadyEnabled)
+
+		return output.ActivateMFAResponse{}, common.NewCustomError(common.CustomErrorCode400, common.Err400MFAAlreadyEnabled, nil, common.HTTPErrorSourceAuth)
+	}
+
+	ok, err := u.mfaAuthenticator.verify(&credential, input.Code)
+	if err != nil {
+		return output.ActivateMFAResponse{}, err
+	}
+	if !ok {
+		logger.Set(nil).Warnf(common.Err401InvalidMFACode)
+
+		return output.ActivateMFAResponse{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth)
+	}
+
+	codes, hashes, err := authentication.NewRecoveryCodes(u.mfaAuthenticator.policy.RecoveryCodeCount)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ActivateMFAResponse{}, err
+	}
+	credential.Enabled = true
+	credential.RecoveryCodeHashes = hashes
+	if err := u.authRepository.SaveMFACredential(credential); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ActivateMFAResponse{}, err
+	}
+
+	return output.ActivateMFAResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyMFA
+// Summary: This is the function which exchanges the MFA challenge issued on login and the code for the tokens.
+// A wrong code is counted as a failed login attempt, and the challenge is invalidated after too many wrong codes.
+// input: input(input.VerifyMFAParam): input parameter
+// output: (output.LoginResponse) login response
+// output: (error) error object
+func (u mfaUsecase) VerifyMFA(input input.VerifyMFAParam) (output.LoginResponse, error) {
+	challenge, err := u.authRepository.GetMFAChallenge(authentication.HashMFAChallengeToken(input.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(common.Err401InvalidMFAChallenge)
+
+			return output.LoginResponse{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth)
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.LoginResponse{}, err
+	}
+	if challenge.Expired(time.Now()) {
+		return output.LoginResponse{}, u.invalidateChallenge(challenge)
+	}
+
+	attemptID, err := u.loginThrottle.check(challenge.Email, input.IPAddress)
+	if err != nil {
+		return output.LoginResponse{}, err
+	}
+	credential, _, err := u.mfaAuthenticator.credential(challenge.Email)
+	if err != nil {
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, err
+	}
+	if !credential.Enabled {
+		// MFA has been disabled after the challenge was issued
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, u.invalidateChallenge(challenge)
+	}
+
+	ok, err := u.mfaAuthenticator.verify(&credential, input.Code)
+	if err != nil {
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, err
+	}
+	if !ok {
+		logger.Set(nil).Warnf(common.Err401InvalidMFACode)
+		if err := u.loginThrottle.recordFailure(attemptID); err != nil {
+			return output.LoginResponse{}, err
+		}
+		if challenge.Attempts+1 >= u.mfaAuthenticator.policy.MaxAttempts {
+			err = u.authRepository.DeleteMFAChallenge(challenge.ID)
+		} else {
+			err = u.authRepository.IncrementMFAChallengeAttempts(challenge.ID)
+		}
+		if err != nil {
+			logger.Set(nil).Errorf(err.Error())
+
+			return output.LoginResponse{}, err
+		}
+
+		return output.LoginResponse{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth)
+	}
+
+	if err := u.authRepository.SaveMFACredential(credential); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, err
+	}
+	if err := u.authRepository.DeleteMFAChallenge(challenge.ID); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, err
+	}
+	if err := u.loginThrottle.recordSuccess(attemptID); err != nil {
+		return output.LoginResponse{}, err
+	}
+
+	accessToken, err := u.mfaAuthenticator.cipher.Decrypt(challenge.AccessToken)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.LoginResponse{}, err
+	}
+	refreshToken, err := u.mfaAuthenticator.cipher.Decrypt(challenge.RefreshToken)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.LoginResponse{}, err
+	}
+
+	return output.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// DisableMFA
+// Summary: This is the function which disables MFA after verifying the code.
+// input: input(input.DisableMFAParam): input parameter
+// output: (error) error object
+func (u mfaUsecase) DisableMFA(input input.DisableMFAParam) error {
+	credential, _, err := u.mfaAuthenticator.credential(input.Email)
+	if err != nil {
+		return err
+	}
+	if !credential.Enabled {
+		logger.Set(nil).Warnf(common.Err400MFANotEnrolled)
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth)
+	}
+
+	ok, err := u.mfaAuthenticator.verify(&credential, input.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		logger.Set(nil).Warnf(common.Err401InvalidMFACode)
+
+		return common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth)
+	}
+
+	if err := u.authRepository.DeleteMFACredential(input.Email); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
+}
+
+// invalidateChallenge
+// Summary: This is the function which deletes the MFA challenge which can no longer be used.
+// input: challenge(authentication.MFAChallenge) MFA challenge
+// output: (error) CustomError which tells the challenge is invalid, or the error of the deletion
+func (u mfaUsecase) invalidateChallenge(challenge authentication.MFAChallenge) error {
+	if err := u.authRepository.DeleteMFAChallenge(challenge.ID); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	logger.Set(nil).Warnf(common.Err401InvalidMFAChallenge)
+
+	return common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth)
+}
@@ -0,0 +1,433 @@
+package usecase_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// TestProjectUsecase_EnrollMFA
+// Summary: This is test class which confirm the operation of API EnrollMFA.
+// Target: auth_mfa_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(未登録の場合、暗号化したシークレットを登録)
+// [x] 1-2. 201: 正常系(有効化前の場合、シークレットを再発行)
+// [x] 2-1. 400: MFAが有効な場合
+// [x] 2-2. 500: クレデンシャル取得エラー
+// [x] 2-3. 500: クレデンシャル登録エラー
+func TestProjectUsecase_EnrollMFA(tt *testing.T) {
+
+	pending := f.NewMFACredential(false)
+
+	tests := []struct {
+		name              string
+		receiveCredential authentication.MFACredential
+		receiveGetError   error
+		receiveSaveError  error
+		expectID          string
+		expect            error
+	}{
+		{
+			name:            "1-1. 201: 正常系(未登録の場合、暗号化したシークレットを登録)",
+			receiveGetError: gorm.ErrRecordNotFound,
+		},
+		{
+			name:              "1-2. 201: 正常系(有効化前の場合、シークレットを再発行)",
+			receiveCredential: pending,
+			expectID:          pending.ID,
+		},
+		{
+			name:              "2-1. 400: MFAが有効な場合",
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode400, common.Err400MFAAlreadyEnabled, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:            "2-2. 500: クレデンシャル取得エラー",
+			receiveGetError: fmt.Errorf("DB Error"),
+			expect:          fmt.Errorf("DB Error"),
+		},
+		{
+			name:             "2-3. 500: クレデンシャル登録エラー",
+			receiveGetError:  gorm.ErrRecordNotFound,
+			receiveSaveError: fmt.Errorf("DB Error"),
+			expect:           fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetMFACredential", f.Email).Return(test.receiveCredential, test.receiveGetError)
+				authRepositoryMock.On("SaveMFACredential", mock.Anything).Return(test.receiveSaveError)
+				mfaUsecase := usecase.NewMFAUsecase(authRepositoryMock, f.NewMFAPolicy(), f.NewSecretCipher(), f.NewLoginThrottlePolicy())
+
+				actual, err := mfaUsecase.EnrollMFA(f.NewInputEnrollMFAParam())
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.NotEmpty(t, actual.Secret)
+					assert.True(t, strings.HasPrefix(actual.ProvisioningURI, "otpauth://totp/authenticator-backend:"+f.Email+"?"))
+					assert.Contains(t, actual.ProvisioningURI, "secret="+actual.Secret)
+					authRepositoryMock.AssertCalled(t, "SaveMFACredential", mock.MatchedBy(func(credential authentication.MFACredential) bool {
+						secret, err := f.NewSecretCipher().Decrypt(credential.Secret)
+						return err == nil && secret == actual.Secret && credential.ID == test.expectID && credential.Email == f.Email && !credential.Enabled
+					}))
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_ActivateMFA
+// Summary: This is test class which confirm the operation of API ActivateMFA.
+// Target: auth_mfa_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(MFAを有効化してリカバリーコードを返却)
+// [x] 2-1. 400: MFAが未登録の場合
+// [x] 2-2. 400: MFAが有効な場合
+// [x] 2-3. 401: コードが誤っている場合
+// [x] 2-4. 500: クレデンシャル登録エラー
+func TestProjectUsecase_ActivateMFA(tt *testing.T) {
+
+	tests := []struct {
+		name              string
+		input             input.ActivateMFAParam
+		receiveCredential authentication.MFACredential
+		receiveGetError   error
+		receiveSaveError  error
+		expect            error
+	}{
+		{
+			name:              "1-1. 201: 正常系(MFAを有効化してリカバリーコードを返却)",
+			input:             f.NewInputActivateMFAParam(),
+			receiveCredential: f.NewMFACredential(false),
+		},
+		{
+			name:            "2-1. 400: MFAが未登録の場合",
+			input:           f.NewInputActivateMFAParam(),
+			receiveGetError: gorm.ErrRecordNotFound,
+			expect:          common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:              "2-2. 400: MFAが有効な場合",
+			input:             f.NewInputActivateMFAParam(),
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode400, common.Err400MFAAlreadyEnabled, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:              "2-3. 401: コードが誤っている場合",
+			input:             input.ActivateMFAParam{Email: f.Email, Code: "abcdef"},
+			receiveCredential: f.NewMFACredential(false),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:              "2-4. 500: クレデンシャル登録エラー",
+			input:             f.NewInputActivateMFAParam(),
+			receiveCredential: f.NewMFACredential(false),
+			receiveSaveError:  fmt.Errorf("DB Error"),
+			expect:            fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetMFACredential", f.Email).Return(test.receiveCredential, test.receiveGetError)
+				authRepositoryMock.On("SaveMFACredential", mock.Anything).Return(test.receiveSaveError)
+				mfaUsecase := usecase.NewMFAUsecase(authRepositoryMock, f.NewMFAPolicy(), f.NewSecretCipher(), f.NewLoginThrottlePolicy())
+
+				actual, err := mfaUsecase.ActivateMFA(test.input)
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Len(t, actual.RecoveryCodes, f.NewMFAPolicy().RecoveryCodeCount)
+					authRepositoryMock.AssertCalled(t, "SaveMFACredential", mock.MatchedBy(func(credential authentication.MFACredential) bool {
+						return credential.Enabled && credential.LastUsedStep > 0 &&
+							len(credential.RecoveryCodeHashes) == len(actual.RecoveryCodes) &&
+							credential.RecoveryCodeHashes[0] == authentication.HashRecoveryCode(actual.RecoveryCodes[0])
+					}))
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_VerifyMFA
+// Summary: This is test class which confirm the operation of API VerifyMFA.
+// Target: auth_mfa_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(TOTPコードの場合、トークンを返却してチャレンジを削除)
+// [x] 1-2. 201: 正常系(リカバリーコードの場合、コードを消費して失敗回数をリセット)
+// [x] 2-1. 401: チャレンジが存在しない場合
+// [x] 2-2. 401: チャレンジが期限切れの場合
+// [x] 2-3. 401: コードが誤っている場合、失敗を記録
+// [x] 2-4. 401: コードの誤りが上限に達した場合、チャレンジを削除
+// [x] 2-5. 401: チャレンジ発行後にMFAが無効化された場合
+// [x] 2-6. 423: アカウントロック中
+// [x] 2-7. 500: チャレンジ取得エラー
+func TestProjectUsecase_VerifyMFA(tt *testing.T) {
+
+	expired := f.NewMFAChallenge()
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	exhausted := f.NewMFAChallenge()
+	exhausted.Attempts = f.NewMFAPolicy().MaxAttempts - 1
+
+	tests := []struct {
+		name                   string
+		input                  input.VerifyMFAParam
+		receiveChallenge       authentication.MFAChallenge
+		receiveChallengeError  error
+		receiveCredential      authentication.MFACredential
+		receiveAccountFailures authentication.LoginAttempts
+		expect                 error
+		expectSave             func(credential authentication.MFACredential) bool
+		expectDelete           bool
+		expectIncrement        bool
+		expectAttempt          authentication.LoginAttemptResult
+	}{
+		{
+			name:              "1-1. 201: 正常系(TOTPコードの場合、トークンを返却してチャレンジを削除)",
+			input:             f.NewInputVerifyMFAParam(),
+			receiveChallenge:  f.NewMFAChallenge(),
+			receiveCredential: f.NewMFACredential(true),
+			expectSave: func(credential authentication.MFACredential) bool {
+				return credential.LastUsedStep > 0 && len(credential.RecoveryCodeHashes) == 1
+			},
+			expectDelete:  true,
+			expectAttempt: authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:                   "1-2. 201: 正常系(リカバリーコードの場合、コードを消費して失敗回数をリセット)",
+			input:                  input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: f.RecoveryCode, IPAddress: f.IpAddress},
+			receiveChallenge:       f.NewMFAChallenge(),
+			receiveCredential:      f.NewMFACredential(true),
+			receiveAccountFailures: f.NewLoginFailures(2, time.Now().Add(-time.Minute)),
+			expectSave: func(credential authentication.MFACredential) bool {
+				return credential.LastUsedStep == 0 && len(credential.RecoveryCodeHashes) == 0
+			},
+			expectDelete:  true,
+			expectAttempt: authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:                  "2-1. 401: チャレンジが存在しない場合",
+			input:                 f.NewInputVerifyMFAParam(),
+			receiveChallengeError: gorm.ErrRecordNotFound,
+			expect:                common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:              "2-2. 401: チャレンジが期限切れの場合",
+			input:             f.NewInputVerifyMFAParam(),
+			receiveChallenge:  expired,
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth),
+			expectDelete:      true,
+		},
+		{
+			name:              "2-3. 401: コードが誤っている場合、失敗を記録",
+			input:             input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "abcdef", IPAddress: f.IpAddress},
+			receiveChallenge:  f.NewMFAChallenge(),
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+			expectIncrement:   true,
+			expectAttempt:     authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:              "2-4. 401: コードの誤りが上限に達した場合、チャレンジを削除",
+			input:             input.VerifyMFAParam{ChallengeToken: f.MFAChallengeToken, Code: "abcdef", IPAddress: f.IpAddress},
+			receiveChallenge:  exhausted,
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+			expectDelete:      true,
+			expectAttempt:     authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:              "2-5. 401: チャレンジ発行後にMFAが無効化された場合",
+			input:             f.NewInputVerifyMFAParam(),
+			receiveChallenge:  f.NewMFAChallenge(),
+			receiveCredential: f.NewMFACredential(false),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFAChallenge, nil, common.HTTPErrorSourceAuth),
+			expectDelete:      true,
+			expectAttempt:     authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:                   "2-6. 423: アカウントロック中",
+			input:                  f.NewInputVerifyMFAParam(),
+			receiveChallenge:       f.NewMFAChallenge(),
+			receiveCredential:      f.NewMFACredential(true),
+			receiveAccountFailures: f.NewLoginFailures(5, time.Now().Add(-time.Minute)),
+			expect:                 common.NewCustomError(common.CustomErrorCode423, common.Err423AccountLocked, nil, common.HTTPErrorSourceAuth),
+			expectAttempt:          authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:                  "2-7. 500: チャレンジ取得エラー",
+			input:                 f.NewInputVerifyMFAParam(),
+			receiveChallengeError: fmt.Errorf("DB Error"),
+			expect:                fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetMFAChallenge", authentication.HashMFAChallengeToken(f.MFAChallengeToken)).Return(test.receiveChallenge, test.receiveChallengeError)
+				authRepositoryMock.On("GetMFACredential", f.Email).Return(test.receiveCredential, nil)
+				authRepositoryMock.On("SaveMFACredential", mock.Anything).Return(nil)
+				authRepositoryMock.On("DeleteMFAChallenge", test.receiveChallenge.ID).Return(nil)
+				authRepositoryMock.On("IncrementMFAChallengeAttempts", test.receiveChallenge.ID).Return(nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.Email == f.Email })).Return(test.receiveAccountFailures, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.IPAddress == f.IpAddress })).Return(authentication.LoginAttempts{}, nil)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.Email, IPAddress: f.IpAddress, Result: authentication.LoginAttemptResultPending}).Return(f.LoginAttemptID, nil)
+				authRepositoryMock.On("UpdateLoginAttemptResult", mock.Anything).Return(nil)
+				mfaUsecase := usecase.NewMFAUsecase(authRepositoryMock, f.NewMFAPolicy(), f.NewSecretCipher(), f.NewLoginThrottlePolicy())
+
+				actual, err := mfaUsecase.VerifyMFA(test.input)
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					authRepositoryMock.AssertNotCalled(t, "SaveMFACredential", mock.Anything)
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, f.Token, actual.AccessToken)
+					assert.Equal(t, f.Token, actual.RefreshToken)
+					assert.False(t, actual.MFARequired)
+					authRepositoryMock.AssertCalled(t, "SaveMFACredential", mock.MatchedBy(test.expectSave))
+				}
+				if test.expectDelete {
+					authRepositoryMock.AssertCalled(t, "DeleteMFAChallenge", test.receiveChallenge.ID)
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "DeleteMFAChallenge", mock.Anything)
+				}
+				if test.expectIncrement {
+					authRepositoryMock.AssertCalled(t, "IncrementMFAChallengeAttempts", test.receiveChallenge.ID)
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "IncrementMFAChallengeAttempts", mock.Anything)
+				}
+				if test.expectAttempt != "" {
+					authRepositoryMock.AssertCalled(t, "UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: test.expectAttempt})
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "CreateLoginAttempt", mock.Anything)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_DisableMFA
+// Summary: This is test class which confirm the operation of API DisableMFA.
+// Target: auth_mfa_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(リカバリーコードで無効化)
+// [x] 1-2. 201: 正常系(TOTPコードで無効化)
+// [x] 2-1. 400: MFAが有効でない場合
+// [x] 2-2. 401: コードが誤っている場合
+// [x] 2-3. 500: クレデンシャル削除エラー
+func TestProjectUsecase_DisableMFA(tt *testing.T) {
+
+	tests := []struct {
+		name               string
+		input              input.DisableMFAParam
+		receiveCredential  authentication.MFACredential
+		receiveGetError    error
+		receiveDeleteError error
+		expect             error
+		expectDelete       bool
+	}{
+		{
+			name:              "1-1. 201: 正常系(リカバリーコードで無効化)",
+			input:             f.NewInputDisableMFAParam(),
+			receiveCredential: f.NewMFACredential(true),
+			expectDelete:      true,
+		},
+		{
+			name:              "1-2. 201: 正常系(TOTPコードで無効化)",
+			input:             input.DisableMFAParam{Email: f.Email, Code: f.NewTOTPCode()},
+			receiveCredential: f.NewMFACredential(true),
+			expectDelete:      true,
+		},
+		{
+			name:            "2-1. 400: MFAが有効でない場合",
+			input:           f.NewInputDisableMFAParam(),
+			receiveGetError: gorm.ErrRecordNotFound,
+			expect:          common.NewCustomError(common.CustomErrorCode400, common.Err400MFANotEnrolled, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:              "2-2. 401: コードが誤っている場合",
+			input:             input.DisableMFAParam{Email: f.Email, Code: "abcdef"},
+			receiveCredential: f.NewMFACredential(true),
+			expect:            common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidMFACode, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:               "2-3. 500: クレデンシャル削除エラー",
+			input:              f.NewInputDisableMFAParam(),
+			receiveCredential:  f.NewMFACredential(true),
+			receiveDeleteError: fmt.Errorf("DB Error"),
+			expect:             fmt.Errorf("DB Error"),
+			expectDelete:       true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetMFACredential", f.Email).Return(test.receiveCredential, test.receiveGetError)
+				authRepositoryMock.On("DeleteMFACredential", f.Email).Return(test.receiveDeleteError)
+				mfaUsecase := usecase.NewMFAUsecase(authRepositoryMock, f.NewMFAPolicy(), f.NewSecretCipher(), f.NewLoginThrottlePolicy())
+
+				err := mfaUsecase.DisableMFA(test.input)
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+				} else {
+					assert.NoError(t, err)
+				}
+				if test.expectDelete {
+					authRepositoryMock.AssertCalled(t, "DeleteMFACredential", f.Email)
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "DeleteMFACredential", mock.Anything)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// IOAuthUsecase
+// Summary: This is interface which defines IOAuthUsecase
+//
+//go:generate mockery --name IOAuthUsecase --output ../test/mock --case underscore
+type IOAuthUsecase interface {
+	Token(input input.OAuthTokenParam) (output.OAuthTokenResponse, error)
+	VerifyAccessToken(input input.VerifyAccessTokenParam) (authentication.OAuthAccessToken, error)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"gorm.io/gorm"
+)
+
+// oauthUsecase
+// Summary: This is the structure which defines the usecase for the OAuth 2.0 client credentials grant.
+type oauthUsecase struct {
+	authRepository repository.AuthRepository
+	signer         authentication.OAuthTokenSigner
+}
+
+// NewOAuthUsecase
+// Summary: This is the function which creates the OAuth usecase.
+// input: a(repository.AuthRepository) auth repository
+// input: signer(authentication.OAuthTokenSigner) signer of the access tokens
+// output: (IOAuthUsecase) OAuth usecase
+func NewOAuthUsecase(a repository.AuthRepository, signer authentication.OAuthTokenSigner) IOAuthUsecase {
+	return &oauthUsecase{a, signer}
+}
+
+// Token
+// Summary: This is the function which issues the access token to the client with the client credentials grant.
+// input: input(input.OAuthTokenParam): input parameter
+// output: (output.OAuthTokenResponse) access token
+// output: (error) error object
+func (u oauthUsecase) Token(input input.OAuthTokenParam) (output.OAuthTokenResponse, error) {
+	if input.GrantType != authentication.OAuthGrantTypeClientCredentials {
+		logger.Set(nil).Warnf(common.OAuthErrUnsupportedGrantType)
+
+		return output.OAuthTokenResponse{}, common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrUnsupportedGrantType, "only client_credentials is supported")
+	}
+
+	client, err := u.authRepository.GetOAuthClient(input.ClientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(common.OAuthErrInvalidClient)
+
+			return output.OAuthTokenResponse{}, common.NewOAuthError(common.CustomErrorCode401, common.OAuthErrInvalidClient, "client authentication failed")
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.OAuthTokenResponse{}, err
+	}
+	if !client.VerifySecret(input.ClientSecret) {
+		logger.Set(nil).Warnf(common.OAuthErrInvalidClient)
+
+		return output.OAuthTokenResponse{}, common.NewOAuthError(common.CustomErrorCode401, common.OAuthErrInvalidClient, "client authentication failed")
+	}
+
+	scopes, ok := client.GrantScopes(input.Scopes())
+	if !ok {
+		logger.Set(nil).Warnf(common.OAuthErrInvalidScope)
+
+		return output.OAuthTokenResponse{}, common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrInvalidScope, "requested scope is not allowed for the client")
+	}
+
+	accessToken, err := u.signer.Sign(client.ClientID, scopes, time.Now())
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.OAuthTokenResponse{}, err
+	}
+
+	return output.OAuthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   authentication.OAuthTokenTypeBearer,
+		ExpiresIn:   int64(u.signer.AccessTokenTTL().Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// VerifyAccessToken
+// Summary: This is the function which verifies the access token and resolves the API key linked to the client.
+// The token is rejected once the client has been deleted even if it has not expired.
+// input: input(input.VerifyAccessTokenParam): input parameter
+// output: (authentication.OAuthAccessToken) verified access token
+// output: (error) error object
+func (u oauthUsecase) VerifyAccessToken(input input.VerifyAccessTokenParam) (authentication.OAuthAccessToken, error) {
+	token, err := u.signer.Verify(input.AccessToken)
+	if err != nil {
+		logger.Set(nil).Warnf(err.Error())
+
+		return authentication.OAuthAccessToken{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidToken, nil, common.HTTPErrorSourceAuth)
+	}
+
+	client, err := u.authRepository.GetOAuthClient(token.ClientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(common.Err401InvalidToken)
+
+			return authentication.OAuthAccessToken{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidToken, nil, common.HTTPErrorSourceAuth)
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return authentication.OAuthAccessToken{}, err
+	}
+	token.APIKeyID = client.APIKeyID
+
+	return token, nil
+}
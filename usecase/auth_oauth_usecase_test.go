@@ -0,0 +1,194 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// TestProjectUsecase_OAuthToken
+// Summary: This is test class which confirm the operation of API OAuthToken.
+// Target: auth_oauth_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系(スコープを要求しない場合、クライアントの全スコープを付与)
+// [x] 1-2. 200: 正常系(許可されたスコープを要求した場合)
+// [x] 2-1. 400: grant_typeがclient_credentialsでない場合
+// [x] 2-2. 400: 許可されていないスコープを要求した場合
+// [x] 2-3. 401: クライアントが存在しない場合
+// [x] 2-4. 401: クライアントシークレットが誤っている場合
+// [x] 2-5. 500: クライアント取得エラー
+// [x] 2-6. 500: 署名鍵が設定されていない場合
+func TestProjectUsecase_OAuthToken(tt *testing.T) {
+
+	tests := []struct {
+		name            string
+		modifyInput     func(i *input.OAuthTokenParam)
+		signer          authentication.OAuthTokenSigner
+		receiveGetError error
+		expect          error
+	}{
+		{
+			name:        "1-1. 200: 正常系(スコープを要求しない場合、クライアントの全スコープを付与)",
+			modifyInput: func(i *input.OAuthTokenParam) {},
+			signer:      f.NewOAuthTokenSigner(),
+		},
+		{
+			name:        "1-2. 200: 正常系(許可されたスコープを要求した場合)",
+			modifyInput: func(i *input.OAuthTokenParam) { i.Scope = authentication.OAuthScopeSystemAuth },
+			signer:      f.NewOAuthTokenSigner(),
+		},
+		{
+			name:        "2-1. 400: grant_typeがclient_credentialsでない場合",
+			modifyInput: func(i *input.OAuthTokenParam) { i.GrantType = "password" },
+			signer:      f.NewOAuthTokenSigner(),
+			expect:      common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrUnsupportedGrantType, "only client_credentials is supported"),
+		},
+		{
+			name:        "2-2. 400: 許可されていないスコープを要求した場合",
+			modifyInput: func(i *input.OAuthTokenParam) { i.Scope = "systemAuth admin" },
+			signer:      f.NewOAuthTokenSigner(),
+			expect:      common.NewOAuthError(common.CustomErrorCode400, common.OAuthErrInvalidScope, "requested scope is not allowed for the client"),
+		},
+		{
+			name:            "2-3. 401: クライアントが存在しない場合",
+			modifyInput:     func(i *input.OAuthTokenParam) {},
+			signer:          f.NewOAuthTokenSigner(),
+			receiveGetError: gorm.ErrRecordNotFound,
+			expect:          common.NewOAuthError(common.CustomErrorCode401, common.OAuthErrInvalidClient, "client authentication failed"),
+		},
+		{
+			name:        "2-4. 401: クライアントシークレットが誤っている場合",
+			modifyInput: func(i *input.OAuthTokenParam) { i.ClientSecret = "invalid_secret" },
+			signer:      f.NewOAuthTokenSigner(),
+			expect:      common.NewOAuthError(common.CustomErrorCode401, common.OAuthErrInvalidClient, "client authentication failed"),
+		},
+		{
+			name:            "2-5. 500: クライアント取得エラー",
+			modifyInput:     func(i *input.OAuthTokenParam) {},
+			signer:          f.NewOAuthTokenSigner(),
+			receiveGetError: fmt.Errorf("DB Error"),
+			expect:          fmt.Errorf("DB Error"),
+		},
+		{
+			name:        "2-6. 500: 署名鍵が設定されていない場合",
+			modifyInput: func(i *input.OAuthTokenParam) {},
+			signer:      authentication.NewOAuthTokenSigner("", "authenticator-backend", 15*time.Minute),
+			expect:      authentication.ErrOAuthSigningKeyNotConfigured,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				param := f.NewInputOAuthTokenParam()
+				test.modifyInput(&param)
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetOAuthClient", f.OAuthClientID).Return(f.NewOAuthClient(), test.receiveGetError)
+				oauthUsecase := usecase.NewOAuthUsecase(authRepositoryMock, test.signer)
+
+				actual, err := oauthUsecase.Token(param)
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, authentication.OAuthTokenTypeBearer, actual.TokenType)
+					assert.Equal(t, int64(900), actual.ExpiresIn)
+					assert.Equal(t, authentication.OAuthScopeSystemAuth, actual.Scope)
+
+					token, err := test.signer.Verify(actual.AccessToken)
+					if assert.NoError(t, err) {
+						assert.Equal(t, f.OAuthClientID, token.ClientID)
+						assert.True(t, token.HasScope(authentication.OAuthScopeSystemAuth))
+					}
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_VerifyAccessToken
+// Summary: This is test class which confirm the operation of API VerifyAccessToken.
+// Target: auth_oauth_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系(クライアントに紐づくAPIキーを返却)
+// [x] 2-1. 401: アクセストークンが不正な場合
+// [x] 2-2. 401: クライアントが削除されている場合
+// [x] 2-3. 500: クライアント取得エラー
+func TestProjectUsecase_VerifyAccessToken(tt *testing.T) {
+
+	accessToken, _ := f.NewOAuthTokenSigner().Sign(f.OAuthClientID, []string{authentication.OAuthScopeSystemAuth}, time.Now())
+
+	tests := []struct {
+		name            string
+		input           input.VerifyAccessTokenParam
+		receiveGetError error
+		expect          error
+	}{
+		{
+			name:  "1-1. 200: 正常系(クライアントに紐づくAPIキーを返却)",
+			input: input.VerifyAccessTokenParam{AccessToken: accessToken},
+		},
+		{
+			name:   "2-1. 401: アクセストークンが不正な場合",
+			input:  input.VerifyAccessTokenParam{AccessToken: "invalid_token"},
+			expect: common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidToken, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:            "2-2. 401: クライアントが削除されている場合",
+			input:           input.VerifyAccessTokenParam{AccessToken: accessToken},
+			receiveGetError: gorm.ErrRecordNotFound,
+			expect:          common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidToken, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:            "2-3. 500: クライアント取得エラー",
+			input:           input.VerifyAccessTokenParam{AccessToken: accessToken},
+			receiveGetError: fmt.Errorf("DB Error"),
+			expect:          fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("GetOAuthClient", f.OAuthClientID).Return(f.NewOAuthClient(), test.receiveGetError)
+				oauthUsecase := usecase.NewOAuthUsecase(authRepositoryMock, f.NewOAuthTokenSigner())
+
+				actual, err := oauthUsecase.VerifyAccessToken(test.input)
+				if test.expect != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expect.Error(), err.Error())
+					}
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, f.OAuthClientID, actual.ClientID)
+					assert.Equal(t, f.ApiKeyID, actual.APIKeyID)
+					assert.True(t, actual.HasScope(authentication.OAuthScopeSystemAuth))
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+)
+
+const newPasswordField = "newPassword"
+
+// passwordPolicyChecker
+// Summary: This is the structure which applies the password policy to the new password of the user.
+type passwordPolicyChecker struct {
+	authRepository repository.AuthRepository
+	policy         authentication.PasswordPolicy
+}
+
+// check
+// Summary: This is the function which validates the new password against the password policy.
+// input: email(string) email of the user
+// input: currentPassword(authentication.Password) password in use. empty when it is unknown
+// input: newPassword(authentication.Password) new password
+// output: (error) error object. CustomError with the violated rules when the password does not satisfy the policy
+func (c passwordPolicyChecker) check(email string, currentPassword authentication.Password, newPassword authentication.Password) error {
+	return c.checkField(newPasswordField, email, currentPassword, newPassword)
+}
+
+// checkField
+// Summary: This is the function which validates the password in the given request field against the password policy.
+// input: field(string) name of the request field reported in the violations
+// input: email(string) email of the user
+// input: currentPassword(authentication.Password) password in use. empty when it is unknown
+// input: newPassword(authentication.Password) new password
+// output: (error) error object. CustomError with the violated rules when the password does not satisfy the policy
+func (c passwordPolicyChecker) checkField(field string, email string, currentPassword authentication.Password, newPassword authentication.Password) error {
+	var histories authentication.PasswordHistories
+	if c.policy.HistoryCount > 0 {
+		var err error
+		histories, err = c.authRepository.ListPasswordHistories(repository.PasswordHistoriesParam{
+			Email: email,
+			Limit: c.policy.HistoryCount,
+		})
+		if err != nil {
+			logger.Set(nil).Errorf(err.Error())
+
+			return err
+		}
+	}
+
+	violations := c.policy.Validate(newPassword, authentication.PasswordPolicyParam{
+		Email:           email,
+		CurrentPassword: currentPassword,
+		Histories:       histories,
+	})
+	if len(violations) > 0 {
+		logger.Set(nil).Warnf(common.Err400PasswordPolicy)
+
+		return common.NewCustomErrorWithViolations(common.CustomErrorCode400, common.Err400PasswordPolicy, violations.ToErrorViolations(field), common.HTTPErrorSourceAuth)
+	}
+	return nil
+}
+
+// record
+// Summary: This is the function which records the new password in the password history.
+// The password has already been changed, so the failure is only logged.
+// input: email(string) email of the user
+// input: newPassword(authentication.Password) new password
+func (c passwordPolicyChecker) record(email string, newPassword authentication.Password) {
+	if c.policy.HistoryCount == 0 {
+		return
+	}
+
+	history, err := authentication.NewPasswordHistory(email, newPassword)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return
+	}
+	if err := c.authRepository.CreatePasswordHistory(repository.CreatePasswordHistoryParam{
+		History: history,
+		Keep:    c.policy.HistoryCount,
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+	}
+}
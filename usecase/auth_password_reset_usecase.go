@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"authenticator-backend/usecase/input"
+)
+
+// IPasswordResetUsecase
+// Summary: This is interface which defines IPasswordResetUsecase
+//
+//go:generate mockery --name IPasswordResetUsecase --output ../test/mock --case underscore
+type IPasswordResetUsecase interface {
+	RequestPasswordReset(ctx context.Context, input input.PasswordResetParam) error
+	ConfirmPasswordReset(ctx context.Context, input input.ConfirmPasswordResetParam) error
+}
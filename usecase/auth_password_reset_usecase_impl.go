@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+)
+
+const (
+	passwordResetMailSubject = "Password reset request"
+	passwordResetCodeParam   = "code"
+)
+
+// passwordResetUsecase
+// Summary: This is the structure which defines the usecase for the password reset.
+type passwordResetUsecase struct {
+	firebaseRepository    repository.FirebaseRepository
+	authRepository        repository.AuthRepository
+	mailer                repository.Mailer
+	resetURL              string
+	throttleLimit         int
+	throttleWindow        time.Duration
+	passwordPolicyChecker passwordPolicyChecker
+}
+
+// NewPasswordResetUsecase
+// Summary: This is the function which creates the password reset usecase.
+// input: r(repository.FirebaseRepository) firebase repository
+// input: a(repository.AuthRepository) auth repository
+// input: m(repository.Mailer) mailer
+// input: resetURL(string) URL of the page to enter the new password. the code is appended as the query parameter
+// input: throttleLimit(int) number of the requests accepted for each account within the throttle window
+// input: throttleWindow(time.Duration) period in which the requests are counted
+// input: policy(authentication.PasswordPolicy) password policy applied to the new password
+// output: (IPasswordResetUsecase) password reset usecase
+func NewPasswordResetUsecase(
+	r repository.FirebaseRepository,
+	a repository.AuthRepository,
+	m repository.Mailer,
+	resetURL string,
+	throttleLimit int,
+	throttleWindow time.Duration,
+	policy authentication.PasswordPolicy,
+) IPasswordResetUsecase {
+	return &passwordResetUsecase{r, a, m, resetURL, throttleLimit, throttleWindow, passwordPolicyChecker{a, policy}}
+}
+
+// RequestPasswordReset
+// Summary: This is the function which sends the password reset code to the operator.
+// The result does not depend on whether the account exists, so that the accounts can not be enumerated.
+// The mail is delivered in the background, so that neither the latency nor the failure of the mail server reveals the account.
+// input: ctx(context.Context): context of the request
+// input: input(input.PasswordResetParam): input parameter
+// output: (error) error object
+func (u passwordResetUsecase) RequestPasswordReset(ctx context.Context, input input.PasswordResetParam) error {
+	email := strings.ToLower(input.OperatorAccountID)
+
+	count, err := u.authRepository.CountPasswordResetRequests(repository.PasswordResetRequestsParam{
+		Email: email,
+		Since: time.Now().Add(-u.throttleWindow),
+	})
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	if count >= int64(u.throttleLimit) {
+		logger.Set(nil).Warnf(common.Err429TooManyRequests)
+
+		return common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyRequests, nil, common.HTTPErrorSourceAuth)
+	}
+	if err := u.authRepository.CreatePasswordResetRequest(email); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+
+	code, err := u.firebaseRepository.GeneratePasswordResetCode(ctx, email)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return convertIdPError(err)
+	}
+	if code == "" {
+		// when the account does not exist
+		logger.Set(nil).Warnf("password reset is requested for an unknown account")
+
+		return nil
+	}
+
+	body, err := u.passwordResetMailBody(code)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	go u.sendPasswordResetMail(email, body)
+
+	return nil
+}
+
+// sendPasswordResetMail
+// Summary: This is the function which sends the password reset mail. The error is only logged because the request has already been answered.
+// input: email(string) email of the account
+// input: body(string) mail body
+func (u passwordResetUsecase) sendPasswordResetMail(email string, body string) {
+	if err := u.mailer.Send(repository.MailParam{
+		To:      email,
+		Subject: passwordResetMailSubject,
+		Body:    body,
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+	}
+}
+
+// ConfirmPasswordReset
+// Summary: This is the function which resets the password with the code sent to the operator after verifying the password policy.
+// input: ctx(context.Context): context of the request
+// input: input(input.ConfirmPasswordResetParam): input parameter
+// output: (error) error object
+func (u passwordResetUsecase) ConfirmPasswordReset(ctx context.Context, input input.ConfirmPasswordResetParam) error {
+	email, err := u.firebaseRepository.VerifyPasswordResetCode(ctx, input.Code)
+	if err != nil {
+		return u.passwordResetCodeError(err)
+	}
+	if err := u.passwordPolicyChecker.check(email, "", input.NewPassword); err != nil {
+		return err
+	}
+
+	if err := u.firebaseRepository.ConfirmPasswordReset(ctx, input.Code, input.NewPassword); err != nil {
+		return u.passwordResetCodeError(err)
+	}
+	u.passwordPolicyChecker.record(email, input.NewPassword)
+
+	return nil
+}
+
+// passwordResetCodeError
+// Summary: This is the function which converts the error of the password reset code to the error returned to the operator.
+// input: err(error) error returned by the IdP
+// output: (error) error object
+func (u passwordResetUsecase) passwordResetCodeError(err error) error {
+	if errors.Is(err, repository.ErrPasswordResetCodeInvalid) {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode400, common.Err400InvalidResetCode, nil, common.HTTPErrorSourceAuth)
+	}
+	logger.Set(nil).Errorf(err.Error())
+
+	return convertIdPError(err)
+}
+
+// passwordResetMailBody
+// Summary: This is the function which builds the body of the password reset mail.
+// input: code(string) password reset code
+// output: (string) mail body
+// output: (error) error object
+func (u passwordResetUsecase) passwordResetMailBody(code string) (string, error) {
+	if u.resetURL == "" {
+		return fmt.Sprintf("A password reset was requested for your account.\n\n"+
+			"Enter the following code to set a new password:\n\n%s\n\n"+
+			"If you did not request a password reset, you can ignore this email.\n", code), nil
+	}
+
+	link, err := url.Parse(u.resetURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set(passwordResetCodeParam, code)
+	link.RawQuery = query.Encode()
+
+	return fmt.Sprintf("A password reset was requested for your account.\n\n"+
+		"Open the following link to set a new password:\n\n%s\n\n"+
+		"If you did not request a password reset, you can ignore this email.\n", link.String()), nil
+}
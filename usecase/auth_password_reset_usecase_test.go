@@ -0,0 +1,270 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestProjectUsecase_RequestPasswordReset
+// Summary: This is normal test class which confirm the operation of API RequestPasswordReset.
+// Target: auth_password_reset_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(リンクを送信)
+// [x] 1-2. 201: 正常系(再設定URL未設定の場合、コードを送信)
+// [x] 1-3. 201: 正常系(アカウントが存在しない場合、メールを送信しない)
+// [x] 1-4. 201: 正常系(メールアドレスを小文字に変換して記録)
+// [x] 1-5. 201: 正常系(メール送信エラーの場合もエラーを返却しない)
+func TestProjectUsecase_RequestPasswordReset(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		input        input.PasswordResetParam
+		resetURL     string
+		receiveCode  string
+		sendError    error
+		expectSend   bool
+		expectInBody string
+	}{
+		{
+			name:         "1-1. 201: 正常系(リンクを送信)",
+			input:        f.NewInputPasswordResetParam(),
+			resetURL:     "http://localhost:3000/passwordReset",
+			receiveCode:  f.PasswordResetCode,
+			expectSend:   true,
+			expectInBody: "http://localhost:3000/passwordReset?code=" + f.PasswordResetCode,
+		},
+		{
+			name:         "1-2. 201: 正常系(再設定URL未設定の場合、コードを送信)",
+			input:        f.NewInputPasswordResetParam(),
+			resetURL:     "",
+			receiveCode:  f.PasswordResetCode,
+			expectSend:   true,
+			expectInBody: "\n\n" + f.PasswordResetCode + "\n\n",
+		},
+		{
+			name:        "1-3. 201: 正常系(アカウントが存在しない場合、メールを送信しない)",
+			input:       f.NewInputPasswordResetParam(),
+			resetURL:    "http://localhost:3000/passwordReset",
+			receiveCode: "",
+			expectSend:  false,
+		},
+		{
+			name:         "1-4. 201: 正常系(メールアドレスを小文字に変換して記録)",
+			input:        input.PasswordResetParam{OperatorAccountID: strings.ToUpper(f.Email)},
+			resetURL:     "http://localhost:3000/passwordReset",
+			receiveCode:  f.PasswordResetCode,
+			expectSend:   true,
+			expectInBody: "http://localhost:3000/passwordReset?code=" + f.PasswordResetCode,
+		},
+		{
+			name:         "1-5. 201: 正常系(メール送信エラーの場合もエラーを返却しない)",
+			input:        f.NewInputPasswordResetParam(),
+			resetURL:     "http://localhost:3000/passwordReset",
+			receiveCode:  f.PasswordResetCode,
+			sendError:    fmt.Errorf("SMTP Error"),
+			expectSend:   true,
+			expectInBody: "http://localhost:3000/passwordReset?code=" + f.PasswordResetCode,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("GeneratePasswordResetCode", mock.Anything, f.Email).Return(test.receiveCode, nil)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("CountPasswordResetRequests", mock.MatchedBy(func(param repository.PasswordResetRequestsParam) bool { return param.Email == f.Email })).Return(int64(2), nil)
+				authRepositoryMock.On("CreatePasswordResetRequest", f.Email).Return(nil)
+				mailerMock := new(mocks.Mailer)
+				sent := make(chan repository.MailParam, 1)
+				mailerMock.On("Send", mock.Anything).Run(func(args mock.Arguments) { sent <- args.Get(0).(repository.MailParam) }).Return(test.sendError)
+				passwordResetUsecase := usecase.NewPasswordResetUsecase(firebaseRepositoryMock, authRepositoryMock, mailerMock, test.resetURL, 3, time.Hour, f.NewPasswordPolicy())
+
+				err := passwordResetUsecase.RequestPasswordReset(context.Background(), test.input)
+				if assert.NoError(t, err) {
+					authRepositoryMock.AssertCalled(t, "CreatePasswordResetRequest", f.Email)
+					if test.expectSend {
+						// the mail is sent in the background
+						select {
+						case param := <-sent:
+							assert.Equal(t, f.Email, param.To)
+							assert.Contains(t, param.Body, test.expectInBody)
+						case <-time.After(time.Second):
+							assert.Fail(t, "mail is not sent")
+						}
+					} else {
+						mailerMock.AssertNotCalled(t, "Send", mock.Anything)
+					}
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_RequestPasswordReset_Abnormal
+// Summary: This is abnormal test class which confirm the operation of API RequestPasswordReset.
+// Target: auth_password_reset_usecase_impl.go
+// TestPattern:
+// [x] 2-1. 429: 要求回数の上限超過
+// [x] 2-2. 500: 要求回数の取得エラー
+// [x] 2-3. 500: コード発行エラー
+func TestProjectUsecase_RequestPasswordReset_Abnormal(tt *testing.T) {
+
+	tests := []struct {
+		name         string
+		receiveCount int64
+		countError   error
+		codeError    error
+		expect       error
+	}{
+		{
+			name:         "2-1. 429: 要求回数の上限超過",
+			receiveCount: 3,
+			expect:       common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyRequests, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 500: 要求回数の取得エラー",
+			countError: fmt.Errorf("DB AccessError"),
+			expect:     fmt.Errorf("DB AccessError"),
+		},
+		{
+			name:      "2-3. 500: コード発行エラー",
+			codeError: fmt.Errorf("IdP Error"),
+			expect:    fmt.Errorf("IdP Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("GeneratePasswordResetCode", mock.Anything, mock.Anything).Return(f.PasswordResetCode, test.codeError)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("CountPasswordResetRequests", mock.Anything).Return(test.receiveCount, test.countError)
+				authRepositoryMock.On("CreatePasswordResetRequest", mock.Anything).Return(nil)
+				mailerMock := new(mocks.Mailer)
+				mailerMock.On("Send", mock.Anything).Return(nil)
+				passwordResetUsecase := usecase.NewPasswordResetUsecase(firebaseRepositoryMock, authRepositoryMock, mailerMock, "", 3, time.Hour, f.NewPasswordPolicy())
+
+				err := passwordResetUsecase.RequestPasswordReset(context.Background(), f.NewInputPasswordResetParam())
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expect.Error(), err.Error())
+				}
+				if test.receiveCount >= 3 {
+					firebaseRepositoryMock.AssertNotCalled(t, "GeneratePasswordResetCode", mock.Anything, mock.Anything)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_ConfirmPasswordReset
+// Summary: This is test class which confirm the operation of API ConfirmPasswordReset.
+// Target: auth_password_reset_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系
+// [x] 2-1. 400: コードが無効
+// [x] 2-2. 400: パスワードポリシー違反
+// [x] 2-3. 400: 再設定時にコードが無効
+// [x] 2-4. 500: コード検証エラー
+// [x] 2-5. 500: 再設定エラー
+func TestProjectUsecase_ConfirmPasswordReset(tt *testing.T) {
+
+	tests := []struct {
+		name                string
+		newPassword         authentication.Password
+		receiveVerifyError  error
+		receiveConfirmError error
+		expect              error
+		expectViolations    []string
+	}{
+		{
+			name: "1-1. 201: 正常系",
+		},
+		{
+			name:               "2-1. 400: コードが無効",
+			receiveVerifyError: repository.ErrPasswordResetCodeInvalid,
+			expect:             common.NewCustomError(common.CustomErrorCode400, common.Err400InvalidResetCode, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:             "2-2. 400: パスワードポリシー違反",
+			newPassword:      authentication.Password("testaccount_user122"),
+			expect:           common.NewCustomError(common.CustomErrorCode400, common.Err400PasswordPolicy, nil, common.HTTPErrorSourceAuth),
+			expectViolations: []string{authentication.PasswordRuleUpperCase, authentication.PasswordRuleSpecialCharacter, authentication.PasswordRuleEmailLocalPart},
+		},
+		{
+			name:                "2-3. 400: 再設定時にコードが無効",
+			receiveConfirmError: repository.ErrPasswordResetCodeInvalid,
+			expect:              common.NewCustomError(common.CustomErrorCode400, common.Err400InvalidResetCode, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:               "2-4. 500: コード検証エラー",
+			receiveVerifyError: fmt.Errorf("IdP Error"),
+			expect:             fmt.Errorf("IdP Error"),
+		},
+		{
+			name:                "2-5. 500: 再設定エラー",
+			receiveConfirmError: fmt.Errorf("IdP Error"),
+			expect:              fmt.Errorf("IdP Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				input := f.NewInputConfirmPasswordResetParam()
+				if test.newPassword != "" {
+					input.NewPassword = test.newPassword
+				}
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("VerifyPasswordResetCode", mock.Anything, input.Code).Return(f.Email, test.receiveVerifyError)
+				firebaseRepositoryMock.On("ConfirmPasswordReset", mock.Anything, input.Code, input.NewPassword).Return(test.receiveConfirmError)
+				passwordResetUsecase := usecase.NewPasswordResetUsecase(firebaseRepositoryMock, new(mocks.AuthRepository), new(mocks.Mailer), "", 3, time.Hour, f.NewPasswordPolicy())
+
+				err := passwordResetUsecase.ConfirmPasswordReset(context.Background(), input)
+				if test.expect == nil {
+					assert.NoError(t, err)
+				} else if assert.Error(t, err) {
+					assert.Equal(t, test.expect.Error(), err.Error())
+				}
+				if test.expectViolations != nil {
+					var customErr *common.CustomError
+					if assert.ErrorAs(t, err, &customErr) {
+						rules := make([]string, len(customErr.Violations))
+						for i, violation := range customErr.Violations {
+							rules[i] = violation.Rule
+						}
+						assert.Equal(t, test.expectViolations, rules)
+					}
+					firebaseRepositoryMock.AssertNotCalled(t, "ConfirmPasswordReset", mock.Anything, mock.Anything, mock.Anything)
+				}
+			},
+		)
+	}
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"authenticator-backend/usecase/input"
 	"authenticator-backend/usecase/output"
 )
@@ -10,7 +12,9 @@ import (
 //
 //go:generate mockery --name IAuthUsecase --output ../test/mock --case underscore
 type IAuthUsecase interface {
-	Login(input input.LoginParam) (output.LoginResponse, error)
-	Refresh(input input.RefreshParam) (output.RefreshResponse, error)
-	ChangePassword(input input.ChangePasswordParam) error
+	Login(ctx context.Context, input input.LoginParam) (output.LoginResponse, error)
+	Refresh(ctx context.Context, input input.RefreshParam) (output.RefreshResponse, error)
+	ChangePassword(ctx context.Context, input input.ChangePasswordParam) (output.ChangePasswordResponse, error)
+	Logout(ctx context.Context, input input.LogoutParam) error
+	UnlockAccount(ctx context.Context, input input.UnlockAccountParam) error
 }
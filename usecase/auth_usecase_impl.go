@@ -1,7 +1,11 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+
 	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 	"authenticator-backend/usecase/input"
@@ -11,34 +15,74 @@ import (
 // authUsecase
 // Summary: This is the structure which defines the usecase for the Auth.
 type authUsecase struct {
-	firebaseRepository repository.FirebaseRepository
+	firebaseRepository    repository.FirebaseRepository
+	authRepository        repository.AuthRepository
+	passwordPolicyChecker passwordPolicyChecker
+	loginThrottle         loginThrottle
+	mfaAuthenticator      mfaAuthenticator
 }
 
 // NewAuthUsecase
 // Summary: This is the function which creates the Auth usecase.
 // input: (repository.FirebaseRepository) r: firebase repository
+// input: (repository.AuthRepository) a: auth repository
+// input: (authentication.PasswordPolicy) policy: password policy applied to the new password
+// input: (authentication.LoginThrottlePolicy) throttlePolicy: policy to throttle the failed login attempts
+// input: (authentication.MFAPolicy) mfaPolicy: policy of the multi-factor authentication
+// input: (authentication.SecretCipher) cipher: cipher to encrypt the tokens kept by the MFA challenges
 // output: (IAuthUsecase) Auth usecase
-func NewAuthUsecase(r repository.FirebaseRepository) IAuthUsecase {
-	return &authUsecase{r}
+func NewAuthUsecase(r repository.FirebaseRepository, a repository.AuthRepository, policy authentication.PasswordPolicy, throttlePolicy authentication.LoginThrottlePolicy, mfaPolicy authentication.MFAPolicy, cipher authentication.SecretCipher) IAuthUsecase {
+	return &authUsecase{r, a, passwordPolicyChecker{a, policy}, loginThrottle{a, throttlePolicy}, mfaAuthenticator{a, mfaPolicy, cipher}}
 }
 
 // Login
 // Summary: This is the function which logs in the operator.
+// The attempt is rejected without calling the IdP while the account is locked or the attempts are throttled.
+// When the account has MFA enabled, the MFA challenge token is returned instead of the tokens.
+// input: ctx(context.Context): context of the request
 // input: input(input.LoginParam): input parameter
 // output: (output.LoginResponse) login response
 // output: (error) error object
-func (u authUsecase) Login(input input.LoginParam) (output.LoginResponse, error) {
-	res, err := u.firebaseRepository.SignInWithPassword(input.OperatorAccountID, input.AccountPassword)
+func (u authUsecase) Login(ctx context.Context, input input.LoginParam) (output.LoginResponse, error) {
+	attemptID, err := u.loginThrottle.check(input.OperatorAccountID, input.IPAddress)
+	if err != nil {
+		return output.LoginResponse{}, err
+	}
+
+	res, err := u.firebaseRepository.SignInWithPassword(ctx, input.OperatorAccountID, input.AccountPassword)
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
+		u.loginThrottle.cancel(attemptID)
 
-		return output.LoginResponse{}, err
+		return output.LoginResponse{}, convertIdPError(err)
 	}
 	if res.AccessToken == "" || res.RefreshToken == "" {
 		// when id/pass is invalid
 		logger.Set(nil).Warnf(common.Err401InvalidCredentials)
+		if err := u.loginThrottle.recordFailure(attemptID); err != nil {
+			return output.LoginResponse{}, err
+		}
 
-		return output.LoginResponse{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth)
+		return output.LoginResponse{}, common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth)
+	}
+
+	credential, _, err := u.mfaAuthenticator.credential(input.OperatorAccountID)
+	if err != nil {
+		u.loginThrottle.cancel(attemptID)
+
+		return output.LoginResponse{}, err
+	}
+	if credential.Enabled {
+		// the consecutive failures are reset when the second factor is verified
+		u.loginThrottle.cancel(attemptID)
+		token, err := u.mfaAuthenticator.challenge(input.OperatorAccountID, res)
+		if err != nil {
+			return output.LoginResponse{}, err
+		}
+		return output.LoginResponse{MFARequired: true, ChallengeToken: token}, nil
+	}
+	if err := u.loginThrottle.recordSuccess(attemptID); err != nil {
+		return output.LoginResponse{}, err
 	}
 
 	return output.LoginResponse{
@@ -49,29 +93,121 @@ func (u authUsecase) Login(input input.LoginParam) (output.LoginResponse, error)
 
 // Refresh
 // Summary: This is the function which refreshes the token.
+// input: ctx(context.Context): context of the request
 // input: input(input.RefreshParam): input parameter
 // output: (output.RefreshResponse) refresh response
 // output: (error) error object
-func (u authUsecase) Refresh(input input.RefreshParam) (output.RefreshResponse, error) {
-	token, err := u.firebaseRepository.RefreshToken(input.RefreshToken)
+func (u authUsecase) Refresh(ctx context.Context, input input.RefreshParam) (output.RefreshResponse, error) {
+	token, err := u.firebaseRepository.RefreshToken(ctx, input.RefreshToken)
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
-		return output.RefreshResponse{}, err
+		return output.RefreshResponse{}, convertIdPError(err)
 	}
 	if token == "" {
 		// when refresh token is invalid
 		logger.Set(nil).Warnf(common.Err401InvalidCredentials)
 
-		return output.RefreshResponse{}, common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth)
+		return output.RefreshResponse{}, common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidRefreshToken, common.HTTPErrorSourceAuth)
 	}
 	return output.RefreshResponse{AccessToken: token}, nil
 }
 
 // ChangePassword
-// Summary: This is the function which changes the password.
+// Summary: This is the function which changes the password after verifying the current password and the password policy.
+// The verification of the current password is throttled and recorded in the same way as the login, so that the password can not be guessed through this function.
+// All the existing sessions are revoked and a new token pair is issued with the new password.
+// input: ctx(context.Context): context of the request
 // input: input(input.ChangePasswordParam): input parameter
+// output: (output.ChangePasswordResponse) change password response
 // output: (error) error object
-func (u authUsecase) ChangePassword(input input.ChangePasswordParam) error {
-	return u.firebaseRepository.ChangePassword(input.UID, input.NewPassword)
+func (u authUsecase) ChangePassword(ctx context.Context, input input.ChangePasswordParam) (output.ChangePasswordResponse, error) {
+	attemptID, err := u.loginThrottle.check(input.Email, input.IPAddress)
+	if err != nil {
+		return output.ChangePasswordResponse{}, err
+	}
+
+	res, err := u.firebaseRepository.SignInWithPassword(ctx, input.Email, input.CurrentPassword)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+		u.loginThrottle.cancel(attemptID)
+
+		return output.ChangePasswordResponse{}, convertIdPError(err)
+	}
+	if res.AccessToken == "" || res.RefreshToken == "" {
+		// when the current password is invalid
+		logger.Set(nil).Warnf(common.Err401InvalidCredentials)
+		if err := u.loginThrottle.recordFailure(attemptID); err != nil {
+			return output.ChangePasswordResponse{}, err
+		}
+
+		return output.ChangePasswordResponse{}, common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth)
+	}
+	if err := u.loginThrottle.recordSuccess(attemptID); err != nil {
+		return output.ChangePasswordResponse{}, err
+	}
+	if err := u.passwordPolicyChecker.check(input.Email, authentication.Password(input.CurrentPassword), input.NewPassword); err != nil {
+		return output.ChangePasswordResponse{}, err
+	}
+
+	if err := u.firebaseRepository.ChangePassword(ctx, input.UID, input.NewPassword); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ChangePasswordResponse{}, convertIdPError(err)
+	}
+	u.passwordPolicyChecker.record(input.Email, input.NewPassword)
+	if err := u.firebaseRepository.RevokeRefreshTokens(ctx, input.UID); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ChangePasswordResponse{}, convertIdPError(err)
+	}
+
+	res, err = u.firebaseRepository.SignInWithPassword(ctx, input.Email, input.NewPassword.ToString())
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ChangePasswordResponse{}, convertIdPError(err)
+	}
+	if res.AccessToken == "" || res.RefreshToken == "" {
+		err := fmt.Errorf("failed to sign in with the new password")
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.ChangePasswordResponse{}, err
+	}
+
+	return output.ChangePasswordResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
+
+// Logout
+// Summary: This is the function which logs out the operator by revoking all the refresh tokens.
+// input: ctx(context.Context): context of the request
+// input: input(input.LogoutParam): input parameter
+// output: (error) error object
+func (u authUsecase) Logout(ctx context.Context, input input.LogoutParam) error {
+	if err := u.firebaseRepository.RevokeRefreshTokens(ctx, input.UID); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return convertIdPError(err)
+	}
+	return nil
+}
+
+// UnlockAccount
+// Summary: This is the function which unlocks the account by resetting its consecutive login failures.
+// input: ctx(context.Context): context of the request
+// input: input(input.UnlockAccountParam): input parameter
+// output: (error) error object
+func (u authUsecase) UnlockAccount(ctx context.Context, input input.UnlockAccountParam) error {
+	if _, err := u.authRepository.CreateLoginAttempt(repository.CreateLoginAttemptParam{
+		Email:  input.OperatorAccountID,
+		Result: authentication.LoginAttemptResultUnlock,
+	}); err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return err
+	}
+	return nil
 }
@@ -1,13 +1,17 @@
 package usecase_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	f "authenticator-backend/test/fixtures"
 	mocks "authenticator-backend/test/mock"
 	"authenticator-backend/usecase"
@@ -17,6 +21,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 // TestProjectUsecase_Login
@@ -24,6 +29,9 @@ import (
 // Target: auth_usecase_impl.go
 // TestPattern:
 // [x] 1-1. 200: 正常系
+// [x] 1-2. 200: 正常系(失敗が記録されている場合、成功を記録して失敗回数をリセット)
+// [x] 1-3. 200: 正常系(MFAが有効な場合、トークンの代わりにチャレンジトークンを返却し、試行を取り消し)
+// [x] 1-4. 200: 正常系(MFAが有効化前の場合、トークンを返却)
 func TestProjectUsecase_Login(tt *testing.T) {
 
 	var method = "GET"
@@ -38,16 +46,48 @@ func TestProjectUsecase_Login(tt *testing.T) {
 		RefreshToken: f.Token,
 	}
 	tests := []struct {
-		name    string
-		input   input.LoginParam
-		receive authentication.LoginResult
-		expect  output.LoginResponse
+		name                   string
+		input                  input.LoginParam
+		receiveAccountFailures authentication.LoginAttempts
+		receive                authentication.LoginResult
+		receiveCredential      authentication.MFACredential
+		receiveCredentialError error
+		expect                 output.LoginResponse
+		expectResult           authentication.LoginAttemptResult
 	}{
 		{
-			name:    "1-1. 200: 正常系",
-			input:   f.NewInputLoginParam(),
-			receive: res,
-			expect:  expected,
+			name:                   "1-1. 200: 正常系",
+			input:                  f.NewInputLoginParam(),
+			receive:                res,
+			receiveCredentialError: gorm.ErrRecordNotFound,
+			expect:                 expected,
+			expectResult:           authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:                   "1-2. 200: 正常系(失敗が記録されている場合、成功を記録して失敗回数をリセット)",
+			input:                  f.NewInputLoginParam(),
+			receiveAccountFailures: f.NewLoginFailures(2, time.Now().Add(-time.Minute)),
+			receive:                res,
+			receiveCredentialError: gorm.ErrRecordNotFound,
+			expect:                 expected,
+			expectResult:           authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:                   "1-3. 200: 正常系(MFAが有効な場合、トークンの代わりにチャレンジトークンを返却し、試行を取り消し)",
+			input:                  f.NewInputLoginParam(),
+			receiveAccountFailures: f.NewLoginFailures(2, time.Now().Add(-time.Minute)),
+			receive:                res,
+			receiveCredential:      f.NewMFACredential(true),
+			expect:                 output.LoginResponse{MFARequired: true},
+			expectResult:           authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:              "1-4. 200: 正常系(MFAが有効化前の場合、トークンを返却)",
+			input:             f.NewInputLoginParam(),
+			receive:           res,
+			receiveCredential: f.NewMFACredential(false),
+			expect:            expected,
+			expectResult:      authentication.LoginAttemptResultSuccess,
 		},
 	}
 
@@ -68,15 +108,39 @@ func TestProjectUsecase_Login(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, mock.Anything).Return(test.receive, nil)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
-
-				actual, err := authusecase.Login(test.input)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(test.receive, nil)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.OperatorAccountID, IPAddress: f.IpAddress, Result: authentication.LoginAttemptResultPending}).Return(f.LoginAttemptID, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool {
+					return param.Email == f.OperatorAccountID && param.ExcludeID == f.LoginAttemptID
+				})).Return(test.receiveAccountFailures, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool {
+					return param.IPAddress == f.IpAddress && param.ExcludeID == f.LoginAttemptID
+				})).Return(authentication.LoginAttempts{}, nil)
+				authRepositoryMock.On("UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: test.expectResult}).Return(nil)
+				authRepositoryMock.On("GetMFACredential", f.OperatorAccountID).Return(test.receiveCredential, test.receiveCredentialError)
+				authRepositoryMock.On("CreateMFAChallenge", mock.Anything).Return(nil)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, authRepositoryMock, f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
+
+				actual, err := authusecase.Login(context.Background(), test.input)
 				if assert.NoError(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
 					assert.Equal(t, test.expect.AccessToken, actual.AccessToken, f.AssertMessage)
 					assert.Equal(t, test.expect.RefreshToken, actual.RefreshToken, f.AssertMessage)
+					assert.Equal(t, test.expect.MFARequired, actual.MFARequired, f.AssertMessage)
+					if test.expect.MFARequired {
+						assert.NotEmpty(t, actual.ChallengeToken)
+						// チャレンジにはIdPのトークンを暗号化して保持する
+						authRepositoryMock.AssertCalled(t, "CreateMFAChallenge", mock.MatchedBy(func(challenge authentication.MFAChallenge) bool {
+							token, err := f.NewSecretCipher().Decrypt(challenge.AccessToken)
+							return err == nil && token == f.Token && challenge.AccessToken != f.Token &&
+								challenge.TokenHash == authentication.HashMFAChallengeToken(actual.ChallengeToken)
+						}))
+					} else {
+						authRepositoryMock.AssertNotCalled(t, "CreateMFAChallenge", mock.Anything)
+					}
+					authRepositoryMock.AssertCalled(t, "UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: test.expectResult})
 				}
 			},
 		)
@@ -90,6 +154,18 @@ func TestProjectUsecase_Login(tt *testing.T) {
 // [x] 2-1. 500: 検証処理エラー
 // [x] 2-2. 401: アクセストークン払い出し失敗
 // [x] 2-3. 401: リフレッシュトークン払い出し失敗
+// [x] 2-4. 423: アカウントロック中
+// [x] 2-5. 429: 連続失敗による待機時間中
+// [x] 2-6. 429: IPアドレスからの失敗回数超過
+// [x] 2-7. 500: 失敗履歴取得エラー
+// [x] 2-8. 500: 失敗記録エラー
+// [x] 2-9. 500: MFAクレデンシャル取得エラー
+// [x] 2-10. 500: MFAチャレンジ記録エラー
+// [x] 2-11. 403: IdPでユーザが無効化されている
+// [x] 2-12. 429: IdPで試行回数超過
+// [x] 2-13. 503: IdPのクォータ超過
+// [x] 2-14. 503: IdP利用不可
+// [x] 2-15. 500: 試行記録エラー
 func TestProjectUsecase_Login_Abnormal(tt *testing.T) {
 
 	var method = "GET"
@@ -104,29 +180,130 @@ func TestProjectUsecase_Login_Abnormal(tt *testing.T) {
 		RefreshToken: "",
 	}
 	tests := []struct {
-		name         string
-		input        input.LoginParam
-		receive      authentication.LoginResult
-		receiveError error
-		expect       error
+		name                        string
+		input                       input.LoginParam
+		receiveAccountFailures      authentication.LoginAttempts
+		receiveAccountFailuresError error
+		receiveIPFailures           authentication.LoginAttempts
+		receiveCreateError          error
+		receiveRecordError          error
+		receive                     authentication.LoginResult
+		receiveError                error
+		receiveCredentialError      error
+		receiveChallengeError       error
+		expect                      error
+		expectRetryAfter            bool
+		expectResult                authentication.LoginAttemptResult
 	}{
 		{
 			name:         "2-1. 200: 検証処理エラー",
 			input:        f.NewInputLoginParam(),
 			receiveError: fmt.Errorf("検証処理エラー"),
 			expect:       fmt.Errorf("検証処理エラー"),
+			expectResult: authentication.LoginAttemptResultCanceled,
 		},
 		{
-			name:    "2-2. 401: アクセストークン払い出し失敗",
-			input:   f.NewInputLoginParam(),
-			receive: resNoAccessToken,
-			expect:  common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth),
+			name:         "2-2. 401: アクセストークン払い出し失敗",
+			input:        f.NewInputLoginParam(),
+			receive:      resNoAccessToken,
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:         "2-3. 401: リフレッシュトークン払い出し失敗",
+			input:        f.NewInputLoginParam(),
+			receive:      resNoRefreshToken,
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:                   "2-4. 423: アカウントロック中",
+			input:                  f.NewInputLoginParam(),
+			receiveAccountFailures: f.NewLoginFailures(5, time.Now().Add(-time.Minute)),
+			expect:                 common.NewCustomError(common.CustomErrorCode423, common.Err423AccountLocked, nil, common.HTTPErrorSourceAuth),
+			expectRetryAfter:       true,
+			expectResult:           authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:                   "2-5. 429: 連続失敗による待機時間中",
+			input:                  f.NewInputLoginParam(),
+			receiveAccountFailures: f.NewLoginFailures(3, time.Now()),
+			expect:                 common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, nil, common.HTTPErrorSourceAuth),
+			expectRetryAfter:       true,
+			expectResult:           authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:              "2-6. 429: IPアドレスからの失敗回数超過",
+			input:             f.NewInputLoginParam(),
+			receiveIPFailures: f.NewLoginFailures(20, time.Now().Add(-time.Minute)),
+			expect:            common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, nil, common.HTTPErrorSourceAuth),
+			expectRetryAfter:  true,
+			expectResult:      authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:                        "2-7. 500: 失敗履歴取得エラー",
+			input:                       f.NewInputLoginParam(),
+			receiveAccountFailuresError: fmt.Errorf("DB Error"),
+			expect:                      fmt.Errorf("DB Error"),
+			expectResult:                authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:               "2-8. 500: 失敗記録エラー",
+			input:              f.NewInputLoginParam(),
+			receive:            resNoAccessToken,
+			receiveRecordError: fmt.Errorf("DB Error"),
+			expect:             fmt.Errorf("DB Error"),
+			expectResult:       authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:                   "2-9. 500: MFAクレデンシャル取得エラー",
+			input:                  f.NewInputLoginParam(),
+			receive:                authentication.LoginResult{AccessToken: f.Token, RefreshToken: f.Token},
+			receiveCredentialError: fmt.Errorf("DB Error"),
+			expect:                 fmt.Errorf("DB Error"),
+			expectResult:           authentication.LoginAttemptResultCanceled,
 		},
 		{
-			name:    "2-3. 401: リフレッシュトークン払い出し失敗",
-			input:   f.NewInputLoginParam(),
-			receive: resNoRefreshToken,
-			expect:  common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth),
+			name:                  "2-10. 500: MFAチャレンジ記録エラー",
+			input:                 f.NewInputLoginParam(),
+			receive:               authentication.LoginResult{AccessToken: f.Token, RefreshToken: f.Token},
+			receiveChallengeError: fmt.Errorf("DB Error"),
+			expect:                fmt.Errorf("DB Error"),
+			expectResult:          authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:         "2-11. 403: IdPでユーザが無効化されている",
+			input:        f.NewInputLoginParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUserDisabled, Message: "USER_DISABLED"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode403, common.Err403UserDisabled, common.ReasonUserDisabled, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:         "2-12. 429: IdPで試行回数超過",
+			input:        f.NewInputLoginParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorTooManyAttempts, Message: "TOO_MANY_ATTEMPTS_TRY_LATER"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, common.ReasonTooManyAttempts, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:         "2-13. 503: IdPのクォータ超過",
+			input:        f.NewInputLoginParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorQuotaExceeded, Message: "QUOTA_EXCEEDED"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPQuotaExceeded, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:         "2-14. 503: IdP利用不可",
+			input:        f.NewInputLoginParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: "Service Unavailable"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable, common.HTTPErrorSourceAuth),
+			expectResult: authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:               "2-15. 500: 試行記録エラー",
+			input:              f.NewInputLoginParam(),
+			receiveCreateError: fmt.Errorf("DB Error"),
+			expect:             fmt.Errorf("DB Error"),
 		},
 	}
 
@@ -147,13 +324,34 @@ func TestProjectUsecase_Login_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, mock.Anything).Return(test.receive, test.receiveError)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
-
-				_, err := authusecase.Login(test.input)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, mock.Anything, mock.Anything).Return(test.receive, test.receiveError)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.Email == f.OperatorAccountID })).Return(test.receiveAccountFailures, test.receiveAccountFailuresError)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.IPAddress == f.IpAddress })).Return(test.receiveIPFailures, nil)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.OperatorAccountID, IPAddress: f.IpAddress, Result: authentication.LoginAttemptResultPending}).Return(f.LoginAttemptID, test.receiveCreateError)
+				authRepositoryMock.On("UpdateLoginAttemptResult", mock.Anything).Return(test.receiveRecordError)
+				authRepositoryMock.On("GetMFACredential", f.OperatorAccountID).Return(f.NewMFACredential(true), test.receiveCredentialError)
+				authRepositoryMock.On("CreateMFAChallenge", mock.Anything).Return(test.receiveChallengeError)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, authRepositoryMock, f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
+
+				_, err := authusecase.Login(context.Background(), test.input)
 				if assert.Error(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					assert.Equal(t, test.expect.Error(), err.Error())
+					assertCustomErrorReason(t, test.expect, err)
+				}
+				if test.expectRetryAfter {
+					var customErr *common.CustomError
+					if assert.ErrorAs(t, err, &customErr) {
+						assert.Greater(t, customErr.RetryAfter, time.Duration(0))
+					}
+					firebaseRepositoryMock.AssertNotCalled(t, "SignInWithPassword", mock.Anything, mock.Anything, mock.Anything)
+				}
+				if test.expectResult != "" {
+					authRepositoryMock.AssertCalled(t, "UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: test.expectResult})
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "UpdateLoginAttemptResult", mock.Anything)
+					firebaseRepositoryMock.AssertNotCalled(t, "SignInWithPassword", mock.Anything, mock.Anything, mock.Anything)
 				}
 			},
 		)
@@ -206,10 +404,10 @@ func TestProjectUsecase_Refresh(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("RefreshToken", mock.Anything).Return(test.receive, nil)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
+				firebaseRepositoryMock.On("RefreshToken", mock.Anything, mock.Anything).Return(test.receive, nil)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, new(mocks.AuthRepository), f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
 
-				actual, err := authusecase.Refresh(test.input)
+				actual, err := authusecase.Refresh(context.Background(), test.input)
 				if assert.NoError(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
@@ -226,6 +424,9 @@ func TestProjectUsecase_Refresh(tt *testing.T) {
 // TestPattern:
 // [x] 2-1. 500: 検証処理エラー
 // [x] 2-2. 401: アクセストークン払い出し失敗
+// [x] 2-3. 401: IdPでトークンの有効期限切れ
+// [x] 2-4. 403: IdPでユーザが無効化されている
+// [x] 2-5. 503: IdP利用不可
 func TestProjectUsecase_Refresh_Abnormal(tt *testing.T) {
 
 	var method = "GET"
@@ -248,7 +449,25 @@ func TestProjectUsecase_Refresh_Abnormal(tt *testing.T) {
 			name:    "2-2. 401: アクセストークン払い出し失敗",
 			input:   f.NewInputRefreshParam(),
 			receive: "",
-			expect:  common.NewCustomError(common.CustomErrorCode401, common.Err401InvalidCredentials, nil, common.HTTPErrorSourceAuth),
+			expect:  common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidRefreshToken, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:         "2-3. 401: IdPでトークンの有効期限切れ",
+			input:        f.NewInputRefreshParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorTokenExpired, Message: "TOKEN_EXPIRED"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidToken, common.ReasonTokenExpired, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:         "2-4. 403: IdPでユーザが無効化されている",
+			input:        f.NewInputRefreshParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUserDisabled, Message: "USER_DISABLED"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode403, common.Err403UserDisabled, common.ReasonUserDisabled, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:         "2-5. 503: IdP利用不可",
+			input:        f.NewInputRefreshParam(),
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: "Service Unavailable"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable, common.HTTPErrorSourceAuth),
 		},
 	}
 
@@ -269,38 +488,81 @@ func TestProjectUsecase_Refresh_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("RefreshToken", mock.Anything).Return(test.receive, test.receiveError)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
+				firebaseRepositoryMock.On("RefreshToken", mock.Anything, mock.Anything).Return(test.receive, test.receiveError)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, new(mocks.AuthRepository), f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
 
-				_, err := authusecase.Refresh(test.input)
+				_, err := authusecase.Refresh(context.Background(), test.input)
 				if assert.Error(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					assert.Equal(t, test.expect.Error(), err.Error())
+					assertCustomErrorReason(t, test.expect, err)
 				}
 			},
 		)
 	}
 }
 
+// assertCustomErrorReason
+// Summary: This is the function which asserts the status code and the reason code when the CustomError is expected.
+// input: t(*testing.T) testing object
+// input: expect(error) expected error
+// input: actual(error) actual error
+func assertCustomErrorReason(t *testing.T, expect error, actual error) {
+	var expectErr *common.CustomError
+	if !errors.As(expect, &expectErr) {
+		return
+	}
+	var actualErr *common.CustomError
+	if assert.ErrorAs(t, actual, &actualErr) {
+		assert.Equal(t, expectErr.Code, actualErr.Code)
+		assert.Equal(t, expectErr.Reason, actualErr.Reason)
+	}
+}
+
 // TestProjectUsecase_ChangePassword
 // Summary: This is normal test class which confirm the operation of API Change Password.
 // Target: auth_usecase_impl.go
 // TestPattern:
-// [x] 1-1. 200: 正常系
+// [x] 1-1. 201: 正常系(新しいパスワードで払い出したトークンを返却)
+// [x] 1-2. 201: 正常系(パスワード履歴が有効な場合、新しいパスワードを履歴に記録)
+// [x] 1-3. 201: 正常系(連続失敗がある場合、成功を記録して失敗回数をリセット)
 func TestProjectUsecase_ChangePassword(tt *testing.T) {
 
-	var method = "PUT"
-	var endPoint = "/auth/v1/authInfo"
+	var method = "POST"
+	var endPoint = "/auth/change"
 
 	tests := []struct {
-		name    string
-		input   input.ChangePasswordParam
-		receive error
-		expect  error
+		name                   string
+		input                  input.ChangePasswordParam
+		historyCount           int
+		receiveAccountFailures authentication.LoginAttempts
+		expect                 output.ChangePasswordResponse
 	}{
 		{
-			name:  "1-1. 200: 正常系",
+			name:  "1-1. 201: 正常系(新しいパスワードで払い出したトークンを返却)",
 			input: f.NewInputChangePasswordParam(),
+			expect: output.ChangePasswordResponse{
+				AccessToken:  "new_access_token",
+				RefreshToken: "new_refresh_token",
+			},
+		},
+		{
+			name:         "1-2. 201: 正常系(パスワード履歴が有効な場合、新しいパスワードを履歴に記録)",
+			input:        f.NewInputChangePasswordParam(),
+			historyCount: 3,
+			expect: output.ChangePasswordResponse{
+				AccessToken:  "new_access_token",
+				RefreshToken: "new_refresh_token",
+			},
+		},
+		{
+			name:                   "1-3. 201: 正常系(連続失敗がある場合、成功を記録して失敗回数をリセット)",
+			input:                  f.NewInputChangePasswordParam(),
+			receiveAccountFailures: f.NewLoginFailures(1, time.Now().Add(-time.Hour)),
+			expect: output.ChangePasswordResponse{
+				AccessToken:  "new_access_token",
+				RefreshToken: "new_refresh_token",
+			},
 		},
 	}
 
@@ -321,11 +583,31 @@ func TestProjectUsecase_ChangePassword(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("ChangePassword", mock.Anything, mock.Anything).Return(test.receive)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, f.Email, f.AccountPassword).Return(authentication.LoginResult{AccessToken: f.Token, RefreshToken: f.Token}, nil)
+				firebaseRepositoryMock.On("ChangePassword", mock.Anything, f.UID, authentication.Password(f.AccountPasswordNew)).Return(nil)
+				firebaseRepositoryMock.On("RevokeRefreshTokens", mock.Anything, f.UID).Return(nil)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, f.Email, f.AccountPasswordNew).Return(authentication.LoginResult{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"}, nil)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.Email, IPAddress: f.IpAddress, Result: authentication.LoginAttemptResultPending}).Return(f.LoginAttemptID, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.Email == f.Email })).Return(test.receiveAccountFailures, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.IPAddress == f.IpAddress })).Return(authentication.LoginAttempts{}, nil)
+				authRepositoryMock.On("UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: authentication.LoginAttemptResultSuccess}).Return(nil)
+				if test.historyCount > 0 {
+					authRepositoryMock.On("ListPasswordHistories", repository.PasswordHistoriesParam{Email: f.Email, Limit: test.historyCount}).Return(authentication.PasswordHistories{}, nil)
+					authRepositoryMock.On("CreatePasswordHistory", mock.MatchedBy(func(param repository.CreatePasswordHistoryParam) bool {
+						return param.Keep == test.historyCount && authentication.PasswordHistories{param.History}.Contains(authentication.Password(f.AccountPasswordNew))
+					})).Return(nil)
+				}
+				policy := f.NewPasswordPolicy()
+				policy.HistoryCount = test.historyCount
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, authRepositoryMock, policy, f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
 
-				err := authusecase.ChangePassword(test.input)
-				assert.NoError(t, err)
+				actual, err := authusecase.ChangePassword(context.Background(), test.input)
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+					firebaseRepositoryMock.AssertExpectations(t)
+					authRepositoryMock.AssertExpectations(t)
+				}
 			},
 		)
 	}
@@ -335,23 +617,270 @@ func TestProjectUsecase_ChangePassword(tt *testing.T) {
 // Summary: This is abnormal test class which confirm the operation of API Change Password.
 // Target: auth_usecase_impl.go
 // TestPattern:
-// [x] 2-1. 500: 変更処理エラー
+// [x] 2-1. 401: 現在のパスワード不一致
+// [x] 2-2. 500: 現在のパスワード検証処理エラー
+// [x] 2-3. 500: 変更処理エラー
+// [x] 2-4. 500: トークン失効処理エラー
+// [x] 2-5. 500: 新しいパスワードでのトークン払い出し失敗
+// [x] 2-6. 400: パスワードポリシー違反
+// [x] 2-7. 400: 過去に使用したパスワード
+// [x] 2-8. 500: パスワード履歴取得エラー
+// [x] 2-9. 423: アカウントロック中(現在のパスワードを検証しない)
+// [x] 2-10. 429: 連続失敗による待機時間中(現在のパスワードを検証しない)
+// [x] 2-11. 500: 失敗記録エラー
+// [x] 2-12. 500: 試行記録エラー
 func TestProjectUsecase_ChangePassword_Abnormal(tt *testing.T) {
 
-	var method = "PUT"
-	var endPoint = "/auth/v1/authInfo"
+	var method = "POST"
+	var endPoint = "/auth/change"
+
+	validResult := authentication.LoginResult{AccessToken: f.Token, RefreshToken: f.Token}
+	usedHistory, _ := authentication.NewPasswordHistory(f.Email, authentication.Password(f.AccountPasswordNew))
+	tests := []struct {
+		name                  string
+		input                 input.ChangePasswordParam
+		historyCount          int
+		receiveSignIn         authentication.LoginResult
+		receiveSignInError    error
+		receiveHistories      authentication.PasswordHistories
+		receiveHistoriesError error
+		receiveChange         error
+		receiveRevoke         error
+		receiveNewSignIn      authentication.LoginResult
+		receiveFailures       authentication.LoginAttempts
+		receiveCreateError    error
+		receiveRecordError    error
+		expect                error
+		expectRetryAfter      bool
+		expectResult          authentication.LoginAttemptResult
+	}{
+		{
+			name:          "2-1. 401: 現在のパスワード不一致",
+			input:         f.NewInputChangePasswordParam(),
+			receiveSignIn: authentication.LoginResult{},
+			expect:        common.NewCustomErrorWithReason(common.CustomErrorCode401, common.Err401InvalidCredentials, common.ReasonInvalidCredentials, common.HTTPErrorSourceAuth),
+			expectResult:  authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:               "2-2. 500: 現在のパスワード検証処理エラー",
+			input:              f.NewInputChangePasswordParam(),
+			receiveSignInError: fmt.Errorf("検証処理エラー"),
+			expect:             fmt.Errorf("検証処理エラー"),
+			expectResult:       authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:          "2-3. 500: 変更処理エラー",
+			input:         f.NewInputChangePasswordParam(),
+			receiveSignIn: validResult,
+			receiveChange: fmt.Errorf("変更処理エラー"),
+			expect:        fmt.Errorf("変更処理エラー"),
+			expectResult:  authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:          "2-4. 500: トークン失効処理エラー",
+			input:         f.NewInputChangePasswordParam(),
+			receiveSignIn: validResult,
+			receiveRevoke: fmt.Errorf("失効処理エラー"),
+			expect:        fmt.Errorf("失効処理エラー"),
+			expectResult:  authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:             "2-5. 500: 新しいパスワードでのトークン払い出し失敗",
+			input:            f.NewInputChangePasswordParam(),
+			receiveSignIn:    validResult,
+			receiveNewSignIn: authentication.LoginResult{},
+			expect:           fmt.Errorf("failed to sign in with the new password"),
+			expectResult:     authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name: "2-6. 400: パスワードポリシー違反",
+			input: func() input.ChangePasswordParam {
+				param := f.NewInputChangePasswordParam()
+				param.NewPassword = authentication.Password("Password1!")
+				return param
+			}(),
+			receiveSignIn: validResult,
+			expect:        common.NewCustomError(common.CustomErrorCode400, common.Err400PasswordPolicy, nil, common.HTTPErrorSourceAuth),
+			expectResult:  authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:             "2-7. 400: 過去に使用したパスワード",
+			input:            f.NewInputChangePasswordParam(),
+			historyCount:     3,
+			receiveSignIn:    validResult,
+			receiveHistories: authentication.PasswordHistories{usedHistory},
+			expect:           common.NewCustomError(common.CustomErrorCode400, common.Err400PasswordPolicy, nil, common.HTTPErrorSourceAuth),
+			expectResult:     authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:                  "2-8. 500: パスワード履歴取得エラー",
+			input:                 f.NewInputChangePasswordParam(),
+			historyCount:          3,
+			receiveSignIn:         validResult,
+			receiveHistoriesError: fmt.Errorf("DB Error"),
+			expect:                fmt.Errorf("DB Error"),
+			expectResult:          authentication.LoginAttemptResultSuccess,
+		},
+		{
+			name:             "2-9. 423: アカウントロック中(現在のパスワードを検証しない)",
+			input:            f.NewInputChangePasswordParam(),
+			receiveSignIn:    validResult,
+			receiveFailures:  f.NewLoginFailures(5, time.Now().Add(-time.Minute)),
+			expect:           common.NewCustomError(common.CustomErrorCode423, common.Err423AccountLocked, nil, common.HTTPErrorSourceAuth),
+			expectRetryAfter: true,
+			expectResult:     authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:             "2-10. 429: 連続失敗による待機時間中(現在のパスワードを検証しない)",
+			input:            f.NewInputChangePasswordParam(),
+			receiveSignIn:    validResult,
+			receiveFailures:  f.NewLoginFailures(3, time.Now()),
+			expect:           common.NewCustomError(common.CustomErrorCode429, common.Err429TooManyLoginAttempts, nil, common.HTTPErrorSourceAuth),
+			expectRetryAfter: true,
+			expectResult:     authentication.LoginAttemptResultCanceled,
+		},
+		{
+			name:               "2-11. 500: 失敗記録エラー",
+			input:              f.NewInputChangePasswordParam(),
+			receiveSignIn:      authentication.LoginResult{},
+			receiveRecordError: fmt.Errorf("DB Error"),
+			expect:             fmt.Errorf("DB Error"),
+			expectResult:       authentication.LoginAttemptResultFailure,
+		},
+		{
+			name:               "2-12. 500: 試行記録エラー",
+			input:              f.NewInputChangePasswordParam(),
+			receiveCreateError: fmt.Errorf("DB Error"),
+			expect:             fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				q := make(url.Values)
+
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), nil)
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				c := e.NewContext(req, rec)
+				c.SetPath(endPoint)
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, f.Email, f.AccountPassword).Return(test.receiveSignIn, test.receiveSignInError)
+				firebaseRepositoryMock.On("ChangePassword", mock.Anything, mock.Anything, mock.Anything).Return(test.receiveChange)
+				firebaseRepositoryMock.On("RevokeRefreshTokens", mock.Anything, mock.Anything).Return(test.receiveRevoke)
+				firebaseRepositoryMock.On("SignInWithPassword", mock.Anything, f.Email, f.AccountPasswordNew).Return(test.receiveNewSignIn, nil)
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.Email == f.Email })).Return(test.receiveFailures, nil)
+				authRepositoryMock.On("ListLoginFailures", mock.MatchedBy(func(param repository.LoginFailuresParam) bool { return param.IPAddress == f.IpAddress })).Return(authentication.LoginAttempts{}, nil)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.Email, IPAddress: f.IpAddress, Result: authentication.LoginAttemptResultPending}).Return(f.LoginAttemptID, test.receiveCreateError)
+				authRepositoryMock.On("UpdateLoginAttemptResult", mock.Anything).Return(test.receiveRecordError)
+				authRepositoryMock.On("ListPasswordHistories", mock.Anything).Return(test.receiveHistories, test.receiveHistoriesError)
+				authRepositoryMock.On("CreatePasswordHistory", mock.Anything).Return(nil)
+				policy := f.NewPasswordPolicy()
+				policy.HistoryCount = test.historyCount
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, authRepositoryMock, policy, f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
+
+				_, err := authusecase.ChangePassword(context.Background(), test.input)
+				if assert.Error(t, err) {
+					assert.Equal(t, test.expect.Error(), err.Error())
+				}
+				if test.expectRetryAfter {
+					var customErr *common.CustomError
+					if assert.ErrorAs(t, err, &customErr) {
+						assert.Greater(t, customErr.RetryAfter, time.Duration(0))
+					}
+					firebaseRepositoryMock.AssertNotCalled(t, "SignInWithPassword", mock.Anything, mock.Anything, mock.Anything)
+					firebaseRepositoryMock.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+				}
+				if test.expectResult != "" {
+					authRepositoryMock.AssertCalled(t, "UpdateLoginAttemptResult", repository.UpdateLoginAttemptResultParam{ID: f.LoginAttemptID, Result: test.expectResult})
+				} else {
+					authRepositoryMock.AssertNotCalled(t, "UpdateLoginAttemptResult", mock.Anything)
+					firebaseRepositoryMock.AssertNotCalled(t, "SignInWithPassword", mock.Anything, mock.Anything, mock.Anything)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_Logout
+// Summary: This is normal test class which confirm the operation of API Logout.
+// Target: auth_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系
+func TestProjectUsecase_Logout(tt *testing.T) {
+
+	var method = "POST"
+	var endPoint = "/auth/logout"
+
+	tests := []struct {
+		name    string
+		input   input.LogoutParam
+		receive error
+	}{
+		{
+			name:  "1-1. 201: 正常系",
+			input: f.NewInputLogoutParam(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				q := make(url.Values)
+
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				req := httptest.NewRequest(method, endPoint+"?"+q.Encode(), nil)
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				c := e.NewContext(req, rec)
+				c.SetPath(endPoint)
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("RevokeRefreshTokens", mock.Anything, f.UID).Return(test.receive)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, new(mocks.AuthRepository), f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
+
+				err := authusecase.Logout(context.Background(), test.input)
+				if assert.NoError(t, err) {
+					firebaseRepositoryMock.AssertExpectations(t)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_Logout_Abnormal
+// Summary: This is abnormal test class which confirm the operation of API Logout.
+// Target: auth_usecase_impl.go
+// TestPattern:
+// [x] 2-1. 500: 失効処理エラー
+func TestProjectUsecase_Logout_Abnormal(tt *testing.T) {
+
+	var method = "POST"
+	var endPoint = "/auth/logout"
 
 	tests := []struct {
 		name    string
-		input   input.ChangePasswordParam
+		input   input.LogoutParam
 		receive error
 		expect  error
 	}{
 		{
-			name:    "2-1. 500: 変更処理エラー",
-			input:   f.NewInputChangePasswordParam(),
-			receive: fmt.Errorf("変更処理エラー"),
-			expect:  fmt.Errorf("変更処理エラー"),
+			name:    "2-1. 500: 失効処理エラー",
+			input:   f.NewInputLogoutParam(),
+			receive: fmt.Errorf("失効処理エラー"),
+			expect:  fmt.Errorf("失効処理エラー"),
 		},
 	}
 
@@ -372,10 +901,10 @@ func TestProjectUsecase_ChangePassword_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				firebaseRepositoryMock.On("ChangePassword", mock.Anything, mock.Anything).Return(test.receive)
-				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock)
+				firebaseRepositoryMock.On("RevokeRefreshTokens", mock.Anything, mock.Anything).Return(test.receive)
+				authusecase := usecase.NewAuthUsecase(firebaseRepositoryMock, new(mocks.AuthRepository), f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
 
-				err := authusecase.ChangePassword(test.input)
+				err := authusecase.Logout(context.Background(), test.input)
 				if assert.Error(t, err) {
 					assert.Equal(t, test.expect.Error(), err.Error())
 				}
@@ -383,3 +912,53 @@ func TestProjectUsecase_ChangePassword_Abnormal(tt *testing.T) {
 		)
 	}
 }
+
+// TestProjectUsecase_UnlockAccount
+// Summary: This is test class which confirm the operation of API UnlockAccount.
+// Target: auth_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(ロック解除を記録)
+// [x] 2-1. 500: 記録エラー
+func TestProjectUsecase_UnlockAccount(tt *testing.T) {
+
+	tests := []struct {
+		name    string
+		input   input.UnlockAccountParam
+		receive error
+		expect  error
+	}{
+		{
+			name:  "1-1. 201: 正常系(ロック解除を記録)",
+			input: f.NewInputUnlockAccountParam(),
+		},
+		{
+			name:    "2-1. 500: 記録エラー",
+			input:   f.NewInputUnlockAccountParam(),
+			receive: fmt.Errorf("DB Error"),
+			expect:  fmt.Errorf("DB Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				authRepositoryMock := new(mocks.AuthRepository)
+				authRepositoryMock.On("CreateLoginAttempt", repository.CreateLoginAttemptParam{Email: f.Email, Result: authentication.LoginAttemptResultUnlock}).Return(f.LoginAttemptID, test.receive)
+				authusecase := usecase.NewAuthUsecase(new(mocks.FirebaseRepository), authRepositoryMock, f.NewPasswordPolicy(), f.NewLoginThrottlePolicy(), f.NewMFAPolicy(), f.NewSecretCipher())
+
+				err := authusecase.UnlockAccount(context.Background(), test.input)
+				if test.expect == nil {
+					if assert.NoError(t, err) {
+						authRepositoryMock.AssertExpectations(t)
+					}
+				} else if assert.Error(t, err) {
+					assert.Equal(t, test.expect.Error(), err.Error())
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+)
+
+// IUserUsecase
+// Summary: This is interface which defines IUserUsecase
+//
+//go:generate mockery --name IUserUsecase --output ../test/mock --case underscore
+type IUserUsecase interface {
+	CreateUser(ctx context.Context, input input.CreateUserParam) (output.UserResponse, error)
+	ListUsers(ctx context.Context, input input.ListUsersParam) (output.UsersResponse, error)
+	DisableUser(ctx context.Context, input input.UserParam) error
+	EnableUser(ctx context.Context, input input.UserParam) error
+	SetUserRole(ctx context.Context, input input.SetUserRoleParam) error
+	DeleteUser(ctx context.Context, input input.UserParam) error
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
+	"authenticator-backend/extension/logger"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"gorm.io/gorm"
+)
+
+const accountPasswordField = "accountPassword"
+
+// userUsecase
+// Summary: This is the structure which defines the usecase for the administration of the users of the identity provider.
+type userUsecase struct {
+	firebaseRepository    repository.FirebaseRepository
+	ouranosRepository     repository.OuranosRepository
+	passwordPolicyChecker passwordPolicyChecker
+}
+
+// NewUserUsecase
+// Summary: This is the function which creates the user usecase.
+// input: r(repository.FirebaseRepository) firebase repository
+// input: o(repository.OuranosRepository) ouranos repository
+// input: a(repository.AuthRepository) auth repository
+// input: policy(authentication.PasswordPolicy) password policy applied to the password of the created user
+// output: (IUserUsecase) user usecase
+func NewUserUsecase(
+	r repository.FirebaseRepository,
+	o repository.OuranosRepository,
+	a repository.AuthRepository,
+	policy authentication.PasswordPolicy,
+) IUserUsecase {
+	return &userUsecase{r, o, passwordPolicyChecker{a, policy}}
+}
+
+// CreateUser
+// Summary: This is the function which creates the user tied to the operator.
+// The user is created as a viewer when the role is not specified.
+// input: ctx(context.Context): context of the request
+// input: input(input.CreateUserParam): input parameter
+// output: (output.UserResponse) created user
+// output: (error) error object
+func (u userUsecase) CreateUser(ctx context.Context, input input.CreateUserParam) (output.UserResponse, error) {
+	if err := u.passwordPolicyChecker.checkField(accountPasswordField, input.OperatorAccountID, "", input.AccountPassword); err != nil {
+		return output.UserResponse{}, err
+	}
+
+	if _, err := u.ouranosRepository.GetOperator(input.OperatorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Set(nil).Warnf(common.Err400OperatorNotFound)
+
+			return output.UserResponse{}, common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth)
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.UserResponse{}, err
+	}
+
+	role := input.Role
+	if role == "" {
+		role = authentication.RoleViewer
+	}
+	uid, err := u.firebaseRepository.CreateUser(ctx, input.OperatorAccountID, input.AccountPassword, input.OperatorID, role)
+	if err != nil {
+		if errors.Is(err, repository.ErrIdPEmailAlreadyExists) {
+			logger.Set(nil).Warnf(err.Error())
+
+			return output.UserResponse{}, common.NewCustomError(common.CustomErrorCode409, common.Err409EmailAlreadyExists, nil, common.HTTPErrorSourceAuth)
+		}
+		logger.Set(nil).Errorf(err.Error())
+
+		return output.UserResponse{}, convertIdPError(err)
+	}
+	u.passwordPolicyChecker.record(input.OperatorAccountID, input.AccountPassword)
+
+	return output.NewUserResponse(authentication.IdPUser{
+		UID:        uid,
+		Email:      input.OperatorAccountID,
+		OperatorID: input.OperatorID,
+		Role:       role,
+	}), nil
+}
+
+// ListUsers
+// Summary: This is the function which lists the users.
+// input: ctx(context.Context): context of the request
+// input: input(input.ListUsersParam): input parameter
+// output: (output.UsersResponse) users
+// output: (error) error object
+func (u userUsecase) ListUsers(ctx context.Context, input input.ListUsersParam) (output.UsersResponse, error) {
+	users, err := u.firebaseRepository.ListUsers(ctx, input.OperatorID)
+	if err != nil {
+		logger.Set(nil).Errorf(err.Error())
+
+		return nil, convertIdPError(err)
+	}
+	return output.NewUsersResponse(users), nil
+}
+
+// DisableUser
+// Summary: This is the function which disables the user so that the user can not sign in.
+// input: ctx(context.Context): context of the request
+// input: input(input.UserParam): input parameter
+// output: (error) error object
+func (u userUsecase) DisableUser(ctx context.Context, input input.UserParam) error {
+	return u.setUserDisabled(ctx, input.UID, true)
+}
+
+// EnableUser
+// Summary: This is the function which enables the disabled user.
+// input: ctx(context.Context): context of the request
+// input: input(input.UserParam): input parameter
+// output: (error) error object
+func (u userUsecase) EnableUser(ctx context.Context, input input.UserParam) error {
+	return u.setUserDisabled(ctx, input.UID, false)
+}
+
+// SetUserRole
+// Summary: This is the function which changes the role of the user.
+// The new role takes effect on the ID tokens issued after the change.
+// input: ctx(context.Context): context of the request
+// input: input(input.SetUserRoleParam): input parameter
+// output: (error) error object
+func (u userUsecase) SetUserRole(ctx context.Context, input input.SetUserRoleParam) error {
+	if err := u.firebaseRepository.SetUserRole(ctx, input.UID, input.Role); err != nil {
+		return userError(err)
+	}
+	return nil
+}
+
+// DeleteUser
+// Summary: This is the function which deletes the user.
+// input: ctx(context.Context): context of the request
+// input: input(input.UserParam): input parameter
+// output: (error) error object
+func (u userUsecase) DeleteUser(ctx context.Context, input input.UserParam) error {
+	if err := u.firebaseRepository.DeleteUser(ctx, input.UID); err != nil {
+		return userError(err)
+	}
+	return nil
+}
+
+// setUserDisabled
+// Summary: This is the function which disables or enables the user.
+// input: ctx(context.Context): context of the request
+// input: uid(string): user ID
+// input: disabled(bool): true to disable the user, false to enable
+// output: (error) error object
+func (u userUsecase) setUserDisabled(ctx context.Context, uid string, disabled bool) error {
+	if err := u.firebaseRepository.SetUserDisabled(ctx, uid, disabled); err != nil {
+		return userError(err)
+	}
+	return nil
+}
+
+// userError
+// Summary: This is the function which converts the error of the identity provider on the existing user.
+// input: err(error): error object
+// output: (error) CustomError when the user does not exist or the identity provider fails, the given error otherwise
+func userError(err error) error {
+	if errors.Is(err, repository.ErrIdPUserNotFound) {
+		logger.Set(nil).Warnf(err.Error())
+
+		return common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth)
+	}
+	logger.Set(nil).Errorf(err.Error())
+
+	return convertIdPError(err)
+}
@@ -0,0 +1,316 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"authenticator-backend/domain/common"
+	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/model/traceability"
+	"authenticator-backend/domain/repository"
+	f "authenticator-backend/test/fixtures"
+	mocks "authenticator-backend/test/mock"
+	"authenticator-backend/usecase"
+	"authenticator-backend/usecase/input"
+	"authenticator-backend/usecase/output"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// TestProjectUsecase_CreateUser
+// Summary: This is test class which confirm the operation of API CreateUser.
+// Target: auth_user_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系：ロール未指定の場合、viewerで作成
+// [x] 1-2. 201: 正常系：ロール指定あり
+// [x] 2-1. 400: パスワードポリシー違反
+// [x] 2-2. 400: 事業者が存在しない場合
+// [x] 2-3. 409: メールアドレスが登録済みの場合
+// [x] 2-4. 500: 事業者取得エラー
+// [x] 2-5. 500: ユーザ作成エラー
+func TestProjectUsecase_CreateUser(tt *testing.T) {
+
+	tests := []struct {
+		name                string
+		input               input.CreateUserParam
+		receiveOperatorErr  error
+		receiveCreateErr    error
+		expect              output.UserResponse
+		expectErr           error
+		expectViolatedField string
+		expectCreate        bool
+	}{
+		{
+			name:  "1-1. 201: 正常系：ロール未指定の場合、viewerで作成",
+			input: f.NewInputCreateUserParam(),
+			expect: output.UserResponse{
+				UID:               f.UID,
+				OperatorAccountID: f.Email,
+				OperatorID:        f.OperatorID,
+				Role:              authentication.RoleViewer,
+			},
+			expectCreate: true,
+		},
+		{
+			name: "1-2. 201: 正常系：ロール指定あり",
+			input: input.CreateUserParam{
+				OperatorAccountID: f.Email,
+				AccountPassword:   authentication.Password(f.AccountPasswordNew),
+				OperatorID:        f.OperatorID,
+				Role:              authentication.RoleEditor,
+			},
+			expect: output.UserResponse{
+				UID:               f.UID,
+				OperatorAccountID: f.Email,
+				OperatorID:        f.OperatorID,
+				Role:              authentication.RoleEditor,
+			},
+			expectCreate: true,
+		},
+		{
+			name:                "2-1. 400: パスワードポリシー違反",
+			input:               input.CreateUserParam{OperatorAccountID: f.Email, AccountPassword: "aaa", OperatorID: f.OperatorID},
+			expectErr:           common.NewCustomError(common.CustomErrorCode400, common.Err400PasswordPolicy, nil, common.HTTPErrorSourceAuth),
+			expectViolatedField: "accountPassword",
+		},
+		{
+			name:               "2-2. 400: 事業者が存在しない場合",
+			input:              f.NewInputCreateUserParam(),
+			receiveOperatorErr: gorm.ErrRecordNotFound,
+			expectErr:          common.NewCustomError(common.CustomErrorCode400, common.Err400OperatorNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:             "2-3. 409: メールアドレスが登録済みの場合",
+			input:            f.NewInputCreateUserParam(),
+			receiveCreateErr: repository.ErrIdPEmailAlreadyExists,
+			expectErr:        common.NewCustomError(common.CustomErrorCode409, common.Err409EmailAlreadyExists, nil, common.HTTPErrorSourceAuth),
+			expectCreate:     true,
+		},
+		{
+			name:               "2-4. 500: 事業者取得エラー",
+			input:              f.NewInputCreateUserParam(),
+			receiveOperatorErr: fmt.Errorf("DB Error"),
+			expectErr:          fmt.Errorf("DB Error"),
+		},
+		{
+			name:             "2-5. 500: ユーザ作成エラー",
+			input:            f.NewInputCreateUserParam(),
+			receiveCreateErr: fmt.Errorf("IdP Error"),
+			expectErr:        fmt.Errorf("IdP Error"),
+			expectCreate:     true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				ouranosRepositoryMock := new(mocks.OuranosRepository)
+				authRepositoryMock := new(mocks.AuthRepository)
+				ouranosRepositoryMock.On("GetOperator", f.OperatorID).Return(traceability.OperatorEntityModel{}, test.receiveOperatorErr)
+				firebaseRepositoryMock.On("CreateUser", mock.Anything, f.Email, test.input.AccountPassword, f.OperatorID, mock.Anything).Return(f.UID, test.receiveCreateErr)
+				userUsecase := usecase.NewUserUsecase(firebaseRepositoryMock, ouranosRepositoryMock, authRepositoryMock, f.NewPasswordPolicy())
+
+				actual, err := userUsecase.CreateUser(context.Background(), test.input)
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+					if test.expectViolatedField != "" {
+						var customErr *common.CustomError
+						if assert.True(t, errors.As(err, &customErr)) && assert.NotEmpty(t, customErr.Violations) {
+							assert.Equal(t, test.expectViolatedField, customErr.Violations[0].Field)
+						}
+					}
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+					firebaseRepositoryMock.AssertCalled(t, "CreateUser", mock.Anything, f.Email, test.input.AccountPassword, f.OperatorID, test.expect.Role)
+				}
+				if test.expectCreate {
+					firebaseRepositoryMock.AssertCalled(t, "CreateUser", mock.Anything, f.Email, test.input.AccountPassword, f.OperatorID, mock.Anything)
+				} else {
+					firebaseRepositoryMock.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_ListUsers
+// Summary: This is test class which confirm the operation of API ListUsers.
+// Target: auth_user_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系
+// [x] 2-1. 500: ユーザ一覧取得エラー
+func TestProjectUsecase_ListUsers(tt *testing.T) {
+
+	tests := []struct {
+		name       string
+		receive    authentication.IdPUsers
+		receiveErr error
+		expect     output.UsersResponse
+		expectErr  error
+	}{
+		{
+			name:    "1-1. 200: 正常系",
+			receive: f.NewIdPUsers(),
+			expect: output.UsersResponse{
+				{UID: f.UID, OperatorAccountID: f.Email, OperatorID: f.OperatorID, Role: authentication.RoleAdmin},
+				{UID: "uid2", OperatorAccountID: f.OperatorAccountID, OperatorID: f.OperatorID, Role: authentication.RoleViewer, Disabled: true},
+			},
+		},
+		{
+			name:       "2-1. 500: ユーザ一覧取得エラー",
+			receiveErr: fmt.Errorf("IdP Error"),
+			expectErr:  fmt.Errorf("IdP Error"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("ListUsers", mock.Anything, f.OperatorID).Return(test.receive, test.receiveErr)
+				userUsecase := usecase.NewUserUsecase(firebaseRepositoryMock, new(mocks.OuranosRepository), new(mocks.AuthRepository), f.NewPasswordPolicy())
+
+				actual, err := userUsecase.ListUsers(context.Background(), input.ListUsersParam{OperatorID: f.OperatorID})
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual)
+				}
+			},
+		)
+	}
+}
+
+// TestProjectUsecase_DisableUser
+// Summary: This is test class which confirm the operation of API DisableUser, EnableUser, SetUserRole and DeleteUser.
+// Target: auth_user_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 201: 正常系(無効化)
+// [x] 1-2. 201: 正常系(有効化)
+// [x] 1-3. 200: 正常系(削除)
+// [x] 1-4. 201: 正常系(ロール変更)
+// [x] 2-1. 404: 無効化対象のユーザが存在しない場合
+// [x] 2-2. 404: 有効化対象のユーザが存在しない場合
+// [x] 2-3. 404: 削除対象のユーザが存在しない場合
+// [x] 2-4. 500: 無効化エラー
+// [x] 2-5. 500: 削除エラー
+// [x] 2-6. 404: ロール変更対象のユーザが存在しない場合
+func TestProjectUsecase_DisableUser(tt *testing.T) {
+
+	tests := []struct {
+		name       string
+		method     string
+		receiveErr error
+		expectErr  error
+	}{
+		{
+			name:   "1-1. 201: 正常系(無効化)",
+			method: "DisableUser",
+		},
+		{
+			name:   "1-2. 201: 正常系(有効化)",
+			method: "EnableUser",
+		},
+		{
+			name:   "1-3. 200: 正常系(削除)",
+			method: "DeleteUser",
+		},
+		{
+			name:   "1-4. 201: 正常系(ロール変更)",
+			method: "SetUserRole",
+		},
+		{
+			name:       "2-1. 404: 無効化対象のユーザが存在しない場合",
+			method:     "DisableUser",
+			receiveErr: repository.ErrIdPUserNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-2. 404: 有効化対象のユーザが存在しない場合",
+			method:     "EnableUser",
+			receiveErr: repository.ErrIdPUserNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-3. 404: 削除対象のユーザが存在しない場合",
+			method:     "DeleteUser",
+			receiveErr: repository.ErrIdPUserNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+		{
+			name:       "2-4. 500: 無効化エラー",
+			method:     "DisableUser",
+			receiveErr: fmt.Errorf("IdP Error"),
+			expectErr:  fmt.Errorf("IdP Error"),
+		},
+		{
+			name:       "2-5. 500: 削除エラー",
+			method:     "DeleteUser",
+			receiveErr: fmt.Errorf("IdP Error"),
+			expectErr:  fmt.Errorf("IdP Error"),
+		},
+		{
+			name:       "2-6. 404: ロール変更対象のユーザが存在しない場合",
+			method:     "SetUserRole",
+			receiveErr: repository.ErrIdPUserNotFound,
+			expectErr:  common.NewCustomError(common.CustomErrorCode404, common.Err404UserNotFound, nil, common.HTTPErrorSourceAuth),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				firebaseRepositoryMock.On("SetUserDisabled", mock.Anything, f.UID, mock.Anything).Return(test.receiveErr)
+				firebaseRepositoryMock.On("SetUserRole", mock.Anything, f.UID, authentication.RoleEditor).Return(test.receiveErr)
+				firebaseRepositoryMock.On("DeleteUser", mock.Anything, f.UID).Return(test.receiveErr)
+				userUsecase := usecase.NewUserUsecase(firebaseRepositoryMock, new(mocks.OuranosRepository), new(mocks.AuthRepository), f.NewPasswordPolicy())
+
+				param := input.UserParam{UID: f.UID}
+				var err error
+				switch test.method {
+				case "DisableUser":
+					err = userUsecase.DisableUser(context.Background(), param)
+					firebaseRepositoryMock.AssertCalled(t, "SetUserDisabled", mock.Anything, f.UID, true)
+				case "EnableUser":
+					err = userUsecase.EnableUser(context.Background(), param)
+					firebaseRepositoryMock.AssertCalled(t, "SetUserDisabled", mock.Anything, f.UID, false)
+				case "SetUserRole":
+					err = userUsecase.SetUserRole(context.Background(), input.SetUserRoleParam{UID: f.UID, Role: authentication.RoleEditor})
+					firebaseRepositoryMock.AssertCalled(t, "SetUserRole", mock.Anything, f.UID, authentication.RoleEditor)
+				case "DeleteUser":
+					err = userUsecase.DeleteUser(context.Background(), param)
+					firebaseRepositoryMock.AssertCalled(t, "DeleteUser", mock.Anything, f.UID)
+				}
+				if test.expectErr != nil {
+					if assert.Error(t, err) {
+						assert.Equal(t, test.expectErr.Error(), err.Error())
+					}
+				} else {
+					assert.NoError(t, err)
+				}
+			},
+		)
+	}
+}
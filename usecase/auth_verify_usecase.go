@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/usecase/input"
 	"authenticator-backend/usecase/output"
@@ -11,7 +13,8 @@ import (
 //
 //go:generate mockery --name IVerifyUsecase --output ../test/mock --case underscore
 type IVerifyUsecase interface {
-	TokenIntrospection(input input.VerifyTokenParam) (output.VerifyTokenResponse, error)
-	IDToken(input input.VerifyIDTokenParam) (authentication.Claims, error)
+	TokenIntrospection(ctx context.Context, input input.VerifyTokenParam) (output.VerifyTokenResponse, error)
+	IntrospectToken(ctx context.Context, input input.IntrospectTokenParam) (output.IntrospectTokenResponse, error)
+	IDToken(ctx context.Context, input input.VerifyIDTokenParam) (authentication.Claims, error)
 	ApiKey(input input.VerifyAPIKeyParam) output.VerifyApiKeyResponse
 }
@@ -1,61 +1,112 @@
 package usecase
 
 import (
+	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
 	"authenticator-backend/domain/repository"
 	"authenticator-backend/extension/logger"
 	"authenticator-backend/usecase/input"
 	"authenticator-backend/usecase/output"
+	"context"
+	"errors"
+	"time"
 )
 
 // verifyUsecase
 // Summary: This is the structure which defines the verify usecase.
 type verifyUsecase struct {
 	firebaseRepository repository.FirebaseRepository
-	authRepository     repository.AuthRepository
+	apiKeyCache        repository.APIKeyCache
+	apiKeyPolicy       authentication.APIKeyPolicy
 }
 
 // NewVerifyUsecase
 // Summary: This is the function which creates the verify usecase.
 // input: f(repository.FirebaseRepository) firebase repository
-// input: a(repository.AuthRepository) auth repository
+// input: c(repository.APIKeyCache) in-memory index of the API keys and the CIDRs
+// input: apiKeyPolicy(authentication.APIKeyPolicy) policy of the expiry and the rotation of the API keys
 // output: (IVerifyUsecase) verify usecase
-func NewVerifyUsecase(f repository.FirebaseRepository, a repository.AuthRepository) IVerifyUsecase {
-	return &verifyUsecase{f, a}
+func NewVerifyUsecase(f repository.FirebaseRepository, c repository.APIKeyCache, apiKeyPolicy authentication.APIKeyPolicy) IVerifyUsecase {
+	return &verifyUsecase{f, c, apiKeyPolicy}
 }
 
 // TokenIntrospection
 // Summary: This is the function which verifies the token.
+// input: ctx(context.Context) context of the request
 // input: input(input.VerifyTokenParam) input parameters
 // output: (output.VerifyTokenResponse) output response
 // output: (error) error object
-func (u verifyUsecase) TokenIntrospection(input input.VerifyTokenParam) (output.VerifyTokenResponse, error) {
-	claims, err := u.firebaseRepository.VerifyIDToken(input.IDToken)
+func (u verifyUsecase) TokenIntrospection(ctx context.Context, input input.VerifyTokenParam) (output.VerifyTokenResponse, error) {
+	// revoked tokens are reported as inactive
+	claims, err := u.firebaseRepository.VerifyIDTokenAndCheckRevoked(ctx, input.IDToken)
 	if err != nil {
 		logger.Set(nil).Errorf(err.Error())
 
-		return output.VerifyTokenResponse{}, err
+		return output.VerifyTokenResponse{}, convertIdPError(err)
 	}
 	return output.VerifyTokenResponse{OperatorID: &claims.OperatorID}, nil
 }
 
+// IntrospectToken
+// Summary: This is the function which introspects the ID token in the format of RFC 7662.
+// The invalid, expired and revoked tokens are reported as inactive, and only the error which prevents the verification, such as the outage of the identity provider, is returned.
+// input: ctx(context.Context) context of the request
+// input: input(input.IntrospectTokenParam) input parameters
+// output: (output.IntrospectTokenResponse) output response
+// output: (error) error object
+func (u verifyUsecase) IntrospectToken(ctx context.Context, input input.IntrospectTokenParam) (output.IntrospectTokenResponse, error) {
+	claims, err := u.firebaseRepository.VerifyIDTokenAndCheckRevoked(ctx, input.Token)
+	if err != nil {
+		err = convertIdPError(err)
+		var customErr *common.CustomError
+		if errors.As(err, &customErr) && !customErr.IsWarn() {
+			logger.Set(nil).Errorf(err.Error())
+
+			return output.IntrospectTokenResponse{}, err
+		}
+		logger.Set(nil).Warnf(err.Error())
+
+		return output.IntrospectTokenResponse{Active: false}, nil
+	}
+	return output.IntrospectTokenResponse{
+		Active:     true,
+		TokenType:  authentication.OAuthTokenTypeBearer,
+		Sub:        claims.Subject,
+		Exp:        claims.Expires,
+		Iat:        claims.IssuedAt,
+		Iss:        claims.Issuer,
+		Aud:        claims.Audience,
+		OperatorID: claims.OperatorID,
+		AuthTime:   claims.AuthTime,
+	}, nil
+}
+
 // IDToken
 // Summary: This is the function which verifies the ID token.
+// input: ctx(context.Context) context of the request
 // input: input(input.VerifyIDTokenParam) input parameters
 // output: (authentication.Claims) claims
 // output: (error) error object
-func (u verifyUsecase) IDToken(input input.VerifyIDTokenParam) (authentication.Claims, error) {
-	claims, err := u.firebaseRepository.VerifyIDToken(input.IDToken)
+func (u verifyUsecase) IDToken(ctx context.Context, input input.VerifyIDTokenParam) (authentication.Claims, error) {
+	var claims authentication.Claims
+	var err error
+	if input.CheckRevoked {
+		claims, err = u.firebaseRepository.VerifyIDTokenAndCheckRevoked(ctx, input.IDToken)
+	} else {
+		claims, err = u.firebaseRepository.VerifyIDToken(ctx, input.IDToken)
+	}
 	if err != nil {
 		logger.Set(nil).Warnf(err.Error())
 
-		return authentication.Claims{}, err
+		return authentication.Claims{}, convertIdPError(err)
 	}
 	return claims, nil
 }
 
 // ApiKey
 // Summary: This is the function which verifies the API key.
+// The API key is valid only within its validity period, and the expiry is reported so that the caller can rotate it.
+// The IP address is checked by the CIDR rules only when the IP address restriction of the API key is enforced.
 // input: input(input.VerifyApiKeyParam) input parameters
 // output: (output.VerifyApiKeyResponse) output response
 func (u verifyUsecase) ApiKey(input input.VerifyAPIKeyParam) output.VerifyApiKeyResponse {
@@ -64,28 +115,37 @@ func (u verifyUsecase) ApiKey(input input.VerifyAPIKeyParam) output.VerifyApiKey
 		IsIPAddressValid: false,
 	}
 
-	// 1. Check the validity of the APIKEY
-	apikeys, err := u.authRepository.ListAPIKeys(repository.APIKeysParam{})
+	index, err := u.apiKeyCache.Index()
 	if err != nil {
 		logger.Set(nil).Warnf(err.Error())
 
 		return output
 	}
-	if apikeys.ContainsAPIKey(input.APIKey) {
-		output.IsAPIKeyValid = true
-	}
-
-	// 2. Check the combination of APIKEY and IP address
-	cidrs, err := u.authRepository.ListCidrs(repository.APIKeyCidrsParam{
-		APIKey: &input.APIKey,
-	})
-	if err != nil {
-		logger.Set(nil).Warnf(err.Error())
 
+	// 1. Check the validity of the APIKEY
+	apikey, ok := index.FindAPIKey(input.APIKey)
+	if !ok {
 		return output
 	}
-	if cidrs.Contains(input.IPAddress) {
+	now := time.Now()
+	output.ExpiresAt = apikey.ExpiresAt
+	output.ExpiresSoon = u.apiKeyPolicy.ExpiresSoon(apikey, now)
+	if !apikey.IsActive(now) {
+		return output
+	}
+	output.IsAPIKeyValid = true
+
+	// 2. Check the combination of APIKEY and IP address by the mode of the IP address restriction
+	switch u.apiKeyPolicy.IPRestrictionModeOf(apikey) {
+	case authentication.IPRestrictionModeOff:
+		output.IsIPAddressValid = true
+	case authentication.IPRestrictionModeReportOnly:
+		if !index.Allows(apikey.ID, input.IPAddress) {
+			logger.Set(nil).Warnf(common.Err403IPNotAuthorizedForKey)
+		}
 		output.IsIPAddressValid = true
+	default:
+		output.IsIPAddressValid = index.Allows(apikey.ID, input.IPAddress)
 	}
 
 	return output
@@ -1,19 +1,23 @@
 package usecase_test
 
 import (
+	"context"
 	"fmt"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"authenticator-backend/domain/common"
 	"authenticator-backend/domain/model/authentication"
+	"authenticator-backend/domain/repository"
 	f "authenticator-backend/test/fixtures"
 	mocks "authenticator-backend/test/mock"
 	"authenticator-backend/usecase"
 	"authenticator-backend/usecase/input"
 	"authenticator-backend/usecase/output"
 
+	"firebase.google.com/go/v4/auth"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -66,11 +70,11 @@ func TestProjectUsecase_TokenIntrospection(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything).Return(test.receive, nil)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				firebaseRepositoryMock.On("VerifyIDTokenAndCheckRevoked", mock.Anything, mock.Anything).Return(test.receive, nil)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
 
-				actual, err := verifyUsecase.TokenIntrospection(test.input)
+				actual, err := verifyUsecase.TokenIntrospection(context.Background(), test.input)
 				if assert.NoError(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
@@ -124,11 +128,11 @@ func TestProjectUsecase_TokenIntrospection_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything).Return(test.receive, test.receiveError)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				firebaseRepositoryMock.On("VerifyIDTokenAndCheckRevoked", mock.Anything, mock.Anything).Return(test.receive, test.receiveError)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
 
-				_, err := verifyUsecase.TokenIntrospection(test.input)
+				_, err := verifyUsecase.TokenIntrospection(context.Background(), test.input)
 				if assert.Error(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
@@ -139,6 +143,93 @@ func TestProjectUsecase_TokenIntrospection_Abnormal(tt *testing.T) {
 	}
 }
 
+// TestProjectUsecase_IntrospectToken
+// Summary: This is test class which confirm the operation of API IntrospectToken.
+// Target: auth_verify_usecase_impl.go
+// TestPattern:
+// [x] 1-1. 200: 正常系：有効なトークンの場合はクレームを返却
+// [x] 1-2. 200: 正常系：検証に失敗したトークンの場合はactiveのみ返却
+// [x] 1-3. 200: 正常系：有効期限切れのトークンの場合はactiveのみ返却
+// [x] 2-1. 503: IdP接続エラー
+func TestProjectUsecase_IntrospectToken(tt *testing.T) {
+
+	claims := authentication.Claims{
+		OperatorID: "e03cc699-7234-31ed-86be-cc18c92208e5",
+		Token: auth.Token{
+			AuthTime: 1700000000,
+			Issuer:   "https://securetoken.google.com/local",
+			Audience: "local",
+			Expires:  1700003600,
+			IssuedAt: 1700000100,
+			Subject:  "uid",
+		},
+	}
+	tests := []struct {
+		name         string
+		receiveError error
+		expect       output.IntrospectTokenResponse
+		expectError  error
+	}{
+		{
+			name: "1-1. 200: 正常系：有効なトークンの場合はクレームを返却",
+			expect: output.IntrospectTokenResponse{
+				Active:     true,
+				TokenType:  "Bearer",
+				Sub:        "uid",
+				Exp:        1700003600,
+				Iat:        1700000100,
+				Iss:        "https://securetoken.google.com/local",
+				Aud:        "local",
+				OperatorID: "e03cc699-7234-31ed-86be-cc18c92208e5",
+				AuthTime:   1700000000,
+			},
+		},
+		{
+			name:         "1-2. 200: 正常系：検証に失敗したトークンの場合はactiveのみ返却",
+			receiveError: fmt.Errorf("ID token has been revoked"),
+			expect:       output.IntrospectTokenResponse{Active: false},
+		},
+		{
+			name:         "1-3. 200: 正常系：有効期限切れのトークンの場合はactiveのみ返却",
+			receiveError: repository.IdPError{Kind: repository.IdPErrorTokenExpired, Message: "ID token has expired"},
+			expect:       output.IntrospectTokenResponse{Active: false},
+		},
+		{
+			name:         "2-1. 503: IdP接続エラー",
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: "circuit breaker is open"},
+			expectError:  common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable, common.HTTPErrorSourceAuth),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		tt.Run(
+			test.name,
+			func(t *testing.T) {
+				t.Parallel()
+
+				firebaseRepositoryMock := new(mocks.FirebaseRepository)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				receive := claims
+				if test.receiveError != nil {
+					receive = authentication.Claims{}
+				}
+				firebaseRepositoryMock.On("VerifyIDTokenAndCheckRevoked", mock.Anything, f.Token).Return(receive, test.receiveError)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
+
+				actual, err := verifyUsecase.IntrospectToken(context.Background(), input.IntrospectTokenParam{Token: f.Token})
+				if test.expectError != nil {
+					assert.Equal(t, test.expectError, err)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.expect, actual, f.AssertMessage)
+				}
+			},
+		)
+	}
+}
+
 // TestProjectUsecase_IDToken
 // Summary: This is normal test class which confirm the operation of API IDToken.
 // Target: auth_verify_usecase_impl.go
@@ -183,11 +274,11 @@ func TestProjectUsecase_IDToken(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything).Return(test.receive, nil)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything, mock.Anything).Return(test.receive, nil)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
 
-				actual, err := verifyUsecase.IDToken(test.input)
+				actual, err := verifyUsecase.IDToken(context.Background(), test.input)
 				if assert.NoError(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
@@ -203,6 +294,7 @@ func TestProjectUsecase_IDToken(tt *testing.T) {
 // Target: auth_verify_usecase_impl.go
 // TestPattern:
 // [x] 2-1. 500: 検証処理エラー
+// [x] 2-2. 503: IdP接続エラー
 func TestProjectUsecase_IDToken_Abnormal(tt *testing.T) {
 
 	var method = "GET"
@@ -222,6 +314,13 @@ func TestProjectUsecase_IDToken_Abnormal(tt *testing.T) {
 			receiveError: fmt.Errorf("検証処理エラー"),
 			expect:       fmt.Errorf("検証処理エラー"),
 		},
+		{
+			name:         "2-2. 503: IdP接続エラー",
+			input:        f.NewInputVerifyIDTokenParam(),
+			receive:      authentication.Claims{},
+			receiveError: repository.IdPError{Kind: repository.IdPErrorUnavailable, Message: "circuit breaker is open"},
+			expect:       common.NewCustomErrorWithReason(common.CustomErrorCode503, common.Err503OuterService, common.ReasonIdPUnavailable, common.HTTPErrorSourceAuth),
+		},
 	}
 
 	for _, test := range tests {
@@ -241,15 +340,16 @@ func TestProjectUsecase_IDToken_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything).Return(test.receive, test.receiveError)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				firebaseRepositoryMock.On("VerifyIDToken", mock.Anything, mock.Anything).Return(test.receive, test.receiveError)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
 
-				_, err := verifyUsecase.IDToken(test.input)
+				_, err := verifyUsecase.IDToken(context.Background(), test.input)
 				if assert.Error(t, err) {
 					// 実際のレスポンスと期待されるレスポンスを比較
 					// 順番が実行ごとに異なるため、順不同で中身を比較
 					assert.Equal(t, test.expect.Error(), err.Error())
+					assertCustomErrorReason(t, test.expect, err)
 				}
 			},
 		)
@@ -261,27 +361,47 @@ func TestProjectUsecase_IDToken_Abnormal(tt *testing.T) {
 // Target: auth_verify_usecase_impl.go
 // TestPattern:
 // [x] 1-1. 200: 両方OK
-// [x] 1-2. 200: APIKEYのみNG
+// [x] 1-2. 200: APIKEYがNGの場合、IPアドレスもNG
 // [x] 1-3. 200: IPアドレスのみNG
 // [x] 1-4. 200: 両方NG
+// [x] 1-5. 200: 有効期限が近い場合は有効期限とともに通知
+// [x] 1-6. 200: 有効期限切れの場合、APIKEY・IPアドレスともNG
+// [x] 1-7. 200: 有効開始日時前の場合、APIKEY・IPアドレスともNG
+// [x] 1-8. 200: IPアドレス制限が無効なAPIキーの場合、CIDRに含まれないIPアドレスもOK
+// [x] 1-9. 200: IPアドレス制限が報告のみのAPIキーの場合、CIDRに含まれないIPアドレスもOK
+// [x] 1-10. 200: 既定のIPアドレス制限が無効でも、APIキーで強制する場合はIPアドレスのみNG
 func TestProjectUsecase_ApiKey(tt *testing.T) {
 
 	var method = "GET"
 	var endPoint = "/apikey"
 
-	resKeys := authentication.APIKeys{
-		authentication.APIKey{
-			APIKey: "36cfd2a8-9f45-0766-77d3-7098c1336a32",
-		},
+	policy := authentication.APIKeyPolicy{ExpiryWarning: 7 * 24 * time.Hour}
+	resKeys := authentication.APIKeys{f.NewAPIKey(authentication.ApplicationAttributeApplication)}
+	expiresSoon := time.Now().Add(24 * time.Hour)
+	expiringKey := f.NewAPIKey(authentication.ApplicationAttributeApplication)
+	expiringKey.ExpiresAt = &expiresSoon
+	expired := time.Now().Add(-time.Hour)
+	expiredKey := f.NewAPIKey(authentication.ApplicationAttributeApplication)
+	expiredKey.ExpiresAt = &expired
+	futureKey := f.NewAPIKey(authentication.ApplicationAttributeApplication)
+	futureKey.NotBefore = time.Now().Add(time.Hour)
+	modeKey := func(mode authentication.IPRestrictionMode) authentication.APIKey {
+		apiKey := f.NewAPIKey(authentication.ApplicationAttributeApplication)
+		apiKey.IPRestrictionMode = &mode
+		return apiKey
 	}
 	resCidrs := authentication.Cidrs{
 		&authentication.Cidr{
-			Cidr: "127.0.0.1/32",
+			Cidr:     "127.0.0.1/32",
+			APIKeyID: f.ApiKeyID,
+			Action:   authentication.CidrActionAllow,
+			Priority: authentication.DefaultCidrPriority,
 		},
 	}
 	tests := []struct {
 		name         string
 		inputFunc    func() input.VerifyAPIKeyParam
+		defaultMode  authentication.IPRestrictionMode
 		receiveKeys  authentication.APIKeys
 		receiveCidrs authentication.Cidrs
 		expect       output.VerifyApiKeyResponse
@@ -299,7 +419,7 @@ func TestProjectUsecase_ApiKey(tt *testing.T) {
 			},
 		},
 		{
-			name: "1-2. 200: APIKEYのみNG",
+			name: "1-2. 200: APIKEYがNGの場合、IPアドレスもNG",
 			inputFunc: func() input.VerifyAPIKeyParam {
 				InputVerifyAPIKeyParam := f.NewInputVerifyAPIKeyParam()
 				InputVerifyAPIKeyParam.APIKey = "APIKEY2"
@@ -309,7 +429,7 @@ func TestProjectUsecase_ApiKey(tt *testing.T) {
 			receiveCidrs: resCidrs,
 			expect: output.VerifyApiKeyResponse{
 				IsAPIKeyValid:    false,
-				IsIPAddressValid: true,
+				IsIPAddressValid: false,
 			},
 		},
 		{
@@ -341,6 +461,89 @@ func TestProjectUsecase_ApiKey(tt *testing.T) {
 				IsIPAddressValid: false,
 			},
 		},
+		{
+			name: "1-5. 200: 有効期限が近い場合は有効期限とともに通知",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				return f.NewInputVerifyAPIKeyParam()
+			},
+			receiveKeys:  authentication.APIKeys{expiringKey},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    true,
+				IsIPAddressValid: true,
+				ExpiresAt:        &expiresSoon,
+				ExpiresSoon:      true,
+			},
+		},
+		{
+			name: "1-6. 200: 有効期限切れの場合、APIKEY・IPアドレスともNG",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				return f.NewInputVerifyAPIKeyParam()
+			},
+			receiveKeys:  authentication.APIKeys{expiredKey},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    false,
+				IsIPAddressValid: false,
+				ExpiresAt:        &expired,
+				ExpiresSoon:      true,
+			},
+		},
+		{
+			name: "1-7. 200: 有効開始日時前の場合、APIKEY・IPアドレスともNG",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				return f.NewInputVerifyAPIKeyParam()
+			},
+			receiveKeys:  authentication.APIKeys{futureKey},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    false,
+				IsIPAddressValid: false,
+			},
+		},
+		{
+			name: "1-8. 200: IPアドレス制限が無効なAPIキーの場合、CIDRに含まれないIPアドレスもOK",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				InputVerifyAPIKeyParam := f.NewInputVerifyAPIKeyParam()
+				InputVerifyAPIKeyParam.IPAddress = "127.0.0.2"
+				return InputVerifyAPIKeyParam
+			},
+			receiveKeys:  authentication.APIKeys{modeKey(authentication.IPRestrictionModeOff)},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    true,
+				IsIPAddressValid: true,
+			},
+		},
+		{
+			name: "1-9. 200: IPアドレス制限が報告のみのAPIキーの場合、CIDRに含まれないIPアドレスもOK",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				InputVerifyAPIKeyParam := f.NewInputVerifyAPIKeyParam()
+				InputVerifyAPIKeyParam.IPAddress = "127.0.0.2"
+				return InputVerifyAPIKeyParam
+			},
+			receiveKeys:  authentication.APIKeys{modeKey(authentication.IPRestrictionModeReportOnly)},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    true,
+				IsIPAddressValid: true,
+			},
+		},
+		{
+			name: "1-10. 200: 既定のIPアドレス制限が無効でも、APIキーで強制する場合はIPアドレスのみNG",
+			inputFunc: func() input.VerifyAPIKeyParam {
+				InputVerifyAPIKeyParam := f.NewInputVerifyAPIKeyParam()
+				InputVerifyAPIKeyParam.IPAddress = "127.0.0.2"
+				return InputVerifyAPIKeyParam
+			},
+			defaultMode:  authentication.IPRestrictionModeOff,
+			receiveKeys:  authentication.APIKeys{modeKey(authentication.IPRestrictionModeEnforce)},
+			receiveCidrs: resCidrs,
+			expect: output.VerifyApiKeyResponse{
+				IsAPIKeyValid:    true,
+				IsIPAddressValid: false,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -360,14 +563,17 @@ func TestProjectUsecase_ApiKey(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				authRepositoryMock.On("ListAPIKeys", mock.Anything).Return(test.receiveKeys, nil)
-				authRepositoryMock.On("ListCidrs", mock.Anything).Return(test.receiveCidrs, nil)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				apiKeyCacheMock.On("Index").Return(authentication.NewAPIKeyIndex(test.receiveKeys, test.receiveCidrs, nil, nil), nil)
+				policy := policy
+				policy.IPRestrictionMode = test.defaultMode
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, policy)
 
 				actual := verifyUsecase.ApiKey(test.inputFunc())
 				assert.Equal(t, test.expect.IsAPIKeyValid, actual.IsAPIKeyValid, f.AssertMessage)
 				assert.Equal(t, test.expect.IsIPAddressValid, actual.IsIPAddressValid, f.AssertMessage)
+				assert.Equal(t, test.expect.ExpiresAt, actual.ExpiresAt, f.AssertMessage)
+				assert.Equal(t, test.expect.ExpiresSoon, actual.ExpiresSoon, f.AssertMessage)
 			},
 		)
 	}
@@ -377,53 +583,29 @@ func TestProjectUsecase_ApiKey(tt *testing.T) {
 // Summary: This is abnormal test class which confirm the operation of API KEY.
 // Target: auth_verify_usecase_impl.go
 // TestPattern:
-// [x] 2-1. 500: 検証処理エラー(Key)
-// [x] 2-1. 500: 検証処理エラー(Cidrs)
+// [x] 2-1. 500: 検証処理エラー(インデックス取得)
 func TestProjectUsecase_ApiKey_Abnormal(tt *testing.T) {
 
 	var method = "GET"
 	var endPoint = "/token"
 
-	resKeys := authentication.APIKeys{
-		authentication.APIKey{
-			APIKey: "36cfd2a8-9f45-0766-77d3-7098c1336a32",
-		},
-	}
 	tests := []struct {
 		name              string
 		inputFunc         func() input.VerifyAPIKeyParam
-		receiveKeys       authentication.APIKeys
-		receiveKeysError  error
-		receiveCidrs      authentication.Cidrs
-		receiveCidrsError error
+		receiveIndexError error
 		expect            output.VerifyApiKeyResponse
 	}{
 		{
-			name: "2-1. 500: 検証処理エラー(Key)",
+			name: "2-1. 500: 検証処理エラー(インデックス取得)",
 			inputFunc: func() input.VerifyAPIKeyParam {
 				return f.NewInputVerifyAPIKeyParam()
 			},
-			receiveKeys:      authentication.APIKeys{},
-			receiveKeysError: fmt.Errorf("検証処理エラー"),
-			receiveCidrs:     authentication.Cidrs{},
+			receiveIndexError: fmt.Errorf("検証処理エラー"),
 			expect: output.VerifyApiKeyResponse{
 				IsAPIKeyValid:    false,
 				IsIPAddressValid: false,
 			},
 		},
-		{
-			name: "2-2. 500: 検証処理エラー(Cidrs)",
-			inputFunc: func() input.VerifyAPIKeyParam {
-				return f.NewInputVerifyAPIKeyParam()
-			},
-			receiveKeys:       resKeys,
-			receiveCidrs:      authentication.Cidrs{},
-			receiveCidrsError: fmt.Errorf("検証処理エラー"),
-			expect: output.VerifyApiKeyResponse{
-				IsAPIKeyValid:    true,
-				IsIPAddressValid: false,
-			},
-		},
 	}
 
 	for _, test := range tests {
@@ -443,10 +625,9 @@ func TestProjectUsecase_ApiKey_Abnormal(tt *testing.T) {
 				c.SetPath(endPoint)
 
 				firebaseRepositoryMock := new(mocks.FirebaseRepository)
-				authRepositoryMock := new(mocks.AuthRepository)
-				authRepositoryMock.On("ListAPIKeys", mock.Anything).Return(test.receiveKeys, test.receiveKeysError)
-				authRepositoryMock.On("ListCidrs", mock.Anything).Return(test.receiveCidrs, test.receiveCidrsError)
-				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, authRepositoryMock)
+				apiKeyCacheMock := new(mocks.APIKeyCache)
+				apiKeyCacheMock.On("Index").Return(authentication.APIKeyIndex{}, test.receiveIndexError)
+				verifyUsecase := usecase.NewVerifyUsecase(firebaseRepositoryMock, apiKeyCacheMock, authentication.APIKeyPolicy{})
 
 				actual := verifyUsecase.ApiKey(test.inputFunc())
 				assert.Equal(t, test.expect.IsAPIKeyValid, actual.IsAPIKeyValid, f.AssertMessage)
@@ -0,0 +1,347 @@
+package input
+
+import (
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// CreateAPIKeyParam
+// Summary: This is the structure which defines the API key creation parameter.
+// RequestAPIKeyID is the ID of the API key of the request, which is recorded as the creator.
+// The API key is valid from the creation and expires with the API key policy when NotBefore and ExpiresAt are not specified.
+// The limits of the requests and the mode of the IP address restriction which are not specified are taken from the defaults.
+type CreateAPIKeyParam struct {
+	ApplicationName      string                              `json:"applicationName"`
+	ApplicationAttribute authentication.ApplicationAttribute `json:"applicationAttribute"`
+	NotBefore            *time.Time                          `json:"notBefore"`
+	ExpiresAt            *time.Time                          `json:"expiresAt"`
+	RateLimitPerMinute   *int                                `json:"rateLimitPerMinute"`
+	RateLimitBurst       *int                                `json:"rateLimitBurst"`
+	DailyQuota           *int                                `json:"dailyQuota"`
+	IPRestrictionMode    *authentication.IPRestrictionMode   `json:"ipRestrictionMode"`
+	RequestAPIKeyID      string                              `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the API key creation parameter.
+// output: (error) error object
+func (i CreateAPIKeyParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ApplicationName,
+			validation.Required,
+			validation.RuneLength(1, 256),
+		),
+		validation.Field(
+			&i.ApplicationAttribute,
+			validation.Required,
+			validation.In(authentication.ApplicationAttributes...),
+		),
+		validation.Field(
+			&i.ExpiresAt,
+			validation.By(func(value interface{}) error {
+				expiresAt, _ := value.(*time.Time)
+				if expiresAt == nil {
+					return nil
+				}
+				notBefore := time.Now()
+				if i.NotBefore != nil {
+					notBefore = *i.NotBefore
+				}
+				if !expiresAt.After(notBefore) {
+					return validation.NewError("validation_expires_at_after_not_before", "must be after notBefore")
+				}
+				return nil
+			}),
+		),
+		validation.Field(
+			&i.RateLimitPerMinute,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.RateLimitBurst,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.DailyQuota,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.IPRestrictionMode,
+			validation.NilOrNotEmpty,
+			validation.In(authentication.IPRestrictionModes...),
+		),
+	)
+}
+
+// RateLimit
+// Summary: This is the function which returns the limits of the requests configured for the API key.
+// output: (authentication.APIKeyRateLimit) limits of the requests
+func (i CreateAPIKeyParam) RateLimit() authentication.APIKeyRateLimit {
+	return authentication.APIKeyRateLimit{
+		RequestsPerMinute: i.RateLimitPerMinute,
+		Burst:             i.RateLimitBurst,
+		DailyQuota:        i.DailyQuota,
+	}
+}
+
+// UpdateAPIKeyParam
+// Summary: This is the structure which defines the parameter to change the API key.
+// The fields which are not specified are not changed.
+type UpdateAPIKeyParam struct {
+	ID                   string                               `json:"id"`
+	ApplicationName      *string                              `json:"applicationName"`
+	ApplicationAttribute *authentication.ApplicationAttribute `json:"applicationAttribute"`
+	RateLimitPerMinute   *int                                 `json:"rateLimitPerMinute"`
+	RateLimitBurst       *int                                 `json:"rateLimitBurst"`
+	DailyQuota           *int                                 `json:"dailyQuota"`
+	IPRestrictionMode    *authentication.IPRestrictionMode    `json:"ipRestrictionMode"`
+	RequestAPIKeyID      string                               `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to change the API key.
+// output: (error) error object
+func (i UpdateAPIKeyParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.ApplicationName,
+			validation.When(i.ApplicationAttribute == nil && i.RateLimit() == (authentication.APIKeyRateLimit{}) && i.IPRestrictionMode == nil, validation.NotNil),
+			validation.NilOrNotEmpty,
+			validation.RuneLength(1, 256),
+		),
+		validation.Field(
+			&i.ApplicationAttribute,
+			validation.NilOrNotEmpty,
+			validation.In(authentication.ApplicationAttributes...),
+		),
+		validation.Field(
+			&i.RateLimitPerMinute,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.RateLimitBurst,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.DailyQuota,
+			validation.Min(0),
+		),
+		validation.Field(
+			&i.IPRestrictionMode,
+			validation.NilOrNotEmpty,
+			validation.In(authentication.IPRestrictionModes...),
+		),
+	)
+}
+
+// RateLimit
+// Summary: This is the function which returns the limits of the requests to change.
+// output: (authentication.APIKeyRateLimit) limits of the requests. the limit which is nil is not changed
+func (i UpdateAPIKeyParam) RateLimit() authentication.APIKeyRateLimit {
+	return authentication.APIKeyRateLimit{
+		RequestsPerMinute: i.RateLimitPerMinute,
+		Burst:             i.RateLimitBurst,
+		DailyQuota:        i.DailyQuota,
+	}
+}
+
+// APIKeyParam
+// Summary: This is the structure which defines the parameter to specify the API key.
+type APIKeyParam struct {
+	ID              string `json:"id"`
+	RequestAPIKeyID string `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to specify the API key.
+// output: (error) error object
+func (i APIKeyParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+	)
+}
+
+// APIKeyOperatorParam
+// Summary: This is the structure which defines the parameter to bind the operator to the API key.
+type APIKeyOperatorParam struct {
+	ID              string `json:"id"`
+	OperatorID      string `json:"operatorId"`
+	RequestAPIKeyID string `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to bind the operator to the API key.
+// output: (error) error object
+func (i APIKeyOperatorParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.OperatorID,
+			validation.Required,
+			is.UUID,
+		),
+	)
+}
+
+// APIKeyCidrParam
+// Summary: This is the structure which defines the parameter to add the CIDR to the API key.
+// The CIDR rule allows the IP addresses with DefaultCidrPriority when Action and Priority are not specified.
+type APIKeyCidrParam struct {
+	ID              string                    `json:"id"`
+	Cidr            string                    `json:"cidr"`
+	Action          authentication.CidrAction `json:"action"`
+	Priority        *int                      `json:"priority"`
+	RequestAPIKeyID string                    `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to add the CIDR to the API key.
+// output: (error) error object
+func (i APIKeyCidrParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.Cidr,
+			validation.Required,
+			validation.RuneLength(1, 43),
+			validation.By(func(value interface{}) error {
+				cidr, _ := value.(string)
+				if cidr == "" {
+					return nil
+				}
+				if _, err := authentication.NewCidr(cidr); err != nil {
+					return validation.NewError("validation_is_cidr", "must be a valid CIDR")
+				}
+				return nil
+			}),
+		),
+		validation.Field(
+			&i.Action,
+			validation.In(authentication.CidrActions...),
+		),
+		validation.Field(
+			&i.Priority,
+			validation.Min(0),
+		),
+	)
+}
+
+// Rule
+// Summary: This is the function which returns the CIDR rule to add with the default action and priority.
+// output: (authentication.CidrAction) action of the CIDR rule
+// output: (int) priority of the CIDR rule
+func (i APIKeyCidrParam) Rule() (authentication.CidrAction, int) {
+	action, priority := i.Action, authentication.DefaultCidrPriority
+	if action == "" {
+		action = authentication.CidrActionAllow
+	}
+	if i.Priority != nil {
+		priority = *i.Priority
+	}
+	return action, priority
+}
+
+// APIKeyClientCertificateParam
+// Summary: This is the structure which defines the parameter to map the client certificate to the API key.
+type APIKeyClientCertificateParam struct {
+	ID              string                               `json:"id"`
+	Type            authentication.ClientCertificateType `json:"type"`
+	Certificate     string                               `json:"certificate"`
+	RequestAPIKeyID string                               `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to map the client certificate to the API key.
+// output: (error) error object
+func (i APIKeyClientCertificateParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.Type,
+			validation.Required,
+			validation.In(authentication.ClientCertificateTypes...),
+		),
+		validation.Field(
+			&i.Certificate,
+			validation.Required,
+			validation.RuneLength(1, 1024),
+			validation.By(func(value interface{}) error {
+				certificate, _ := value.(string)
+				if certificate == "" || i.Type != authentication.ClientCertificateTypeFingerprint {
+					return nil
+				}
+				if _, err := authentication.NewClientCertificate(i.Type, certificate); err != nil {
+					return validation.NewError("validation_is_fingerprint", "must be a valid SHA-256 fingerprint")
+				}
+				return nil
+			}),
+		),
+	)
+}
+
+// APIKeyPermissionParam
+// Summary: This is the structure which defines the parameter to grant the route permission to the API key.
+// Method is the HTTP method or "*", and Path is the route path such as "/api/v1/authInfo", which ends with "*" to match the routes by the prefix.
+type APIKeyPermissionParam struct {
+	ID              string `json:"id"`
+	Method          string `json:"method"`
+	Path            string `json:"path"`
+	RequestAPIKeyID string `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to grant the route permission to the API key.
+// output: (error) error object
+func (i APIKeyPermissionParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.Method,
+			validation.Required,
+			validation.In(authentication.APIKeyPermissionMethods...),
+		),
+		validation.Field(
+			&i.Path,
+			validation.Required,
+			validation.RuneLength(1, 256),
+			validation.By(func(value interface{}) error {
+				path, _ := value.(string)
+				if path == "" || path == authentication.APIKeyPermissionWildcard || strings.HasPrefix(path, "/") {
+					return nil
+				}
+				return validation.NewError("validation_is_route_path", "must be a route path starting with a slash or *")
+			}),
+		),
+	)
+}
@@ -1,7 +1,6 @@
 package input
 
 import (
-	"regexp"
 	"strings"
 
 	"authenticator-backend/domain/model/authentication"
@@ -15,6 +14,7 @@ import (
 type LoginParam struct {
 	OperatorAccountID string `json:"operatorAccountId"`
 	AccountPassword   string `json:"accountPassword"`
+	IPAddress         string `json:"-"`
 }
 
 // Validate
@@ -81,8 +81,11 @@ func (i *RefreshParam) Mask() {
 // ChangePasswordParam
 // Summary: This is the structure which defines the change password parameter.
 type ChangePasswordParam struct {
-	UID         string
-	NewPassword authentication.Password `json:"newPassword"`
+	UID             string
+	Email           string                  `json:"-"`
+	CurrentPassword string                  `json:"currentPassword"`
+	NewPassword     authentication.Password `json:"newPassword"`
+	IPAddress       string                  `json:"-"`
 }
 
 // Validate
@@ -104,14 +107,17 @@ func (i ChangePasswordParam) validate() error {
 			&i.UID,
 			validation.Required,
 		),
+		validation.Field(
+			&i.Email,
+			validation.Required,
+		),
+		validation.Field(
+			&i.CurrentPassword,
+			validation.Required,
+		),
 		validation.Field(
 			&i.NewPassword,
 			validation.Required,
-			validation.Length(8, 20), // between 8 and 20 characters
-			validation.Match(regexp.MustCompile(`[A-Z]`)).Error("must include at least one upper case letter"),         // at least one upper case letter
-			validation.Match(regexp.MustCompile(`[a-z]`)).Error("must include at least one lower case letter"),         // at least one lower case letter
-			validation.Match(regexp.MustCompile(`[0-9]`)).Error("must include at least one digit"),                     // at least one digit
-			validation.Match(regexp.MustCompile(`[!@#\$%\^&\*]`)).Error("must include at least one special character"), // at least one special character
 		),
 	)
 }
@@ -119,5 +125,201 @@ func (i ChangePasswordParam) validate() error {
 // Mask
 // Summary: This is the function which masks the confidential information.
 func (i *ChangePasswordParam) Mask() {
+	i.CurrentPassword = strings.Repeat("*", len(i.CurrentPassword))
 	i.NewPassword = authentication.Password(strings.Repeat("*", len(i.NewPassword)))
 }
+
+// LogoutParam
+// Summary: This is the structure which defines the logout parameter.
+type LogoutParam struct {
+	UID string
+}
+
+// Validate
+// Summary: This is the function which validates the logout parameter.
+// output: (error) error object
+func (i LogoutParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.UID,
+			validation.Required,
+		),
+	)
+}
+
+// PasswordResetParam
+// Summary: This is the structure which defines the password reset request parameter.
+type PasswordResetParam struct {
+	OperatorAccountID string `json:"operatorAccountId"`
+}
+
+// Validate
+// Summary: This is the function which validates the password reset request parameter.
+// output: (error) error object
+func (i PasswordResetParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.OperatorAccountID,
+			validation.Required,
+			is.Email,
+		),
+	)
+}
+
+// ConfirmPasswordResetParam
+// Summary: This is the structure which defines the password reset confirmation parameter.
+type ConfirmPasswordResetParam struct {
+	Code        string                  `json:"code"`
+	NewPassword authentication.Password `json:"newPassword"`
+}
+
+// Validate
+// Summary: This is the function which validates the password reset confirmation parameter.
+// output: (error) error object
+func (i ConfirmPasswordResetParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.Code,
+			validation.Required,
+		),
+		validation.Field(
+			&i.NewPassword,
+			validation.Required,
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *ConfirmPasswordResetParam) Mask() {
+	i.Code = strings.Repeat("*", len(i.Code))
+	i.NewPassword = authentication.Password(strings.Repeat("*", len(i.NewPassword)))
+}
+
+// UnlockAccountParam
+// Summary: This is the structure which defines the account unlock parameter.
+type UnlockAccountParam struct {
+	OperatorAccountID string `json:"operatorAccountId"`
+}
+
+// Validate
+// Summary: This is the function which validates the account unlock parameter.
+// output: (error) error object
+func (i UnlockAccountParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.OperatorAccountID,
+			validation.Required,
+			is.Email,
+		),
+	)
+}
+
+// EnrollMFAParam
+// Summary: This is the structure which defines the MFA enrollment parameter.
+type EnrollMFAParam struct {
+	Email string `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the MFA enrollment parameter.
+// output: (error) error object
+func (i EnrollMFAParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.Email,
+			validation.Required,
+		),
+	)
+}
+
+// ActivateMFAParam
+// Summary: This is the structure which defines the MFA activation parameter.
+type ActivateMFAParam struct {
+	Email string `json:"-"`
+	Code  string `json:"code"`
+}
+
+// Validate
+// Summary: This is the function which validates the MFA activation parameter.
+// output: (error) error object
+func (i ActivateMFAParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.Email,
+			validation.Required,
+		),
+		validation.Field(
+			&i.Code,
+			validation.Required,
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *ActivateMFAParam) Mask() {
+	i.Code = strings.Repeat("*", len(i.Code))
+}
+
+// VerifyMFAParam
+// Summary: This is the structure which defines the MFA verification parameter.
+// Code is the TOTP code or one of the recovery codes.
+type VerifyMFAParam struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+	IPAddress      string `json:"-"`
+}
+
+// Validate
+// Summary: This is the function which validates the MFA verification parameter.
+// output: (error) error object
+func (i VerifyMFAParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.ChallengeToken,
+			validation.Required,
+		),
+		validation.Field(
+			&i.Code,
+			validation.Required,
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *VerifyMFAParam) Mask() {
+	i.ChallengeToken = strings.Repeat("*", len(i.ChallengeToken))
+	i.Code = strings.Repeat("*", len(i.Code))
+}
+
+// DisableMFAParam
+// Summary: This is the structure which defines the MFA disabling parameter.
+// Code is the TOTP code or one of the recovery codes.
+type DisableMFAParam struct {
+	Email string `json:"-"`
+	Code  string `json:"code"`
+}
+
+// Validate
+// Summary: This is the function which validates the MFA disabling parameter.
+// output: (error) error object
+func (i DisableMFAParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.Email,
+			validation.Required,
+		),
+		validation.Field(
+			&i.Code,
+			validation.Required,
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *DisableMFAParam) Mask() {
+	i.Code = strings.Repeat("*", len(i.Code))
+}
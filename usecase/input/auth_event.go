@@ -0,0 +1,72 @@
+package input
+
+import (
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// ListAuthEventsParam
+// Summary: This is the structure which defines the auth event list parameter.
+// From and To are RFC 3339 timestamps, and Event is the comma-separated list of the event types.
+type ListAuthEventsParam struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	OperatorID string `json:"operatorId"`
+	Event      string `json:"event"`
+	Cursor     string `json:"cursor"`
+	Limit      int    `json:"limit"`
+}
+
+// Validate
+// Summary: This is the function which validates the auth event list parameter.
+// output: (error) error object
+func (i ListAuthEventsParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.From,
+			validation.Date(time.RFC3339),
+		),
+		validation.Field(
+			&i.To,
+			validation.Date(time.RFC3339),
+		),
+		validation.Field(
+			&i.OperatorID,
+			is.UUID,
+		),
+		validation.Field(
+			&i.Cursor,
+			validation.By(func(value interface{}) error {
+				cursor, _ := value.(string)
+				if cursor == "" {
+					return nil
+				}
+				_, err := authentication.ParseAuthEventCursor(cursor)
+				return err
+			}),
+		),
+		validation.Field(
+			&i.Limit,
+			validation.Min(0),
+			validation.Max(1000),
+		),
+	)
+}
+
+// Events
+// Summary: This is the function which splits the comma-separated event parameter.
+// output: ([]string) event types
+func (i ListAuthEventsParam) Events() []string {
+	var events []string
+	for _, event := range strings.Split(i.Event, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
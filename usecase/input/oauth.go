@@ -0,0 +1,56 @@
+package input
+
+import (
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// OAuthTokenParam
+// Summary: This is the structure which defines the parameter of the OAuth 2.0 token request.
+// The client credentials are also accepted in the Authorization header with the Basic scheme.
+type OAuthTokenParam struct {
+	GrantType    string `form:"grant_type" json:"grant_type"`
+	ClientID     string `form:"client_id" json:"client_id"`
+	ClientSecret string `form:"client_secret" json:"client_secret"`
+	Scope        string `form:"scope" json:"scope"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter of the OAuth 2.0 token request.
+// output: (error) error object
+func (i OAuthTokenParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.GrantType,
+			validation.Required,
+		),
+		validation.Field(
+			&i.ClientID,
+			validation.Required,
+		),
+		validation.Field(
+			&i.ClientSecret,
+			validation.Required,
+		),
+	)
+}
+
+// Scopes
+// Summary: This is the function which splits the space-delimited scope parameter.
+// output: ([]string) requested scopes
+func (i OAuthTokenParam) Scopes() []string {
+	return strings.Fields(i.Scope)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *OAuthTokenParam) Mask() {
+	i.ClientSecret = strings.Repeat("*", len(i.ClientSecret))
+}
+
+// VerifyAccessTokenParam
+// Summary: This is the structure which defines the parameter to verify the OAuth 2.0 access token.
+type VerifyAccessTokenParam struct {
+	AccessToken string
+}
@@ -0,0 +1,111 @@
+package input
+
+import (
+	"strings"
+
+	"authenticator-backend/domain/model/authentication"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// CreateUserParam
+// Summary: This is the structure which defines the user creation parameter.
+type CreateUserParam struct {
+	OperatorAccountID string                  `json:"operatorAccountId"`
+	AccountPassword   authentication.Password `json:"accountPassword"`
+	OperatorID        string                  `json:"operatorId"`
+	Role              authentication.Role     `json:"role"`
+}
+
+// Validate
+// Summary: This is the function which validates the user creation parameter.
+// output: (error) error object
+func (i CreateUserParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.OperatorAccountID,
+			validation.Required,
+			is.Email,
+		),
+		validation.Field(
+			&i.AccountPassword,
+			validation.Required,
+		),
+		validation.Field(
+			&i.OperatorID,
+			validation.Required,
+			is.UUID,
+		),
+		validation.Field(
+			&i.Role,
+			validation.In(authentication.Roles...),
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (i *CreateUserParam) Mask() {
+	i.AccountPassword = authentication.Password(strings.Repeat("*", len(i.AccountPassword)))
+}
+
+// ListUsersParam
+// Summary: This is the structure which defines the user list parameter.
+type ListUsersParam struct {
+	OperatorID string `json:"operatorId"`
+}
+
+// Validate
+// Summary: This is the function which validates the user list parameter.
+// output: (error) error object
+func (i ListUsersParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.OperatorID,
+			is.UUID,
+		),
+	)
+}
+
+// UserParam
+// Summary: This is the structure which defines the parameter to specify the user.
+type UserParam struct {
+	UID string `json:"uid"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to specify the user.
+// output: (error) error object
+func (i UserParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.UID,
+			validation.Required,
+		),
+	)
+}
+
+// SetUserRoleParam
+// Summary: This is the structure which defines the parameter to change the role of the user.
+type SetUserRoleParam struct {
+	UID  string              `json:"uid"`
+	Role authentication.Role `json:"role"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter to change the role of the user.
+// output: (error) error object
+func (i SetUserRoleParam) Validate() error {
+	return validation.ValidateStruct(&i,
+		validation.Field(
+			&i.UID,
+			validation.Required,
+		),
+		validation.Field(
+			&i.Role,
+			validation.Required,
+			validation.In(authentication.Roles...),
+		),
+	)
+}
@@ -1,6 +1,10 @@
 package input
 
 import (
+	"strings"
+
+	"authenticator-backend/domain/model/authentication"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -22,10 +26,38 @@ func (p VerifyTokenParam) Validate() error {
 	)
 }
 
+// IntrospectTokenParam
+// Summary: This is the structure which defines the parameter of the token introspection defined in RFC 7662.
+// The parameter is accepted in the form-encoded body as well as in JSON.
+type IntrospectTokenParam struct {
+	Token         string `form:"token" json:"token"`
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint"`
+}
+
+// Validate
+// Summary: This is the function which validates the parameter of the token introspection.
+// The token_type_hint is optional and only the ID token is introspected, so the hint is not validated.
+// output: (error) error object
+func (p IntrospectTokenParam) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(
+			&p.Token,
+			validation.Required,
+		),
+	)
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (p *IntrospectTokenParam) Mask() {
+	p.Token = strings.Repeat("*", len(p.Token))
+}
+
 // VerifyIDTokenParam
 // Summary: This is the structure which defines the verify ID token parameter.
 type VerifyIDTokenParam struct {
-	IDToken string `json:"idToken"`
+	IDToken      string `json:"idToken"`
+	CheckRevoked bool   `json:"-"`
 }
 
 // VerifyAPIKeyParam
@@ -35,6 +67,12 @@ type VerifyAPIKeyParam struct {
 	APIKey    string `json:"apiKey"`
 }
 
+// Mask
+// Summary: This is the function which masks the API key except for the prefix.
+func (p *VerifyAPIKeyParam) Mask() {
+	p.APIKey = authentication.MaskAPIKey(p.APIKey)
+}
+
 // Validate
 // Summary: This is the function which validates the verify API key parameter.
 // output: (error) error object
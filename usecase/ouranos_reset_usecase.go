@@ -5,5 +5,5 @@ package usecase
 //
 //go:generate mockery --name IResetUsecase --output ../test/mock --case underscore
 type IResetUsecase interface {
-	Reset(apikey string) error
+	Reset(apiKeyID string) error
 }
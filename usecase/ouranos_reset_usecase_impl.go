@@ -28,10 +28,10 @@ func NewResetUsecase(r repository.OuranosRepository, a repository.AuthRepository
 
 // Reset
 // Summary: This is the function which resets the datastore resources.
-// input: apikey(string): apikey
+// input: apiKeyID(string): ID of the apikey
 // output: (error) error object
-func (u *resetUsecase) Reset(apikey string) error {
-	param := repository.APIKeyOperatorsParam{APIKey: &apikey}
+func (u *resetUsecase) Reset(apiKeyID string) error {
+	param := repository.APIKeyOperatorsParam{APIKeyID: &apiKeyID}
 	apikeyOperators, err := u.AuthRepository.ListAPIKeyOperators(param)
 	if err != nil {
 		logger.Set(nil).Error(err.Error())
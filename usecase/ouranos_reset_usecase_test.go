@@ -27,7 +27,7 @@ func TestProjectUsecase_Reset(tt *testing.T) {
 
 	apikeyOperator := authentication.APIKeyOperators{
 		{
-			APIKey:     "apikey",
+			APIKeyID:   f.ApiKeyID,
 			OperatorID: f.OperatorId,
 		},
 	}
@@ -122,7 +122,7 @@ func TestProjectUsecase_Reset(tt *testing.T) {
 	}{
 		{
 			name:           "1-1. 200: 正常系",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 		},
 	}
@@ -181,7 +181,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 
 	apikeyOperator := authentication.APIKeyOperators{
 		{
-			APIKey:     "apikey",
+			APIKeyID:   f.ApiKeyID,
 			OperatorID: f.OperatorId,
 		},
 	}
@@ -288,7 +288,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 	}{
 		{
 			name:           "2-1. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         dsResGetError,
 			error2:         nil,
@@ -305,7 +305,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-2. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         dsResGetError,
@@ -322,7 +322,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-3. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -339,7 +339,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-4. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -356,7 +356,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-5. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -373,7 +373,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-6. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -390,7 +390,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-7. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -407,7 +407,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-8. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -424,7 +424,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-9. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -441,7 +441,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-10. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -458,7 +458,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-11. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -475,7 +475,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-12. 500: データ取得エラー",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
@@ -492,7 +492,7 @@ func TestProjectUsecase_Reset_Abnormal(tt *testing.T) {
 		},
 		{
 			name:           "2-13. 500: データ取得エラー(CFPなし)",
-			input:          "apikey",
+			input:          f.ApiKeyID,
 			receive_apikey: apikeyOperator,
 			error1:         nil,
 			error2:         nil,
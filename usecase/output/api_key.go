@@ -0,0 +1,176 @@
+package output
+
+import (
+	"strings"
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+)
+
+// CreateAPIKeyResponse
+// Summary: This is the structure which defines the response of the API key creation.
+// The API key is returned only in this response.
+type CreateAPIKeyResponse struct {
+	ID                   string                              `json:"id"`
+	APIKey               string                              `json:"apiKey"`
+	ApplicationName      string                              `json:"applicationName"`
+	ApplicationAttribute authentication.ApplicationAttribute `json:"applicationAttribute"`
+	NotBefore            time.Time                           `json:"notBefore"`
+	ExpiresAt            *time.Time                          `json:"expiresAt"`
+	RateLimitPerMinute   *int                                `json:"rateLimitPerMinute"`
+	RateLimitBurst       *int                                `json:"rateLimitBurst"`
+	DailyQuota           *int                                `json:"dailyQuota"`
+	IPRestrictionMode    *authentication.IPRestrictionMode   `json:"ipRestrictionMode"`
+}
+
+// NewCreateAPIKeyResponse
+// Summary: This is the function which converts the created API key to the response.
+// input: apiKey(authentication.APIKey) created API key
+// input: key(string) generated key
+// output: (CreateAPIKeyResponse) API key creation response
+func NewCreateAPIKeyResponse(apiKey authentication.APIKey, key string) CreateAPIKeyResponse {
+	return CreateAPIKeyResponse{
+		ID:                   apiKey.ID,
+		APIKey:               key,
+		ApplicationName:      apiKey.ApplicationName,
+		ApplicationAttribute: apiKey.Attribute,
+		NotBefore:            apiKey.NotBefore,
+		ExpiresAt:            apiKey.ExpiresAt,
+		RateLimitPerMinute:   apiKey.RateLimit.RequestsPerMinute,
+		RateLimitBurst:       apiKey.RateLimit.Burst,
+		DailyQuota:           apiKey.RateLimit.DailyQuota,
+		IPRestrictionMode:    apiKey.IPRestrictionMode,
+	}
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *CreateAPIKeyResponse) Mask() {
+	o.APIKey = strings.Repeat("*", len(o.APIKey))
+}
+
+// RotateAPIKeyResponse
+// Summary: This is the structure which defines the response of the API key rotation.
+// The rotated API key is valid together with the successor until PreviousExpiresAt.
+type RotateAPIKeyResponse struct {
+	CreateAPIKeyResponse
+	PreviousID        string    `json:"previousId"`
+	PreviousExpiresAt time.Time `json:"previousExpiresAt"`
+}
+
+// SigningSecretResponse
+// Summary: This is the structure which defines the response of the signing secret generation.
+// The signing secret is returned only in this response.
+type SigningSecretResponse struct {
+	ID            string `json:"id"`
+	SigningSecret string `json:"signingSecret"`
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *SigningSecretResponse) Mask() {
+	o.SigningSecret = strings.Repeat("*", len(o.SigningSecret))
+}
+
+// APIKeyResponse
+// Summary: This is the structure which defines the API key response.
+type APIKeyResponse struct {
+	ID                   string                              `json:"id"`
+	KeyPrefix            string                              `json:"keyPrefix"`
+	ApplicationName      string                              `json:"applicationName"`
+	ApplicationAttribute authentication.ApplicationAttribute `json:"applicationAttribute"`
+	NotBefore            time.Time                           `json:"notBefore"`
+	ExpiresAt            *time.Time                          `json:"expiresAt"`
+	RateLimitPerMinute   *int                                `json:"rateLimitPerMinute"`
+	RateLimitBurst       *int                                `json:"rateLimitBurst"`
+	DailyQuota           *int                                `json:"dailyQuota"`
+	IPRestrictionMode    *authentication.IPRestrictionMode   `json:"ipRestrictionMode"`
+	RequestSigning       bool                                `json:"requestSigning"`
+	OperatorIDs          []string                            `json:"operatorIds"`
+	Cidrs                []CidrRuleResponse                  `json:"cidrs"`
+	ClientCertificates   []ClientCertificateResponse         `json:"clientCertificates"`
+	Permissions          []PermissionResponse                `json:"permissions"`
+	CreatedAt            time.Time                           `json:"createdAt"`
+	CreatedUserID        string                              `json:"createdUserId"`
+	UpdatedAt            time.Time                           `json:"updatedAt"`
+	UpdatedUserID        string                              `json:"updatedUserId"`
+}
+
+// CidrRuleResponse
+// Summary: This is the structure which defines the CIDR rule response.
+type CidrRuleResponse struct {
+	Cidr     string                    `json:"cidr"`
+	Action   authentication.CidrAction `json:"action"`
+	Priority int                       `json:"priority"`
+}
+
+// ClientCertificateResponse
+// Summary: This is the structure which defines the response of the client certificate mapped to the API key.
+type ClientCertificateResponse struct {
+	Type        authentication.ClientCertificateType `json:"type"`
+	Certificate string                               `json:"certificate"`
+}
+
+// PermissionResponse
+// Summary: This is the structure which defines the response of the route permission granted to the API key.
+type PermissionResponse struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// APIKeysResponse
+// Summary: This is the type which defines the API key list response.
+type APIKeysResponse []APIKeyResponse
+
+// NewAPIKeysResponse
+// Summary: This is the function which converts the API keys and the operators, CIDRs, client certificates and permissions bound to them to the response.
+// input: apiKeys(authentication.APIKeys) API keys
+// input: operators(authentication.APIKeyOperators) operators bound to the API keys
+// input: cidrs(authentication.Cidrs) CIDR rules added to the API keys
+// input: certificates(authentication.ClientCertificates) client certificates mapped to the API keys
+// input: permissions(authentication.APIKeyPermissions) route permissions granted to the API keys
+// output: (APIKeysResponse) API key list response
+func NewAPIKeysResponse(apiKeys authentication.APIKeys, operators authentication.APIKeyOperators, cidrs authentication.Cidrs, certificates authentication.ClientCertificates, permissions authentication.APIKeyPermissions) APIKeysResponse {
+	operatorIDs := map[string][]string{}
+	for _, operator := range operators {
+		operatorIDs[operator.APIKeyID] = append(operatorIDs[operator.APIKeyID], operator.OperatorID)
+	}
+	cidrRules := map[string][]CidrRuleResponse{}
+	for _, cidr := range cidrs {
+		cidrRules[cidr.APIKeyID] = append(cidrRules[cidr.APIKeyID], CidrRuleResponse{Cidr: cidr.Cidr, Action: cidr.Action, Priority: cidr.Priority})
+	}
+	clientCertificates := map[string][]ClientCertificateResponse{}
+	for _, certificate := range certificates {
+		clientCertificates[certificate.APIKeyID] = append(clientCertificates[certificate.APIKeyID], ClientCertificateResponse{Type: certificate.Type, Certificate: certificate.Certificate})
+	}
+	permissionsByAPIKey := map[string][]PermissionResponse{}
+	for _, permission := range permissions {
+		permissionsByAPIKey[permission.APIKeyID] = append(permissionsByAPIKey[permission.APIKeyID], PermissionResponse{Method: permission.Method, Path: permission.Path})
+	}
+
+	res := make(APIKeysResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		res[i] = APIKeyResponse{
+			ID:                   apiKey.ID,
+			KeyPrefix:            apiKey.KeyPrefix,
+			ApplicationName:      apiKey.ApplicationName,
+			ApplicationAttribute: apiKey.Attribute,
+			NotBefore:            apiKey.NotBefore,
+			ExpiresAt:            apiKey.ExpiresAt,
+			RateLimitPerMinute:   apiKey.RateLimit.RequestsPerMinute,
+			RateLimitBurst:       apiKey.RateLimit.Burst,
+			DailyQuota:           apiKey.RateLimit.DailyQuota,
+			IPRestrictionMode:    apiKey.IPRestrictionMode,
+			RequestSigning:       apiKey.RequiresSignature(),
+			OperatorIDs:          append([]string{}, operatorIDs[apiKey.ID]...),
+			Cidrs:                append([]CidrRuleResponse{}, cidrRules[apiKey.ID]...),
+			ClientCertificates:   append([]ClientCertificateResponse{}, clientCertificates[apiKey.ID]...),
+			Permissions:          append([]PermissionResponse{}, permissionsByAPIKey[apiKey.ID]...),
+			CreatedAt:            apiKey.CreatedAt,
+			CreatedUserID:        apiKey.CreatedUserID,
+			UpdatedAt:            apiKey.UpdatedAt,
+			UpdatedUserID:        apiKey.UpdatedUserID,
+		}
+	}
+	return res
+}
@@ -4,15 +4,19 @@ import "strings"
 
 // LoginResponse
 // Summary: This is the structure which defines the login response.
+// When the account has MFA enabled, only the challenge token to be exchanged for the tokens is returned.
 type LoginResponse struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken    string `json:"accessToken,omitempty"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
+	MFARequired    bool   `json:"mfaRequired,omitempty"`
+	ChallengeToken string `json:"challengeToken,omitempty"`
 }
 
 // Mask
 // Summary: This is the function which masks the confidential information.
 func (o *LoginResponse) Mask() {
 	o.RefreshToken = strings.Repeat("*", len(o.RefreshToken))
+	o.ChallengeToken = strings.Repeat("*", len(o.ChallengeToken))
 }
 
 // RefreshResponse
@@ -20,3 +24,44 @@ func (o *LoginResponse) Mask() {
 type RefreshResponse struct {
 	AccessToken string `json:"accessToken"`
 }
+
+// ChangePasswordResponse
+// Summary: This is the structure which defines the change password response.
+type ChangePasswordResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *ChangePasswordResponse) Mask() {
+	o.RefreshToken = strings.Repeat("*", len(o.RefreshToken))
+}
+
+// EnrollMFAResponse
+// Summary: This is the structure which defines the MFA enrollment response.
+type EnrollMFAResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *EnrollMFAResponse) Mask() {
+	o.Secret = strings.Repeat("*", len(o.Secret))
+	o.ProvisioningURI = strings.Repeat("*", len(o.ProvisioningURI))
+}
+
+// ActivateMFAResponse
+// Summary: This is the structure which defines the MFA activation response.
+type ActivateMFAResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *ActivateMFAResponse) Mask() {
+	for i, code := range o.RecoveryCodes {
+		o.RecoveryCodes[i] = strings.Repeat("*", len(code))
+	}
+}
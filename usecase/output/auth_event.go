@@ -0,0 +1,52 @@
+package output
+
+import (
+	"time"
+
+	"authenticator-backend/domain/model/authentication"
+)
+
+// AuthEventResponse
+// Summary: This is the structure which defines the auth event response.
+type AuthEventResponse struct {
+	ID                string    `json:"id"`
+	Event             string    `json:"event"`
+	Result            bool      `json:"result"`
+	ReasonCode        string    `json:"reasonCode"`
+	IPAddress         string    `json:"ipAddress"`
+	APIKeyID          *string   `json:"apiKeyId"`
+	OperatorID        *string   `json:"operatorId"`
+	OperatorAccountID *string   `json:"operatorAccountId"`
+	OccurredAt        time.Time `json:"occurredAt"`
+}
+
+// AuthEventsResponse
+// Summary: This is the structure which defines the auth event list response.
+// NextCursor is nil when there are no more events.
+type AuthEventsResponse struct {
+	Events     []AuthEventResponse `json:"events"`
+	NextCursor *string             `json:"nextCursor"`
+}
+
+// NewAuthEventsResponse
+// Summary: This is the function which converts the auth events to the response.
+// input: events(authentication.AuthEvents) auth events
+// input: nextCursor(*string) cursor of the next page
+// output: (AuthEventsResponse) auth event list response
+func NewAuthEventsResponse(events authentication.AuthEvents, nextCursor *string) AuthEventsResponse {
+	res := AuthEventsResponse{Events: make([]AuthEventResponse, len(events)), NextCursor: nextCursor}
+	for i, event := range events {
+		res.Events[i] = AuthEventResponse{
+			ID:                event.ID,
+			Event:             event.Event,
+			Result:            event.Result,
+			ReasonCode:        event.ReasonCode,
+			IPAddress:         event.IPAddress,
+			APIKeyID:          event.APIKeyID,
+			OperatorID:        event.OperatorID,
+			OperatorAccountID: event.OperatorAccountID,
+			OccurredAt:        event.OccurredAt,
+		}
+	}
+	return res
+}
@@ -0,0 +1,25 @@
+package output
+
+import "strings"
+
+// OAuthTokenResponse
+// Summary: This is the structure which defines the response of the OAuth 2.0 token request.
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// Mask
+// Summary: This is the function which masks the confidential information.
+func (o *OAuthTokenResponse) Mask() {
+	o.AccessToken = strings.Repeat("*", len(o.AccessToken))
+}
+
+// OAuthErrorResponse
+// Summary: This is the structure which defines the error response of the OAuth 2.0 token request.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
@@ -0,0 +1,43 @@
+package output
+
+import "authenticator-backend/domain/model/authentication"
+
+// UserResponse
+// Summary: This is the structure which defines the user response.
+type UserResponse struct {
+	UID               string              `json:"uid"`
+	OperatorAccountID string              `json:"operatorAccountId"`
+	OperatorID        string              `json:"operatorId"`
+	Role              authentication.Role `json:"role"`
+	Disabled          bool                `json:"disabled"`
+}
+
+// NewUserResponse
+// Summary: This is the function which converts the user of the identity provider to the response.
+// input: user(authentication.IdPUser) user of the identity provider
+// output: (UserResponse) user response
+func NewUserResponse(user authentication.IdPUser) UserResponse {
+	return UserResponse{
+		UID:               user.UID,
+		OperatorAccountID: user.Email,
+		OperatorID:        user.OperatorID,
+		Role:              user.Role,
+		Disabled:          user.Disabled,
+	}
+}
+
+// UsersResponse
+// Summary: This is the type which defines the user list response.
+type UsersResponse []UserResponse
+
+// NewUsersResponse
+// Summary: This is the function which converts the users of the identity provider to the response.
+// input: users(authentication.IdPUsers) users of the identity provider
+// output: (UsersResponse) user list response
+func NewUsersResponse(users authentication.IdPUsers) UsersResponse {
+	res := make(UsersResponse, len(users))
+	for i, user := range users {
+		res[i] = NewUserResponse(user)
+	}
+	return res
+}
@@ -1,14 +1,34 @@
 package output
 
+import "time"
+
 // VerifyTokenResponse
 // Summary: This is the structure which defines the verify token response.
 type VerifyTokenResponse struct {
 	OperatorID *string `json:"operatorId"`
 }
 
+// IntrospectTokenResponse
+// Summary: This is the structure which defines the response of the token introspection defined in RFC 7662.
+// Only Active is returned when the token is not active, so that nothing about the token is disclosed.
+type IntrospectTokenResponse struct {
+	Active     bool   `json:"active"`
+	TokenType  string `json:"token_type,omitempty"`
+	Sub        string `json:"sub,omitempty"`
+	Exp        int64  `json:"exp,omitempty"`
+	Iat        int64  `json:"iat,omitempty"`
+	Iss        string `json:"iss,omitempty"`
+	Aud        string `json:"aud,omitempty"`
+	OperatorID string `json:"operator_id,omitempty"`
+	AuthTime   int64  `json:"auth_time,omitempty"`
+}
+
 // VerifyApiKeyResponse
 // Summary: This is the structure which defines the verify API key response.
+// ExpiresAt and ExpiresSoon report the expiry of the API key so that the caller can rotate it before it expires.
 type VerifyApiKeyResponse struct {
-	IsAPIKeyValid    bool `json:"isApiKeyValid"`
-	IsIPAddressValid bool `json:"isIpAddressValid"`
+	IsAPIKeyValid    bool       `json:"isApiKeyValid"`
+	IsIPAddressValid bool       `json:"isIpAddressValid"`
+	ExpiresAt        *time.Time `json:"expiresAt"`
+	ExpiresSoon      bool       `json:"expiresSoon"`
 }